@@ -0,0 +1,27 @@
+// Package activity reports bookings, search impressions and profile views
+// to the trending tracker of the recommendation service.
+package activity
+
+import (
+	"log"
+
+	recommendation "github.com/harlow/go-micro-services/services/recommendation/proto"
+	"golang.org/x/net/context"
+)
+
+// Record reports activity on hotels without holding up the caller's
+// request; a lost activity only makes trending slightly stale.
+func Record(client recommendation.RecommendationClient, kind recommendation.Activity, hotelIds []string) {
+	if len(hotelIds) == 0 {
+		return
+	}
+	go func() {
+		_, err := client.RecordActivity(context.Background(), &recommendation.ActivityRequest{
+			Activity: kind,
+			HotelIds: hotelIds,
+		})
+		if err != nil {
+			log.Println("Failed record activity: ", err)
+		}
+	}()
+}
@@ -0,0 +1,172 @@
+// Package authtoken issues and verifies signed, expiring session tokens.
+//
+// Tokens are JSON Web Tokens signed with HMAC-SHA256. The header names the
+// key that signed the token, so keys can be rotated: tokens are signed
+// with the first key and verified with any key still configured.
+package authtoken
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrInvalid is returned for tokens that are malformed, signed by an
+	// unknown key or whose signature does not match.
+	ErrInvalid = errors.New("authtoken: invalid token")
+	// ErrExpired is returned for tokens past their expiry.
+	ErrExpired = errors.New("authtoken: token expired")
+)
+
+// Claims is what a token says about its session.
+type Claims struct {
+	// Subject is the username the token was issued to
+	Subject string `json:"sub"`
+	// ID identifies the token, for revocation
+	ID string `json:"jti"`
+	// IssuedAt and ExpiresAt are unix seconds
+	IssuedAt  int64 `json:"iat"`
+	ExpiresAt int64 `json:"exp"`
+	// AuthTime is when the user last gave their password; refreshed
+	// tokens keep it, so a session cannot be refreshed forever
+	AuthTime int64 `json:"auth_time"`
+}
+
+type header struct {
+	Alg string `json:"alg"`
+	Typ string `json:"typ"`
+	Kid string `json:"kid"`
+}
+
+type key struct {
+	id     string
+	secret []byte
+}
+
+// Signer signs and verifies tokens.
+type Signer struct {
+	keys []key
+	// TTL is how long a token is valid
+	TTL time.Duration
+	// MaxAge bounds how long refreshes can extend a session past AuthTime
+	MaxAge time.Duration
+}
+
+// NewSigner returns a Signer for comma separated "kid:secret" keys. The
+// first key signs new tokens; the others only verify, which lets tokens
+// signed by a retired key live out their TTL.
+func NewSigner(keys string, ttl, maxAge time.Duration) (*Signer, error) {
+	s := &Signer{TTL: ttl, MaxAge: maxAge}
+	for _, k := range strings.Split(keys, ",") {
+		kv := strings.SplitN(strings.TrimSpace(k), ":", 2)
+		if len(kv) != 2 || kv[0] == "" || len(kv[1]) < 16 {
+			return nil, fmt.Errorf("authtoken: keys must be kid:secret with a secret of at least 16 characters")
+		}
+		s.keys = append(s.keys, key{id: kv[0], secret: []byte(kv[1])})
+	}
+	return s, nil
+}
+
+// Issue returns a new token for username. authTime is when the user gave
+// their password, the zero time meaning now.
+func (s *Signer) Issue(username string, authTime time.Time) (string, *Claims, error) {
+	now := time.Now()
+	if authTime.IsZero() {
+		authTime = now
+	}
+
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return "", nil, err
+	}
+
+	exp := now.Add(s.TTL)
+	if limit := authTime.Add(s.MaxAge); exp.After(limit) {
+		exp = limit
+	}
+	if !exp.After(now) {
+		return "", nil, ErrExpired
+	}
+
+	c := &Claims{
+		Subject:   username,
+		ID:        hex.EncodeToString(id),
+		IssuedAt:  now.Unix(),
+		ExpiresAt: exp.Unix(),
+		AuthTime:  authTime.Unix(),
+	}
+
+	k := s.keys[0]
+	h, err := json.Marshal(header{Alg: "HS256", Typ: "JWT", Kid: k.id})
+	if err != nil {
+		return "", nil, err
+	}
+	p, err := json.Marshal(c)
+	if err != nil {
+		return "", nil, err
+	}
+	signed := encode(h) + "." + encode(p)
+	return signed + "." + encode(sign(k.secret, signed)), c, nil
+}
+
+// Verify checks the signature and expiry of a token and returns its claims.
+func (s *Signer) Verify(token string) (*Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, ErrInvalid
+	}
+
+	var h header
+	if err := decodeJSON(parts[0], &h); err != nil || h.Alg != "HS256" {
+		return nil, ErrInvalid
+	}
+	var secret []byte
+	for _, k := range s.keys {
+		if k.id == h.Kid {
+			secret = k.secret
+		}
+	}
+	if secret == nil {
+		return nil, ErrInvalid
+	}
+
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil || !hmac.Equal(sig, sign(secret, parts[0]+"."+parts[1])) {
+		return nil, ErrInvalid
+	}
+
+	c := new(Claims)
+	if err := decodeJSON(parts[1], c); err != nil || c.Subject == "" || c.ID == "" {
+		return nil, ErrInvalid
+	}
+	if time.Now().Unix() >= c.ExpiresAt {
+		return nil, ErrExpired
+	}
+	return c, nil
+}
+
+func sign(secret []byte, s string) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(s))
+	return mac.Sum(nil)
+}
+
+func encode(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func decodeJSON(s string, v interface{}) error {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, v)
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"github.com/harlow/go-micro-services/passhash"
 	"gopkg.in/mgo.v2"
 	"gopkg.in/mgo.v2/bson"
 
@@ -13,6 +14,7 @@ type Admin struct {
 	Password string   `bson:"password"`
 	Hotels   []string `bason: "hotels"`
 	Id       string   `bason: "id"`
+	Role     string   `bson:"role,omitempty"`
 }
 
 func initializeDatabase(url string) *mgo.Session {
@@ -28,17 +30,24 @@ func initializeDatabase(url string) *mgo.Session {
 	password := "123"
 	l := []string{"1", "2", "3", "4"}
 	id := "0"
+	// manages all four hotels
+	role := "chain_admin"
 	count, err := c.Find(&bson.M{"name": name}).Count()
 	if err != nil {
 		log.Fatal(err)
 	}
 	if count == 0 {
+		pass, err := passhash.Hash(password)
+		if err != nil {
+			log.Fatal(err)
+		}
 		err = c.Insert(&Admin{
 			name,
 			email,
-			password,
+			pass,
 			l,
 			id,
+			role,
 		})
 		if err != nil {
 			log.Fatal(err)
@@ -48,5 +57,9 @@ func initializeDatabase(url string) *mgo.Session {
 	if err != nil {
 		log.Fatal(err)
 	}
+	err = session.DB("admin-db").C("onboarding").EnsureIndexKey("state", "updated")
+	if err != nil {
+		log.Fatal(err)
+	}
 	return session
 }
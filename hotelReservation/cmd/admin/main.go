@@ -4,6 +4,9 @@ import (
 	"encoding/json"
 	"flag"
 	"fmt"
+	"github.com/bradfitz/gomemcache/memcache"
+	"github.com/harlow/go-micro-services/lockout"
+	"github.com/harlow/go-micro-services/rbac"
 	"github.com/harlow/go-micro-services/registry"
 	"github.com/harlow/go-micro-services/services/admin"
 	"github.com/harlow/go-micro-services/tracing"
@@ -11,6 +14,7 @@ import (
 	"log"
 	"os"
 	"strconv"
+	"time"
 )
 
 func main() {
@@ -29,6 +33,17 @@ func main() {
 
 	mongo_session := initializeDatabase(result["AdminMongoAddress"])
 	defer mongo_session.Close()
+
+	// bound the connections the session pool opens, mgo defaults to 4096
+	if pool_limit, err := strconv.Atoi(result["AdminMongoPoolLimit"]); err == nil && pool_limit > 0 {
+		mongo_session.SetPoolLimit(pool_limit)
+	}
+	// failed logins are counted in memcached, shared with the user service and frontend
+	fmt.Printf("lockout memc addr port = %s\n", result["LockoutMemcAddress"])
+	lockout_memc := memcache.New(result["LockoutMemcAddress"])
+	lockout_memc.Timeout = time.Second * 2
+	lockout_memc.MaxIdleConns = 64
+
 	serv_port, _ := strconv.Atoi(result["AdminPort"])
 	serv_ip := result["AdminIP"]
 
@@ -51,12 +66,21 @@ func main() {
 		panic(err)
 	}
 
+	// signs the identities calls between the services are made by
+	identity, err := rbac.NewSigner(result["IdentityKeys"])
+	if err != nil {
+		panic(err)
+	}
+
 	srv := &admin.Server{
 		Tracer:       tracer,
 		Registry:     registry,
+		Identity:     identity,
 		Port:         serv_port,
 		IpAddr:       serv_ip,
 		MongoSession: mongo_session,
+		Lockout:      lockout.New(lockout_memc, "admin", lockout.AccountPolicy),
+		AddrLockout:  lockout.New(lockout_memc, "addr", lockout.AddrPolicy),
 	}
 	log.Fatal(srv.Run())
 }
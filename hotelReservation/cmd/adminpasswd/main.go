@@ -0,0 +1,82 @@
+// Command adminpasswd replaces the plain text passwords of the admin
+// records in admin-db with hashes. Records already hashed are left alone,
+// so it is safe to run more than once. Admins it misses are upgraded on
+// their next login.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+
+	"github.com/harlow/go-micro-services/passhash"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+type admin struct {
+	Email    string `bson:"email"`
+	Password string `bson:"password"`
+}
+
+func main() {
+	var result map[string]string
+	if jsonFile, err := os.Open("config.json"); err == nil {
+		byteValue, _ := ioutil.ReadAll(jsonFile)
+		jsonFile.Close()
+		json.Unmarshal(byteValue, &result)
+	}
+
+	var (
+		mongoaddr = flag.String("mongoaddr", result["AdminMongoAddress"], "Admin MongoDB address")
+		dryRun    = flag.Bool("n", false, "Only report the records that would be migrated")
+	)
+	flag.Parse()
+
+	session, err := mgo.Dial(*mongoaddr)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer session.Close()
+
+	c := session.DB("admin-db").C("admin")
+
+	var admins []admin
+	if err := c.Find(bson.M{}).Select(bson.M{"email": 1, "password": 1}).All(&admins); err != nil {
+		log.Fatal(err)
+	}
+
+	migrated := 0
+	for _, a := range admins {
+		if passhash.IsHash(a.Password) {
+			continue
+		}
+		if *dryRun {
+			fmt.Printf("would migrate %s\n", a.Email)
+			migrated++
+			continue
+		}
+
+		pass, err := passhash.Hash(a.Password)
+		if err != nil {
+			log.Fatal(err)
+		}
+		// only if the password did not change meanwhile
+		err = c.Update(
+			bson.M{"email": a.Email, "password": a.Password},
+			bson.M{"$set": bson.M{"password": pass}},
+		)
+		if err == mgo.ErrNotFound {
+			continue
+		}
+		if err != nil {
+			log.Fatal(err)
+		}
+		migrated++
+	}
+
+	fmt.Printf("%d of %d admin records migrated\n", migrated, len(admins))
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"gopkg.in/mgo.v2"
+
+	"log"
+	"time"
+)
+
+func initializeDatabase(url string) *mgo.Session {
+	session, err := mgo.Dial(url)
+	if err != nil {
+		panic(err)
+	}
+	// defer session.Close()
+	c := session.DB("alert-db").C("alerts")
+
+	// a guest's alerts are listed newest first
+	err = c.EnsureIndexKey("username", "-_id")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// active alerts are evaluated those checked longest ago first
+	err = c.EnsureIndexKey("state", "checked")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// alerts are dropped a while after their stay
+	err = c.EnsureIndex(mgo.Index{
+		Key:         []string{"expires"},
+		ExpireAfter: time.Second,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	return session
+}
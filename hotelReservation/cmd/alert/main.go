@@ -0,0 +1,76 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"github.com/harlow/go-micro-services/rbac"
+	"github.com/harlow/go-micro-services/registry"
+	"github.com/harlow/go-micro-services/services/alert"
+	"github.com/harlow/go-micro-services/tracing"
+	"io/ioutil"
+	"log"
+	"os"
+	"strconv"
+)
+
+func main() {
+	jsonFile, err := os.Open("config.json")
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	defer jsonFile.Close()
+
+	byteValue, _ := ioutil.ReadAll(jsonFile)
+
+	var result map[string]string
+	json.Unmarshal([]byte(byteValue), &result)
+
+	mongo_session := initializeDatabase(result["AlertMongoAddress"])
+	defer mongo_session.Close()
+
+	serv_port, _ := strconv.Atoi(result["AlertPort"])
+	serv_ip := result["AlertIP"]
+
+	fmt.Printf("alert ip = %s, port = %d\n", serv_ip, serv_port)
+
+	var (
+		jaegeraddr = flag.String("jaegeraddr", result["jaegerAddress"], "Jaeger server addr")
+		consuladdr = flag.String("consuladdr", result["consulAddress"], "Consul address")
+	)
+	flag.Parse()
+
+	tracer, err := tracing.Init("alert", *jaegeraddr)
+	if err != nil {
+		panic(err)
+	}
+
+	registry, err := registry.NewClient(*consuladdr)
+	if err != nil {
+		panic(err)
+	}
+
+	// signs the identities calls between the services are made by
+	identity, err := rbac.NewSigner(result["IdentityKeys"])
+	if err != nil {
+		panic(err)
+	}
+
+	// where triggered alerts are sent
+	notifier, err := alert.NewNotifier(result["AlertNotifiers"], result["AlertWebhookSecret"])
+	if err != nil {
+		panic(err)
+	}
+
+	srv := &alert.Server{
+		Tracer:       tracer,
+		Registry:     registry,
+		Identity:     identity,
+		Port:         serv_port,
+		IpAddr:       serv_ip,
+		MongoSession: mongo_session,
+		Notifier:     notifier,
+	}
+	log.Fatal(srv.Run())
+}
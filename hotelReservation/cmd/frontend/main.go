@@ -8,7 +8,11 @@ import (
 	"log"
 	"os"
 	"strconv"
+	"time"
 
+	"github.com/bradfitz/gomemcache/memcache"
+	"github.com/harlow/go-micro-services/lockout"
+	"github.com/harlow/go-micro-services/rbac"
 	"github.com/harlow/go-micro-services/registry"
 	"github.com/harlow/go-micro-services/services/frontend"
 	"github.com/harlow/go-micro-services/tracing"
@@ -27,6 +31,12 @@ func main() {
 	var result map[string]string
 	json.Unmarshal([]byte(byteValue), &result)
 
+	// failed logins are counted in memcached, shared with the user and admin services
+	fmt.Printf("lockout memc addr port = %s\n", result["LockoutMemcAddress"])
+	lockout_memc := memcache.New(result["LockoutMemcAddress"])
+	lockout_memc.Timeout = time.Second * 2
+	lockout_memc.MaxIdleConns = 64
+
 	serv_port, _ := strconv.Atoi(result["FrontendPort"])
 	serv_ip   := result["FrontendIP"]
 
@@ -49,11 +59,20 @@ func main() {
 		panic(err)
 	}
 
+	// signs the identities calls between the services are made by
+	identity, err := rbac.NewSigner(result["IdentityKeys"])
+	if err != nil {
+		panic(err)
+	}
+
 	srv := &frontend.Server{
 		Registry: registry,
+		Identity: identity,
 		Tracer:   tracer,
 		IpAddr:	  serv_ip,
 		Port:     serv_port,
+
+		AddrLockout: lockout.New(lockout_memc, "addr", lockout.AddrPolicy),
 	}
 	log.Fatal(srv.Run())
 }
@@ -106,7 +106,11 @@ func initializeDatabase(url string) *mgo.Session {
 		}
 	}
 
-	err = c.EnsureIndexKey("hotelId")
+	// a hotel has one location
+	err = c.EnsureIndex(mgo.Index{
+		Key:    []string{"hotelId"},
+		Unique: true,
+	})
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -9,6 +9,7 @@ import (
 	"os"
 	"strconv"
 
+	"github.com/harlow/go-micro-services/rbac"
 	"github.com/harlow/go-micro-services/registry"
 	"github.com/harlow/go-micro-services/services/geo"
 	"github.com/harlow/go-micro-services/tracing"
@@ -52,13 +53,21 @@ func main() {
 		panic(err)
 	}
 
+	// signs the identities calls between the services are made by
+	identity, err := rbac.NewSigner(result["IdentityKeys"])
+	if err != nil {
+		panic(err)
+	}
+
 	srv := &geo.Server{
 		// Port:     *port,
 		Port:     serv_port,
 		IpAddr:	  serv_ip,
 		Tracer:   tracer,
 		Registry: registry,
+		Identity: identity,
 		MongoSession: mongo_session,
+		IndexType: result["GeoIndex"],
 	}
 	log.Fatal(srv.Run())
 }
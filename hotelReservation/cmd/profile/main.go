@@ -9,6 +9,7 @@ import (
 	"os"
 	"strconv"
 
+	"github.com/harlow/go-micro-services/rbac"
 	"github.com/harlow/go-micro-services/registry"
 	"github.com/harlow/go-micro-services/services/profile"
 	"github.com/harlow/go-micro-services/tracing"
@@ -60,10 +61,17 @@ func main() {
 		panic(err)
 	}
 
+	// signs the identities calls between the services are made by
+	identity, err := rbac.NewSigner(result["IdentityKeys"])
+	if err != nil {
+		panic(err)
+	}
+
 	srv := profile.Server{
 		Tracer:   tracer,
 		// Port:     *port,
 		Registry: registry,
+		Identity: identity,
 		Port:     serv_port,
 		IpAddr:	  serv_ip,
 		MongoSession: mongo_session,
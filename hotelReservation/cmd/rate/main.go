@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"flag"
 	"fmt"
+	"github.com/harlow/go-micro-services/rbac"
 	"github.com/harlow/go-micro-services/registry"
 	"github.com/harlow/go-micro-services/services/rate"
 	"github.com/harlow/go-micro-services/tracing"
@@ -59,10 +60,17 @@ func main() {
 		panic(err)
 	}
 
+	// signs the identities calls between the services are made by
+	identity, err := rbac.NewSigner(result["IdentityKeys"])
+	if err != nil {
+		panic(err)
+	}
+
 	srv := &rate.Server{
 		Tracer:   tracer,
 		// Port:     *port,
 		Registry: registry,
+		Identity: identity,
 		Port:     serv_port,
 		IpAddr:	  serv_ip,
 		MongoSession: mongo_session,
@@ -8,6 +8,7 @@ import (
 	"log"
 	"os"
 
+	"github.com/harlow/go-micro-services/rbac"
 	"github.com/harlow/go-micro-services/registry"
 	"github.com/harlow/go-micro-services/services/recommendation"
 	"github.com/harlow/go-micro-services/tracing"
@@ -53,10 +54,17 @@ func main() {
 		panic(err)
 	}
 
+	// signs the identities calls between the services are made by
+	identity, err := rbac.NewSigner(result["IdentityKeys"])
+	if err != nil {
+		panic(err)
+	}
+
 	srv := &recommendation.Server{
 		Tracer:   tracer,
 		// Port:     *port,
 		Registry: registry,
+		Identity: identity,
 		Port:     serv_port,
 		IpAddr:	  serv_ip,
 		MongoSession: mongo_session,
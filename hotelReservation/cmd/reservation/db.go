@@ -133,6 +133,21 @@ func initializeDatabase(url string) *mgo.Session {
 		log.Fatal(err)
 	}
 
+	err = session.DB("reservation-db").C("closures").EnsureIndexKey("hotelId", "inDate")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	err = session.DB("reservation-db").C("cancellations").EnsureIndexKey("hotelId", "inDate")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	err = session.DB("reservation-db").C("outbox").EnsureIndexKey("reservationId")
+	if err != nil {
+		log.Fatal(err)
+	}
+
 
 	return session
 }
\ No newline at end of file
@@ -8,6 +8,7 @@ import (
 	"log"
 	"os"
 
+	"github.com/harlow/go-micro-services/rbac"
 	"github.com/harlow/go-micro-services/registry"
 	"github.com/harlow/go-micro-services/services/search"
 	"github.com/harlow/go-micro-services/tracing"
@@ -49,12 +50,19 @@ func main() {
 		panic(err)
 	}
 
+	// signs the identities calls between the services are made by
+	identity, err := rbac.NewSigner(result["IdentityKeys"])
+	if err != nil {
+		panic(err)
+	}
+
 	srv := &search.Server{
 		Tracer:   tracer,
 		// Port:     *port,
 		Port:     serv_port,
 		IpAddr:	  serv_ip,
 		Registry: registry,
+		Identity: identity,
 	}
 	log.Fatal(srv.Run())
 }
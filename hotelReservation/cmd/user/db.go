@@ -1,12 +1,13 @@
 package main
 
 import (
-	"crypto/sha256"
+	"github.com/harlow/go-micro-services/passhash"
 	"gopkg.in/mgo.v2"
 	"gopkg.in/mgo.v2/bson"
 	"strconv"
 	"log"
 	"fmt"
+	"time"
 )
 
 type User struct {
@@ -16,7 +17,6 @@ type User struct {
 	Sex string `bson:"sex"`
 	Mail string `bson:"mail"`
 	Phone string `bson:"phone"`
-	Orderhistory string `bson:"orderhistory"`
 }
 
 func initializeDatabase(url string) *mgo.Session {
@@ -36,23 +36,22 @@ func initializeDatabase(url string) *mgo.Session {
 		for j := 0; j < 10; j++ {
 			password += suffix
 		}
-    
-		sum := sha256.Sum256([]byte(password))
-		pass := fmt.Sprintf("%x", sum)
 
 		var age int32 = 30
 		sex := "male"
 		mail := suffix + "@cornell.edu"
 		phone := "(607) 262-" + suffix
-		orderhistory := "order_" + suffix 
-    
-    
+
 		count, err := c.Find(&bson.M{"username": user_name}).Count()
 		if err != nil {
 			log.Fatal(err)
 		}
 		if count == 0{
-			err = c.Insert(&User{user_name, pass, age, sex, mail, phone, orderhistory})
+			pass, err := passhash.Hash(password)
+			if err != nil {
+				log.Fatal(err)
+			}
+			err = c.Insert(&User{user_name, pass, age, sex, mail, phone})
 			if err != nil {
 				log.Fatal(err)
 			}
@@ -65,6 +64,74 @@ func initializeDatabase(url string) *mgo.Session {
 		log.Fatal(err)
 	}
 
+	// order history pages are read newest first
+	err = session.DB("user-db").C("orders").EnsureIndexKey("username", "-_id")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// a user's points ledger is read newest first, and by reservation
+	err = session.DB("user-db").C("points").EnsureIndexKey("username", "-_id")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	err = session.DB("user-db").C("points").EnsureIndexKey("username", "reservationId")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	err = session.DB("user-db").C("loyalty").EnsureIndex(mgo.Index{
+		Key:    []string{"username"},
+		Unique: true,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// a user's wishlists have distinct names, and share tokens
+	err = session.DB("user-db").C("wishlists").EnsureIndex(mgo.Index{
+		Key:    []string{"username", "name"},
+		Unique: true,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	err = session.DB("user-db").C("wishlists").EnsureIndex(mgo.Index{
+		Key:    []string{"shareToken"},
+		Unique: true,
+		Sparse: true,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// a user's changes are looked up newest first
+	err = session.DB("user-db").C("audit").EnsureIndexKey("username", "-at")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// logged out session tokens are dropped once they expire
+	err = session.DB("user-db").C("revoked").EnsureIndex(mgo.Index{
+		Key:         []string{"expires"},
+		ExpireAfter: time.Second,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	err = session.DB("user-db").C("revoked").EnsureIndexKey("jti")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	err = session.DB("user-db").C("revoked").EnsureIndexKey("sub")
+	if err != nil {
+		log.Fatal(err)
+	}
+
 
 	return session
 
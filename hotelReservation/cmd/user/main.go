@@ -4,6 +4,10 @@ import (
 	"encoding/json"
 	"flag"
 	"fmt"
+	"github.com/bradfitz/gomemcache/memcache"
+	"github.com/harlow/go-micro-services/authtoken"
+	"github.com/harlow/go-micro-services/lockout"
+	"github.com/harlow/go-micro-services/rbac"
 	"github.com/harlow/go-micro-services/registry"
 	"github.com/harlow/go-micro-services/services/user"
 	"github.com/harlow/go-micro-services/tracing"
@@ -11,6 +15,14 @@ import (
 	"log"
 	"os"
 	"strconv"
+	"time"
+)
+
+const (
+	// how long a session token is valid
+	tokenTTL = time.Hour
+	// how long refreshing tokens can keep a session alive after login
+	sessionMaxAge = 24 * time.Hour
 )
 
 func main() {
@@ -29,6 +41,25 @@ func main() {
 
 	mongo_session := initializeDatabase(result["UserMongoAddress"])
 	defer mongo_session.Close()
+
+	// order histories used to be kept as a string on the user
+	migrated, err := user.MigrateOrderHistory(mongo_session)
+	if err != nil {
+		log.Println("Failed migrate order histories: ", err)
+	} else if migrated > 0 {
+		fmt.Printf("migrated %d orders\n", migrated)
+	}
+
+	// bound the connections the session pool opens, mgo defaults to 4096
+	if pool_limit, err := strconv.Atoi(result["UserMongoPoolLimit"]); err == nil && pool_limit > 0 {
+		mongo_session.SetPoolLimit(pool_limit)
+	}
+	// failed logins are counted in memcached, shared with the admin service and frontend
+	fmt.Printf("lockout memc addr port = %s\n", result["LockoutMemcAddress"])
+	lockout_memc := memcache.New(result["LockoutMemcAddress"])
+	lockout_memc.Timeout = time.Second * 2
+	lockout_memc.MaxIdleConns = 64
+
 	serv_port, _ := strconv.Atoi(result["UserPort"])
 	serv_ip   := result["UserIP"]
 
@@ -51,13 +82,28 @@ func main() {
 		panic(err)
 	}
 
+	tokens, err := authtoken.NewSigner(result["SessionKeys"], tokenTTL, sessionMaxAge)
+	if err != nil {
+		panic(err)
+	}
+
+	// signs the identities calls between the services are made by
+	identity, err := rbac.NewSigner(result["IdentityKeys"])
+	if err != nil {
+		panic(err)
+	}
+
 	srv := &user.Server{
 		Tracer:   tracer,
 		// Port:     *port,
 		Registry: registry,
+		Identity: identity,
 		Port:     serv_port,
 		IpAddr:	  serv_ip,
 		MongoSession: mongo_session,
+		Tokens:       tokens,
+		Lockout:      lockout.New(lockout_memc, "user", lockout.AccountPolicy),
+		AddrLockout:  lockout.New(lockout_memc, "addr", lockout.AddrPolicy),
 	}
 	log.Fatal(srv.Run())
 }
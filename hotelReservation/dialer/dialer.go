@@ -7,6 +7,7 @@ import (
 	"github.com/grpc-ecosystem/grpc-opentracing/go/otgrpc"
 	consul "github.com/hashicorp/consul/api"
 	lb "github.com/olivere/grpc/lb/consul"
+	"github.com/harlow/go-micro-services/rbac"
 	opentracing "github.com/opentracing/opentracing-go"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/keepalive"
@@ -22,6 +23,14 @@ func WithTracer(tracer opentracing.Tracer) DialOption {
 	}
 }
 
+// WithIdentity signs the identity of the caller into each rpc call, see
+// rbac.Signer.Credentials
+func WithIdentity(signer *rbac.Signer, self *rbac.Identity) DialOption {
+	return func(name string) (grpc.DialOption, error) {
+		return grpc.WithPerRPCCredentials(signer.Credentials(self)), nil
+	}
+}
+
 // WithBalancer enables client side load balancing
 func WithBalancer(registry *consul.Client) DialOption {
 	return func(name string) (grpc.DialOption, error) {
@@ -0,0 +1,46 @@
+package lockout
+
+import "log"
+
+// Key is a key whose failures a limiter counts.
+type Key struct {
+	Limiter *Limiter
+	Key     string
+}
+
+// Keys returns the keys the failed logins of an account from a client
+// address are counted by. A nil limiter or an empty key is left out.
+func Keys(accounts *Limiter, account string, addrs *Limiter, addr string) []Key {
+	var keys []Key
+	if accounts != nil && account != "" {
+		keys = append(keys, Key{accounts, account})
+	}
+	if addrs != nil && addr != "" {
+		keys = append(keys, Key{addrs, addr})
+	}
+	return keys
+}
+
+// CheckAll returns the *Error of the first of keys that may not attempt
+// a login now. Keys are let through if memcached fails.
+func CheckAll(keys []Key) *Error {
+	for _, k := range keys {
+		err := k.Limiter.Check(k.Key)
+		if e, ok := err.(*Error); ok {
+			return e
+		}
+		if err != nil {
+			log.Println("Failed check login failures: ", err)
+		}
+	}
+	return nil
+}
+
+// FailAll records a failed login of every one of keys.
+func FailAll(keys []Key) {
+	for _, k := range keys {
+		if err := k.Limiter.Fail(k.Key); err != nil {
+			log.Println("Failed record login failure: ", err)
+		}
+	}
+}
@@ -0,0 +1,197 @@
+// Package lockout throttles failed logins.
+//
+// Failures are counted per key, such as an account or a client address,
+// in memcached so every replica of a service sees the same counts. After
+// a few free failures each further one makes the key wait exponentially
+// longer before its next attempt, and enough of them lock the key for a
+// while. A success resets the key.
+package lockout
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bradfitz/gomemcache/memcache"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Policy is how a limiter treats the failures of a key.
+type Policy struct {
+	// FreeFailures is the number of failures allowed without delay
+	FreeFailures int
+	// BaseDelay is the delay after the first failure past the free ones,
+	// doubled by each further one up to MaxDelay
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+	// LockAfter failures lock the key for LockDuration
+	LockAfter    int
+	LockDuration time.Duration
+	// Window is how long failures are counted from the first one
+	Window time.Duration
+}
+
+var (
+	// AccountPolicy is meant for the failures of a single account.
+	AccountPolicy = Policy{
+		FreeFailures: 3,
+		BaseDelay:    time.Second,
+		MaxDelay:     5 * time.Minute,
+		LockAfter:    10,
+		LockDuration: 15 * time.Minute,
+		Window:       15 * time.Minute,
+	}
+	// AddrPolicy is meant for the failures of a client address, which may
+	// be shared by many users.
+	AddrPolicy = Policy{
+		FreeFailures: 20,
+		BaseDelay:    time.Second,
+		MaxDelay:     5 * time.Minute,
+		LockAfter:    100,
+		LockDuration: time.Hour,
+		Window:       time.Hour,
+	}
+)
+
+// delay returns how long a key with the given number of failures waits,
+// and whether it is locked.
+func (p Policy) delay(failures int) (time.Duration, bool) {
+	if p.LockAfter > 0 && failures >= p.LockAfter {
+		return p.LockDuration, true
+	}
+	if failures <= p.FreeFailures {
+		return 0, false
+	}
+	d := p.BaseDelay
+	for i := p.FreeFailures + 1; i < failures && d < p.MaxDelay; i++ {
+		d *= 2
+	}
+	if d > p.MaxDelay {
+		d = p.MaxDelay
+	}
+	return d, false
+}
+
+// Error is returned for a key that must wait before its next attempt.
+type Error struct {
+	Locked     bool
+	RetryAfter time.Duration
+}
+
+func (e *Error) Error() string {
+	retry := (e.RetryAfter + time.Second - 1) / time.Second * time.Second
+	if e.Locked {
+		return fmt.Sprintf("too many failed logins, locked for %v", retry)
+	}
+	return fmt.Sprintf("too many failed logins, retry in %v", retry)
+}
+
+// Status returns e as a gRPC error: FailedPrecondition for a locked key,
+// ResourceExhausted for one that has to wait.
+func (e *Error) Status() error {
+	if e.Locked {
+		return status.Error(codes.FailedPrecondition, e.Error())
+	}
+	return status.Error(codes.ResourceExhausted, e.Error())
+}
+
+// Limiter counts the failures of the keys of a scope.
+type Limiter struct {
+	mc     *memcache.Client
+	scope  string
+	policy Policy
+}
+
+// New returns a limiter of the keys of scope. Limiters of different
+// scopes sharing a memcached count separately.
+func New(mc *memcache.Client, scope string, policy Policy) *Limiter {
+	return &Limiter{mc: mc, scope: scope, policy: policy}
+}
+
+// memcached keys can not hold spaces or control characters, so keys are
+// hashed.
+func (l *Limiter) key(kind, key string) string {
+	sum := sha1.Sum([]byte(key))
+	return "lockout:" + l.scope + ":" + kind + ":" + hex.EncodeToString(sum[:])
+}
+
+// Check returns an *Error if key may not attempt a login now. Other
+// errors are from memcached.
+func (l *Limiter) Check(key string) error {
+	item, err := l.mc.Get(l.key("until", key))
+	if err == memcache.ErrCacheMiss {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	// "<unix nanoseconds>:<1 if locked>"
+	parts := strings.SplitN(string(item.Value), ":", 2)
+	until, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return fmt.Errorf("lockout: malformed entry %q", item.Value)
+	}
+	wait := time.Unix(0, until).Sub(time.Now())
+	if wait <= 0 {
+		return nil
+	}
+	return &Error{
+		Locked:     len(parts) == 2 && parts[1] == "1",
+		RetryAfter: wait,
+	}
+}
+
+// Fail records a failed login of key.
+func (l *Limiter) Fail(key string) error {
+	counter := l.key("failures", key)
+	failures, err := l.mc.Increment(counter, 1)
+	if err == memcache.ErrCacheMiss {
+		// the window starts with the first failure
+		err = l.mc.Add(&memcache.Item{
+			Key:        counter,
+			Value:      []byte("1"),
+			Expiration: seconds(l.policy.Window),
+		})
+		failures = 1
+		if err == memcache.ErrNotStored {
+			failures, err = l.mc.Increment(counter, 1)
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	wait, locked := l.policy.delay(int(failures))
+	if wait <= 0 {
+		return nil
+	}
+	value := strconv.FormatInt(time.Now().Add(wait).UnixNano(), 10) + ":0"
+	if locked {
+		value = value[:len(value)-1] + "1"
+	}
+	return l.mc.Set(&memcache.Item{
+		Key:        l.key("until", key),
+		Value:      []byte(value),
+		Expiration: seconds(wait),
+	})
+}
+
+// Reset forgets the failures of key.
+func (l *Limiter) Reset(key string) error {
+	for _, kind := range []string{"failures", "until"} {
+		if err := l.mc.Delete(l.key(kind, key)); err != nil && err != memcache.ErrCacheMiss {
+			return err
+		}
+	}
+	return nil
+}
+
+// seconds rounds d up to the whole seconds of a memcached expiration.
+func seconds(d time.Duration) int32 {
+	return int32((d + time.Second - 1) / time.Second)
+}
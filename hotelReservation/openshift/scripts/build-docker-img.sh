@@ -58,7 +58,7 @@ REGISTRY=$(oc registry info)
 cd ../
 ROOT_FOLDER=$(pwd)
 
-for i in frontend geo profile rate recommend rsv search user
+for i in frontend geo profile rate recommend rsv search user alert
 do
   IMAGE=hotel_reserv_${i}_single_node
   echo Processing image ${IMAGE}
@@ -2,7 +2,7 @@
 
 NS="hotel-res"
 
-work="consul frontend geo jaeger memcached-profile memcached-rate memcached-reserve mongodb-geo mongodb-profile mongodb-rate mongodb-recommendation mongodb-reservation mongodb-user profile rate recommendation reservation search user"
+work="consul frontend geo jaeger memcached-lockout memcached-profile memcached-rate memcached-reserve mongodb-alert mongodb-geo mongodb-profile mongodb-rate mongodb-recommendation mongodb-reservation mongodb-user profile rate recommendation reservation search user alert"
 
 for d in ${work}
 do
@@ -26,7 +26,7 @@ fi
 
 cd ..
 
-for s in consul frontend geo jaeger memcached-profile memcached-rate memcached-reserve mongodb-geo mongodb-profile mongodb-rate mongodb-recommendation mongodb-reservation mongodb-user profile rate recommendation reservation search user
+for s in consul frontend geo jaeger memcached-lockout memcached-profile memcached-rate memcached-reserve mongodb-alert mongodb-geo mongodb-profile mongodb-rate mongodb-recommendation mongodb-reservation mongodb-user profile rate recommendation reservation search user alert
 do
  	oc delete service/$s -n ${NS} &
  	oc delete deployment/$s -n ${NS} &
@@ -36,7 +36,7 @@ wait
 
 #	oc delete pod/$d -n ${NS} &
 
-for i in geo profile rate recommendation reservation user
+for i in geo profile rate recommendation reservation user alert
 do
 	oc delete pv/$i -n ${NS} &
 done
@@ -0,0 +1,65 @@
+// Package passhash hashes and verifies passwords.
+//
+// Hashes are encoded in the bcrypt modular crypt format,
+// "$2a$<cost>$<salt><hash>", which records the algorithm, the cost and a
+// random per-password salt. Legacy hashes, the hex encoded unsalted SHA-256
+// of the password, are still verified so they can be upgraded on the next
+// successful login.
+package passhash
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"strings"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// DefaultCost is the bcrypt cost of new hashes. Hashes of a lower cost
+// are reported as needing a rehash.
+const DefaultCost = bcrypt.DefaultCost
+
+// Hash returns the encoded hash of password.
+func Hash(password string) (string, error) {
+	b, err := bcrypt.GenerateFromPassword([]byte(password), DefaultCost)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// Verify reports whether password matches the encoded hash, and if so,
+// whether the hash should be replaced by a new one from Hash.
+func Verify(encoded, password string) (ok, rehash bool) {
+	if IsLegacy(encoded) {
+		sum := sha256.Sum256([]byte(password))
+		ok = subtle.ConstantTimeCompare([]byte(hex.EncodeToString(sum[:])), []byte(strings.ToLower(encoded))) == 1
+		return ok, ok
+	}
+
+	if bcrypt.CompareHashAndPassword([]byte(encoded), []byte(password)) != nil {
+		return false, false
+	}
+	cost, err := bcrypt.Cost([]byte(encoded))
+	return true, err != nil || cost < DefaultCost
+}
+
+// IsHash reports whether encoded is a hash Verify understands, as opposed
+// to, say, a password stored in plain text.
+func IsHash(encoded string) bool {
+	if IsLegacy(encoded) {
+		return true
+	}
+	_, err := bcrypt.Cost([]byte(encoded))
+	return err == nil
+}
+
+// IsLegacy reports whether encoded is an unsalted SHA-256 hash.
+func IsLegacy(encoded string) bool {
+	if len(encoded) != sha256.Size*2 {
+		return false
+	}
+	_, err := hex.DecodeString(encoded)
+	return err == nil
+}
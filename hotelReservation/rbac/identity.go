@@ -0,0 +1,215 @@
+package rbac
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	identityHeader  = "x-identity"
+	signatureHeader = "x-identity-signature"
+	// how long a signed identity is accepted; calls are signed as they
+	// are made, so this only has to cover clock skew
+	maxIdentityAge = time.Minute
+)
+
+// ErrInvalid is returned for identities that are malformed, signed by an
+// unknown key, whose signature does not match or that are too old.
+var ErrInvalid = errors.New("rbac: invalid identity")
+
+// signed is an identity as sent in the metadata of a call.
+type signed struct {
+	Identity
+	// SignedAt is in unix seconds
+	SignedAt int64 `json:"iat"`
+}
+
+type key struct {
+	id     string
+	secret []byte
+}
+
+// Signer signs and verifies the identities sent with calls. Every service
+// must share its keys.
+type Signer struct {
+	keys []key
+}
+
+// NewSigner returns a Signer for comma separated "kid:secret" keys. The
+// first key signs; the others only verify, so a key is rotated by adding
+// the new one second everywhere, then moving it first, then dropping the
+// old one.
+func NewSigner(keys string) (*Signer, error) {
+	s := new(Signer)
+	for _, k := range strings.Split(keys, ",") {
+		kv := strings.SplitN(strings.TrimSpace(k), ":", 2)
+		if len(kv) != 2 || kv[0] == "" || len(kv[1]) < 16 {
+			return nil, fmt.Errorf("rbac: keys must be kid:secret with a secret of at least 16 characters")
+		}
+		s.keys = append(s.keys, key{id: kv[0], secret: []byte(kv[1])})
+	}
+	return s, nil
+}
+
+func mac(secret []byte, payload string) string {
+	h := hmac.New(sha256.New, secret)
+	h.Write([]byte(payload))
+	return base64.RawURLEncoding.EncodeToString(h.Sum(nil))
+}
+
+// metadata returns the metadata sending id.
+func (s *Signer) metadata(id *Identity) (map[string]string, error) {
+	b, err := json.Marshal(signed{Identity: *id, SignedAt: time.Now().Unix()})
+	if err != nil {
+		return nil, err
+	}
+	payload := base64.RawURLEncoding.EncodeToString(b)
+	k := s.keys[0]
+	return map[string]string{
+		identityHeader:  payload,
+		signatureHeader: k.id + "." + mac(k.secret, payload),
+	}, nil
+}
+
+// verify returns the identity sent in md, or nil if there is none.
+func (s *Signer) verify(md metadata.MD) (*Identity, error) {
+	payloads, sigs := md[identityHeader], md[signatureHeader]
+	if len(payloads) == 0 && len(sigs) == 0 {
+		return nil, nil
+	}
+	if len(payloads) != 1 || len(sigs) != 1 {
+		return nil, ErrInvalid
+	}
+
+	kidSig := strings.SplitN(sigs[0], ".", 2)
+	if len(kidSig) != 2 {
+		return nil, ErrInvalid
+	}
+	var secret []byte
+	for _, k := range s.keys {
+		if k.id == kidSig[0] {
+			secret = k.secret
+		}
+	}
+	if secret == nil || !hmac.Equal([]byte(mac(secret, payloads[0])), []byte(kidSig[1])) {
+		return nil, ErrInvalid
+	}
+
+	b, err := base64.RawURLEncoding.DecodeString(payloads[0])
+	if err != nil {
+		return nil, ErrInvalid
+	}
+	var id signed
+	if err := json.Unmarshal(b, &id); err != nil {
+		return nil, ErrInvalid
+	}
+	age := time.Since(time.Unix(id.SignedAt, 0))
+	if age > maxIdentityAge || age < -maxIdentityAge {
+		return nil, ErrInvalid
+	}
+	if _, ok := ParseRole(string(id.Role)); !ok {
+		return nil, ErrInvalid
+	}
+	return &id.Identity, nil
+}
+
+type outgoingKey struct{}
+
+type incomingKey struct{}
+
+// WithIdentity returns a context whose calls are made on behalf of id.
+func WithIdentity(ctx context.Context, id Identity) context.Context {
+	return context.WithValue(ctx, outgoingKey{}, &id)
+}
+
+// Outgoing returns the identity set by WithIdentity that the calls made
+// with a context are made on behalf of, or nil.
+func Outgoing(ctx context.Context) *Identity {
+	id, _ := ctx.Value(outgoingKey{}).(*Identity)
+	return id
+}
+
+// FromContext returns the identity that made the call a context is
+// serving, or nil for anonymous calls. The identity is not passed on to
+// the calls made with the context.
+func FromContext(ctx context.Context) *Identity {
+	id, _ := ctx.Value(incomingKey{}).(*Identity)
+	return id
+}
+
+// perRPC are call credentials signing the identity of the context of each
+// call, or else their own.
+type perRPC struct {
+	signer *Signer
+	self   *Identity
+}
+
+func (c perRPC) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	id := Outgoing(ctx)
+	if id == nil {
+		id = c.self
+	}
+	if id == nil {
+		return nil, nil
+	}
+	return c.signer.metadata(id)
+}
+
+func (perRPC) RequireTransportSecurity() bool {
+	return false
+}
+
+// Credentials returns call credentials sending the identity set by
+// WithIdentity on the context of a call, or else self. A nil self makes
+// the other calls anonymous.
+func (s *Signer) Credentials(self *Identity) credentials.PerRPCCredentials {
+	return perRPC{signer: s, self: self}
+}
+
+// UnaryServerInterceptor rejects the calls policy does not allow. The
+// identity of allowed calls is available to their handlers from
+// FromContext.
+func UnaryServerInterceptor(s *Signer, policy Policy) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		id, err := s.verify(md)
+		if err != nil {
+			return nil, status.Error(codes.Unauthenticated, err.Error())
+		}
+		if err := policy.Authorize(info.FullMethod, id, req); err != nil {
+			return nil, err
+		}
+		if id != nil {
+			ctx = context.WithValue(ctx, incomingKey{}, id)
+		}
+		return handler(ctx, req)
+	}
+}
+
+// ChainUnaryServer returns an interceptor running interceptors in order,
+// the first outermost, as grpc.UnaryInterceptor takes only one.
+func ChainUnaryServer(interceptors ...grpc.UnaryServerInterceptor) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		next := handler
+		for i := len(interceptors) - 1; i >= 0; i-- {
+			interceptor, inner := interceptors[i], next
+			next = func(ctx context.Context, req interface{}) (interface{}, error) {
+				return interceptor(ctx, req, info, inner)
+			}
+		}
+		return next(ctx, req)
+	}
+}
@@ -0,0 +1,141 @@
+// Package rbac authorizes gRPC calls by the role of their caller.
+//
+// Callers are guests, hotel managers, chain admins, operators, or the
+// services themselves. Each service has a Policy saying which roles may
+// call each of its methods. Hotel managers and chain admins are granted
+// hotels, and may only call methods about those hotels; guests may only
+// call methods about themselves. Operators and services may call any
+// method.
+//
+// The identity of a caller is signed and sent in the metadata of each
+// call, see Signer.
+package rbac
+
+import (
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Role is what a caller may do.
+type Role string
+
+const (
+	// Guest is a user booking hotels
+	Guest Role = "guest"
+	// HotelManager manages the hotels it is granted
+	HotelManager Role = "hotel_manager"
+	// ChainAdmin manages the hotels of a chain, and their managers
+	ChainAdmin Role = "chain_admin"
+	// Operator runs the application, for every hotel and user
+	Operator Role = "operator"
+	// Service is the role of the services calling each other
+	Service Role = "service"
+)
+
+// ParseRole returns the role named s, or false if there is none.
+func ParseRole(s string) (Role, bool) {
+	switch r := Role(s); r {
+	case Guest, HotelManager, ChainAdmin, Operator, Service:
+		return r, true
+	}
+	return "", false
+}
+
+// hotelScoped returns whether r is limited to the hotels it is granted.
+func (r Role) hotelScoped() bool {
+	return r == HotelManager || r == ChainAdmin
+}
+
+// Identity is who a call is made by.
+type Identity struct {
+	// Subject is the username of a guest, the email of an admin or the
+	// name of a service
+	Subject string `json:"sub"`
+	Role    Role   `json:"role"`
+	// Hotels are the hotels granted to a hotel manager or chain admin
+	Hotels []string `json:"hotels,omitempty"`
+}
+
+// Granted returns whether id may act on hotelId.
+func (id *Identity) Granted(hotelId string) bool {
+	if !id.Role.hotelScoped() {
+		return true
+	}
+	for _, h := range id.Hotels {
+		if h == hotelId {
+			return true
+		}
+	}
+	return false
+}
+
+// Rule is who may call a method.
+type Rule struct {
+	// Public methods may be called by anyone, even without an identity.
+	// They authenticate their callers themselves or serve public data.
+	Public bool
+	// Roles may call the method, besides operators and services
+	Roles []Role
+	// Hotels returns the hotels a request is about. Hotel managers and
+	// chain admins must be granted each of them. A request about no hotel
+	// in particular is about all of them, which only operators and
+	// services may call.
+	Hotels func(req interface{}) []string
+	// Owner returns the user a request is about, if any. Only that guest,
+	// operators and services may call it, even if the method is public.
+	Owner func(req interface{}) string
+}
+
+// Policy maps the full names of the methods of a service, such as
+// "/user.User/CheckUser", to their rules. Methods without a rule may only
+// be called by operators and services.
+type Policy map[string]Rule
+
+// Authorize returns nil if id may call method with req, or else an
+// Unauthenticated or PermissionDenied status error. A nil id is an
+// anonymous caller.
+func (p Policy) Authorize(method string, id *Identity, req interface{}) error {
+	if id != nil && (id.Role == Operator || id.Role == Service) {
+		return nil
+	}
+	rule := p[method]
+
+	if rule.Owner != nil {
+		if owner := rule.Owner(req); owner != "" {
+			if id == nil {
+				return status.Errorf(codes.Unauthenticated, "%s requires a signed in user", method)
+			}
+			if id.Role != Guest || id.Subject != owner {
+				return status.Errorf(codes.PermissionDenied, "%s may not act for user %q", id.Subject, owner)
+			}
+			return nil
+		}
+	}
+	if rule.Public {
+		return nil
+	}
+
+	if id == nil {
+		return status.Errorf(codes.Unauthenticated, "%s requires an identity", method)
+	}
+	allowed := false
+	for _, r := range rule.Roles {
+		allowed = allowed || r == id.Role
+	}
+	if !allowed {
+		return status.Errorf(codes.PermissionDenied, "role %q may not call %s", id.Role, method)
+	}
+
+	if rule.Hotels != nil {
+		hotels := rule.Hotels(req)
+		if len(hotels) == 0 {
+			return status.Errorf(codes.PermissionDenied, "role %q may not call %s for all hotels", id.Role, method)
+		}
+		for _, h := range hotels {
+			if !id.Granted(h) {
+				return status.Errorf(codes.PermissionDenied, "%s is not granted hotel %s", id.Subject, h)
+			}
+		}
+	}
+	return nil
+}
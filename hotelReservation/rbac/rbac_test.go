@@ -0,0 +1,181 @@
+package rbac
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const testKeys = "k1:0123456789abcdef,k0:fedcba9876543210"
+
+type hotelRequest struct {
+	hotelIds []string
+	username string
+}
+
+var testPolicy = Policy{
+	"/test.Test/Public": {Public: true},
+	"/test.Test/Hotels": {
+		Roles: []Role{HotelManager, ChainAdmin},
+		Hotels: func(req interface{}) []string {
+			return req.(*hotelRequest).hotelIds
+		},
+	},
+	"/test.Test/Owner": {
+		Public: true,
+		Owner: func(req interface{}) string {
+			return req.(*hotelRequest).username
+		},
+	},
+}
+
+func testSigner(t *testing.T) *Signer {
+	s, err := NewSigner(testKeys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+// signedAt returns the metadata s sends id with, as if signed at t.
+func signedAt(s *Signer, id Identity, t time.Time) metadata.MD {
+	b, _ := json.Marshal(signed{Identity: id, SignedAt: t.Unix()})
+	payload := base64.RawURLEncoding.EncodeToString(b)
+	k := s.keys[0]
+	return metadata.Pairs(identityHeader, payload, signatureHeader, k.id+"."+mac(k.secret, payload))
+}
+
+func TestVerify(t *testing.T) {
+	s := testSigner(t)
+	manager := Identity{Subject: "manager@example.com", Role: HotelManager, Hotels: []string{"1"}}
+	now := time.Now()
+
+	tamper := func(md metadata.MD, header, value string) metadata.MD {
+		md = md.Copy()
+		md[header] = []string{value}
+		return md
+	}
+	valid := signedAt(s, manager, now)
+	forged, _ := json.Marshal(signed{
+		Identity: Identity{Subject: manager.Subject, Role: Operator},
+		SignedAt: now.Unix(),
+	})
+	rotated, _ := NewSigner("k0:fedcba9876543210")
+	unknown, _ := NewSigner("k2:0123456789abcdef")
+
+	tests := []struct {
+		name  string
+		md    metadata.MD
+		want  *Identity
+		valid bool
+	}{
+		{name: "anonymous", md: metadata.MD{}, valid: true},
+		{name: "signed", md: valid, want: &manager, valid: true},
+		{name: "signed by an older key", md: signedAt(rotated, manager, now), want: &manager, valid: true},
+		{name: "signed by an unknown key", md: signedAt(unknown, manager, now)},
+		{name: "tampered signature", md: tamper(valid, signatureHeader, valid[signatureHeader][0]+"x")},
+		{name: "tampered identity", md: tamper(valid, identityHeader, base64.RawURLEncoding.EncodeToString(forged))},
+		{name: "missing signature", md: metadata.Pairs(identityHeader, valid[identityHeader][0])},
+		{name: "expired", md: signedAt(s, manager, now.Add(-2*maxIdentityAge))},
+		{name: "from the future", md: signedAt(s, manager, now.Add(2*maxIdentityAge))},
+		{name: "unknown role", md: signedAt(s, Identity{Subject: "root", Role: "root"}, now)},
+	}
+
+	for _, tt := range tests {
+		got, err := s.verify(tt.md)
+		if !tt.valid {
+			if err != ErrInvalid {
+				t.Errorf("%s: got %v, %v, want ErrInvalid", tt.name, got, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if (got == nil) != (tt.want == nil) || got != nil && (got.Subject != tt.want.Subject || got.Role != tt.want.Role) {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestAuthorize(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		id     *Identity
+		req    *hotelRequest
+		want   codes.Code
+	}{
+		{"public", "/test.Test/Public", nil, &hotelRequest{}, codes.OK},
+		{"no rule", "/test.Test/Internal", &Identity{Role: ChainAdmin, Hotels: []string{"1"}}, &hotelRequest{}, codes.PermissionDenied},
+		{"no rule for services", "/test.Test/Internal", &Identity{Role: Service}, &hotelRequest{}, codes.OK},
+		{"anonymous", "/test.Test/Hotels", nil, &hotelRequest{hotelIds: []string{"1"}}, codes.Unauthenticated},
+		{"granted hotel", "/test.Test/Hotels", &Identity{Role: HotelManager, Hotels: []string{"1", "2"}}, &hotelRequest{hotelIds: []string{"1"}}, codes.OK},
+		{"wrong role", "/test.Test/Hotels", &Identity{Role: Guest}, &hotelRequest{hotelIds: []string{"1"}}, codes.PermissionDenied},
+		{"hotel outside the list", "/test.Test/Hotels", &Identity{Role: HotelManager, Hotels: []string{"1"}}, &hotelRequest{hotelIds: []string{"1", "2"}}, codes.PermissionDenied},
+		{"all hotels", "/test.Test/Hotels", &Identity{Role: ChainAdmin, Hotels: []string{"1"}}, &hotelRequest{}, codes.PermissionDenied},
+		{"operator", "/test.Test/Hotels", &Identity{Role: Operator}, &hotelRequest{}, codes.OK},
+		{"owner", "/test.Test/Owner", &Identity{Subject: "alice", Role: Guest}, &hotelRequest{username: "alice"}, codes.OK},
+		{"other user", "/test.Test/Owner", &Identity{Subject: "bob", Role: Guest}, &hotelRequest{username: "alice"}, codes.PermissionDenied},
+		{"anonymous owner", "/test.Test/Owner", nil, &hotelRequest{username: "alice"}, codes.Unauthenticated},
+		{"no owner", "/test.Test/Owner", nil, &hotelRequest{}, codes.OK},
+	}
+
+	for _, tt := range tests {
+		err := testPolicy.Authorize(tt.method, tt.id, tt.req)
+		if got := status.Code(err); got != tt.want {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	s := testSigner(t)
+	intercept := UnaryServerInterceptor(s, testPolicy)
+	info := &grpc.UnaryServerInfo{FullMethod: "/test.Test/Hotels"}
+	req := &hotelRequest{hotelIds: []string{"1"}}
+
+	var caller *Identity
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		caller = FromContext(ctx)
+		return nil, nil
+	}
+
+	manager := Identity{Subject: "manager@example.com", Role: HotelManager, Hotels: []string{"1"}}
+	md, err := s.metadata(&manager)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.New(md))
+	if _, err := intercept(ctx, req, info, handler); err != nil {
+		t.Fatal(err)
+	}
+	if caller == nil || caller.Subject != manager.Subject {
+		t.Errorf("got caller %v, want %s", caller, manager.Subject)
+	}
+
+	// an expired identity is rejected before the policy is checked
+	caller = nil
+	ctx = metadata.NewIncomingContext(context.Background(), signedAt(s, manager, time.Now().Add(-time.Hour)))
+	_, err = intercept(ctx, req, info, handler)
+	if status.Code(err) != codes.Unauthenticated || caller != nil {
+		t.Errorf("got %v, want Unauthenticated", err)
+	}
+
+	// so is one another service signed with a key this one does not know
+	other, _ := NewSigner("k2:0123456789abcdef")
+	md, _ = other.metadata(&Identity{Subject: "srv-other", Role: Service})
+	ctx = metadata.NewIncomingContext(context.Background(), metadata.New(md))
+	_, err = intercept(ctx, req, info, handler)
+	if status.Code(err) != codes.Unauthenticated || caller != nil {
+		t.Errorf("got %v, want Unauthenticated", err)
+	}
+}
@@ -0,0 +1,88 @@
+package admin
+
+import (
+	"log"
+
+	"github.com/harlow/go-micro-services/rbac"
+	pb "github.com/harlow/go-micro-services/services/admin/proto"
+	geo "github.com/harlow/go-micro-services/services/geo/proto"
+	profile "github.com/harlow/go-micro-services/services/profile/proto"
+	recommendation "github.com/harlow/go-micro-services/services/recommendation/proto"
+	reservation "github.com/harlow/go-micro-services/services/reservation/proto"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// decommissionStep takes a hotel out of one service. Steps must be safe
+// to repeat, so a failed decommission is finished by calling it again.
+type decommissionStep struct {
+	name string
+	do   func(ctx context.Context, hotelId string) error
+}
+
+// decommissionSteps are the steps of DecommissionHotel, in order. The
+// reservation service goes first as it refuses hotels with stays to come.
+func (s *Server) decommissionSteps() []decommissionStep {
+	return []decommissionStep{
+		{
+			name: "reservation",
+			do: func(ctx context.Context, hotelId string) error {
+				_, err := s.reservationClient.DecommissionHotel(ctx, &reservation.CapacityRequest{HotelId: hotelId})
+				return err
+			},
+		},
+		{
+			name: "geo",
+			do: func(ctx context.Context, hotelId string) error {
+				_, err := s.geoClient.RemoveHotelLocation(ctx, &geo.RemoveLocationRequest{HotelId: hotelId})
+				return err
+			},
+		},
+		{
+			name: "recommendation",
+			do: func(ctx context.Context, hotelId string) error {
+				_, err := s.recommendationClient.RemoveHotel(ctx, &recommendation.HotelRequest{HotelId: hotelId})
+				return err
+			},
+		},
+		{
+			name: "profile",
+			do: func(ctx context.Context, hotelId string) error {
+				_, err := s.profileClient.RetireProfile(ctx, &profile.DeleteProfileRequest{HotelId: hotelId})
+				return err
+			},
+		},
+	}
+}
+
+// DecommissionHotel takes a hotel out of the reservation, geo and
+// recommendation services and the profile suggestions. Its reservations,
+// rate plans and profile are kept for the stays booked there.
+func (s *Server) DecommissionHotel(ctx context.Context, req *pb.DecommissionRequest) (*pb.DecommissionReply, error) {
+	if req.Id == "" {
+		return nil, status.Error(codes.InvalidArgument, "hotel id must be set")
+	}
+
+	res := new(pb.DecommissionReply)
+	for _, step := range s.decommissionSteps() {
+		sctx, cancel := context.WithTimeout(ctx, stepTimeout)
+		err := step.do(sctx, req.Id)
+		cancel()
+		if err != nil {
+			log.Printf("Failed decommission hotel %s at %s: %v\n", req.Id, step.name, err)
+			if status.Code(err) == codes.Unknown {
+				return nil, status.Errorf(codes.Internal, "decommission failed at %s, retry to finish it: %v", step.name, err)
+			}
+			return nil, err
+		}
+		res.Steps = append(res.Steps, step.name)
+	}
+
+	by := ""
+	if caller := rbac.FromContext(ctx); caller != nil {
+		by = caller.Subject
+	}
+	log.Printf("Decommissioned hotel %s by %q\n", req.Id, by)
+	return res, nil
+}
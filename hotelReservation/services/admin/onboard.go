@@ -0,0 +1,365 @@
+package admin
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	pb "github.com/harlow/go-micro-services/services/admin/proto"
+	geo "github.com/harlow/go-micro-services/services/geo/proto"
+	profile "github.com/harlow/go-micro-services/services/profile/proto"
+	rate "github.com/harlow/go-micro-services/services/rate/proto"
+	recommendation "github.com/harlow/go-micro-services/services/recommendation/proto"
+	reservation "github.com/harlow/go-micro-services/services/reservation/proto"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+const (
+	// how long a service may take to provision a hotel
+	stepTimeout = 30 * time.Second
+	// how often onboardings cut short are looked for
+	recoverInterval = time.Minute
+	// an onboarding without progress for this long was cut short, by a
+	// crash or restart, and is undone
+	onboardingTimeout = 5 * time.Minute
+)
+
+// States of an onboarding. Done onboardings are kept so the hotel id is
+// not onboarded twice; undone ones are deleted.
+const (
+	onboardingRunning = "running"
+	onboardingFailed  = "failed"
+	onboardingDone    = "done"
+)
+
+// onboarding is the progress of a CreateHotel saga in admin-db.onboarding.
+// Steps lists the steps started, in order, for them to be undone if one
+// fails.
+type onboarding struct {
+	HotelId string    `bson:"_id"`
+	State   string    `bson:"state"`
+	Steps   []string  `bson:"steps"`
+	Error   string    `bson:"error,omitempty"`
+	Started time.Time `bson:"started"`
+	Updated time.Time `bson:"updated"`
+}
+
+// onboardingStep provisions a hotel in one service. undo removes it again
+// and must be safe to repeat.
+type onboardingStep struct {
+	name string
+	do   func(ctx context.Context, req *pb.CreateHotelRequest) error
+	undo func(ctx context.Context, hotelId string) error
+}
+
+// onboardingSteps are the steps of CreateHotel, in order. The location
+// goes last as it makes the hotel searchable.
+func (s *Server) onboardingSteps() []onboardingStep {
+	return []onboardingStep{
+		{
+			name: "profile",
+			do: func(ctx context.Context, req *pb.CreateHotelRequest) error {
+				a := req.Address
+				_, err := s.profileClient.CreateProfile(ctx, &profile.Hotel{
+					Id:          req.Id,
+					Name:        req.Name,
+					PhoneNumber: req.PhoneNumber,
+					Description: req.Description,
+					Address: &profile.Address{
+						StreetNumber: a.StreetNumber,
+						StreetName:   a.StreetName,
+						City:         a.City,
+						State:        a.State,
+						Country:      a.Country,
+						PostalCode:   a.PostalCode,
+						Lat:          a.Lat,
+						Lon:          a.Lon,
+					},
+					Price: req.Price,
+				})
+				return err
+			},
+			undo: func(ctx context.Context, hotelId string) error {
+				_, err := s.profileClient.DeleteProfile(ctx, &profile.DeleteProfileRequest{HotelId: hotelId})
+				return err
+			},
+		},
+		{
+			name: "rate",
+			do: func(ctx context.Context, req *pb.CreateHotelRequest) error {
+				plans := make([]*rate.RatePlan, 0, len(req.RatePlans))
+				for _, p := range req.RatePlans {
+					plans = append(plans, &rate.RatePlan{
+						HotelId: req.Id,
+						Code:    p.Code,
+						InDate:  p.InDate,
+						OutDate: p.OutDate,
+						RoomType: &rate.RoomType{
+							BookableRate:       p.BookableRate,
+							TotalRate:          p.TotalRate,
+							TotalRateInclusive: p.TotalRateInclusive,
+							Code:               p.Code,
+							RoomDescription:    p.RoomDescription,
+						},
+					})
+				}
+				_, err := s.rateClient.AddRatePlans(ctx, &rate.RatePlansRequest{HotelId: req.Id, RatePlans: plans})
+				return err
+			},
+			undo: func(ctx context.Context, hotelId string) error {
+				_, err := s.rateClient.DeleteRatePlans(ctx, &rate.RatePlansRequest{HotelId: hotelId})
+				return err
+			},
+		},
+		{
+			name: "reservation",
+			do: func(ctx context.Context, req *pb.CreateHotelRequest) error {
+				_, err := s.reservationClient.AddCapacity(ctx, &reservation.CapacityRequest{
+					HotelId:      req.Id,
+					NumberOfRoom: req.Rooms,
+				})
+				return err
+			},
+			undo: func(ctx context.Context, hotelId string) error {
+				_, err := s.reservationClient.RemoveCapacity(ctx, &reservation.CapacityRequest{HotelId: hotelId})
+				return err
+			},
+		},
+		{
+			name: "recommendation",
+			do: func(ctx context.Context, req *pb.CreateHotelRequest) error {
+				_, err := s.recommendationClient.AddHotel(ctx, &recommendation.HotelRequest{
+					HotelId: req.Id,
+					Lat:     float64(req.Address.Lat),
+					Lon:     float64(req.Address.Lon),
+				})
+				return err
+			},
+			undo: func(ctx context.Context, hotelId string) error {
+				_, err := s.recommendationClient.RemoveHotel(ctx, &recommendation.HotelRequest{HotelId: hotelId})
+				return err
+			},
+		},
+		{
+			name: "geo",
+			do: func(ctx context.Context, req *pb.CreateHotelRequest) error {
+				_, err := s.geoClient.AddHotelLocation(ctx, &geo.LocationRequest{
+					HotelId: req.Id,
+					Lat:     req.Address.Lat,
+					Lon:     req.Address.Lon,
+				})
+				return err
+			},
+			undo: func(ctx context.Context, hotelId string) error {
+				_, err := s.geoClient.RemoveHotelLocation(ctx, &geo.RemoveLocationRequest{HotelId: hotelId})
+				return err
+			},
+		},
+	}
+}
+
+// checkCreateHotel validates a new hotel before any service is called,
+// so bad input leaves nothing to undo.
+func checkCreateHotel(req *pb.CreateHotelRequest) error {
+	if strings.TrimSpace(req.Id) == "" || strings.TrimSpace(req.Name) == "" {
+		return fmt.Errorf("hotel id and name must be set")
+	}
+	a := req.Address
+	if a == nil || a.StreetName == "" || a.City == "" || a.Country == "" {
+		return fmt.Errorf("address must have a street name, city and country")
+	}
+	if a.Lat < -90 || a.Lat > 90 || a.Lon < -180 || a.Lon > 180 {
+		return fmt.Errorf("lat/lon out of range")
+	}
+	if req.Price < 0 {
+		return fmt.Errorf("price must not be negative")
+	}
+	if req.Rooms <= 0 {
+		return fmt.Errorf("rooms must be positive")
+	}
+	seen := make(map[string]bool)
+	for _, p := range req.RatePlans {
+		if p.Code == "" {
+			return fmt.Errorf("rate plans must have a code")
+		}
+		key := p.Code + " " + p.InDate
+		if seen[key] {
+			return fmt.Errorf("rate plan %q is given twice from %s", p.Code, p.InDate)
+		}
+		seen[key] = true
+		in, err1 := time.Parse("2006-01-02", p.InDate)
+		out, err2 := time.Parse("2006-01-02", p.OutDate)
+		if err1 != nil || err2 != nil {
+			return fmt.Errorf("rate plan %q: dates must be YYYY-MM-DD", p.Code)
+		}
+		if !in.Before(out) {
+			return fmt.Errorf("rate plan %q: outDate must be after inDate", p.Code)
+		}
+		if p.BookableRate <= 0 || p.TotalRate <= 0 || p.TotalRateInclusive < p.TotalRate {
+			return fmt.Errorf("rate plan %q: rates must be positive, the inclusive one no lower than the total", p.Code)
+		}
+	}
+	return nil
+}
+
+// CreateHotel provisions a new hotel in each service in turn, recording
+// every step as it starts in admin-db.onboarding. If a step fails the
+// steps started are undone in reverse, and the error of the step is
+// returned; if undoing fails too the onboarding is left failed and
+// retried by recoverLoop.
+func (s *Server) CreateHotel(ctx context.Context, req *pb.CreateHotelRequest) (*pb.CreateHotelReply, error) {
+	if err := checkCreateHotel(req); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	session := s.MongoSession.Copy()
+	defer session.Close()
+	c := session.DB("admin-db").C("onboarding")
+
+	now := time.Now()
+	err := c.Insert(&onboarding{
+		HotelId: req.Id,
+		State:   onboardingRunning,
+		Steps:   []string{},
+		Started: now,
+		Updated: now,
+	})
+	if mgo.IsDup(err) {
+		return nil, status.Errorf(codes.AlreadyExists, "hotel %s is or was already onboarded", req.Id)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	res := &pb.CreateHotelReply{Id: req.Id}
+	for _, step := range s.onboardingSteps() {
+		// recorded before it runs, so a crash while it runs still gets it
+		// undone; undoing a step that did nothing is harmless
+		err := c.UpdateId(req.Id, bson.M{
+			"$push": bson.M{"steps": step.name},
+			"$set":  bson.M{"updated": time.Now()},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		sctx, cancel := context.WithTimeout(ctx, stepTimeout)
+		err = step.do(sctx, req)
+		cancel()
+		if err != nil {
+			log.Printf("Failed onboard hotel %s at %s: %v\n", req.Id, step.name, err)
+			// a step refusing a hotel it already has created nothing, and
+			// must not undo what was there before
+			if status.Code(err) == codes.AlreadyExists {
+				if perr := c.UpdateId(req.Id, bson.M{"$pull": bson.M{"steps": step.name}}); perr != nil {
+					log.Printf("Failed forget step %s of hotel %s: %v\n", step.name, req.Id, perr)
+					return nil, status.Errorf(codes.Internal, "onboarding failed at %s (%v) and could not be undone yet: %v", step.name, err, perr)
+				}
+			}
+			// the saga is not stopped by the caller going away
+			if cerr := s.compensate(context.Background(), c, req.Id, err); cerr != nil {
+				log.Printf("Failed undo onboarding of hotel %s: %v\n", req.Id, cerr)
+				return nil, status.Errorf(codes.Internal, "onboarding failed at %s (%v) and could not be undone yet: %v", step.name, err, cerr)
+			}
+			if status.Code(err) == codes.Unknown {
+				return nil, status.Errorf(codes.Internal, "onboarding failed at %s and was undone: %v", step.name, err)
+			}
+			return nil, err
+		}
+		res.Steps = append(res.Steps, step.name)
+	}
+
+	err = c.UpdateId(req.Id, bson.M{"$set": bson.M{"state": onboardingDone, "updated": time.Now()}})
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("Onboarded hotel %s\n", req.Id)
+	return res, nil
+}
+
+// compensate undoes the recorded steps of an onboarding, last first, and
+// deletes it. If a step can not be undone the onboarding is marked failed
+// with the steps still to undo.
+func (s *Server) compensate(ctx context.Context, c *mgo.Collection, hotelId string, cause error) error {
+	var o onboarding
+	if err := c.FindId(hotelId).One(&o); err != nil {
+		return err
+	}
+
+	undo := make(map[string]func(context.Context, string) error)
+	for _, step := range s.onboardingSteps() {
+		undo[step.name] = step.undo
+	}
+
+	for i := len(o.Steps) - 1; i >= 0; i-- {
+		if err := undo[o.Steps[i]](ctx, hotelId); err != nil {
+			msg := fmt.Sprintf("undo %s: %v", o.Steps[i], err)
+			if cause != nil {
+				msg = fmt.Sprintf("%v; %s", cause, msg)
+			}
+			uerr := c.UpdateId(hotelId, bson.M{"$set": bson.M{
+				"state":   onboardingFailed,
+				"steps":   o.Steps[:i+1],
+				"error":   msg,
+				"updated": time.Now(),
+			}})
+			if uerr != nil {
+				log.Println("Failed record onboarding failure: ", uerr)
+			}
+			return err
+		}
+	}
+
+	return c.RemoveId(hotelId)
+}
+
+func (s *Server) recoverLoop() {
+	for range time.Tick(recoverInterval) {
+		if err := s.recoverOnboardings(); err != nil {
+			log.Println("Failed recover onboardings: ", err)
+		}
+	}
+}
+
+// recoverOnboardings undoes the onboardings that failed to be undone, or
+// stopped making progress. Each is claimed by moving its updated time, so
+// that replicas do not undo it at once.
+func (s *Server) recoverOnboardings() error {
+	session := s.MongoSession.Copy()
+	defer session.Close()
+	c := session.DB("admin-db").C("onboarding")
+
+	cutoff := time.Now().Add(-onboardingTimeout)
+	var stale []onboarding
+	err := c.Find(bson.M{
+		"state":   bson.M{"$in": []string{onboardingRunning, onboardingFailed}},
+		"updated": bson.M{"$lt": cutoff},
+	}).All(&stale)
+	if err != nil {
+		return err
+	}
+
+	for _, o := range stale {
+		err := c.Update(
+			bson.M{"_id": o.HotelId, "updated": o.Updated},
+			bson.M{"$set": bson.M{"updated": time.Now()}},
+		)
+		if err == mgo.ErrNotFound {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if err := s.compensate(context.Background(), c, o.HotelId, nil); err != nil {
+			log.Printf("Failed undo onboarding of hotel %s: %v\n", o.HotelId, err)
+			continue
+		}
+		log.Printf("Undid onboarding of hotel %s\n", o.HotelId)
+	}
+	return nil
+}
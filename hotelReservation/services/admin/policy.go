@@ -0,0 +1,43 @@
+package admin
+
+import (
+	"github.com/harlow/go-micro-services/rbac"
+	pb "github.com/harlow/go-micro-services/services/admin/proto"
+)
+
+// self is the identity the admin service calls other services with.
+var self = &rbac.Identity{Subject: name, Role: rbac.Service}
+
+// policy is who may call the admin service. Chain admins may only
+// register admins of their own hotels, and decommission them; only
+// operators create hotels. Admins only get reports on their hotels.
+var policy = rbac.Policy{
+	"/admin.Admin/Login": {Public: true},
+	"/admin.Admin/Register": {
+		Roles: []rbac.Role{rbac.ChainAdmin},
+		Hotels: func(req interface{}) []string {
+			return req.(*pb.RegisterRequest).Hotels
+		},
+	},
+	"/admin.Admin/Update": {
+		Roles: []rbac.Role{rbac.HotelManager, rbac.ChainAdmin},
+		Hotels: func(req interface{}) []string {
+			return []string{req.(*pb.UpdateRequest).Id}
+		},
+	},
+	"/admin.Admin/DecommissionHotel": {
+		Roles: []rbac.Role{rbac.ChainAdmin},
+		Hotels: func(req interface{}) []string {
+			return []string{req.(*pb.DecommissionRequest).Id}
+		},
+	},
+	"/admin.Admin/HotelReport": {
+		Roles: []rbac.Role{rbac.HotelManager, rbac.ChainAdmin},
+		Hotels: func(req interface{}) []string {
+			return req.(*pb.ReportRequest).HotelIds
+		},
+	},
+	"/admin.Admin/CheckHotel": {
+		Roles: []rbac.Role{rbac.HotelManager, rbac.ChainAdmin},
+	},
+}
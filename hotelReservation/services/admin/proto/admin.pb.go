@@ -1,11 +1,11 @@
 // Code generated by protoc-gen-go. DO NOT EDIT.
-// source: admin.proto
+// source: services/admin/proto/admin.proto
 
 /*
 Package admin is a generated protocol buffer package.
 
 It is generated from these files:
-	admin.proto
+	services/admin/proto/admin.proto
 
 It has these top-level messages:
 	CheckRequest
@@ -16,6 +16,15 @@ It has these top-level messages:
 	RegisterReply
 	LoginRequest
 	LoginReply
+	CreateHotelRequest
+	HotelAddress
+	HotelRatePlan
+	CreateHotelReply
+	DecommissionRequest
+	DecommissionReply
+	ReportRequest
+	ReportReply
+	HotelReport
 */
 package admin
 
@@ -127,12 +136,15 @@ func (m *UpdateReply) GetCorrect() bool {
 	return false
 }
 
+// role is hotel_manager, chain_admin or operator, empty meaning
+// hotel_manager. Chain admins may only register hotel managers.
 type RegisterRequest struct {
 	Name     string   `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
 	Email    string   `protobuf:"bytes,2,opt,name=email" json:"email,omitempty"`
 	Password string   `protobuf:"bytes,3,opt,name=password" json:"password,omitempty"`
 	Hotels   []string `protobuf:"bytes,4,rep,name=hotels" json:"hotels,omitempty"`
 	Id       string   `protobuf:"bytes,5,opt,name=id" json:"id,omitempty"`
+	Role     string   `protobuf:"bytes,6,opt,name=role" json:"role,omitempty"`
 }
 
 func (m *RegisterRequest) Reset()                    { *m = RegisterRequest{} }
@@ -175,6 +187,13 @@ func (m *RegisterRequest) GetId() string {
 	return ""
 }
 
+func (m *RegisterRequest) GetRole() string {
+	if m != nil {
+		return m.Role
+	}
+	return ""
+}
+
 type RegisterReply struct {
 	Correct bool `protobuf:"varint,1,opt,name=correct" json:"correct,omitempty"`
 }
@@ -191,9 +210,12 @@ func (m *RegisterReply) GetCorrect() bool {
 	return false
 }
 
+// clientIp is the address of the client logging in, for throttling
+// failed logins.
 type LoginRequest struct {
 	Email    string `protobuf:"bytes,1,opt,name=email" json:"email,omitempty"`
 	Password string `protobuf:"bytes,2,opt,name=password" json:"password,omitempty"`
+	ClientIp string `protobuf:"bytes,3,opt,name=clientIp" json:"clientIp,omitempty"`
 }
 
 func (m *LoginRequest) Reset()                    { *m = LoginRequest{} }
@@ -215,8 +237,18 @@ func (m *LoginRequest) GetPassword() string {
 	return ""
 }
 
+func (m *LoginRequest) GetClientIp() string {
+	if m != nil {
+		return m.ClientIp
+	}
+	return ""
+}
+
+// role and hotels are the grants of the admin, set if correct.
 type LoginReply struct {
-	Correct bool `protobuf:"varint,1,opt,name=correct" json:"correct,omitempty"`
+	Correct bool     `protobuf:"varint,1,opt,name=correct" json:"correct,omitempty"`
+	Role    string   `protobuf:"bytes,2,opt,name=role" json:"role,omitempty"`
+	Hotels  []string `protobuf:"bytes,3,rep,name=hotels" json:"hotels,omitempty"`
 }
 
 func (m *LoginReply) Reset()                    { *m = LoginReply{} }
@@ -231,6 +263,450 @@ func (m *LoginReply) GetCorrect() bool {
 	return false
 }
 
+func (m *LoginReply) GetRole() string {
+	if m != nil {
+		return m.Role
+	}
+	return ""
+}
+
+func (m *LoginReply) GetHotels() []string {
+	if m != nil {
+		return m.Hotels
+	}
+	return nil
+}
+
+// rooms is the number of rooms of the hotel, ratePlans may be empty.
+type CreateHotelRequest struct {
+	Id          string           `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	Name        string           `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
+	PhoneNumber string           `protobuf:"bytes,3,opt,name=phoneNumber" json:"phoneNumber,omitempty"`
+	Description string           `protobuf:"bytes,4,opt,name=description" json:"description,omitempty"`
+	Address     *HotelAddress    `protobuf:"bytes,5,opt,name=address" json:"address,omitempty"`
+	Price       float32          `protobuf:"fixed32,6,opt,name=price" json:"price,omitempty"`
+	Rooms       int32            `protobuf:"varint,7,opt,name=rooms" json:"rooms,omitempty"`
+	RatePlans   []*HotelRatePlan `protobuf:"bytes,8,rep,name=ratePlans" json:"ratePlans,omitempty"`
+}
+
+func (m *CreateHotelRequest) Reset()                    { *m = CreateHotelRequest{} }
+func (m *CreateHotelRequest) String() string            { return proto.CompactTextString(m) }
+func (*CreateHotelRequest) ProtoMessage()               {}
+func (*CreateHotelRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
+
+func (m *CreateHotelRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *CreateHotelRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *CreateHotelRequest) GetPhoneNumber() string {
+	if m != nil {
+		return m.PhoneNumber
+	}
+	return ""
+}
+
+func (m *CreateHotelRequest) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+func (m *CreateHotelRequest) GetAddress() *HotelAddress {
+	if m != nil {
+		return m.Address
+	}
+	return nil
+}
+
+func (m *CreateHotelRequest) GetPrice() float32 {
+	if m != nil {
+		return m.Price
+	}
+	return 0
+}
+
+func (m *CreateHotelRequest) GetRooms() int32 {
+	if m != nil {
+		return m.Rooms
+	}
+	return 0
+}
+
+func (m *CreateHotelRequest) GetRatePlans() []*HotelRatePlan {
+	if m != nil {
+		return m.RatePlans
+	}
+	return nil
+}
+
+type HotelAddress struct {
+	StreetNumber string  `protobuf:"bytes,1,opt,name=streetNumber" json:"streetNumber,omitempty"`
+	StreetName   string  `protobuf:"bytes,2,opt,name=streetName" json:"streetName,omitempty"`
+	City         string  `protobuf:"bytes,3,opt,name=city" json:"city,omitempty"`
+	State        string  `protobuf:"bytes,4,opt,name=state" json:"state,omitempty"`
+	Country      string  `protobuf:"bytes,5,opt,name=country" json:"country,omitempty"`
+	PostalCode   string  `protobuf:"bytes,6,opt,name=postalCode" json:"postalCode,omitempty"`
+	Lat          float32 `protobuf:"fixed32,7,opt,name=lat" json:"lat,omitempty"`
+	Lon          float32 `protobuf:"fixed32,8,opt,name=lon" json:"lon,omitempty"`
+}
+
+func (m *HotelAddress) Reset()                    { *m = HotelAddress{} }
+func (m *HotelAddress) String() string            { return proto.CompactTextString(m) }
+func (*HotelAddress) ProtoMessage()               {}
+func (*HotelAddress) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
+
+func (m *HotelAddress) GetStreetNumber() string {
+	if m != nil {
+		return m.StreetNumber
+	}
+	return ""
+}
+
+func (m *HotelAddress) GetStreetName() string {
+	if m != nil {
+		return m.StreetName
+	}
+	return ""
+}
+
+func (m *HotelAddress) GetCity() string {
+	if m != nil {
+		return m.City
+	}
+	return ""
+}
+
+func (m *HotelAddress) GetState() string {
+	if m != nil {
+		return m.State
+	}
+	return ""
+}
+
+func (m *HotelAddress) GetCountry() string {
+	if m != nil {
+		return m.Country
+	}
+	return ""
+}
+
+func (m *HotelAddress) GetPostalCode() string {
+	if m != nil {
+		return m.PostalCode
+	}
+	return ""
+}
+
+func (m *HotelAddress) GetLat() float32 {
+	if m != nil {
+		return m.Lat
+	}
+	return 0
+}
+
+func (m *HotelAddress) GetLon() float32 {
+	if m != nil {
+		return m.Lon
+	}
+	return 0
+}
+
+type HotelRatePlan struct {
+	Code               string  `protobuf:"bytes,1,opt,name=code" json:"code,omitempty"`
+	InDate             string  `protobuf:"bytes,2,opt,name=inDate" json:"inDate,omitempty"`
+	OutDate            string  `protobuf:"bytes,3,opt,name=outDate" json:"outDate,omitempty"`
+	BookableRate       float64 `protobuf:"fixed64,4,opt,name=bookableRate" json:"bookableRate,omitempty"`
+	TotalRate          float64 `protobuf:"fixed64,5,opt,name=totalRate" json:"totalRate,omitempty"`
+	TotalRateInclusive float64 `protobuf:"fixed64,6,opt,name=totalRateInclusive" json:"totalRateInclusive,omitempty"`
+	RoomDescription    string  `protobuf:"bytes,7,opt,name=roomDescription" json:"roomDescription,omitempty"`
+}
+
+func (m *HotelRatePlan) Reset()                    { *m = HotelRatePlan{} }
+func (m *HotelRatePlan) String() string            { return proto.CompactTextString(m) }
+func (*HotelRatePlan) ProtoMessage()               {}
+func (*HotelRatePlan) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
+
+func (m *HotelRatePlan) GetCode() string {
+	if m != nil {
+		return m.Code
+	}
+	return ""
+}
+
+func (m *HotelRatePlan) GetInDate() string {
+	if m != nil {
+		return m.InDate
+	}
+	return ""
+}
+
+func (m *HotelRatePlan) GetOutDate() string {
+	if m != nil {
+		return m.OutDate
+	}
+	return ""
+}
+
+func (m *HotelRatePlan) GetBookableRate() float64 {
+	if m != nil {
+		return m.BookableRate
+	}
+	return 0
+}
+
+func (m *HotelRatePlan) GetTotalRate() float64 {
+	if m != nil {
+		return m.TotalRate
+	}
+	return 0
+}
+
+func (m *HotelRatePlan) GetTotalRateInclusive() float64 {
+	if m != nil {
+		return m.TotalRateInclusive
+	}
+	return 0
+}
+
+func (m *HotelRatePlan) GetRoomDescription() string {
+	if m != nil {
+		return m.RoomDescription
+	}
+	return ""
+}
+
+// steps are the services the hotel was provisioned in, in order.
+type CreateHotelReply struct {
+	Id    string   `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	Steps []string `protobuf:"bytes,2,rep,name=steps" json:"steps,omitempty"`
+}
+
+func (m *CreateHotelReply) Reset()                    { *m = CreateHotelReply{} }
+func (m *CreateHotelReply) String() string            { return proto.CompactTextString(m) }
+func (*CreateHotelReply) ProtoMessage()               {}
+func (*CreateHotelReply) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
+
+func (m *CreateHotelReply) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *CreateHotelReply) GetSteps() []string {
+	if m != nil {
+		return m.Steps
+	}
+	return nil
+}
+
+type DecommissionRequest struct {
+	Id string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+}
+
+func (m *DecommissionRequest) Reset()                    { *m = DecommissionRequest{} }
+func (m *DecommissionRequest) String() string            { return proto.CompactTextString(m) }
+func (*DecommissionRequest) ProtoMessage()               {}
+func (*DecommissionRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
+
+func (m *DecommissionRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+// steps are the services the hotel was taken out of, in order.
+type DecommissionReply struct {
+	Steps []string `protobuf:"bytes,1,rep,name=steps" json:"steps,omitempty"`
+}
+
+func (m *DecommissionReply) Reset()                    { *m = DecommissionReply{} }
+func (m *DecommissionReply) String() string            { return proto.CompactTextString(m) }
+func (*DecommissionReply) ProtoMessage()               {}
+func (*DecommissionReply) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
+
+func (m *DecommissionReply) GetSteps() []string {
+	if m != nil {
+		return m.Steps
+	}
+	return nil
+}
+
+// The report covers the nights from inDate up to outDate.
+type ReportRequest struct {
+	HotelIds []string `protobuf:"bytes,1,rep,name=hotelIds" json:"hotelIds,omitempty"`
+	InDate   string   `protobuf:"bytes,2,opt,name=inDate" json:"inDate,omitempty"`
+	OutDate  string   `protobuf:"bytes,3,opt,name=outDate" json:"outDate,omitempty"`
+}
+
+func (m *ReportRequest) Reset()                    { *m = ReportRequest{} }
+func (m *ReportRequest) String() string            { return proto.CompactTextString(m) }
+func (*ReportRequest) ProtoMessage()               {}
+func (*ReportRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
+
+func (m *ReportRequest) GetHotelIds() []string {
+	if m != nil {
+		return m.HotelIds
+	}
+	return nil
+}
+
+func (m *ReportRequest) GetInDate() string {
+	if m != nil {
+		return m.InDate
+	}
+	return ""
+}
+
+func (m *ReportRequest) GetOutDate() string {
+	if m != nil {
+		return m.OutDate
+	}
+	return ""
+}
+
+type ReportReply struct {
+	Hotels []*HotelReport `protobuf:"bytes,1,rep,name=hotels" json:"hotels,omitempty"`
+}
+
+func (m *ReportReply) Reset()                    { *m = ReportReply{} }
+func (m *ReportReply) String() string            { return proto.CompactTextString(m) }
+func (*ReportReply) ProtoMessage()               {}
+func (*ReportReply) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
+
+func (m *ReportReply) GetHotels() []*HotelReport {
+	if m != nil {
+		return m.Hotels
+	}
+	return nil
+}
+
+// Reservations do not record what was paid, so the revenue figures are
+// estimates: estimatedRevenue prices each room night sold at the lowest
+// bookable rate of the rate plans covering it, before any loyalty
+// discount, which makes it a lower bound of what the rate plans ask.
+// unpricedRoomNights are sold nights no rate plan covers, left out of
+// estimatedRevenue and estimatedAdr. occupancy is roomNightsSold over
+// roomNightsAvailable, estimatedAdr is estimatedRevenue per priced room
+// night sold, estimatedRevpar is estimatedRevenue per room night
+// available. averageLeadDays is over the stays whose booking time is
+// known.
+type HotelReport struct {
+	HotelId             string  `protobuf:"bytes,1,opt,name=hotelId" json:"hotelId,omitempty"`
+	RoomNightsAvailable int64   `protobuf:"varint,2,opt,name=roomNightsAvailable" json:"roomNightsAvailable,omitempty"`
+	RoomNightsSold      int64   `protobuf:"varint,3,opt,name=roomNightsSold" json:"roomNightsSold,omitempty"`
+	UnpricedRoomNights  int64   `protobuf:"varint,4,opt,name=unpricedRoomNights" json:"unpricedRoomNights,omitempty"`
+	EstimatedRevenue    float64 `protobuf:"fixed64,5,opt,name=estimatedRevenue" json:"estimatedRevenue,omitempty"`
+	Occupancy           float64 `protobuf:"fixed64,6,opt,name=occupancy" json:"occupancy,omitempty"`
+	EstimatedAdr        float64 `protobuf:"fixed64,7,opt,name=estimatedAdr" json:"estimatedAdr,omitempty"`
+	EstimatedRevpar     float64 `protobuf:"fixed64,8,opt,name=estimatedRevpar" json:"estimatedRevpar,omitempty"`
+	Cancellations       int32   `protobuf:"varint,9,opt,name=cancellations" json:"cancellations,omitempty"`
+	CancelledRoomNights int32   `protobuf:"varint,10,opt,name=cancelledRoomNights" json:"cancelledRoomNights,omitempty"`
+	Stays               int32   `protobuf:"varint,11,opt,name=stays" json:"stays,omitempty"`
+	AverageLeadDays     float64 `protobuf:"fixed64,12,opt,name=averageLeadDays" json:"averageLeadDays,omitempty"`
+}
+
+func (m *HotelReport) Reset()                    { *m = HotelReport{} }
+func (m *HotelReport) String() string            { return proto.CompactTextString(m) }
+func (*HotelReport) ProtoMessage()               {}
+func (*HotelReport) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
+
+func (m *HotelReport) GetHotelId() string {
+	if m != nil {
+		return m.HotelId
+	}
+	return ""
+}
+
+func (m *HotelReport) GetRoomNightsAvailable() int64 {
+	if m != nil {
+		return m.RoomNightsAvailable
+	}
+	return 0
+}
+
+func (m *HotelReport) GetRoomNightsSold() int64 {
+	if m != nil {
+		return m.RoomNightsSold
+	}
+	return 0
+}
+
+func (m *HotelReport) GetUnpricedRoomNights() int64 {
+	if m != nil {
+		return m.UnpricedRoomNights
+	}
+	return 0
+}
+
+func (m *HotelReport) GetEstimatedRevenue() float64 {
+	if m != nil {
+		return m.EstimatedRevenue
+	}
+	return 0
+}
+
+func (m *HotelReport) GetOccupancy() float64 {
+	if m != nil {
+		return m.Occupancy
+	}
+	return 0
+}
+
+func (m *HotelReport) GetEstimatedAdr() float64 {
+	if m != nil {
+		return m.EstimatedAdr
+	}
+	return 0
+}
+
+func (m *HotelReport) GetEstimatedRevpar() float64 {
+	if m != nil {
+		return m.EstimatedRevpar
+	}
+	return 0
+}
+
+func (m *HotelReport) GetCancellations() int32 {
+	if m != nil {
+		return m.Cancellations
+	}
+	return 0
+}
+
+func (m *HotelReport) GetCancelledRoomNights() int32 {
+	if m != nil {
+		return m.CancelledRoomNights
+	}
+	return 0
+}
+
+func (m *HotelReport) GetStays() int32 {
+	if m != nil {
+		return m.Stays
+	}
+	return 0
+}
+
+func (m *HotelReport) GetAverageLeadDays() float64 {
+	if m != nil {
+		return m.AverageLeadDays
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*CheckRequest)(nil), "admin.CheckRequest")
 	proto.RegisterType((*CheckReply)(nil), "admin.CheckReply")
@@ -240,6 +716,15 @@ func init() {
 	proto.RegisterType((*RegisterReply)(nil), "admin.RegisterReply")
 	proto.RegisterType((*LoginRequest)(nil), "admin.LoginRequest")
 	proto.RegisterType((*LoginReply)(nil), "admin.LoginReply")
+	proto.RegisterType((*CreateHotelRequest)(nil), "admin.CreateHotelRequest")
+	proto.RegisterType((*HotelAddress)(nil), "admin.HotelAddress")
+	proto.RegisterType((*HotelRatePlan)(nil), "admin.HotelRatePlan")
+	proto.RegisterType((*CreateHotelReply)(nil), "admin.CreateHotelReply")
+	proto.RegisterType((*DecommissionRequest)(nil), "admin.DecommissionRequest")
+	proto.RegisterType((*DecommissionReply)(nil), "admin.DecommissionReply")
+	proto.RegisterType((*ReportRequest)(nil), "admin.ReportRequest")
+	proto.RegisterType((*ReportReply)(nil), "admin.ReportReply")
+	proto.RegisterType((*HotelReport)(nil), "admin.HotelReport")
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -253,10 +738,24 @@ const _ = grpc.SupportPackageIsVersion4
 // Client API for Admin service
 
 type AdminClient interface {
+	// Login checks the email and password. After too many failures it
+	// returns ResourceExhausted until the admin or client may try again, or
+	// FailedPrecondition while it is locked.
 	Login(ctx context.Context, in *LoginRequest, opts ...grpc.CallOption) (*LoginReply, error)
 	Register(ctx context.Context, in *RegisterRequest, opts ...grpc.CallOption) (*RegisterReply, error)
 	Update(ctx context.Context, in *UpdateRequest, opts ...grpc.CallOption) (*UpdateReply, error)
 	CheckHotel(ctx context.Context, in *CheckRequest, opts ...grpc.CallOption) (*CheckReply, error)
+	// CreateHotel provisions a new hotel in every service: its profile, rate
+	// plans, number of rooms, recommendations and location, the last making
+	// it searchable. If a step fails the earlier ones are undone.
+	CreateHotel(ctx context.Context, in *CreateHotelRequest, opts ...grpc.CallOption) (*CreateHotelReply, error)
+	// DecommissionHotel takes a hotel without upcoming stays out of service
+	// for good: it can no longer be booked, found or recommended, while its
+	// past reservations and profile are kept. It can be retried until done.
+	DecommissionHotel(ctx context.Context, in *DecommissionRequest, opts ...grpc.CallOption) (*DecommissionReply, error)
+	// HotelReport returns the occupancy, revenue, cancellations and booking
+	// lead time of each of the given hotels over a date range.
+	HotelReport(ctx context.Context, in *ReportRequest, opts ...grpc.CallOption) (*ReportReply, error)
 }
 
 type adminClient struct {
@@ -303,13 +802,54 @@ func (c *adminClient) CheckHotel(ctx context.Context, in *CheckRequest, opts ...
 	return out, nil
 }
 
+func (c *adminClient) CreateHotel(ctx context.Context, in *CreateHotelRequest, opts ...grpc.CallOption) (*CreateHotelReply, error) {
+	out := new(CreateHotelReply)
+	err := grpc.Invoke(ctx, "/admin.Admin/CreateHotel", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) DecommissionHotel(ctx context.Context, in *DecommissionRequest, opts ...grpc.CallOption) (*DecommissionReply, error) {
+	out := new(DecommissionReply)
+	err := grpc.Invoke(ctx, "/admin.Admin/DecommissionHotel", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) HotelReport(ctx context.Context, in *ReportRequest, opts ...grpc.CallOption) (*ReportReply, error) {
+	out := new(ReportReply)
+	err := grpc.Invoke(ctx, "/admin.Admin/HotelReport", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for Admin service
 
 type AdminServer interface {
+	// Login checks the email and password. After too many failures it
+	// returns ResourceExhausted until the admin or client may try again, or
+	// FailedPrecondition while it is locked.
 	Login(context.Context, *LoginRequest) (*LoginReply, error)
 	Register(context.Context, *RegisterRequest) (*RegisterReply, error)
 	Update(context.Context, *UpdateRequest) (*UpdateReply, error)
 	CheckHotel(context.Context, *CheckRequest) (*CheckReply, error)
+	// CreateHotel provisions a new hotel in every service: its profile, rate
+	// plans, number of rooms, recommendations and location, the last making
+	// it searchable. If a step fails the earlier ones are undone.
+	CreateHotel(context.Context, *CreateHotelRequest) (*CreateHotelReply, error)
+	// DecommissionHotel takes a hotel without upcoming stays out of service
+	// for good: it can no longer be booked, found or recommended, while its
+	// past reservations and profile are kept. It can be retried until done.
+	DecommissionHotel(context.Context, *DecommissionRequest) (*DecommissionReply, error)
+	// HotelReport returns the occupancy, revenue, cancellations and booking
+	// lead time of each of the given hotels over a date range.
+	HotelReport(context.Context, *ReportRequest) (*ReportReply, error)
 }
 
 func RegisterAdminServer(s *grpc.Server, srv AdminServer) {
@@ -388,6 +928,60 @@ func _Admin_CheckHotel_Handler(srv interface{}, ctx context.Context, dec func(in
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Admin_CreateHotel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateHotelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).CreateHotel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/admin.Admin/CreateHotel",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).CreateHotel(ctx, req.(*CreateHotelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_DecommissionHotel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DecommissionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).DecommissionHotel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/admin.Admin/DecommissionHotel",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).DecommissionHotel(ctx, req.(*DecommissionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_HotelReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).HotelReport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/admin.Admin/HotelReport",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).HotelReport(ctx, req.(*ReportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Admin_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "admin.Admin",
 	HandlerType: (*AdminServer)(nil),
@@ -408,34 +1002,87 @@ var _Admin_serviceDesc = grpc.ServiceDesc{
 			MethodName: "CheckHotel",
 			Handler:    _Admin_CheckHotel_Handler,
 		},
+		{
+			MethodName: "CreateHotel",
+			Handler:    _Admin_CreateHotel_Handler,
+		},
+		{
+			MethodName: "DecommissionHotel",
+			Handler:    _Admin_DecommissionHotel_Handler,
+		},
+		{
+			MethodName: "HotelReport",
+			Handler:    _Admin_HotelReport_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
-	Metadata: "admin.proto",
+	Metadata: "services/admin/proto/admin.proto",
 }
 
-func init() { proto.RegisterFile("admin.proto", fileDescriptor0) }
+func init() { proto.RegisterFile("services/admin/proto/admin.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 332 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x52, 0x4f, 0x4f, 0xbb, 0x40,
-	0x10, 0x0d, 0xb4, 0xf4, 0xd7, 0xdf, 0xb4, 0xd5, 0xb8, 0x36, 0x0d, 0xe1, 0xd4, 0x70, 0xf0, 0xcf,
-	0xa5, 0x26, 0xb5, 0x07, 0x8f, 0x1a, 0x2f, 0x1e, 0xbc, 0x48, 0xe2, 0x07, 0x58, 0x61, 0x42, 0x37,
-	0x02, 0x8b, 0xcb, 0x1a, 0xe3, 0xc9, 0x2f, 0xea, 0x87, 0x31, 0xbb, 0xec, 0xd2, 0x42, 0x53, 0x6e,
-	0xbc, 0x59, 0xe6, 0xbd, 0x37, 0x33, 0x0f, 0x26, 0x34, 0xc9, 0x59, 0xb1, 0x2a, 0x05, 0x97, 0x9c,
-	0x78, 0x1a, 0x84, 0x1b, 0x98, 0x3e, 0x6e, 0x31, 0x7e, 0x8f, 0xf0, 0xe3, 0x13, 0x2b, 0x49, 0xe6,
-	0xe0, 0x61, 0x4e, 0x59, 0xe6, 0xbb, 0x4b, 0xe7, 0xea, 0x7f, 0x54, 0x03, 0x72, 0x02, 0x2e, 0x4b,
-	0x7c, 0x47, 0x97, 0x5c, 0x96, 0x84, 0x17, 0x00, 0xa6, 0xab, 0xcc, 0xbe, 0x89, 0x0f, 0xff, 0x62,
-	0x2e, 0x04, 0xc6, 0x52, 0xff, 0x32, 0x8e, 0x2c, 0x0c, 0x5f, 0x60, 0xf6, 0x5a, 0x26, 0x54, 0xa2,
-	0xa5, 0xef, 0x10, 0x91, 0x05, 0x8c, 0x24, 0x15, 0x29, 0x4a, 0xa3, 0x67, 0x50, 0x4d, 0x59, 0x48,
-	0x2c, 0xa4, 0x3f, 0xd0, 0x0f, 0x16, 0x86, 0x97, 0x30, 0xb1, 0x94, 0xfd, 0xda, 0x3f, 0x70, 0x1a,
-	0x61, 0xca, 0x2a, 0x89, 0xc2, 0xaa, 0x13, 0x18, 0x16, 0x34, 0x47, 0xa3, 0xaf, 0xbf, 0x8f, 0x0c,
-	0x1c, 0xc0, 0xb8, 0xa4, 0x55, 0xf5, 0xc5, 0x45, 0x62, 0x0c, 0x34, 0x58, 0x79, 0xde, 0x72, 0x89,
-	0x59, 0xe5, 0x0f, 0x97, 0x03, 0xe5, 0xb9, 0x46, 0x66, 0x36, 0xaf, 0x59, 0xd2, 0x35, 0xcc, 0x76,
-	0x06, 0xfa, 0xbd, 0xde, 0xc3, 0xf4, 0x99, 0xa7, 0xac, 0x38, 0xb8, 0x82, 0x73, 0xcc, 0x94, 0xdb,
-	0x36, 0xa5, 0x2e, 0x62, 0x18, 0x7a, 0x95, 0xd6, 0xbf, 0x0e, 0x78, 0x0f, 0xea, 0xf2, 0xe4, 0x06,
-	0x3c, 0xdd, 0x41, 0xce, 0x57, 0x75, 0x2e, 0xf6, 0x1d, 0x04, 0x67, 0xed, 0xa2, 0x22, 0xbd, 0x83,
-	0xb1, 0x9d, 0x87, 0x2c, 0xcc, 0x73, 0x67, 0xc3, 0xc1, 0xfc, 0xa0, 0xae, 0x3a, 0xd7, 0x30, 0xaa,
-	0x6f, 0x46, 0xec, 0x7b, 0x2b, 0x15, 0x01, 0xe9, 0x54, 0x55, 0xcf, 0xc6, 0x44, 0xec, 0x49, 0x2d,
-	0xb7, 0xf1, 0xb8, 0x9f, 0xd5, 0xc6, 0xe3, 0x2e, 0x8a, 0x6f, 0x23, 0x1d, 0xee, 0xdb, 0xbf, 0x00,
-	0x00, 0x00, 0xff, 0xff, 0xf2, 0x70, 0xd5, 0xa4, 0xeb, 0x02, 0x00, 0x00,
+	// 978 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x56, 0xcd, 0x6e, 0xe4, 0x44,
+	0x10, 0x96, 0xed, 0x4c, 0x32, 0xa9, 0x49, 0x76, 0x93, 0x4e, 0xb4, 0x18, 0x0b, 0xa1, 0x91, 0x05,
+	0x4b, 0x76, 0x25, 0x12, 0x34, 0xac, 0xc4, 0x72, 0x1c, 0x25, 0x07, 0x22, 0xad, 0x56, 0xd0, 0x88,
+	0x1b, 0x1c, 0x3a, 0x76, 0x6b, 0x62, 0xad, 0xa7, 0xdb, 0x74, 0xf7, 0x0c, 0x9a, 0x67, 0xe0, 0xc8,
+	0x23, 0xf0, 0x56, 0xdc, 0x78, 0x04, 0xde, 0x00, 0x55, 0xff, 0x78, 0x6c, 0x67, 0x12, 0x69, 0x6f,
+	0x5d, 0x5f, 0xb5, 0xab, 0xbf, 0xaa, 0xfa, 0xaa, 0xdb, 0x30, 0xd5, 0x5c, 0xad, 0xab, 0x82, 0xeb,
+	0x2b, 0x56, 0x2e, 0x2b, 0x71, 0xd5, 0x28, 0x69, 0xa4, 0x5b, 0x5f, 0xda, 0x35, 0x19, 0x59, 0x23,
+	0x7f, 0x03, 0x47, 0xd7, 0xf7, 0xbc, 0xf8, 0x40, 0xf9, 0xef, 0x2b, 0xae, 0x0d, 0x39, 0x87, 0x11,
+	0x5f, 0xb2, 0xaa, 0x4e, 0xe3, 0x69, 0x74, 0x71, 0x48, 0x9d, 0x41, 0x9e, 0x41, 0x5c, 0x95, 0x69,
+	0x64, 0xa1, 0xb8, 0x2a, 0xf3, 0x97, 0x00, 0xfe, 0xab, 0xa6, 0xde, 0x90, 0x14, 0x0e, 0x0a, 0xa9,
+	0x14, 0x2f, 0x8c, 0xdd, 0x32, 0xa6, 0xc1, 0xcc, 0x7f, 0x82, 0xe3, 0x5f, 0x9a, 0x92, 0x19, 0x1e,
+	0xc2, 0x0f, 0x02, 0x91, 0x17, 0xb0, 0x6f, 0x98, 0x5a, 0x70, 0xe3, 0xcf, 0xf3, 0x96, 0x0b, 0x29,
+	0x0c, 0x17, 0x26, 0x4d, 0xac, 0x23, 0x98, 0xf9, 0x57, 0x30, 0x09, 0x21, 0x9f, 0x3e, 0xfb, 0xaf,
+	0x08, 0x9e, 0x53, 0xbe, 0xa8, 0xb4, 0xe1, 0x2a, 0x1c, 0x4f, 0x60, 0x4f, 0xb0, 0x25, 0xf7, 0x04,
+	0xec, 0xfa, 0x91, 0x8c, 0x33, 0x18, 0x37, 0x4c, 0xeb, 0x3f, 0xa4, 0x2a, 0x3d, 0x83, 0xd6, 0x46,
+	0xd2, 0xf7, 0xd2, 0xf0, 0x5a, 0xa7, 0x7b, 0xd3, 0x04, 0x49, 0x3b, 0xcb, 0x27, 0x37, 0x6a, 0x93,
+	0x23, 0xb0, 0xa7, 0x64, 0xcd, 0xd3, 0x7d, 0x77, 0x1a, 0xae, 0xf3, 0x57, 0x70, 0xbc, 0x25, 0xf5,
+	0x74, 0x02, 0xbf, 0xc2, 0xd1, 0x3b, 0xb9, 0xa8, 0xc4, 0x83, 0xd6, 0x44, 0x8f, 0x11, 0x8d, 0x07,
+	0x44, 0x33, 0x18, 0x17, 0x75, 0xc5, 0x85, 0xb9, 0x6d, 0x42, 0x12, 0xc1, 0xce, 0x29, 0x80, 0x8f,
+	0xfe, 0x24, 0x8b, 0x36, 0x89, 0x78, 0x9b, 0x44, 0xa7, 0x00, 0x49, 0xb7, 0x00, 0xf9, 0x9f, 0x31,
+	0x90, 0x6b, 0xc5, 0x99, 0xe1, 0x3f, 0x20, 0xf0, 0x58, 0xd3, 0x43, 0x17, 0xe2, 0x4e, 0x17, 0xa6,
+	0x30, 0x69, 0xee, 0xa5, 0xe0, 0xef, 0x57, 0xcb, 0x3b, 0xae, 0x3c, 0xdb, 0x2e, 0x84, 0x3b, 0x4a,
+	0xae, 0x0b, 0x55, 0x35, 0xa6, 0x92, 0x22, 0xdd, 0x73, 0x3b, 0x3a, 0x10, 0xf9, 0x1a, 0x0e, 0x58,
+	0x59, 0x2a, 0xae, 0xb5, 0x6d, 0xc2, 0x64, 0x76, 0x76, 0xe9, 0x14, 0x6f, 0xd9, 0xcc, 0x9d, 0x8b,
+	0x86, 0x3d, 0x58, 0xcf, 0x46, 0x55, 0x85, 0xeb, 0x4f, 0x4c, 0x9d, 0x81, 0xa8, 0x92, 0x72, 0xa9,
+	0xd3, 0x83, 0x69, 0x74, 0x31, 0xa2, 0xce, 0x20, 0x33, 0x38, 0x54, 0xcc, 0xf0, 0x1f, 0x6b, 0x26,
+	0x74, 0x3a, 0x9e, 0x26, 0x17, 0x93, 0xd9, 0x79, 0x37, 0x38, 0xf5, 0x4e, 0xba, 0xdd, 0x96, 0xff,
+	0x13, 0xc1, 0x51, 0xf7, 0x64, 0x92, 0xc3, 0x91, 0x36, 0x8a, 0x73, 0xe3, 0x93, 0x74, 0x15, 0xe9,
+	0x61, 0xe4, 0x73, 0x00, 0x6f, 0x6f, 0x2b, 0xd4, 0x41, 0xb0, 0x76, 0x45, 0x65, 0x36, 0xbe, 0x40,
+	0x76, 0x8d, 0x94, 0xb5, 0x61, 0x86, 0xfb, 0x9a, 0x38, 0xc3, 0xb5, 0x74, 0x25, 0x8c, 0xda, 0x78,
+	0x49, 0x06, 0x13, 0xcf, 0x68, 0xa4, 0x36, 0xac, 0xbe, 0x96, 0x65, 0x50, 0x67, 0x07, 0x21, 0x27,
+	0x90, 0xd4, 0xcc, 0xd8, 0x02, 0xc4, 0x14, 0x97, 0x16, 0x91, 0x22, 0x1d, 0x7b, 0x44, 0x8a, 0xfc,
+	0xbf, 0x08, 0x8e, 0x7b, 0x99, 0x5b, 0x66, 0x18, 0xcf, 0xcf, 0x16, 0xae, 0x51, 0x28, 0x95, 0xb8,
+	0x61, 0x26, 0x64, 0xe2, 0x2d, 0xe4, 0x26, 0x57, 0xc6, 0x3a, 0xfc, 0x78, 0x7b, 0x13, 0x6b, 0x74,
+	0x27, 0xe5, 0x07, 0x76, 0x57, 0x73, 0x1a, 0x52, 0x8a, 0x68, 0x0f, 0x23, 0x9f, 0xc1, 0xa1, 0x91,
+	0x86, 0xd9, 0xa3, 0x6d, 0x6e, 0x11, 0xdd, 0x02, 0xe4, 0x12, 0x48, 0x6b, 0xdc, 0x8a, 0xa2, 0x5e,
+	0xe9, 0x6a, 0xed, 0xb2, 0x8c, 0xe8, 0x0e, 0x0f, 0xb9, 0x80, 0xe7, 0xd8, 0xe3, 0x9b, 0x8e, 0xb6,
+	0x0e, 0x2c, 0xa7, 0x21, 0x9c, 0xbf, 0x85, 0x93, 0x9e, 0xba, 0x71, 0x70, 0x86, 0xda, 0xb6, 0xbd,
+	0xe0, 0x8d, 0x4e, 0x63, 0x3b, 0x19, 0xce, 0xc8, 0xbf, 0x84, 0xb3, 0x1b, 0x5e, 0xc8, 0xe5, 0xb2,
+	0xd2, 0xba, 0x92, 0xe2, 0x91, 0xc1, 0xc8, 0x5f, 0xc1, 0x69, 0x7f, 0x1b, 0x9e, 0xd0, 0x46, 0x8c,
+	0xba, 0x11, 0x7f, 0xc3, 0x7b, 0xa4, 0x91, 0xca, 0x84, 0x58, 0x19, 0x8c, 0xed, 0x14, 0xde, 0x96,
+	0x61, 0x67, 0x6b, 0x7f, 0x7c, 0x1b, 0xf2, 0xef, 0x61, 0x12, 0xc2, 0x23, 0x87, 0xd7, 0xed, 0xc0,
+	0x47, 0x56, 0xfb, 0xa4, 0xa7, 0x7d, 0xb7, 0x31, 0x5c, 0x02, 0xff, 0x26, 0x30, 0xe9, 0xe0, 0x78,
+	0x88, 0x27, 0xe2, 0x33, 0x0d, 0x26, 0xf9, 0x06, 0xce, 0xb0, 0xc4, 0xef, 0xab, 0xc5, 0xbd, 0xd1,
+	0xf3, 0x35, 0xab, 0x6a, 0x6c, 0xb1, 0xe5, 0x98, 0xd0, 0x5d, 0x2e, 0xf2, 0x12, 0x9e, 0x6d, 0xe1,
+	0x9f, 0x65, 0xed, 0xee, 0xe6, 0x84, 0x0e, 0x50, 0xd4, 0xc0, 0x4a, 0xd8, 0x79, 0x2e, 0x69, 0xeb,
+	0xb1, 0x5a, 0x4a, 0xe8, 0x0e, 0x0f, 0x79, 0x0d, 0x27, 0x5c, 0x9b, 0x6a, 0xc9, 0x0c, 0x2f, 0x29,
+	0x5f, 0x73, 0xb1, 0x0a, 0xc2, 0x7a, 0x80, 0xa3, 0xfa, 0x64, 0x51, 0xac, 0x1a, 0x26, 0x8a, 0x8d,
+	0x97, 0xd5, 0x16, 0x40, 0xfd, 0xb6, 0x5f, 0xcc, 0x4b, 0x65, 0xa5, 0x14, 0xd1, 0x1e, 0x86, 0x8a,
+	0xeb, 0x46, 0x6d, 0x98, 0xb2, 0x93, 0x15, 0xd1, 0x21, 0x4c, 0xbe, 0x80, 0xe3, 0x82, 0x89, 0x82,
+	0xd7, 0x35, 0x43, 0x05, 0xea, 0xf4, 0xd0, 0x5e, 0x4a, 0x7d, 0x10, 0xeb, 0xe8, 0x81, 0x5e, 0xba,
+	0x60, 0xf7, 0xee, 0x72, 0xf9, 0x1b, 0x63, 0xa3, 0xd3, 0x89, 0xbb, 0xe4, 0xac, 0x81, 0xbc, 0xd8,
+	0x9a, 0x2b, 0xb6, 0xe0, 0xef, 0x38, 0x2b, 0x6f, 0xd0, 0x7f, 0xe4, 0x78, 0x0d, 0xe0, 0xd9, 0xdf,
+	0x09, 0x8c, 0xe6, 0xa8, 0x00, 0x72, 0x05, 0x23, 0xfb, 0x8c, 0x90, 0x70, 0xd7, 0x76, 0x9f, 0xac,
+	0xec, 0xb4, 0x0f, 0xa2, 0x94, 0xde, 0xc2, 0x38, 0x3c, 0x80, 0xe4, 0x85, 0x77, 0x0f, 0x9e, 0xe9,
+	0xec, 0xfc, 0x01, 0x8e, 0x5f, 0xce, 0x60, 0xdf, 0xbd, 0xfc, 0x24, 0xf8, 0x7b, 0xff, 0x16, 0x19,
+	0x19, 0xa0, 0xf8, 0xcd, 0x1b, 0xff, 0xa3, 0x62, 0x05, 0xd9, 0x72, 0xec, 0xfe, 0xf1, 0x64, 0xa7,
+	0x7d, 0x10, 0xbf, 0x9a, 0xc3, 0xa4, 0x33, 0xe8, 0xe4, 0xd3, 0xb0, 0xe3, 0xc1, 0xd3, 0x96, 0x7d,
+	0xb2, 0xcb, 0x85, 0x21, 0x6e, 0xfb, 0xa3, 0xec, 0x02, 0x65, 0x7e, 0xf7, 0x8e, 0xbb, 0x20, 0x4b,
+	0x77, 0xfa, 0x30, 0xd4, 0x77, 0xfd, 0x79, 0xda, 0x16, 0xa7, 0x33, 0xfe, 0x19, 0x19, 0xa0, 0x4d,
+	0xbd, 0xb9, 0xdb, 0xb7, 0x7f, 0x7a, 0xdf, 0xfe, 0x3f, 0x00, 0xb6, 0x57, 0xbb, 0xdc, 0x0d, 0x0a,
+	0x00, 0x00,
 }
@@ -0,0 +1,92 @@
+package admin
+
+import (
+	pb "github.com/harlow/go-micro-services/services/admin/proto"
+	rate "github.com/harlow/go-micro-services/services/rate/proto"
+	reservation "github.com/harlow/go-micro-services/services/reservation/proto"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// HotelReport computes the occupancy and estimated revenue of hotels from
+// the rooms the reservation service sold and the rate plans of the rate
+// service.
+// The policy has checked the caller was granted every hotel.
+func (s *Server) HotelReport(ctx context.Context, req *pb.ReportRequest) (*pb.ReportReply, error) {
+	if len(req.HotelIds) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "hotel ids must be set")
+	}
+
+	bookings, err := s.reservationClient.HotelBookings(ctx, &reservation.Request{
+		HotelId: req.HotelIds,
+		InDate:  req.InDate,
+		OutDate: req.OutDate,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	rates, err := s.rateClient.GetRates(ctx, &rate.Request{
+		HotelIds: req.HotelIds,
+		InDate:   req.InDate,
+		OutDate:  req.OutDate,
+	})
+	if err != nil {
+		return nil, err
+	}
+	plans := make(map[string][]*rate.RatePlan)
+	for _, p := range rates.RatePlans {
+		plans[p.HotelId] = append(plans[p.HotelId], p)
+	}
+
+	res := new(pb.ReportReply)
+	for _, h := range bookings.Hotels {
+		report := &pb.HotelReport{
+			HotelId:             h.HotelId,
+			Cancellations:       h.Cancellations,
+			CancelledRoomNights: h.CancelledRoomNights,
+			Stays:               h.Stays,
+		}
+		for _, n := range h.Nights {
+			report.RoomNightsAvailable += int64(n.RoomsAvailable)
+			report.RoomNightsSold += int64(n.RoomsSold)
+			if n.RoomsSold == 0 {
+				continue
+			}
+			if price, ok := nightlyRate(plans[h.HotelId], n.Date); ok {
+				report.EstimatedRevenue += price * float64(n.RoomsSold)
+			} else {
+				report.UnpricedRoomNights += int64(n.RoomsSold)
+			}
+		}
+
+		if report.RoomNightsAvailable > 0 {
+			report.Occupancy = float64(report.RoomNightsSold) / float64(report.RoomNightsAvailable)
+			report.EstimatedRevpar = report.EstimatedRevenue / float64(report.RoomNightsAvailable)
+		}
+		if priced := report.RoomNightsSold - report.UnpricedRoomNights; priced > 0 {
+			report.EstimatedAdr = report.EstimatedRevenue / float64(priced)
+		}
+		if h.Stays > 0 {
+			report.AverageLeadDays = float64(h.LeadDays) / float64(h.Stays)
+		}
+		res.Hotels = append(res.Hotels, report)
+	}
+	return res, nil
+}
+
+// nightlyRate returns the lowest bookable rate of the plans covering the
+// night of date, and false if none does.
+func nightlyRate(plans []*rate.RatePlan, date string) (float64, bool) {
+	price, ok := 0.0, false
+	for _, p := range plans {
+		if p.RoomType == nil || date < p.InDate || date >= p.OutDate {
+			continue
+		}
+		if !ok || p.RoomType.BookableRate < price {
+			price, ok = p.RoomType.BookableRate, true
+		}
+	}
+	return price, ok
+}
@@ -0,0 +1,148 @@
+package admin
+
+import (
+	"math"
+	"testing"
+
+	pb "github.com/harlow/go-micro-services/services/admin/proto"
+	rate "github.com/harlow/go-micro-services/services/rate/proto"
+	reservation "github.com/harlow/go-micro-services/services/reservation/proto"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+)
+
+// fakeBookings answers HotelBookings with a fixed report.
+type fakeBookings struct {
+	reservation.ReservationClient
+	report *reservation.BookingReport
+}
+
+func (f fakeBookings) HotelBookings(ctx context.Context, in *reservation.Request, opts ...grpc.CallOption) (*reservation.BookingReport, error) {
+	return f.report, nil
+}
+
+// fakeRates answers GetRates with fixed rate plans.
+type fakeRates struct {
+	rate.RateClient
+	plans []*rate.RatePlan
+}
+
+func (f fakeRates) GetRates(ctx context.Context, in *rate.Request, opts ...grpc.CallOption) (*rate.Result, error) {
+	return &rate.Result{RatePlans: f.plans}, nil
+}
+
+func plan(hotelId, inDate, outDate string, bookable float64) *rate.RatePlan {
+	return &rate.RatePlan{
+		HotelId:  hotelId,
+		InDate:   inDate,
+		OutDate:  outDate,
+		RoomType: &rate.RoomType{BookableRate: bookable},
+	}
+}
+
+func TestHotelReport(t *testing.T) {
+	s := &Server{
+		reservationClient: fakeBookings{report: &reservation.BookingReport{Hotels: []*reservation.HotelBookings{
+			{
+				// closed on the 10th, the reservation service reports
+				// no rooms for it
+				HotelId: "1",
+				Nights: []*reservation.NightSales{
+					{Date: "2015-04-09", RoomsSold: 5, RoomsAvailable: 10},
+					{Date: "2015-04-10", RoomsSold: 0, RoomsAvailable: 0},
+					{Date: "2015-04-11", RoomsSold: 2, RoomsAvailable: 10},
+				},
+				Cancellations:       1,
+				CancelledRoomNights: 2,
+				Stays:               2,
+				LeadDays:            7,
+			},
+			{
+				// decommissioned on the 10th, no plan covers the 9th
+				HotelId: "2",
+				Nights: []*reservation.NightSales{
+					{Date: "2015-04-09", RoomsSold: 4, RoomsAvailable: 4},
+					{Date: "2015-04-10", RoomsSold: 0, RoomsAvailable: 0},
+					{Date: "2015-04-11", RoomsSold: 0, RoomsAvailable: 0},
+				},
+			},
+			{
+				HotelId: "3",
+				Nights: []*reservation.NightSales{
+					{Date: "2015-04-09", RoomsSold: 0, RoomsAvailable: 0},
+				},
+			},
+		}}},
+		rateClient: fakeRates{plans: []*rate.RatePlan{
+			// the lower of overlapping plans prices a night
+			plan("1", "2015-04-09", "2015-04-12", 100),
+			plan("1", "2015-04-11", "2015-04-12", 80),
+			plan("2", "2015-04-10", "2015-04-12", 50),
+		}},
+	}
+
+	res, err := s.HotelReport(context.Background(), &pb.ReportRequest{
+		HotelIds: []string{"1", "2", "3"},
+		InDate:   "2015-04-09",
+		OutDate:  "2015-04-12",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []*pb.HotelReport{
+		{
+			HotelId:             "1",
+			RoomNightsAvailable: 20,
+			RoomNightsSold:      7,
+			EstimatedRevenue:    5*100 + 2*80,
+			Occupancy:           7.0 / 20,
+			EstimatedAdr:        660.0 / 7,
+			EstimatedRevpar:     660.0 / 20,
+			Cancellations:       1,
+			CancelledRoomNights: 2,
+			Stays:               2,
+			AverageLeadDays:     3.5,
+		},
+		{
+			HotelId:             "2",
+			RoomNightsAvailable: 4,
+			RoomNightsSold:      4,
+			UnpricedRoomNights:  4,
+			Occupancy:           1,
+		},
+		{
+			HotelId: "3",
+		},
+	}
+	if len(res.Hotels) != len(want) {
+		t.Fatalf("got %d hotels, want %d", len(res.Hotels), len(want))
+	}
+	for i, got := range res.Hotels {
+		w := want[i]
+		if got.HotelId != w.HotelId ||
+			got.RoomNightsAvailable != w.RoomNightsAvailable ||
+			got.RoomNightsSold != w.RoomNightsSold ||
+			got.UnpricedRoomNights != w.UnpricedRoomNights ||
+			got.Cancellations != w.Cancellations ||
+			got.CancelledRoomNights != w.CancelledRoomNights ||
+			got.Stays != w.Stays {
+			t.Errorf("hotel %d: got %v, want %v", i, got, w)
+			continue
+		}
+		for _, f := range []struct {
+			name      string
+			got, want float64
+		}{
+			{"estimatedRevenue", got.EstimatedRevenue, w.EstimatedRevenue},
+			{"occupancy", got.Occupancy, w.Occupancy},
+			{"estimatedAdr", got.EstimatedAdr, w.EstimatedAdr},
+			{"estimatedRevpar", got.EstimatedRevpar, w.EstimatedRevpar},
+			{"averageLeadDays", got.AverageLeadDays, w.AverageLeadDays},
+		} {
+			if math.Abs(f.got-f.want) > 1e-9 {
+				t.Errorf("hotel %s: %s = %v, want %v", w.HotelId, f.name, f.got, f.want)
+			}
+		}
+	}
+}
@@ -1,17 +1,28 @@
 package admin
 
 import (
-
+	"crypto/subtle"
 	// "encoding/json"
 	"fmt"
 	"github.com/grpc-ecosystem/grpc-opentracing/go/otgrpc"
+	"github.com/harlow/go-micro-services/dialer"
+	"github.com/harlow/go-micro-services/lockout"
+	"github.com/harlow/go-micro-services/passhash"
+	"github.com/harlow/go-micro-services/rbac"
 	"github.com/harlow/go-micro-services/registry"
 	pb "github.com/harlow/go-micro-services/services/admin/proto"
+	geo "github.com/harlow/go-micro-services/services/geo/proto"
+	profile "github.com/harlow/go-micro-services/services/profile/proto"
+	rate "github.com/harlow/go-micro-services/services/rate/proto"
+	recommendation "github.com/harlow/go-micro-services/services/recommendation/proto"
+	reservation "github.com/harlow/go-micro-services/services/reservation/proto"
 
 	"github.com/opentracing/opentracing-go"
 	"golang.org/x/net/context"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/status"
 	"gopkg.in/mgo.v2"
 	"gopkg.in/mgo.v2/bson"
 	// "io/ioutil"
@@ -24,17 +35,34 @@ import (
 const name = "srv-admin"
 
 type Server struct {
+	profileClient        profile.ProfileClient
+	recommendationClient recommendation.RecommendationClient
+	geoClient            geo.GeoClient
+	rateClient           rate.RateClient
+	reservationClient    reservation.ReservationClient
+
 	Tracer       opentracing.Tracer
 	Port         int
 	IpAddr       string
 	MongoSession *mgo.Session
 	Registry     *registry.Client
+	// Identity signs and verifies the identities calls are made by
+	Identity *rbac.Signer
+
+	// Lockout and AddrLockout throttle failed logins per admin and per
+	// client address, nil disables them
+	Lockout     *lockout.Limiter
+	AddrLockout *lockout.Limiter
 }
 
 func (s *Server) Run() error {
 	if s.Port == 0 {
 		return fmt.Errorf("server port must be set")
 	}
+
+	if s.Identity == nil {
+		return fmt.Errorf("identity keys must be set")
+	}
 	srv := grpc.NewServer(
 		grpc.KeepaliveParams(keepalive.ServerParameters{
 			Timeout: 120 * time.Second,
@@ -42,11 +70,31 @@ func (s *Server) Run() error {
 		grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
 			PermitWithoutStream: true,
 		}),
-		grpc.UnaryInterceptor(
+		grpc.UnaryInterceptor(rbac.ChainUnaryServer(
 			otgrpc.OpenTracingServerInterceptor(s.Tracer),
-		),
+			rbac.UnaryServerInterceptor(s.Identity, policy),
+		)),
 	)
 	pb.RegisterAdminServer(srv, s)
+
+	if err := s.initProfileClient("srv-profile"); err != nil {
+		return err
+	}
+	if err := s.initRecommendationClient("srv-recommendation"); err != nil {
+		return err
+	}
+	if err := s.initGeoClient("srv-geo"); err != nil {
+		return err
+	}
+	if err := s.initRateClient("srv-rate"); err != nil {
+		return err
+	}
+	if err := s.initReservationClient("srv-reservation"); err != nil {
+		return err
+	}
+
+	go s.recoverLoop()
+
 	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", s.Port))
 	if err != nil {
 		log.Fatalf("failed to listen: %v", err)
@@ -64,46 +112,176 @@ func (s *Server) Shutdown() {
 	s.Registry.Deregister(name)
 }
 
+func (s *Server) initProfileClient(name string) error {
+	conn, err := dialer.Dial(
+		name,
+		dialer.WithTracer(s.Tracer),
+		dialer.WithBalancer(s.Registry.Client),
+		dialer.WithIdentity(s.Identity, self),
+	)
+	if err != nil {
+		return fmt.Errorf("dialer error: %v", err)
+	}
+	s.profileClient = profile.NewProfileClient(conn)
+	return nil
+}
+
+func (s *Server) initRecommendationClient(name string) error {
+	conn, err := dialer.Dial(
+		name,
+		dialer.WithTracer(s.Tracer),
+		dialer.WithBalancer(s.Registry.Client),
+		dialer.WithIdentity(s.Identity, self),
+	)
+	if err != nil {
+		return fmt.Errorf("dialer error: %v", err)
+	}
+	s.recommendationClient = recommendation.NewRecommendationClient(conn)
+	return nil
+}
+
+func (s *Server) initGeoClient(name string) error {
+	conn, err := dialer.Dial(
+		name,
+		dialer.WithTracer(s.Tracer),
+		dialer.WithBalancer(s.Registry.Client),
+		dialer.WithIdentity(s.Identity, self),
+	)
+	if err != nil {
+		return fmt.Errorf("dialer error: %v", err)
+	}
+	s.geoClient = geo.NewGeoClient(conn)
+	return nil
+}
+
+func (s *Server) initRateClient(name string) error {
+	conn, err := dialer.Dial(
+		name,
+		dialer.WithTracer(s.Tracer),
+		dialer.WithBalancer(s.Registry.Client),
+		dialer.WithIdentity(s.Identity, self),
+	)
+	if err != nil {
+		return fmt.Errorf("dialer error: %v", err)
+	}
+	s.rateClient = rate.NewRateClient(conn)
+	return nil
+}
+
+func (s *Server) initReservationClient(name string) error {
+	conn, err := dialer.Dial(
+		name,
+		dialer.WithTracer(s.Tracer),
+		dialer.WithBalancer(s.Registry.Client),
+		dialer.WithIdentity(s.Identity, self),
+	)
+	if err != nil {
+		return fmt.Errorf("dialer error: %v", err)
+	}
+	s.reservationClient = reservation.NewReservationClient(conn)
+	return nil
+}
+
 //Checker the password and email input to make sure they are matched with the data in the database
 func (s *Server) Login(ctx context.Context, req *pb.LoginRequest) (*pb.LoginReply, error) {
 	res := new(pb.LoginReply)
-	session, err := mgo.Dial("mongodb-admin")
-	if err != nil {
-		panic(err)
+	res.Correct = false
+
+	keys := lockout.Keys(s.Lockout, req.Email, s.AddrLockout, req.ClientIp)
+	if e := lockout.CheckAll(keys); e != nil {
+		return nil, e.Status()
 	}
+
+	session := s.MongoSession.Copy()
 	defer session.Close()
 	c := session.DB("admin-db").C("admin")
 	admin := new(Admin)
 	err1 := c.Find(bson.M{"email": req.Email}).One(admin)
-	res.Correct = false
-	if err1 != nil {
-		log.Fatal(err1)
-	} else {
-		if admin != nil {
-			res.Correct = req.Password == admin.Password
+	if err1 != nil && err1 != mgo.ErrNotFound {
+		return nil, err1
+	}
+	if err1 == nil {
+		var rehash bool
+		res.Correct, rehash = checkPassword(admin.Password, req.Password)
+		if rehash {
+			upgradePassword(c, admin, req.Password)
+		}
+		if res.Correct {
+			res.Role = string(admin.role())
+			res.Hotels = admin.Hotels
+		}
+	}
+
+	// unknown emails count too, so they can not be told apart
+	if res.Correct {
+		if s.Lockout != nil {
+			if err := s.Lockout.Reset(req.Email); err != nil {
+				log.Println("Failed reset login failures: ", err)
+			}
 		}
+	} else {
+		lockout.FailAll(keys)
 	}
 	return res, nil
 }
+
+// checkPassword is passhash.Verify that also accepts the plain text
+// passwords of admins the migration has not reached yet.
+func checkPassword(stored, password string) (ok, rehash bool) {
+	if passhash.IsHash(stored) {
+		return passhash.Verify(stored, password)
+	}
+	ok = subtle.ConstantTimeCompare([]byte(stored), []byte(password)) == 1
+	return ok, ok
+}
+
+// upgradePassword replaces the stored password of an admin who just
+// logged in with a current hash.
+func upgradePassword(c *mgo.Collection, admin *Admin, password string) {
+	pass, err := passhash.Hash(password)
+	if err != nil {
+		log.Println("Failed hash admin password: ", err)
+		return
+	}
+	err = c.Update(
+		bson.M{"email": admin.Email, "password": admin.Password},
+		bson.M{"$set": bson.M{"password": pass}},
+	)
+	if err != nil && err != mgo.ErrNotFound {
+		log.Println("Failed upgrade admin password: ", err)
+	}
+}
 func (s *Server) Register(ctx context.Context, req *pb.RegisterRequest) (*pb.RegisterReply, error) {
 	res := new(pb.RegisterReply)
 	res.Correct = false
 	name := req.Name
 	email := req.Email
-	password := req.Password
+	password, err := passhash.Hash(req.Password)
+	if err != nil {
+		return nil, err
+	}
 	hotels := req.Hotels
 	id := req.Id
-
-	session, err := mgo.Dial("mongodb-admin")
-	if err != nil {
-		panic(err)
+	role := rbac.HotelManager
+	if req.Role != "" {
+		var ok bool
+		role, ok = rbac.ParseRole(req.Role)
+		if !ok || role == rbac.Guest || role == rbac.Service {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid admin role %q", req.Role)
+		}
+	}
+	// the policy has checked the hotels of chain admins, not the role
+	if caller := rbac.FromContext(ctx); caller != nil && caller.Role == rbac.ChainAdmin && role != rbac.HotelManager {
+		return nil, status.Errorf(codes.PermissionDenied, "chain admins may only register hotel managers")
 	}
+
+	session := s.MongoSession.Copy()
 	defer session.Close()
 
 	c := session.DB("admin-db").C("admin")
 	count, err := c.Find(&bson.M{"name": name}).Count()
 	if count == 0 {
-		err = c.Insert(&Admin{name, email, password, hotels, id})
+		err = c.Insert(&Admin{name, email, password, hotels, id, role})
 		if err != nil {
 			log.Fatal(err)
 		} else {
@@ -121,16 +299,20 @@ func (s *Server) Update(ctx context.Context, req *pb.UpdateRequest) (*pb.UpdateR
 	id := req.Id
 	target := req.Target
 	content := req.Content
-	//get that the orignal content
-	session, err := mgo.Dial("mongodb-profile")
-	if err != nil {
-		panic(err)
-	}
-	defer session.Close()
-	c := session.DB("profile-db").C("hotels")
-	err = c.Update(bson.M{"id": id}, bson.M{"$set": bson.M{target: content}})
+	// the profile service drops the cached profile, which must go before
+	// recommendations read the price
+	_, err := s.profileClient.UpdateProfile(ctx, &profile.UpdateProfileRequest{
+		HotelId: id,
+		Target:  target,
+		Content: content,
+	})
 	if err == nil {
 		res.Correct = true
+
+		// the price recommendations rank by may have changed
+		if _, err := s.recommendationClient.Refresh(ctx, &recommendation.RefreshRequest{HotelIds: []string{id}}); err != nil {
+			log.Println("Failed refresh recommendations: ", err)
+		}
 	}
 	return res, nil
 
@@ -139,10 +321,7 @@ func (s *Server) CheckHotel(ctx context.Context, req *pb.CheckRequest) (*pb.Chec
 	res := new(pb.CheckReply)
 	res.Correct = false
 	id := req.Id
-	session, err := mgo.Dial("mongodb-admin")
-	if err != nil {
-		panic(err)
-	}
+	session := s.MongoSession.Copy()
 	defer session.Close()
 	c := session.DB("admin-db").C("admin")
 	admin := new(Admin)
@@ -158,9 +337,19 @@ func (s *Server) CheckHotel(ctx context.Context, req *pb.CheckRequest) (*pb.Chec
 }
 
 type Admin struct {
-	Name     string   `bason:"name"`
-	Email    string   `bason:"email"`
-	Password string   `bson:"password"`
-	Hotels   []string `bason: "hotels"`
-	Id       string   `bason: "id"`
+	Name     string    `bason:"name"`
+	Email    string    `bason:"email"`
+	Password string    `bson:"password"`
+	Hotels   []string  `bason: "hotels"`
+	Id       string    `bason: "id"`
+	Role     rbac.Role `bson:"role,omitempty"`
+}
+
+// role returns the role of the admin. Admins registered before roles
+// manage their hotels.
+func (a *Admin) role() rbac.Role {
+	if a.Role == "" {
+		return rbac.HotelManager
+	}
+	return a.Role
 }
@@ -0,0 +1,208 @@
+package alert
+
+import (
+	"log"
+	"time"
+
+	pb "github.com/harlow/go-micro-services/services/alert/proto"
+	rate "github.com/harlow/go-micro-services/services/rate/proto"
+	reservation "github.com/harlow/go-micro-services/services/reservation/proto"
+	"golang.org/x/net/context"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+const (
+	// how often the active alerts are evaluated
+	evaluateInterval = time.Minute
+	// alerts evaluated per round, those checked longest ago first
+	maxAlertsPerRound = 5000
+)
+
+func (s *Server) evaluateLoop() {
+	for range time.Tick(evaluateInterval) {
+		if err := s.evaluate(context.Background()); err != nil {
+			log.Println("Failed evaluate alerts: ", err)
+		}
+	}
+}
+
+// stay is what alerts are evaluated together by: one availability and
+// one rate call per stay.
+type stay struct {
+	InDate     string
+	OutDate    string
+	RoomNumber int32
+}
+
+// evaluate expires the alerts whose stay has begun and triggers the
+// active alerts whose hotel frees up or drops its rate. Replicas may
+// evaluate at once, each alert triggers only once.
+func (s *Server) evaluate(ctx context.Context) error {
+	session := s.MongoSession.Copy()
+	defer session.Close()
+
+	c := session.DB("alert-db").C("alerts")
+
+	now := time.Now()
+	_, err := c.UpdateAll(
+		bson.M{"state": pb.State_ACTIVE, "inDate": bson.M{"$lt": now.UTC().Format("2006-01-02")}},
+		bson.M{"$set": bson.M{"state": pb.State_EXPIRED}},
+	)
+	if err != nil {
+		return err
+	}
+
+	var alerts []alert
+	err = c.Find(bson.M{"state": pb.State_ACTIVE}).Sort("checked").Limit(maxAlertsPerRound).All(&alerts)
+	if err != nil {
+		return err
+	}
+
+	stays := make(map[stay][]*alert)
+	for i := range alerts {
+		a := &alerts[i]
+		k := stay{InDate: a.InDate, OutDate: a.OutDate, RoomNumber: a.RoomNumber}
+		stays[k] = append(stays[k], a)
+	}
+	for k, alerts := range stays {
+		if err := s.evaluateStay(ctx, c, k, alerts, now); err != nil {
+			log.Println("Failed evaluate alerts: ", err)
+		}
+	}
+	return nil
+}
+
+func (s *Server) evaluateStay(ctx context.Context, c *mgo.Collection, k stay, alerts []*alert, now time.Time) error {
+	var hotelIds []string
+	seen := make(map[string]bool)
+	for _, a := range alerts {
+		if !seen[a.HotelId] {
+			seen[a.HotelId] = true
+			hotelIds = append(hotelIds, a.HotelId)
+		}
+	}
+
+	availResp, err := s.reservationClient.CheckAvailability(ctx, &reservation.Request{
+		HotelId:    hotelIds,
+		InDate:     k.InDate,
+		OutDate:    k.OutDate,
+		RoomNumber: k.RoomNumber,
+	})
+	if err != nil {
+		return err
+	}
+	available := make(map[string]bool)
+	for _, hotelId := range availResp.HotelId {
+		available[hotelId] = true
+	}
+
+	// only the rates of available hotels matter
+	var rateIds []string
+	rated := make(map[string]bool)
+	for _, a := range alerts {
+		if a.Kind == pb.Kind_PRICE && available[a.HotelId] && !rated[a.HotelId] {
+			rated[a.HotelId] = true
+			rateIds = append(rateIds, a.HotelId)
+		}
+	}
+	lowest := make(map[string]float64)
+	if len(rateIds) > 0 {
+		rateResp, err := s.rateClient.GetRates(ctx, &rate.Request{
+			HotelIds: rateIds,
+			InDate:   k.InDate,
+			OutDate:  k.OutDate,
+		})
+		if err != nil {
+			return err
+		}
+		for _, p := range rateResp.RatePlans {
+			if p.RoomType == nil || p.RoomType.BookableRate <= 0 {
+				continue
+			}
+			if r, ok := lowest[p.HotelId]; !ok || p.RoomType.BookableRate < r {
+				lowest[p.HotelId] = p.RoomType.BookableRate
+			}
+		}
+	}
+
+	ids := make([]bson.ObjectId, 0, len(alerts))
+	var soldOut []bson.ObjectId
+	for _, a := range alerts {
+		ids = append(ids, a.ID)
+		if !available[a.HotelId] {
+			if !a.SoldOut {
+				soldOut = append(soldOut, a.ID)
+			}
+			continue
+		}
+		// a hotel that had rooms all along has not freed up
+		if a.Kind == pb.Kind_AVAILABILITY && !a.SoldOut {
+			continue
+		}
+		r, priced := lowest[a.HotelId]
+		if a.Kind == pb.Kind_PRICE && (!priced || r > a.MaxRate) {
+			continue
+		}
+		if err := s.trigger(ctx, c, a, r, now); err != nil {
+			log.Println("Failed notify alert: ", err)
+		}
+	}
+
+	if len(soldOut) > 0 {
+		_, err = c.UpdateAll(
+			bson.M{"_id": bson.M{"$in": soldOut}, "state": pb.State_ACTIVE},
+			bson.M{"$set": bson.M{"soldOut": true}},
+		)
+		if err != nil {
+			return err
+		}
+	}
+	_, err = c.UpdateAll(
+		bson.M{"_id": bson.M{"$in": ids}, "state": pb.State_ACTIVE},
+		bson.M{"$set": bson.M{"checked": now}},
+	)
+	return err
+}
+
+// trigger marks an alert triggered and sends its notification. If that
+// fails the alert is active again, to be tried next round.
+func (s *Server) trigger(ctx context.Context, c *mgo.Collection, a *alert, r float64, now time.Time) error {
+	set := bson.M{"state": pb.State_TRIGGERED, "triggered": now}
+	if a.Kind == pb.Kind_PRICE {
+		set["rate"] = r
+	}
+	err := c.Update(bson.M{"_id": a.ID, "state": pb.State_ACTIVE}, bson.M{"$set": set})
+	if err == mgo.ErrNotFound {
+		// deleted, or triggered by another replica
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	n := &Notification{
+		AlertId:     a.ID.Hex(),
+		Username:    a.Username,
+		Kind:        a.Kind.String(),
+		HotelId:     a.HotelId,
+		InDate:      a.InDate,
+		OutDate:     a.OutDate,
+		RoomNumber:  a.RoomNumber,
+		MaxRate:     a.MaxRate,
+		TriggeredAt: now.UTC(),
+	}
+	if a.Kind == pb.Kind_PRICE {
+		n.Rate = r
+	}
+	if err := s.Notifier.Notify(ctx, n); err != nil {
+		if err := c.Update(
+			bson.M{"_id": a.ID, "state": pb.State_TRIGGERED},
+			bson.M{"$set": bson.M{"state": pb.State_ACTIVE}, "$unset": bson.M{"triggered": "", "rate": ""}},
+		); err != nil && err != mgo.ErrNotFound {
+			log.Println("Failed reactivate alert: ", err)
+		}
+		return err
+	}
+	return nil
+}
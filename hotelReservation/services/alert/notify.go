@@ -0,0 +1,141 @@
+package alert
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"golang.org/x/net/context"
+)
+
+// webhookTimeout bounds each webhook call
+const webhookTimeout = 10 * time.Second
+
+// Notification is sent when an alert triggers. Rate is the lowest rate of
+// the hotel, for price alerts.
+type Notification struct {
+	AlertId     string    `json:"alertId"`
+	Username    string    `json:"username"`
+	Kind        string    `json:"kind"`
+	HotelId     string    `json:"hotelId"`
+	InDate      string    `json:"inDate"`
+	OutDate     string    `json:"outDate"`
+	RoomNumber  int32     `json:"roomNumber"`
+	MaxRate     float64   `json:"maxRate,omitempty"`
+	Rate        float64   `json:"rate,omitempty"`
+	TriggeredAt time.Time `json:"triggeredAt"`
+}
+
+// Notifier sends the notifications of triggered alerts. A failed
+// notification is sent again, so a notification may arrive more than
+// once.
+type Notifier interface {
+	Notify(ctx context.Context, n *Notification) error
+}
+
+// NewNotifier returns the notifier of comma separated sinks: "log" writes
+// notifications to the log, "file:<path>" appends them to a file as JSON
+// lines and "webhook:<url>" posts them as JSON. Webhook posts are signed
+// with secret in an X-Alert-Signature header if it is set.
+func NewNotifier(sinks, secret string) (Notifier, error) {
+	var ns multiNotifier
+	for _, sink := range strings.Split(sinks, ",") {
+		sink = strings.TrimSpace(sink)
+		switch {
+		case sink == "log":
+			ns = append(ns, &logNotifier{logger: log.New(os.Stderr, "alert: ", log.LstdFlags)})
+		case strings.HasPrefix(sink, "file:"):
+			f, err := os.OpenFile(strings.TrimPrefix(sink, "file:"), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+			if err != nil {
+				return nil, err
+			}
+			ns = append(ns, &logNotifier{logger: log.New(f, "", 0)})
+		case strings.HasPrefix(sink, "webhook:"):
+			u, err := url.Parse(strings.TrimPrefix(sink, "webhook:"))
+			if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+				return nil, fmt.Errorf("alert: invalid webhook url in %q", sink)
+			}
+			ns = append(ns, &webhookNotifier{
+				url:    u.String(),
+				secret: []byte(secret),
+				client: &http.Client{Timeout: webhookTimeout},
+			})
+		default:
+			return nil, fmt.Errorf("alert: unknown notifier sink %q", sink)
+		}
+	}
+	if len(ns) == 1 {
+		return ns[0], nil
+	}
+	return ns, nil
+}
+
+// multiNotifier sends notifications to several notifiers.
+type multiNotifier []Notifier
+
+func (m multiNotifier) Notify(ctx context.Context, n *Notification) error {
+	var first error
+	for _, notifier := range m {
+		if err := notifier.Notify(ctx, n); err != nil && first == nil {
+			first = err
+		}
+	}
+	return first
+}
+
+// logNotifier writes notifications as JSON lines.
+type logNotifier struct {
+	logger *log.Logger
+}
+
+func (l *logNotifier) Notify(ctx context.Context, n *Notification) error {
+	b, err := json.Marshal(n)
+	if err != nil {
+		return err
+	}
+	return l.logger.Output(2, string(b))
+}
+
+// webhookNotifier posts notifications as JSON to a url. Any response but
+// a 2xx is a failure.
+type webhookNotifier struct {
+	url    string
+	secret []byte
+	client *http.Client
+}
+
+func (w *webhookNotifier) Notify(ctx context.Context, n *Notification) error {
+	b, err := json.Marshal(n)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest("POST", w.url, bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if len(w.secret) > 0 {
+		h := hmac.New(sha256.New, w.secret)
+		h.Write(b)
+		req.Header.Set("X-Alert-Signature", "sha256="+hex.EncodeToString(h.Sum(nil)))
+	}
+
+	resp, err := w.client.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("alert: webhook answered %s", resp.Status)
+	}
+	return nil
+}
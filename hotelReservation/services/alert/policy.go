@@ -0,0 +1,32 @@
+package alert
+
+import (
+	"github.com/harlow/go-micro-services/rbac"
+	pb "github.com/harlow/go-micro-services/services/alert/proto"
+)
+
+// self is the identity the alert service calls other services with.
+var self = &rbac.Identity{Subject: name, Role: rbac.Service}
+
+// policy is who may call the alert service. Guests watch hotels for
+// themselves; alerts are erased with their user by the user service.
+var policy = rbac.Policy{
+	"/alert.Alert/CreateAlert": {
+		Roles: []rbac.Role{rbac.Guest},
+		Owner: func(req interface{}) string {
+			return req.(*pb.CreateRequest).Username
+		},
+	},
+	"/alert.Alert/ListAlerts": {
+		Roles: []rbac.Role{rbac.Guest},
+		Owner: func(req interface{}) string {
+			return req.(*pb.ListRequest).Username
+		},
+	},
+	"/alert.Alert/DeleteAlert": {
+		Roles: []rbac.Role{rbac.Guest},
+		Owner: func(req interface{}) string {
+			return req.(*pb.DeleteRequest).Username
+		},
+	},
+}
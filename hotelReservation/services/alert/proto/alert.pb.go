@@ -0,0 +1,577 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: services/alert/proto/alert.proto
+
+/*
+Package alert is a generated protocol buffer package.
+
+It is generated from these files:
+	services/alert/proto/alert.proto
+
+It has these top-level messages:
+	CreateRequest
+	Watch
+	ListRequest
+	ListResult
+	DeleteRequest
+	DeleteResult
+	EraseResult
+*/
+package alert
+
+import proto "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+
+import (
+	context "golang.org/x/net/context"
+	grpc "google.golang.org/grpc"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+// An AVAILABILITY alert triggers once the hotel, after it was seen sold
+// out for the dates, has roomNumber rooms free again. A PRICE alert
+// triggers once the hotel has the rooms free and a rate at or below
+// maxRate.
+type Kind int32
+
+const (
+	Kind_AVAILABILITY Kind = 0
+	Kind_PRICE        Kind = 1
+)
+
+var Kind_name = map[int32]string{
+	0: "AVAILABILITY",
+	1: "PRICE",
+}
+var Kind_value = map[string]int32{
+	"AVAILABILITY": 0,
+	"PRICE":        1,
+}
+
+func (x Kind) String() string {
+	return proto.EnumName(Kind_name, int32(x))
+}
+func (Kind) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{0} }
+
+// Alerts are ACTIVE until they trigger, or expire at their inDate.
+type State int32
+
+const (
+	State_ACTIVE    State = 0
+	State_TRIGGERED State = 1
+	State_EXPIRED   State = 2
+)
+
+var State_name = map[int32]string{
+	0: "ACTIVE",
+	1: "TRIGGERED",
+	2: "EXPIRED",
+}
+var State_value = map[string]int32{
+	"ACTIVE":    0,
+	"TRIGGERED": 1,
+	"EXPIRED":   2,
+}
+
+func (x State) String() string {
+	return proto.EnumName(State_name, int32(x))
+}
+func (State) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{1} }
+
+// roomNumber defaults to 1.
+type CreateRequest struct {
+	Username   string  `protobuf:"bytes,1,opt,name=username" json:"username,omitempty"`
+	HotelId    string  `protobuf:"bytes,2,opt,name=hotelId" json:"hotelId,omitempty"`
+	InDate     string  `protobuf:"bytes,3,opt,name=inDate" json:"inDate,omitempty"`
+	OutDate    string  `protobuf:"bytes,4,opt,name=outDate" json:"outDate,omitempty"`
+	RoomNumber int32   `protobuf:"varint,5,opt,name=roomNumber" json:"roomNumber,omitempty"`
+	Kind       Kind    `protobuf:"varint,6,opt,name=kind,enum=alert.Kind" json:"kind,omitempty"`
+	MaxRate    float64 `protobuf:"fixed64,7,opt,name=maxRate" json:"maxRate,omitempty"`
+}
+
+func (m *CreateRequest) Reset()                    { *m = CreateRequest{} }
+func (m *CreateRequest) String() string            { return proto.CompactTextString(m) }
+func (*CreateRequest) ProtoMessage()               {}
+func (*CreateRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{0} }
+
+func (m *CreateRequest) GetUsername() string {
+	if m != nil {
+		return m.Username
+	}
+	return ""
+}
+
+func (m *CreateRequest) GetHotelId() string {
+	if m != nil {
+		return m.HotelId
+	}
+	return ""
+}
+
+func (m *CreateRequest) GetInDate() string {
+	if m != nil {
+		return m.InDate
+	}
+	return ""
+}
+
+func (m *CreateRequest) GetOutDate() string {
+	if m != nil {
+		return m.OutDate
+	}
+	return ""
+}
+
+func (m *CreateRequest) GetRoomNumber() int32 {
+	if m != nil {
+		return m.RoomNumber
+	}
+	return 0
+}
+
+func (m *CreateRequest) GetKind() Kind {
+	if m != nil {
+		return m.Kind
+	}
+	return Kind_AVAILABILITY
+}
+
+func (m *CreateRequest) GetMaxRate() float64 {
+	if m != nil {
+		return m.MaxRate
+	}
+	return 0
+}
+
+// rate is the lowest rate seen when a PRICE alert triggered. created,
+// checked and triggered are in unix seconds, 0 if not yet.
+type Watch struct {
+	Id         string  `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	HotelId    string  `protobuf:"bytes,2,opt,name=hotelId" json:"hotelId,omitempty"`
+	InDate     string  `protobuf:"bytes,3,opt,name=inDate" json:"inDate,omitempty"`
+	OutDate    string  `protobuf:"bytes,4,opt,name=outDate" json:"outDate,omitempty"`
+	RoomNumber int32   `protobuf:"varint,5,opt,name=roomNumber" json:"roomNumber,omitempty"`
+	Kind       Kind    `protobuf:"varint,6,opt,name=kind,enum=alert.Kind" json:"kind,omitempty"`
+	MaxRate    float64 `protobuf:"fixed64,7,opt,name=maxRate" json:"maxRate,omitempty"`
+	State      State   `protobuf:"varint,8,opt,name=state,enum=alert.State" json:"state,omitempty"`
+	Rate       float64 `protobuf:"fixed64,9,opt,name=rate" json:"rate,omitempty"`
+	Created    int64   `protobuf:"varint,10,opt,name=created" json:"created,omitempty"`
+	Checked    int64   `protobuf:"varint,11,opt,name=checked" json:"checked,omitempty"`
+	Triggered  int64   `protobuf:"varint,12,opt,name=triggered" json:"triggered,omitempty"`
+}
+
+func (m *Watch) Reset()                    { *m = Watch{} }
+func (m *Watch) String() string            { return proto.CompactTextString(m) }
+func (*Watch) ProtoMessage()               {}
+func (*Watch) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{1} }
+
+func (m *Watch) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Watch) GetHotelId() string {
+	if m != nil {
+		return m.HotelId
+	}
+	return ""
+}
+
+func (m *Watch) GetInDate() string {
+	if m != nil {
+		return m.InDate
+	}
+	return ""
+}
+
+func (m *Watch) GetOutDate() string {
+	if m != nil {
+		return m.OutDate
+	}
+	return ""
+}
+
+func (m *Watch) GetRoomNumber() int32 {
+	if m != nil {
+		return m.RoomNumber
+	}
+	return 0
+}
+
+func (m *Watch) GetKind() Kind {
+	if m != nil {
+		return m.Kind
+	}
+	return Kind_AVAILABILITY
+}
+
+func (m *Watch) GetMaxRate() float64 {
+	if m != nil {
+		return m.MaxRate
+	}
+	return 0
+}
+
+func (m *Watch) GetState() State {
+	if m != nil {
+		return m.State
+	}
+	return State_ACTIVE
+}
+
+func (m *Watch) GetRate() float64 {
+	if m != nil {
+		return m.Rate
+	}
+	return 0
+}
+
+func (m *Watch) GetCreated() int64 {
+	if m != nil {
+		return m.Created
+	}
+	return 0
+}
+
+func (m *Watch) GetChecked() int64 {
+	if m != nil {
+		return m.Checked
+	}
+	return 0
+}
+
+func (m *Watch) GetTriggered() int64 {
+	if m != nil {
+		return m.Triggered
+	}
+	return 0
+}
+
+type ListRequest struct {
+	Username string `protobuf:"bytes,1,opt,name=username" json:"username,omitempty"`
+}
+
+func (m *ListRequest) Reset()                    { *m = ListRequest{} }
+func (m *ListRequest) String() string            { return proto.CompactTextString(m) }
+func (*ListRequest) ProtoMessage()               {}
+func (*ListRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{2} }
+
+func (m *ListRequest) GetUsername() string {
+	if m != nil {
+		return m.Username
+	}
+	return ""
+}
+
+type ListResult struct {
+	Alerts []*Watch `protobuf:"bytes,1,rep,name=alerts" json:"alerts,omitempty"`
+}
+
+func (m *ListResult) Reset()                    { *m = ListResult{} }
+func (m *ListResult) String() string            { return proto.CompactTextString(m) }
+func (*ListResult) ProtoMessage()               {}
+func (*ListResult) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
+
+func (m *ListResult) GetAlerts() []*Watch {
+	if m != nil {
+		return m.Alerts
+	}
+	return nil
+}
+
+type DeleteRequest struct {
+	Username string `protobuf:"bytes,1,opt,name=username" json:"username,omitempty"`
+	AlertId  string `protobuf:"bytes,2,opt,name=alertId" json:"alertId,omitempty"`
+}
+
+func (m *DeleteRequest) Reset()                    { *m = DeleteRequest{} }
+func (m *DeleteRequest) String() string            { return proto.CompactTextString(m) }
+func (*DeleteRequest) ProtoMessage()               {}
+func (*DeleteRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
+
+func (m *DeleteRequest) GetUsername() string {
+	if m != nil {
+		return m.Username
+	}
+	return ""
+}
+
+func (m *DeleteRequest) GetAlertId() string {
+	if m != nil {
+		return m.AlertId
+	}
+	return ""
+}
+
+type DeleteResult struct {
+	Deleted bool `protobuf:"varint,1,opt,name=deleted" json:"deleted,omitempty"`
+}
+
+func (m *DeleteResult) Reset()                    { *m = DeleteResult{} }
+func (m *DeleteResult) String() string            { return proto.CompactTextString(m) }
+func (*DeleteResult) ProtoMessage()               {}
+func (*DeleteResult) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+
+func (m *DeleteResult) GetDeleted() bool {
+	if m != nil {
+		return m.Deleted
+	}
+	return false
+}
+
+type EraseResult struct {
+	Erased int32 `protobuf:"varint,1,opt,name=erased" json:"erased,omitempty"`
+}
+
+func (m *EraseResult) Reset()                    { *m = EraseResult{} }
+func (m *EraseResult) String() string            { return proto.CompactTextString(m) }
+func (*EraseResult) ProtoMessage()               {}
+func (*EraseResult) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
+
+func (m *EraseResult) GetErased() int32 {
+	if m != nil {
+		return m.Erased
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterType((*CreateRequest)(nil), "alert.CreateRequest")
+	proto.RegisterType((*Watch)(nil), "alert.Watch")
+	proto.RegisterType((*ListRequest)(nil), "alert.ListRequest")
+	proto.RegisterType((*ListResult)(nil), "alert.ListResult")
+	proto.RegisterType((*DeleteRequest)(nil), "alert.DeleteRequest")
+	proto.RegisterType((*DeleteResult)(nil), "alert.DeleteResult")
+	proto.RegisterType((*EraseResult)(nil), "alert.EraseResult")
+	proto.RegisterEnum("alert.Kind", Kind_name, Kind_value)
+	proto.RegisterEnum("alert.State", State_name, State_value)
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// Client API for Alert service
+
+type AlertClient interface {
+	// CreateAlert starts watching a hotel for a guest's dates
+	CreateAlert(ctx context.Context, in *CreateRequest, opts ...grpc.CallOption) (*Watch, error)
+	// ListAlerts returns a guest's alerts, newest first
+	ListAlerts(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListResult, error)
+	// DeleteAlert stops and deletes an alert
+	DeleteAlert(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteResult, error)
+	// EraseAlerts deletes every alert of a guest
+	EraseAlerts(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*EraseResult, error)
+}
+
+type alertClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewAlertClient(cc *grpc.ClientConn) AlertClient {
+	return &alertClient{cc}
+}
+
+func (c *alertClient) CreateAlert(ctx context.Context, in *CreateRequest, opts ...grpc.CallOption) (*Watch, error) {
+	out := new(Watch)
+	err := grpc.Invoke(ctx, "/alert.Alert/CreateAlert", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *alertClient) ListAlerts(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListResult, error) {
+	out := new(ListResult)
+	err := grpc.Invoke(ctx, "/alert.Alert/ListAlerts", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *alertClient) DeleteAlert(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteResult, error) {
+	out := new(DeleteResult)
+	err := grpc.Invoke(ctx, "/alert.Alert/DeleteAlert", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *alertClient) EraseAlerts(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*EraseResult, error) {
+	out := new(EraseResult)
+	err := grpc.Invoke(ctx, "/alert.Alert/EraseAlerts", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for Alert service
+
+type AlertServer interface {
+	// CreateAlert starts watching a hotel for a guest's dates
+	CreateAlert(context.Context, *CreateRequest) (*Watch, error)
+	// ListAlerts returns a guest's alerts, newest first
+	ListAlerts(context.Context, *ListRequest) (*ListResult, error)
+	// DeleteAlert stops and deletes an alert
+	DeleteAlert(context.Context, *DeleteRequest) (*DeleteResult, error)
+	// EraseAlerts deletes every alert of a guest
+	EraseAlerts(context.Context, *ListRequest) (*EraseResult, error)
+}
+
+func RegisterAlertServer(s *grpc.Server, srv AlertServer) {
+	s.RegisterService(&_Alert_serviceDesc, srv)
+}
+
+func _Alert_CreateAlert_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AlertServer).CreateAlert(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/alert.Alert/CreateAlert",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AlertServer).CreateAlert(ctx, req.(*CreateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Alert_ListAlerts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AlertServer).ListAlerts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/alert.Alert/ListAlerts",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AlertServer).ListAlerts(ctx, req.(*ListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Alert_DeleteAlert_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AlertServer).DeleteAlert(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/alert.Alert/DeleteAlert",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AlertServer).DeleteAlert(ctx, req.(*DeleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Alert_EraseAlerts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AlertServer).EraseAlerts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/alert.Alert/EraseAlerts",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AlertServer).EraseAlerts(ctx, req.(*ListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Alert_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "alert.Alert",
+	HandlerType: (*AlertServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateAlert",
+			Handler:    _Alert_CreateAlert_Handler,
+		},
+		{
+			MethodName: "ListAlerts",
+			Handler:    _Alert_ListAlerts_Handler,
+		},
+		{
+			MethodName: "DeleteAlert",
+			Handler:    _Alert_DeleteAlert_Handler,
+		},
+		{
+			MethodName: "EraseAlerts",
+			Handler:    _Alert_EraseAlerts_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "services/alert/proto/alert.proto",
+}
+
+func init() { proto.RegisterFile("services/alert/proto/alert.proto", fileDescriptor0) }
+
+var fileDescriptor0 = []byte{
+	// 512 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xd4, 0x94, 0xc1, 0x6e, 0xd3, 0x4c,
+	0x10, 0xc7, 0xbb, 0x49, 0xec, 0x24, 0xe3, 0xa4, 0xca, 0x37, 0x1f, 0xaa, 0x56, 0x11, 0x02, 0xcb,
+	0x80, 0x64, 0x7a, 0x68, 0x44, 0x2a, 0x24, 0xae, 0x21, 0xb1, 0x2a, 0x8b, 0x08, 0x55, 0x4b, 0x54,
+	0xe0, 0xe8, 0xc6, 0xab, 0xc6, 0x6a, 0x12, 0xc3, 0x7a, 0x83, 0x78, 0x44, 0xce, 0x3c, 0x01, 0x8f,
+	0x82, 0x76, 0x76, 0xdd, 0x26, 0x1c, 0x50, 0xaf, 0xdc, 0xf6, 0x37, 0xff, 0xfd, 0xcf, 0xee, 0xcc,
+	0x78, 0x0d, 0x61, 0x25, 0xd5, 0xb7, 0x62, 0x29, 0xab, 0x51, 0xb6, 0x96, 0x4a, 0x8f, 0xbe, 0xa8,
+	0x52, 0x97, 0x76, 0x7d, 0x46, 0x6b, 0xf4, 0x08, 0xa2, 0x9f, 0x0c, 0xfa, 0x53, 0x25, 0x33, 0x2d,
+	0x85, 0xfc, 0xba, 0x93, 0x95, 0xc6, 0x21, 0x74, 0x76, 0x95, 0x54, 0xdb, 0x6c, 0x23, 0x39, 0x0b,
+	0x59, 0xdc, 0x15, 0x77, 0x8c, 0x1c, 0xda, 0xab, 0x52, 0xcb, 0x75, 0x9a, 0xf3, 0x06, 0x49, 0x35,
+	0xe2, 0x09, 0xf8, 0xc5, 0x76, 0x96, 0x69, 0xc9, 0x9b, 0x24, 0x38, 0x32, 0x8e, 0x72, 0xa7, 0x49,
+	0x68, 0x59, 0x87, 0x43, 0x7c, 0x02, 0xa0, 0xca, 0x72, 0xf3, 0x7e, 0xb7, 0xb9, 0x96, 0x8a, 0x7b,
+	0x21, 0x8b, 0x3d, 0xb1, 0x17, 0xc1, 0xa7, 0xd0, 0xba, 0x2d, 0xb6, 0x39, 0xf7, 0x43, 0x16, 0x1f,
+	0x8f, 0x83, 0x33, 0x7b, 0xf9, 0x77, 0xc5, 0x36, 0x17, 0x24, 0x98, 0xd4, 0x9b, 0xec, 0xbb, 0x30,
+	0xa9, 0xdb, 0x21, 0x8b, 0x99, 0xa8, 0x31, 0xfa, 0xd1, 0x00, 0xef, 0x63, 0xa6, 0x97, 0x2b, 0x3c,
+	0x86, 0x46, 0x91, 0xbb, 0x32, 0x1a, 0x45, 0xfe, 0x8f, 0x14, 0x80, 0x11, 0x78, 0x95, 0x36, 0xf1,
+	0x0e, 0x79, 0x7b, 0xce, 0xfb, 0xc1, 0xc4, 0x84, 0x95, 0x10, 0xa1, 0xa5, 0xcc, 0x96, 0x2e, 0x59,
+	0x69, 0x6d, 0x32, 0x2e, 0x69, 0x98, 0x39, 0x87, 0x90, 0xc5, 0x4d, 0x51, 0x23, 0x29, 0x2b, 0xb9,
+	0xbc, 0x95, 0x39, 0x0f, 0x9c, 0x62, 0x11, 0x1f, 0x43, 0x57, 0xab, 0xe2, 0xe6, 0x46, 0x2a, 0x99,
+	0xf3, 0x1e, 0x69, 0xf7, 0x81, 0xe8, 0x25, 0x04, 0xf3, 0xa2, 0xd2, 0x0f, 0xf8, 0x38, 0xa2, 0x31,
+	0x80, 0xdd, 0x5a, 0xed, 0xd6, 0x1a, 0x9f, 0x83, 0x4f, 0x97, 0xae, 0x38, 0x0b, 0x9b, 0x71, 0x70,
+	0x57, 0x03, 0xcd, 0x45, 0x38, 0x2d, 0x4a, 0xa0, 0x3f, 0x93, 0x6b, 0xf9, 0xe0, 0xaf, 0x8f, 0x6c,
+	0xf7, 0xc3, 0x73, 0x18, 0xc5, 0xd0, 0xab, 0xd3, 0xd0, 0xe1, 0x1c, 0xda, 0x39, 0xb1, 0x9d, 0x7d,
+	0x47, 0xd4, 0x18, 0xbd, 0x80, 0x20, 0x51, 0x59, 0x55, 0x6f, 0x3c, 0x01, 0x5f, 0x1a, 0xb4, 0xfb,
+	0x3c, 0xe1, 0xe8, 0xf4, 0x19, 0xb4, 0xcc, 0xa0, 0x70, 0x00, 0xbd, 0xc9, 0xd5, 0x24, 0x9d, 0x4f,
+	0xde, 0xa6, 0xf3, 0x74, 0xf1, 0x79, 0x70, 0x84, 0x5d, 0xf0, 0x2e, 0x45, 0x3a, 0x4d, 0x06, 0xec,
+	0x74, 0x04, 0x1e, 0x4d, 0x04, 0x01, 0xfc, 0xc9, 0x74, 0x91, 0x5e, 0x25, 0x83, 0x23, 0xec, 0x43,
+	0x77, 0x21, 0xd2, 0x8b, 0x8b, 0x44, 0x24, 0xb3, 0x01, 0xc3, 0x00, 0xda, 0xc9, 0xa7, 0xcb, 0xd4,
+	0x40, 0x63, 0xfc, 0x8b, 0x81, 0x37, 0x31, 0x57, 0xc6, 0x57, 0x10, 0xd8, 0x57, 0x67, 0xf1, 0x91,
+	0x6b, 0xce, 0xc1, 0x4b, 0x1c, 0x1e, 0xb4, 0x0c, 0xcf, 0x6d, 0x7b, 0xc9, 0x50, 0x21, 0x3a, 0x6d,
+	0x6f, 0x38, 0xc3, 0xff, 0x0e, 0x62, 0x54, 0xdf, 0x1b, 0x08, 0x6c, 0x63, 0x0e, 0xcf, 0x39, 0xe8,
+	0xf9, 0xf0, 0xff, 0x3f, 0xa2, 0xe4, 0x7c, 0xed, 0x1a, 0xf5, 0x97, 0xf3, 0xea, 0xd8, 0x5e, 0x43,
+	0xaf, 0x7d, 0xfa, 0xbb, 0x9c, 0xff, 0x1e, 0x00, 0xa2, 0x68, 0xa0, 0x32, 0x81, 0x04, 0x00, 0x00,
+}
@@ -0,0 +1,342 @@
+package alert
+
+import (
+	"fmt"
+	"log"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-opentracing/go/otgrpc"
+	"github.com/harlow/go-micro-services/dialer"
+	"github.com/harlow/go-micro-services/rbac"
+	"github.com/harlow/go-micro-services/registry"
+	pb "github.com/harlow/go-micro-services/services/alert/proto"
+	rate "github.com/harlow/go-micro-services/services/rate/proto"
+	reservation "github.com/harlow/go-micro-services/services/reservation/proto"
+	"github.com/opentracing/opentracing-go"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/status"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+const name = "srv-alert"
+
+const (
+	// a guest may have at most this many active alerts
+	maxActiveAlerts = 50
+	// alerts cover at most this many nights
+	maxAlertNights = 30
+	// alerts are dropped from alert-db this long after their stay
+	alertRetention = 30 * 24 * time.Hour
+)
+
+// Server implements the alert service
+type Server struct {
+	reservationClient reservation.ReservationClient
+	rateClient        rate.RateClient
+
+	Tracer       opentracing.Tracer
+	Port         int
+	IpAddr       string
+	MongoSession *mgo.Session
+	Registry     *registry.Client
+	// Identity signs and verifies the identities calls are made by
+	Identity *rbac.Signer
+	// Notifier sends the notifications of triggered alerts
+	Notifier Notifier
+}
+
+// alert is an alert in alert-db.alerts.
+type alert struct {
+	ID         bson.ObjectId `bson:"_id"`
+	Username   string        `bson:"username"`
+	HotelId    string        `bson:"hotelId"`
+	InDate     string        `bson:"inDate"`
+	OutDate    string        `bson:"outDate"`
+	RoomNumber int32         `bson:"roomNumber"`
+	Kind       pb.Kind       `bson:"kind"`
+	MaxRate    float64       `bson:"maxRate,omitempty"`
+	State      pb.State      `bson:"state"`
+	Rate       float64       `bson:"rate,omitempty"`
+	Created    time.Time     `bson:"created"`
+	Checked    time.Time     `bson:"checked,omitempty"`
+	Triggered  time.Time     `bson:"triggered,omitempty"`
+	// SoldOut is set once the hotel was seen without rooms for the stay;
+	// an availability alert only triggers after that
+	SoldOut bool `bson:"soldOut,omitempty"`
+	// Expires is when alert-db drops the alert
+	Expires time.Time `bson:"expires"`
+}
+
+func unix(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.Unix()
+}
+
+func (a *alert) proto() *pb.Watch {
+	return &pb.Watch{
+		Id:         a.ID.Hex(),
+		HotelId:    a.HotelId,
+		InDate:     a.InDate,
+		OutDate:    a.OutDate,
+		RoomNumber: a.RoomNumber,
+		Kind:       a.Kind,
+		MaxRate:    a.MaxRate,
+		State:      a.State,
+		Rate:       a.Rate,
+		Created:    unix(a.Created),
+		Checked:    unix(a.Checked),
+		Triggered:  unix(a.Triggered),
+	}
+}
+
+// Run starts the server
+func (s *Server) Run() error {
+	if s.Port == 0 {
+		return fmt.Errorf("server port must be set")
+	}
+
+	if s.Identity == nil {
+		return fmt.Errorf("identity keys must be set")
+	}
+
+	if s.Notifier == nil {
+		return fmt.Errorf("notifier must be set")
+	}
+
+	srv := grpc.NewServer(
+		grpc.KeepaliveParams(keepalive.ServerParameters{
+			Timeout: 120 * time.Second,
+		}),
+		grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
+			PermitWithoutStream: true,
+		}),
+		grpc.UnaryInterceptor(rbac.ChainUnaryServer(
+			otgrpc.OpenTracingServerInterceptor(s.Tracer),
+			rbac.UnaryServerInterceptor(s.Identity, policy),
+		)),
+	)
+
+	pb.RegisterAlertServer(srv, s)
+
+	// init grpc clients
+	if err := s.initReservationClient("srv-reservation"); err != nil {
+		return err
+	}
+	if err := s.initRateClient("srv-rate"); err != nil {
+		return err
+	}
+
+	go s.evaluateLoop()
+
+	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", s.Port))
+	if err != nil {
+		log.Fatalf("failed to listen: %v", err)
+	}
+
+	err = s.Registry.Register(name, s.IpAddr, s.Port)
+	if err != nil {
+		return fmt.Errorf("failed register: %v", err)
+	}
+
+	return srv.Serve(lis)
+}
+
+// Shutdown cleans up any processes
+func (s *Server) Shutdown() {
+	s.Registry.Deregister(name)
+}
+
+func (s *Server) initReservationClient(name string) error {
+	conn, err := dialer.Dial(
+		name,
+		dialer.WithTracer(s.Tracer),
+		dialer.WithBalancer(s.Registry.Client),
+		dialer.WithIdentity(s.Identity, self),
+	)
+	if err != nil {
+		return fmt.Errorf("dialer error: %v", err)
+	}
+	s.reservationClient = reservation.NewReservationClient(conn)
+	return nil
+}
+
+func (s *Server) initRateClient(name string) error {
+	conn, err := dialer.Dial(
+		name,
+		dialer.WithTracer(s.Tracer),
+		dialer.WithBalancer(s.Registry.Client),
+		dialer.WithIdentity(s.Identity, self),
+	)
+	if err != nil {
+		return fmt.Errorf("dialer error: %v", err)
+	}
+	s.rateClient = rate.NewRateClient(conn)
+	return nil
+}
+
+// stayDates parses the dates of a stay, which must start no earlier than
+// today and last at least one night.
+func stayDates(inDate, outDate string, now time.Time) (time.Time, time.Time, error) {
+	in, err := time.Parse("2006-01-02", inDate)
+	if err != nil {
+		return in, in, fmt.Errorf("inDate must be YYYY-MM-DD")
+	}
+	out, err := time.Parse("2006-01-02", outDate)
+	if err != nil {
+		return in, out, fmt.Errorf("outDate must be YYYY-MM-DD")
+	}
+	if !in.Before(out) {
+		return in, out, fmt.Errorf("outDate must be after inDate")
+	}
+	if out.Sub(in) > maxAlertNights*24*time.Hour {
+		return in, out, fmt.Errorf("alerts cover at most %d nights", maxAlertNights)
+	}
+	if inDate < now.UTC().Format("2006-01-02") {
+		return in, out, fmt.Errorf("inDate must not be in the past")
+	}
+	return in, out, nil
+}
+
+// CreateAlert starts watching a hotel for the dates of a guest. It does
+// not check that the hotel exists; alerts on unknown hotels never trigger.
+func (s *Server) CreateAlert(ctx context.Context, req *pb.CreateRequest) (*pb.Watch, error) {
+	hotelId := strings.TrimSpace(req.HotelId)
+	if req.Username == "" || hotelId == "" {
+		return nil, status.Error(codes.InvalidArgument, "username and hotel id must be set")
+	}
+	now := time.Now()
+	_, out, err := stayDates(req.InDate, req.OutDate, now)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	roomNumber := req.RoomNumber
+	if roomNumber == 0 {
+		roomNumber = 1
+	}
+	if roomNumber < 0 {
+		return nil, status.Error(codes.InvalidArgument, "room number must be positive")
+	}
+	switch req.Kind {
+	case pb.Kind_AVAILABILITY:
+	case pb.Kind_PRICE:
+		if req.MaxRate <= 0 {
+			return nil, status.Error(codes.InvalidArgument, "price alerts must have a positive max rate")
+		}
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "unknown alert kind %v", req.Kind)
+	}
+
+	session := s.MongoSession.Copy()
+	defer session.Close()
+
+	c := session.DB("alert-db").C("alerts")
+
+	count, err := c.Find(bson.M{"username": req.Username, "state": pb.State_ACTIVE}).Count()
+	if err != nil {
+		return nil, err
+	}
+	if count >= maxActiveAlerts {
+		return nil, status.Errorf(codes.FailedPrecondition, "a guest may have at most %d active alerts", maxActiveAlerts)
+	}
+
+	a := &alert{
+		ID:         bson.NewObjectId(),
+		Username:   req.Username,
+		HotelId:    hotelId,
+		InDate:     req.InDate,
+		OutDate:    req.OutDate,
+		RoomNumber: roomNumber,
+		Kind:       req.Kind,
+		State:      pb.State_ACTIVE,
+		Created:    now,
+		Expires:    out.Add(alertRetention),
+	}
+	if req.Kind == pb.Kind_PRICE {
+		a.MaxRate = req.MaxRate
+	}
+	if req.Kind == pb.Kind_AVAILABILITY {
+		// if this fails the first evaluation finds out
+		availResp, err := s.reservationClient.CheckAvailability(ctx, &reservation.Request{
+			HotelId:    []string{hotelId},
+			InDate:     req.InDate,
+			OutDate:    req.OutDate,
+			RoomNumber: roomNumber,
+		})
+		if err != nil {
+			log.Println("Failed check availability: ", err)
+		} else {
+			a.SoldOut = len(availResp.HotelId) == 0
+		}
+	}
+	if err := c.Insert(a); err != nil {
+		return nil, err
+	}
+	return a.proto(), nil
+}
+
+// ListAlerts returns the alerts of a guest, newest first.
+func (s *Server) ListAlerts(ctx context.Context, req *pb.ListRequest) (*pb.ListResult, error) {
+	res := new(pb.ListResult)
+
+	session := s.MongoSession.Copy()
+	defer session.Close()
+
+	var alerts []alert
+	err := session.DB("alert-db").C("alerts").Find(bson.M{"username": req.Username}).Sort("-_id").All(&alerts)
+	if err != nil {
+		return nil, err
+	}
+	for i := range alerts {
+		res.Alerts = append(res.Alerts, alerts[i].proto())
+	}
+	return res, nil
+}
+
+// DeleteAlert deletes an alert of a guest.
+func (s *Server) DeleteAlert(ctx context.Context, req *pb.DeleteRequest) (*pb.DeleteResult, error) {
+	res := new(pb.DeleteResult)
+	if req.Username == "" || !bson.IsObjectIdHex(req.AlertId) {
+		return nil, status.Error(codes.InvalidArgument, "username and a valid alert id must be set")
+	}
+
+	session := s.MongoSession.Copy()
+	defer session.Close()
+
+	err := session.DB("alert-db").C("alerts").Remove(bson.M{
+		"_id":      bson.ObjectIdHex(req.AlertId),
+		"username": req.Username,
+	})
+	if err == mgo.ErrNotFound {
+		return res, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	res.Deleted = true
+	return res, nil
+}
+
+// EraseAlerts deletes every alert of a guest, for the user service to
+// erase a user.
+func (s *Server) EraseAlerts(ctx context.Context, req *pb.ListRequest) (*pb.EraseResult, error) {
+	if req.Username == "" {
+		return nil, status.Error(codes.InvalidArgument, "username must be set")
+	}
+
+	session := s.MongoSession.Copy()
+	defer session.Close()
+
+	info, err := session.DB("alert-db").C("alerts").RemoveAll(bson.M{"username": req.Username})
+	if err != nil {
+		return nil, err
+	}
+	return &pb.EraseResult{Erased: int32(info.Removed)}, nil
+}
@@ -0,0 +1,139 @@
+package frontend
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/harlow/go-micro-services/services/alert/proto"
+)
+
+// alertsHandler returns the alerts of the user, newest first.
+func (s *Server) alertsHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	ctx := r.Context()
+
+	username, ok := s.authenticate(w, r)
+	if !ok {
+		return
+	}
+	ctx = asGuest(ctx, username)
+
+	listResp, err := s.alertClient.ListAlerts(ctx, &alert.ListRequest{
+		Username: username,
+	})
+	if writeCallError(w, err) {
+		return
+	}
+
+	alerts := listResp.Alerts
+	if alerts == nil {
+		alerts = []*alert.Watch{}
+	}
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"alerts": alerts,
+	})
+}
+
+// alertCreateHandler creates an alert on a hotel for a stay. With
+// kind=availability, the default, it triggers once the hotel has the rooms
+// of the number param free; with kind=price once it also has a rate at or
+// below the max_rate param.
+func (s *Server) alertCreateHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	ctx := r.Context()
+
+	hotelId := r.URL.Query().Get("hotelId")
+	if hotelId == "" {
+		http.Error(w, "Please specify hotelId params", http.StatusBadRequest)
+		return
+	}
+	inDate, outDate := r.URL.Query().Get("inDate"), r.URL.Query().Get("outDate")
+	if inDate == "" || outDate == "" {
+		http.Error(w, "Please specify inDate/outDate params", http.StatusBadRequest)
+		return
+	}
+	if !checkDataFormat(inDate) || !checkDataFormat(outDate) {
+		http.Error(w, "Please check inDate/outDate format (YYYY-MM-DD)", http.StatusBadRequest)
+		return
+	}
+	numberOfRoom := 1
+	if num := r.URL.Query().Get("number"); num != "" {
+		var err error
+		numberOfRoom, err = strconv.Atoi(num)
+		if err != nil || numberOfRoom < 1 {
+			http.Error(w, "Please check number params", http.StatusBadRequest)
+			return
+		}
+	}
+
+	req := &alert.CreateRequest{
+		HotelId:    hotelId,
+		InDate:     inDate,
+		OutDate:    outDate,
+		RoomNumber: int32(numberOfRoom),
+	}
+	switch r.URL.Query().Get("kind") {
+	case "", "availability":
+		req.Kind = alert.Kind_AVAILABILITY
+	case "price":
+		req.Kind = alert.Kind_PRICE
+		maxRate, err := strconv.ParseFloat(r.URL.Query().Get("max_rate"), 64)
+		if err != nil || maxRate <= 0 {
+			http.Error(w, "Please specify a positive max_rate params", http.StatusBadRequest)
+			return
+		}
+		req.MaxRate = maxRate
+	default:
+		http.Error(w, "Please check kind params (availability or price)", http.StatusBadRequest)
+		return
+	}
+
+	username, ok := s.authenticate(w, r)
+	if !ok {
+		return
+	}
+	ctx = asGuest(ctx, username)
+	req.Username = username
+
+	watch, err := s.alertClient.CreateAlert(ctx, req)
+	if writeCallError(w, err) {
+		return
+	}
+
+	json.NewEncoder(w).Encode(watch)
+}
+
+// alertDeleteHandler deletes an alert of the user.
+func (s *Server) alertDeleteHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	ctx := r.Context()
+
+	id := r.URL.Query().Get("id")
+	if id == "" {
+		http.Error(w, "Please specify id params", http.StatusBadRequest)
+		return
+	}
+
+	username, ok := s.authenticate(w, r)
+	if !ok {
+		return
+	}
+	ctx = asGuest(ctx, username)
+
+	deleteResp, err := s.alertClient.DeleteAlert(ctx, &alert.DeleteRequest{
+		Username: username,
+		AlertId:  id,
+	})
+	if writeCallError(w, err) {
+		return
+	}
+
+	str := "Delete successfully!"
+	if !deleteResp.Deleted {
+		str = "Failed. No such alert."
+	}
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"message": str,
+	})
+}
@@ -0,0 +1,185 @@
+package frontend
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/harlow/go-micro-services/rbac"
+	"github.com/harlow/go-micro-services/services/admin/proto"
+	"github.com/harlow/go-micro-services/services/user/proto"
+	"golang.org/x/net/context"
+)
+
+// bearerToken returns the session token of an "Authorization: Bearer"
+// header, or "".
+func bearerToken(r *http.Request) string {
+	h := r.Header.Get("Authorization")
+	if len(h) > len("Bearer ") && strings.EqualFold(h[:len("Bearer ")], "Bearer ") {
+		return strings.TrimSpace(h[len("Bearer "):])
+	}
+	return ""
+}
+
+// asGuest returns a context whose calls are made on behalf of the guest
+// username, who the services then authorize.
+func asGuest(ctx context.Context, username string) context.Context {
+	return rbac.WithIdentity(ctx, rbac.Identity{Subject: username, Role: rbac.Guest})
+}
+
+// authenticateAdmin checks the email and password of an admin and returns
+// a context whose calls are made on its behalf. If the admin is not
+// authenticated it writes the error response and returns false.
+func (s *Server) authenticateAdmin(w http.ResponseWriter, r *http.Request, email, password string) (context.Context, bool) {
+	ctx := r.Context()
+
+	loginResp, err := s.adminClient.Login(ctx, &admin.LoginRequest{
+		Email:    email,
+		Password: password,
+		ClientIp: clientIp(r),
+	})
+	if writeLoginError(w, err) {
+		return nil, false
+	}
+	if !loginResp.Correct {
+		http.Error(w, "Failed. Please check your email and password. ", http.StatusUnauthorized)
+		return nil, false
+	}
+	role, ok := rbac.ParseRole(loginResp.Role)
+	if !ok {
+		http.Error(w, "Unknown admin role "+loginResp.Role, http.StatusInternalServerError)
+		return nil, false
+	}
+
+	return rbac.WithIdentity(ctx, rbac.Identity{
+		Subject: email,
+		Role:    role,
+		Hotels:  loginResp.Hotels,
+	}), true
+}
+
+// authenticate returns the user making a request, from its session token
+// or else its username and password params. If the request is not
+// authenticated it writes the error response and returns false.
+func (s *Server) authenticate(w http.ResponseWriter, r *http.Request) (string, bool) {
+	ctx := r.Context()
+
+	if token := bearerToken(r); token != "" {
+		tokenResp, err := s.userClient.VerifyToken(ctx, &user.TokenRequest{Token: token})
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return "", false
+		}
+		if !tokenResp.Valid {
+			http.Error(w, "Invalid or expired session token", http.StatusUnauthorized)
+			return "", false
+		}
+		return tokenResp.Username, true
+	}
+
+	username, password := r.URL.Query().Get("username"), r.URL.Query().Get("password")
+	if username == "" || password == "" {
+		http.Error(w, "Please specify username and password", http.StatusBadRequest)
+		return "", false
+	}
+
+	userResp, err := s.userClient.CheckUser(ctx, &user.Request{
+		Username: username,
+		Password: password,
+		ClientIp: clientIp(r),
+	})
+	if writeLoginError(w, err) {
+		return "", false
+	}
+	if !userResp.Correct {
+		http.Error(w, "Failed. Please check your username and password. ", http.StatusUnauthorized)
+		return "", false
+	}
+	return username, true
+}
+
+// loginHandler checks a username and password and returns a session token
+// for the Authorization header of later requests.
+func (s *Server) loginHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	ctx := r.Context()
+
+	username, password := r.FormValue("username"), r.FormValue("password")
+	if username == "" || password == "" {
+		http.Error(w, "Please specify username and password", http.StatusBadRequest)
+		return
+	}
+
+	loginResp, err := s.userClient.Login(ctx, &user.Request{
+		Username: username,
+		Password: password,
+		ClientIp: clientIp(r),
+	})
+	if writeLoginError(w, err) {
+		return
+	}
+	if !loginResp.Correct {
+		http.Error(w, "Failed. Please check your username and password. ", http.StatusUnauthorized)
+		return
+	}
+
+	writeToken(w, loginResp)
+}
+
+// refreshHandler replaces the session token of the request with a new one.
+func (s *Server) refreshHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	ctx := r.Context()
+
+	token := bearerToken(r)
+	if token == "" {
+		http.Error(w, "Please specify a bearer token", http.StatusBadRequest)
+		return
+	}
+
+	refreshResp, err := s.userClient.RefreshToken(ctx, &user.TokenRequest{Token: token})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if !refreshResp.Correct {
+		http.Error(w, "Invalid or expired session token", http.StatusUnauthorized)
+		return
+	}
+
+	writeToken(w, refreshResp)
+}
+
+// logoutHandler revokes the session token of the request.
+func (s *Server) logoutHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	ctx := r.Context()
+
+	token := bearerToken(r)
+	if token == "" {
+		http.Error(w, "Please specify a bearer token", http.StatusBadRequest)
+		return
+	}
+
+	logoutResp, err := s.userClient.Logout(ctx, &user.TokenRequest{Token: token})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	str := "Logout successfully!"
+	if !logoutResp.Correct {
+		str = "Failed. Invalid or expired session token. "
+	}
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"message": str,
+	})
+}
+
+func writeToken(w http.ResponseWriter, res *user.LoginResult) {
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"token":     res.Token,
+		"tokenType": "Bearer",
+		"expiresAt": res.ExpiresAt,
+	})
+}
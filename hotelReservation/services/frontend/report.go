@@ -0,0 +1,102 @@
+package frontend
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/harlow/go-micro-services/rbac"
+	"github.com/harlow/go-micro-services/services/admin/proto"
+)
+
+// reportColumns is the header of a report downloaded as CSV.
+var reportColumns = []string{
+	"hotelId", "roomNightsAvailable", "roomNightsSold", "unpricedRoomNights",
+	"estimatedRevenue", "occupancy", "estimatedAdr", "estimatedRevpar",
+	"cancellations", "cancelledRoomNights", "stays", "averageLeadDays",
+}
+
+// adminReportHandler returns the occupancy and estimated revenue report of
+// the hotels of the hotelIds param, or else of every hotel of the admin,
+// as JSON or, with format=csv, as a CSV download.
+func (s *Server) adminReportHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	email, password := r.URL.Query().Get("email"), r.URL.Query().Get("password")
+	if email == "" || password == "" {
+		http.Error(w, "Please specify email /password params", http.StatusBadRequest)
+		return
+	}
+	inDate, outDate := r.URL.Query().Get("inDate"), r.URL.Query().Get("outDate")
+	if inDate == "" || outDate == "" {
+		http.Error(w, "Please specify inDate/outDate params", http.StatusBadRequest)
+		return
+	}
+	if !checkDataFormat(inDate) || !checkDataFormat(outDate) {
+		http.Error(w, "Please check inDate/outDate format (YYYY-MM-DD)", http.StatusBadRequest)
+		return
+	}
+	format := r.URL.Query().Get("format")
+	if format != "" && format != "json" && format != "csv" {
+		http.Error(w, "Please specify format json or csv", http.StatusBadRequest)
+		return
+	}
+
+	ctx, ok := s.authenticateAdmin(w, r, email, password)
+	if !ok {
+		return
+	}
+	hotelIds := hotelIdsParam(r, "hotelIds")
+	if len(hotelIds) == 0 {
+		if id := rbac.Outgoing(ctx); id != nil {
+			hotelIds = id.Hotels
+		}
+	}
+
+	reportResp, err := s.adminClient.HotelReport(ctx, &admin.ReportRequest{
+		HotelIds: hotelIds,
+		InDate:   inDate,
+		OutDate:  outDate,
+	})
+	if writeCallError(w, err) {
+		return
+	}
+
+	if format != "csv" {
+		hotels := reportResp.Hotels
+		if hotels == nil {
+			hotels = []*admin.HotelReport{}
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"inDate":  inDate,
+			"outDate": outDate,
+			"hotels":  hotels,
+		})
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/csv")
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{
+		"filename": "report-" + inDate + "-" + outDate + ".csv",
+	}))
+	cw := csv.NewWriter(w)
+	cw.Write(reportColumns)
+	for _, h := range reportResp.Hotels {
+		cw.Write([]string{
+			h.HotelId,
+			strconv.FormatInt(h.RoomNightsAvailable, 10),
+			strconv.FormatInt(h.RoomNightsSold, 10),
+			strconv.FormatInt(h.UnpricedRoomNights, 10),
+			strconv.FormatFloat(h.EstimatedRevenue, 'f', 2, 64),
+			strconv.FormatFloat(h.Occupancy, 'f', 4, 64),
+			strconv.FormatFloat(h.EstimatedAdr, 'f', 2, 64),
+			strconv.FormatFloat(h.EstimatedRevpar, 'f', 2, 64),
+			strconv.Itoa(int(h.Cancellations)),
+			strconv.Itoa(int(h.CancelledRoomNights)),
+			strconv.Itoa(int(h.Stays)),
+			strconv.FormatFloat(h.AverageLeadDays, 'f', 1, 64),
+		})
+	}
+	cw.Flush()
+}
@@ -4,8 +4,12 @@ import (
 	"encoding/json"
 	"fmt"
 	"github.com/harlow/go-micro-services/dialer"
+	"github.com/harlow/go-micro-services/lockout"
+	"github.com/harlow/go-micro-services/rbac"
 	"github.com/harlow/go-micro-services/registry"
 	"github.com/harlow/go-micro-services/services/admin/proto"
+	"github.com/harlow/go-micro-services/services/alert/proto"
+	"github.com/harlow/go-micro-services/services/geo/proto"
 	"github.com/harlow/go-micro-services/services/profile/proto"
 	"github.com/harlow/go-micro-services/services/recommendation/proto"
 	"github.com/harlow/go-micro-services/services/reservation/proto"
@@ -13,22 +17,35 @@ import (
 	"github.com/harlow/go-micro-services/services/user/proto"
 	"github.com/harlow/go-micro-services/tracing"
 	"github.com/opentracing/opentracing-go"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"log"
+	"mime"
 	"net/http"
 	"strconv"
+	"strings"
 )
 
 // Server implements frontend service
 type Server struct {
 	searchClient         search.SearchClient
+	geoClient            geo.GeoClient
 	profileClient        profile.ProfileClient
 	recommendationClient recommendation.RecommendationClient
 	userClient           user.UserClient
 	adminClient          admin.AdminClient
 	reservationClient    reservation.ReservationClient
+	alertClient          alert.AlertClient
 	IpAddr               string
 	Port                 int
 	Tracer               opentracing.Tracer
 	Registry             *registry.Client
+	// Identity signs the identities of the clients calls are made for
+	Identity *rbac.Signer
+	// AddrLockout rejects clients with too many failed logins, nil
+	// disables it
+	AddrLockout *lockout.Limiter
 }
 
 // Run the server
@@ -37,10 +54,18 @@ func (s *Server) Run() error {
 		return fmt.Errorf("server port must be set")
 	}
 
+	if s.Identity == nil {
+		return fmt.Errorf("identity keys must be set")
+	}
+
 	if err := s.initSearchClient("srv-search"); err != nil {
 		return err
 	}
 
+	if err := s.initGeoClient("srv-geo"); err != nil {
+		return err
+	}
+
 	if err := s.initProfileClient("srv-profile"); err != nil {
 		return err
 	}
@@ -60,22 +85,53 @@ func (s *Server) Run() error {
 	if err := s.initAdminClient("srv-admin"); err != nil {
 		return err
 	}
+
+	if err := s.initAlertClient("srv-alert"); err != nil {
+		return err
+	}
 	// fmt.Printf("frontend before mux\n")
 
 	mux := tracing.NewServeMux(s.Tracer)
 	mux.Handle("/", http.FileServer(http.Dir("services/frontend/static")))
 	mux.Handle("/hotels", http.HandlerFunc(s.searchHandler))
-	mux.Handle("/recommendations", http.HandlerFunc(s.recommendHandler))
-	mux.Handle("/user", http.HandlerFunc(s.userHandler))
+	mux.Handle("/hotels/map", http.HandlerFunc(s.mapHandler))
+	mux.Handle("/recommendations", s.throttle(http.HandlerFunc(s.recommendHandler)))
+	mux.Handle("/trending", http.HandlerFunc(s.trendingHandler))
+	mux.Handle("/suggest", http.HandlerFunc(s.suggestHandler))
+	mux.Handle("/user", s.throttle(http.HandlerFunc(s.userHandler)))
+	mux.Handle("/user/orders", s.throttle(http.HandlerFunc(s.userOrdersHandler)))
+	mux.Handle("/user/loyalty", s.throttle(http.HandlerFunc(s.userLoyaltyHandler)))
+	mux.Handle("/user/loyalty/history", s.throttle(http.HandlerFunc(s.userLoyaltyHistoryHandler)))
+	mux.Handle("/wishlists", s.throttle(http.HandlerFunc(s.wishlistsHandler)))
+	mux.Handle("/wishlists/create", s.throttle(http.HandlerFunc(s.wishlistCreateHandler)))
+	mux.Handle("/wishlists/update", s.throttle(http.HandlerFunc(s.wishlistUpdateHandler)))
+	mux.Handle("/wishlists/delete", s.throttle(http.HandlerFunc(s.wishlistDeleteHandler)))
+	mux.Handle("/wishlists/share", s.throttle(http.HandlerFunc(s.wishlistShareHandler)))
+	mux.Handle("/wishlist", s.throttle(http.HandlerFunc(s.wishlistHandler)))
+	mux.Handle("/wishlist/shared", http.HandlerFunc(s.sharedWishlistHandler))
+	mux.Handle("/alerts", s.throttle(http.HandlerFunc(s.alertsHandler)))
+	mux.Handle("/alerts/create", s.throttle(http.HandlerFunc(s.alertCreateHandler)))
+	mux.Handle("/alerts/delete", s.throttle(http.HandlerFunc(s.alertDeleteHandler)))
+	mux.Handle("/user/export", s.throttle(http.HandlerFunc(s.userExportHandler)))
+	mux.Handle("/user/erase", s.throttle(http.HandlerFunc(s.userEraseHandler)))
+	mux.Handle("/login", s.throttle(http.HandlerFunc(s.loginHandler)))
+	mux.Handle("/logout", http.HandlerFunc(s.logoutHandler))
+	mux.Handle("/refresh", http.HandlerFunc(s.refreshHandler))
 	mux.Handle("/userregister", http.HandlerFunc(s.userRegisterHandler))
-	mux.Handle("/usermodify", http.HandlerFunc(s.userModifyHandler))
-	mux.Handle("/userdelete", http.HandlerFunc(s.userDeleteHandler))
-	mux.Handle("/userevaluate", http.HandlerFunc(s.userEvaluateHandler))
-	mux.Handle("/reservation", http.HandlerFunc(s.reservationHandler))
-	mux.Handle("/cancelreservation", http.HandlerFunc(s.cancelReservationHandler))
-	mux.Handle("/adminlogin", http.HandlerFunc(s.adminLoginHandler))
+	mux.Handle("/usermodify", s.throttle(http.HandlerFunc(s.userModifyHandler)))
+	mux.Handle("/userdelete", s.throttle(http.HandlerFunc(s.userDeleteHandler)))
+	mux.Handle("/userevaluate", s.throttle(http.HandlerFunc(s.userEvaluateHandler)))
+	mux.Handle("/reservation", s.throttle(http.HandlerFunc(s.reservationHandler)))
+	mux.Handle("/cancelreservation", s.throttle(http.HandlerFunc(s.cancelReservationHandler)))
+	mux.Handle("/adminlogin", s.throttle(http.HandlerFunc(s.adminLoginHandler)))
 	mux.Handle("/daminregister", http.HandlerFunc(s.adminRegisterHandler))
-	mux.Handle("/updateProfile", http.HandlerFunc(s.updateProfileHandler))
+	mux.Handle("/updateProfile", s.throttle(http.HandlerFunc(s.updateProfileHandler)))
+	mux.Handle("/adminhotel", s.throttle(http.HandlerFunc(s.adminCreateHotelHandler)))
+	mux.Handle("/adminhotel/closures", s.throttle(http.HandlerFunc(s.adminClosuresHandler)))
+	mux.Handle("/adminhotel/close", s.throttle(http.HandlerFunc(s.adminCloseHotelHandler)))
+	mux.Handle("/adminhotel/reopen", s.throttle(http.HandlerFunc(s.adminReopenHotelHandler)))
+	mux.Handle("/adminhotel/decommission", s.throttle(http.HandlerFunc(s.adminDecommissionHandler)))
+	mux.Handle("/adminreport", s.throttle(http.HandlerFunc(s.adminReportHandler)))
 	// fmt.Printf("frontend starts serving\n")
 
 	return http.ListenAndServe(fmt.Sprintf(":%d", s.Port), mux)
@@ -85,6 +141,7 @@ func (s *Server) initAdminClient(name string) error {
 		name,
 		dialer.WithTracer(s.Tracer),
 		dialer.WithBalancer(s.Registry.Client),
+		dialer.WithIdentity(s.Identity, nil),
 	)
 	if err != nil {
 		return fmt.Errorf("dialer error: %v", err)
@@ -92,11 +149,26 @@ func (s *Server) initAdminClient(name string) error {
 	s.adminClient = admin.NewAdminClient(conn)
 	return nil
 }
+func (s *Server) initAlertClient(name string) error {
+	conn, err := dialer.Dial(
+		name,
+		dialer.WithTracer(s.Tracer),
+		dialer.WithBalancer(s.Registry.Client),
+		dialer.WithIdentity(s.Identity, nil),
+	)
+	if err != nil {
+		return fmt.Errorf("dialer error: %v", err)
+	}
+	s.alertClient = alert.NewAlertClient(conn)
+	return nil
+}
+
 func (s *Server) initSearchClient(name string) error {
 	conn, err := dialer.Dial(
 		name,
 		dialer.WithTracer(s.Tracer),
 		dialer.WithBalancer(s.Registry.Client),
+		dialer.WithIdentity(s.Identity, nil),
 	)
 	if err != nil {
 		return fmt.Errorf("dialer error: %v", err)
@@ -105,11 +177,26 @@ func (s *Server) initSearchClient(name string) error {
 	return nil
 }
 
+func (s *Server) initGeoClient(name string) error {
+	conn, err := dialer.Dial(
+		name,
+		dialer.WithTracer(s.Tracer),
+		dialer.WithBalancer(s.Registry.Client),
+		dialer.WithIdentity(s.Identity, nil),
+	)
+	if err != nil {
+		return fmt.Errorf("dialer error: %v", err)
+	}
+	s.geoClient = geo.NewGeoClient(conn)
+	return nil
+}
+
 func (s *Server) initProfileClient(name string) error {
 	conn, err := dialer.Dial(
 		name,
 		dialer.WithTracer(s.Tracer),
 		dialer.WithBalancer(s.Registry.Client),
+		dialer.WithIdentity(s.Identity, nil),
 	)
 	if err != nil {
 		return fmt.Errorf("dialer error: %v", err)
@@ -123,6 +210,7 @@ func (s *Server) initRecommendationClient(name string) error {
 		name,
 		dialer.WithTracer(s.Tracer),
 		dialer.WithBalancer(s.Registry.Client),
+		dialer.WithIdentity(s.Identity, nil),
 	)
 	if err != nil {
 		return fmt.Errorf("dialer error: %v", err)
@@ -136,6 +224,7 @@ func (s *Server) initUserClient(name string) error {
 		name,
 		dialer.WithTracer(s.Tracer),
 		dialer.WithBalancer(s.Registry.Client),
+		dialer.WithIdentity(s.Identity, nil),
 	)
 	if err != nil {
 		return fmt.Errorf("dialer error: %v", err)
@@ -149,6 +238,7 @@ func (s *Server) initReservation(name string) error {
 		name,
 		dialer.WithTracer(s.Tracer),
 		dialer.WithBalancer(s.Registry.Client),
+		dialer.WithIdentity(s.Identity, nil),
 	)
 	if err != nil {
 		return fmt.Errorf("dialer error: %v", err)
@@ -182,14 +272,36 @@ func (s *Server) searchHandler(w http.ResponseWriter, r *http.Request) {
 	Lon, _ := strconv.ParseFloat(sLon, 32)
 	lon := float32(Lon)
 
+	// optional search radius (km) and result limit, capped by the geo service
+	var radius float64
+	if sRadius := r.URL.Query().Get("radius"); sRadius != "" {
+		var err error
+		radius, err = strconv.ParseFloat(sRadius, 32)
+		if err != nil || radius < 0 {
+			http.Error(w, "Please check radius params", http.StatusBadRequest)
+			return
+		}
+	}
+	limit := 0
+	if sLimit := r.URL.Query().Get("limit"); sLimit != "" {
+		var err error
+		limit, err = strconv.Atoi(sLimit)
+		if err != nil || limit < 0 {
+			http.Error(w, "Please check limit params", http.StatusBadRequest)
+			return
+		}
+	}
+
 	// fmt.Printf("starts searchHandler querying downstream\n")
 
 	// search for best hotels
 	searchResp, err := s.searchClient.Nearby(ctx, &search.NearbyRequest{
-		Lat:     lat,
-		Lon:     lon,
-		InDate:  inDate,
-		OutDate: outDate,
+		Lat:      lat,
+		Lon:      lon,
+		InDate:   inDate,
+		OutDate:  outDate,
+		RadiusKm: float32(radius),
+		Limit:    int32(limit),
 	})
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
@@ -220,8 +332,9 @@ func (s *Server) searchHandler(w http.ResponseWriter, r *http.Request) {
 
 	// hotel profiles
 	profileResp, err := s.profileClient.GetProfiles(ctx, &profile.Request{
-		HotelIds: reservationResp.HotelId,
-		Locale:   locale,
+		HotelIds:   reservationResp.HotelId,
+		Locale:     locale,
+		Background: true,
 	})
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
@@ -230,34 +343,52 @@ func (s *Server) searchHandler(w http.ResponseWriter, r *http.Request) {
 
 	// fmt.Printf("searchHandler gets profileResp\n")
 
-	json.NewEncoder(w).Encode(geoJSONResponse(profileResp.Hotels))
+	distances := make(map[string]map[string]interface{})
+	for _, h := range searchResp.Hotels {
+		distances[h.HotelId] = map[string]interface{}{
+			"distance": h.DistanceKm,
+		}
+	}
+
+	json.NewEncoder(w).Encode(geoJSONResponseWith(profileResp.Hotels, distances))
 }
 
-func (s *Server) recommendHandler(w http.ResponseWriter, r *http.Request) {
+// mapHandler returns the hotels inside a map viewport,
+// bbox=minLon,minLat,maxLon,maxLat as in GeoJSON. With a zoom
+// level, nearby hotels are grouped into cluster features.
+func (s *Server) mapHandler(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Access-Control-Allow-Origin", "*")
 	ctx := r.Context()
 
-	sLat, sLon := r.URL.Query().Get("lat"), r.URL.Query().Get("lon")
-	if sLat == "" || sLon == "" {
-		http.Error(w, "Please specify location params", http.StatusBadRequest)
+	sw, ne, err := parseBBox(r.URL.Query().Get("bbox"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
-	Lat, _ := strconv.ParseFloat(sLat, 64)
-	lat := float64(Lat)
-	Lon, _ := strconv.ParseFloat(sLon, 64)
-	lon := float64(Lon)
 
-	require := r.URL.Query().Get("require")
-	if require != "dis" && require != "rate" && require != "price" && require != "mix"  {
-		http.Error(w, "Please specify require params", http.StatusBadRequest)
+	if sZoom := r.URL.Query().Get("zoom"); sZoom != "" {
+		zoom, err := strconv.Atoi(sZoom)
+		if err != nil || zoom < 0 {
+			http.Error(w, "Please check zoom params", http.StatusBadRequest)
+			return
+		}
+		s.clusterHandler(w, r, sw, ne, zoom)
 		return
 	}
 
-	// recommend hotels
-	recResp, err := s.recommendationClient.GetRecommendations(ctx, &recommendation.Request{
-		Require: require,
-		Lat:     float64(lat),
-		Lon:     float64(lon),
+	limit := 0
+	if sLimit := r.URL.Query().Get("limit"); sLimit != "" {
+		limit, err = strconv.Atoi(sLimit)
+		if err != nil || limit < 0 {
+			http.Error(w, "Please check limit params", http.StatusBadRequest)
+			return
+		}
+	}
+
+	geoResp, err := s.geoClient.WithinBounds(ctx, &geo.BoundsRequest{
+		Sw:    sw,
+		Ne:    ne,
+		Limit: int32(limit),
 	})
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
@@ -270,10 +401,10 @@ func (s *Server) recommendHandler(w http.ResponseWriter, r *http.Request) {
 		locale = "en"
 	}
 
-	// hotel profiles
 	profileResp, err := s.profileClient.GetProfiles(ctx, &profile.Request{
-		HotelIds: recResp.HotelIds,
-		Locale:   locale,
+		HotelIds:   geoResp.HotelIds,
+		Locale:     locale,
+		Background: true,
 	})
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
@@ -282,246 +413,752 @@ func (s *Server) recommendHandler(w http.ResponseWriter, r *http.Request) {
 
 	json.NewEncoder(w).Encode(geoJSONResponse(profileResp.Hotels))
 }
-func (s *Server) adminRegisterHandler(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Access-Control-Allow-Origin", "*")
+
+// clusterHandler writes clusters as GeoJSON features with a point_count
+// property, and hotels that are not clustered as regular hotel features
+func (s *Server) clusterHandler(w http.ResponseWriter, r *http.Request, sw, ne *geo.Point, zoom int) {
 	ctx := r.Context()
-	// hotels := []string {"1", "2", "3"}
-	hotels := r.URL.Query().Get("hotels")
-	if hotels == "" {
-		http.Error(w, "Please specify username and password", http.StatusBadRequest)
-		return
-	}
-	var hotelsArr []string
-	json.Unmarshal([]byte(hotels), hotelsArr)
-	name, email, password, id := r.URL.Query().Get("name"), r.URL.Query().Get("email"), r.URL.Query().Get("password"), r.URL.Query().Get("id")
-	if name == "" || email == "" || password == "" || id == "" {
-		http.Error(w, "Please specify username and password", http.StatusBadRequest)
-		return
-	}
-	recResp, err := s.adminClient.Register(ctx, &admin.RegisterRequest{
-		Name:     name,
-		Email:    email,
-		Password: password,
-		Hotels:   hotelsArr,
-		Id:       id,
+
+	clusterResp, err := s.geoClient.Clusters(ctx, &geo.ClusterRequest{
+		Sw:   sw,
+		Ne:   ne,
+		Zoom: int32(zoom),
 	})
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
-	str := "Register successfully!"
-	if recResp.Correct == false {
-		str = "Failed. Please check your register input. "
-	}
 
-	res := map[string]interface{}{
-		"message": str,
+	fs := []interface{}{}
+	hotelIds := make([]string, 0)
+	for i, c := range clusterResp.Clusters {
+		if c.HotelId != "" {
+			hotelIds = append(hotelIds, c.HotelId)
+			continue
+		}
+		fs = append(fs, map[string]interface{}{
+			"type": "Feature",
+			"id":   fmt.Sprintf("cluster-%d-%d", zoom, i),
+			"properties": map[string]interface{}{
+				"cluster":                 true,
+				"point_count":             c.Count,
+				"point_count_abbreviated": abbreviateCount(c.Count),
+			},
+			"geometry": map[string]interface{}{
+				"type": "Point",
+				"coordinates": []float32{
+					c.Lon,
+					c.Lat,
+				},
+			},
+		})
 	}
 
-	json.NewEncoder(w).Encode(res)
-}
-func (s *Server) updateProfileHandler(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Access-Control-Allow-Origin", "*")
-	ctx := r.Context()
-	email, password := r.URL.Query().Get("password"), r.URL.Query().Get("password")
-	if email == "" || password == "" {
-		http.Error(w, "Please specify email /password params", http.StatusBadRequest)
-		return
-	}
-	recResp, err := s.adminClient.Login(ctx, &admin.LoginRequest{
-		Email:    email,
-		Password: password,
-	})
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	if recResp.Correct == false {
-		str := "Failed. Please check your username and password. "
-		res := map[string]interface{}{
-			"message": str,
+	if len(hotelIds) > 0 {
+		// grab locale from query params or default to en
+		locale := r.URL.Query().Get("locale")
+		if locale == "" {
+			locale = "en"
 		}
 
-		json.NewEncoder(w).Encode(res)
-		return
-	} else {
-		id := r.URL.Query().Get("id")
-		if id == "" {
-			http.Error(w, "Please specify id params", http.StatusBadRequest)
-			return
-		}
-		checkResp, err1 := s.adminClient.CheckHotel(ctx, &admin.CheckRequest{
-			Email: email,
-			Id:    id,
+		profileResp, err := s.profileClient.GetProfiles(ctx, &profile.Request{
+			HotelIds:   hotelIds,
+			Locale:     locale,
+			Background: true,
 		})
-		if err1 != nil {
+		if err != nil {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
 		}
-		if checkResp.Correct == false {
-			str := "It is not your hotel, you could not update it "
-			res := map[string]interface{}{
-				"message": str,
-			}
-
-			json.NewEncoder(w).Encode(res)
-			return
-		} else {
-			target, content := r.URL.Query().Get("target"), r.URL.Query().Get("content")
-			if target == "" {
-				http.Error(w, "Please specify target/content params", http.StatusBadRequest)
-				return
-			}
-			updateResp, err2 := s.adminClient.Update(ctx, &admin.UpdateRequest{
-				Id:      id,
-				Target:  target,
-				Content: content,
-			})
-			if err2 != nil {
-				http.Error(w, err.Error(), http.StatusInternalServerError)
-				return
-			}
-			str1 := "Update fail"
-			if updateResp.Correct == true {
-				str1 = "Success"
-			}
-			res := map[string]interface{}{
-				"message": str1,
-			}
-			json.NewEncoder(w).Encode(res)
-			return
-		}
+		hotels := geoJSONResponse(profileResp.Hotels)["features"].([]interface{})
+		fs = append(fs, hotels...)
 	}
 
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"type":     "FeatureCollection",
+		"features": fs,
+	})
 }
-func (s *Server) adminLoginHandler(w http.ResponseWriter, r *http.Request) {
+
+func (s *Server) suggestHandler(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Access-Control-Allow-Origin", "*")
 	ctx := r.Context()
-	//get parameter
-	email, password := r.URL.Query().Get("email"), r.URL.Query().Get("password")
-	if email == "" || password == "" {
-		http.Error(w, "Please specify username and password", http.StatusBadRequest)
+
+	prefix := r.URL.Query().Get("prefix")
+	if prefix == "" {
+		http.Error(w, "Please specify prefix params", http.StatusBadRequest)
 		return
 	}
-	recResp, err := s.adminClient.Login(ctx, &admin.LoginRequest{
-		Email:    email,
-		Password: password,
+
+	limit := 0
+	if l := r.URL.Query().Get("limit"); l != "" {
+		limit, _ = strconv.Atoi(l)
+	}
+
+	suggestResp, err := s.profileClient.Suggest(ctx, &profile.SuggestRequest{
+		Prefix: prefix,
+		Limit:  int32(limit),
 	})
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
-	str := "Login successfully!"
-	if recResp.Correct == false {
-		str = "Failed. Please check your username and password. "
+
+	// every suggestion carries coordinates the client can pass to /hotels
+	suggestions := make([]interface{}, 0, len(suggestResp.Suggestions))
+	for _, sg := range suggestResp.Suggestions {
+		suggestions = append(suggestions, map[string]interface{}{
+			"kind":       sg.Kind,
+			"text":       sg.Text,
+			"hotelId":    sg.HotelId,
+			"hotelCount": sg.HotelCount,
+			"lat":        sg.Lat,
+			"lon":        sg.Lon,
+		})
 	}
 
 	res := map[string]interface{}{
-		"message": str,
+		"suggestions": suggestions,
 	}
 
 	json.NewEncoder(w).Encode(res)
 }
 
-func (s *Server) userHandler(w http.ResponseWriter, r *http.Request) {
+func (s *Server) recommendHandler(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Access-Control-Allow-Origin", "*")
 	ctx := r.Context()
 
-	username, password := r.URL.Query().Get("username"), r.URL.Query().Get("password")
-	if username == "" || password == "" {
-		http.Error(w, "Please specify username and password", http.StatusBadRequest)
+	sLat, sLon := r.URL.Query().Get("lat"), r.URL.Query().Get("lon")
+	if sLat == "" || sLon == "" {
+		http.Error(w, "Please specify location params", http.StatusBadRequest)
 		return
 	}
+	Lat, _ := strconv.ParseFloat(sLat, 64)
+	lat := float64(Lat)
+	Lon, _ := strconv.ParseFloat(sLon, 64)
+	lon := float64(Lon)
 
-	// Check username and password
-	recResp, err := s.userClient.CheckUser(ctx, &user.Request{
-		Username: username,
-		Password: password,
-	})
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+	// the recommendation service rejects strategies it does not know
+	require := r.URL.Query().Get("require")
+	if require == "" {
+		http.Error(w, "Please specify require params", http.StatusBadRequest)
 		return
 	}
 
-	str := "Login successfully!"
-	if recResp.Correct == false {
-		str = "Failed. Please check your username and password. "
+	// personal recommendations are only given to the user themselves
+	username := ""
+	if require == "personal" {
+		var ok bool
+		if username, ok = s.authenticate(w, r); !ok {
+			return
+		}
+		ctx = asGuest(ctx, username)
 	}
 
-	res := map[string]interface{}{
-		"message": str,
+	var err error
+	k := 0
+	if sK := r.URL.Query().Get("k"); sK != "" {
+		k, err = strconv.Atoi(sK)
+		if err != nil || k < 0 {
+			http.Error(w, "Please check k params", http.StatusBadRequest)
+			return
+		}
 	}
 
-	json.NewEncoder(w).Encode(res)
-}
-
-func (s *Server) userRegisterHandler(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Access-Control-Allow-Origin", "*")
-	ctx := r.Context()
+	// weights=distance,rate,price tunes the mix
+	var weights *recommendation.Factors
+	if sWeights := r.URL.Query().Get("weights"); sWeights != "" {
+		ws := strings.Split(sWeights, ",")
+		vs := make([]float64, len(ws))
+		for i := range ws {
+			vs[i], err = strconv.ParseFloat(ws[i], 64)
+			if err != nil {
+				break
+			}
+		}
+		if err != nil || len(vs) != 3 {
+			http.Error(w, "Please check weights params (distance,rate,price)", http.StatusBadRequest)
+			return
+		}
+		weights = &recommendation.Factors{
+			Distance: vs[0],
+			Rate:     vs[1],
+			Price:    vs[2],
+		}
+	}
 
-	username, password, age, sex, mail, phone := r.URL.Query().Get("username"), r.URL.Query().Get("password"), r.URL.Query().Get("age"), r.URL.Query().Get("sex"), r.URL.Query().Get("mail"), r.URL.Query().Get("phone")
-	if username == "" || password == "" {
-		http.Error(w, "Please specify username and password", http.StatusBadRequest)
+	// recommend hotels
+	recResp, err := s.recommendationClient.GetRecommendations(ctx, &recommendation.Request{
+		Require:  require,
+		Lat:      float64(lat),
+		Lon:      float64(lon),
+		Username: username,
+		K:        int32(k),
+		Weights:  weights,
+	})
+	if status.Code(err) == codes.InvalidArgument {
+		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
-
-	age_int, err := strconv.ParseInt(age, 10, 32)
 	if err != nil {
-		panic(err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
 	}
 
-	// Register
-	recResp, err := s.userClient.Register(ctx, &user.RegisterRequest{
-		Username: username,
-		Password: password,
-		Age:      int32(age_int),
-		Sex:      sex,
-		Mail:     mail,
-		Phone:    phone,
+	// grab locale from query params or default to en
+	locale := r.URL.Query().Get("locale")
+	if locale == "" {
+		locale = "en"
+	}
+
+	// hotel profiles
+	profileResp, err := s.profileClient.GetProfiles(ctx, &profile.Request{
+		HotelIds:   recResp.HotelIds,
+		Locale:     locale,
+		Background: true,
 	})
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
-	str := "Register successfully!"
-	if recResp.Correct == false {
-		str = "Failed. Please check your username and password. "
-	}
-
-	res := map[string]interface{}{
-		"message": str,
+	extra := make(map[string]map[string]interface{})
+	for i, rec := range recResp.Recommendations {
+		extra[rec.HotelId] = map[string]interface{}{
+			"rank":                i + 1,
+			"recommendationScore": rec.Score,
+			"contributions":       rec.Contributions,
+			"reason":              rec.Reason,
+		}
 	}
 
-	json.NewEncoder(w).Encode(res)
+	json.NewEncoder(w).Encode(geoJSONResponseWith(profileResp.Hotels, extra))
 }
-
-func (s *Server) userModifyHandler(w http.ResponseWriter, r *http.Request) {
+// trendingHandler returns the hotels with the most recent bookings, search
+// impressions and views near a location, radius in km.
+func (s *Server) trendingHandler(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Access-Control-Allow-Origin", "*")
 	ctx := r.Context()
 
-	username, password, age, sex, mail, phone := r.URL.Query().Get("username"), r.URL.Query().Get("password"), r.URL.Query().Get("age"), r.URL.Query().Get("sex"), r.URL.Query().Get("mail"), r.URL.Query().Get("phone")
-	if username == "" || password == "" {
-		http.Error(w, "Please specify username and password", http.StatusBadRequest)
+	sLat, sLon := r.URL.Query().Get("lat"), r.URL.Query().Get("lon")
+	if sLat == "" || sLon == "" {
+		http.Error(w, "Please specify location params", http.StatusBadRequest)
+		return
+	}
+	lat, err1 := strconv.ParseFloat(sLat, 64)
+	lon, err2 := strconv.ParseFloat(sLon, 64)
+	if err1 != nil || err2 != nil {
+		http.Error(w, "Please check location params", http.StatusBadRequest)
 		return
 	}
 
-	age_int, err := strconv.ParseInt(age, 10, 32)
-	if err != nil {
-		panic(err)
+	var err error
+	radius := 0.0
+	if sRadius := r.URL.Query().Get("radius"); sRadius != "" {
+		radius, err = strconv.ParseFloat(sRadius, 64)
+		if err != nil {
+			http.Error(w, "Please check radius params", http.StatusBadRequest)
+			return
+		}
+	}
+	k := 0
+	if sK := r.URL.Query().Get("k"); sK != "" {
+		k, err = strconv.Atoi(sK)
+		if err != nil || k < 0 {
+			http.Error(w, "Please check k params", http.StatusBadRequest)
+			return
+		}
+	}
+
+	trendingResp, err := s.recommendationClient.Trending(ctx, &recommendation.TrendingRequest{
+		Lat:      lat,
+		Lon:      lon,
+		RadiusKm: radius,
+		K:        int32(k),
+	})
+	if status.Code(err) == codes.InvalidArgument {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// grab locale from query params or default to en
+	locale := r.URL.Query().Get("locale")
+	if locale == "" {
+		locale = "en"
+	}
+
+	hotelIds := make([]string, 0, len(trendingResp.Hotels))
+	extra := make(map[string]map[string]interface{})
+	for i, h := range trendingResp.Hotels {
+		hotelIds = append(hotelIds, h.HotelId)
+		extra[h.HotelId] = map[string]interface{}{
+			"rank":          i + 1,
+			"trendingScore": h.Score,
+			"bookings":      h.Bookings,
+			"impressions":   h.Impressions,
+			"views":         h.Views,
+			"distance":      h.DistanceKm,
+		}
+	}
+
+	// listing trending hotels must not count as views, or they would
+	// keep themselves trending
+	profileResp, err := s.profileClient.GetProfiles(ctx, &profile.Request{
+		HotelIds:   hotelIds,
+		Locale:     locale,
+		Background: true,
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	json.NewEncoder(w).Encode(geoJSONResponseWith(profileResp.Hotels, extra))
+}
+
+func (s *Server) adminRegisterHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	// hotels := []string {"1", "2", "3"}
+	hotels := r.URL.Query().Get("hotels")
+	if hotels == "" {
+		http.Error(w, "Please specify username and password", http.StatusBadRequest)
+		return
+	}
+	var hotelsArr []string
+	if err := json.Unmarshal([]byte(hotels), &hotelsArr); err != nil {
+		http.Error(w, "Please specify hotels as a JSON array of hotel ids", http.StatusBadRequest)
+		return
+	}
+	name, email, password, id := r.URL.Query().Get("name"), r.URL.Query().Get("email"), r.URL.Query().Get("password"), r.URL.Query().Get("id")
+	if name == "" || email == "" || password == "" || id == "" {
+		http.Error(w, "Please specify username and password", http.StatusBadRequest)
+		return
+	}
+
+	// admins are registered by a chain admin or operator
+	adminEmail, adminPassword := r.URL.Query().Get("admin_email"), r.URL.Query().Get("admin_password")
+	if adminEmail == "" || adminPassword == "" {
+		http.Error(w, "Please specify admin_email and admin_password params", http.StatusBadRequest)
+		return
+	}
+	ctx, ok := s.authenticateAdmin(w, r, adminEmail, adminPassword)
+	if !ok {
+		return
+	}
+
+	recResp, err := s.adminClient.Register(ctx, &admin.RegisterRequest{
+		Name:     name,
+		Email:    email,
+		Password: password,
+		Hotels:   hotelsArr,
+		Id:       id,
+		Role:     r.URL.Query().Get("role"),
+	})
+	switch status.Code(err) {
+	case codes.InvalidArgument:
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	case codes.PermissionDenied:
+		http.Error(w, status.Convert(err).Message(), http.StatusForbidden)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	str := "Register successfully!"
+	if recResp.Correct == false {
+		str = "Failed. Please check your register input. "
+	}
+
+	res := map[string]interface{}{
+		"message": str,
+	}
+
+	json.NewEncoder(w).Encode(res)
+}
+func (s *Server) updateProfileHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	email, password := r.URL.Query().Get("email"), r.URL.Query().Get("password")
+	if email == "" || password == "" {
+		http.Error(w, "Please specify email /password params", http.StatusBadRequest)
+		return
+	}
+	id := r.URL.Query().Get("id")
+	if id == "" {
+		http.Error(w, "Please specify id params", http.StatusBadRequest)
+		return
+	}
+	target, content := r.URL.Query().Get("target"), r.URL.Query().Get("content")
+	if target == "" {
+		http.Error(w, "Please specify target/content params", http.StatusBadRequest)
+		return
+	}
+
+	ctx, ok := s.authenticateAdmin(w, r, email, password)
+	if !ok {
+		return
+	}
+
+	// the admin service only lets admins update the hotels they are granted
+	updateResp, err := s.adminClient.Update(ctx, &admin.UpdateRequest{
+		Id:      id,
+		Target:  target,
+		Content: content,
+	})
+	if status.Code(err) == codes.PermissionDenied {
+		http.Error(w, "It is not your hotel, you could not update it ", http.StatusForbidden)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	str1 := "Update fail"
+	if updateResp.Correct == true {
+		str1 = "Success"
+	}
+	res := map[string]interface{}{
+		"message": str1,
+	}
+	json.NewEncoder(w).Encode(res)
+}
+// adminCreateHotelHandler onboards the hotel POSTed as JSON, with the
+// fields of admin.CreateHotelRequest, in every service. Only operators may.
+func (s *Server) adminCreateHotelHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "Please POST the hotel as JSON", http.StatusMethodNotAllowed)
+		return
+	}
+	email, password := r.URL.Query().Get("email"), r.URL.Query().Get("password")
+	if email == "" || password == "" {
+		http.Error(w, "Please specify email /password params", http.StatusBadRequest)
+		return
+	}
+
+	req := new(admin.CreateHotelRequest)
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1<<20)).Decode(req); err != nil {
+		http.Error(w, "Please check the hotel JSON: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	ctx, ok := s.authenticateAdmin(w, r, email, password)
+	if !ok {
+		return
+	}
+
+	createResp, err := s.adminClient.CreateHotel(ctx, req)
+	if writeCallError(w, err) {
+		return
+	}
+
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"message": "Success",
+		"id":      createResp.Id,
+		"steps":   createResp.Steps,
+	})
+}
+// adminHotelRequest authenticates the admin of the email and password
+// params for the hotel of the hotelId param. If either is missing or the
+// admin is not authenticated it writes the error response and returns
+// false.
+func (s *Server) adminHotelRequest(w http.ResponseWriter, r *http.Request) (context.Context, string, bool) {
+	email, password := r.URL.Query().Get("email"), r.URL.Query().Get("password")
+	if email == "" || password == "" {
+		http.Error(w, "Please specify email /password params", http.StatusBadRequest)
+		return nil, "", false
+	}
+	hotelId := r.URL.Query().Get("hotelId")
+	if hotelId == "" {
+		http.Error(w, "Please specify hotelId params", http.StatusBadRequest)
+		return nil, "", false
+	}
+
+	ctx, ok := s.authenticateAdmin(w, r, email, password)
+	return ctx, hotelId, ok
+}
+
+// adminClosuresHandler returns the closures of a hotel that have not ended.
+func (s *Server) adminClosuresHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	ctx, hotelId, ok := s.adminHotelRequest(w, r)
+	if !ok {
+		return
+	}
+
+	listResp, err := s.reservationClient.ListClosures(ctx, &reservation.ClosureRequest{
+		HotelId: hotelId,
+	})
+	if writeCallError(w, err) {
+		return
+	}
+
+	closures := listResp.Closures
+	if closures == nil {
+		closures = []*reservation.Closure{}
+	}
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"closures": closures,
+	})
+}
+
+// adminCloseHotelHandler closes a hotel from the inDate param up to the
+// outDate param, for the reason param.
+func (s *Server) adminCloseHotelHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	inDate, outDate := r.URL.Query().Get("inDate"), r.URL.Query().Get("outDate")
+	if inDate == "" || outDate == "" {
+		http.Error(w, "Please specify inDate/outDate params", http.StatusBadRequest)
+		return
+	}
+	if !checkDataFormat(inDate) || !checkDataFormat(outDate) {
+		http.Error(w, "Please check inDate/outDate format (YYYY-MM-DD)", http.StatusBadRequest)
+		return
+	}
+	reason := r.URL.Query().Get("reason")
+	if reason == "" {
+		http.Error(w, "Please specify reason params", http.StatusBadRequest)
+		return
+	}
+	ctx, hotelId, ok := s.adminHotelRequest(w, r)
+	if !ok {
+		return
+	}
+
+	closure, err := s.reservationClient.AddClosure(ctx, &reservation.Closure{
+		HotelId: hotelId,
+		InDate:  inDate,
+		OutDate: outDate,
+		Reason:  reason,
+	})
+	if writeCallError(w, err) {
+		return
+	}
+
+	json.NewEncoder(w).Encode(closure)
+}
+
+// adminReopenHotelHandler removes the closure of the id param.
+func (s *Server) adminReopenHotelHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	id := r.URL.Query().Get("id")
+	if id == "" {
+		http.Error(w, "Please specify id params", http.StatusBadRequest)
+		return
+	}
+	ctx, hotelId, ok := s.adminHotelRequest(w, r)
+	if !ok {
+		return
+	}
+
+	removeResp, err := s.reservationClient.RemoveClosure(ctx, &reservation.ClosureRequest{
+		HotelId:   hotelId,
+		ClosureId: id,
+	})
+	if writeCallError(w, err) {
+		return
+	}
+
+	str := "Reopen successfully!"
+	if !removeResp.Removed {
+		str = "Failed. No such closure."
+	}
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"message": str,
+	})
+}
+
+// adminDecommissionHandler takes a hotel out of service for good.
+func (s *Server) adminDecommissionHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	ctx, hotelId, ok := s.adminHotelRequest(w, r)
+	if !ok {
+		return
+	}
+
+	decommissionResp, err := s.adminClient.DecommissionHotel(ctx, &admin.DecommissionRequest{
+		Id: hotelId,
+	})
+	if writeCallError(w, err) {
+		return
+	}
+
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"message": "Success",
+		"steps":   decommissionResp.Steps,
+	})
+}
+
+func (s *Server) adminLoginHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	ctx := r.Context()
+	//get parameter
+	email, password := r.URL.Query().Get("email"), r.URL.Query().Get("password")
+	if email == "" || password == "" {
+		http.Error(w, "Please specify username and password", http.StatusBadRequest)
+		return
+	}
+	recResp, err := s.adminClient.Login(ctx, &admin.LoginRequest{
+		Email:    email,
+		Password: password,
+		ClientIp: clientIp(r),
+	})
+	if writeLoginError(w, err) {
+		return
+	}
+	str := "Login successfully!"
+	if recResp.Correct == false {
+		str = "Failed. Please check your username and password. "
+	}
+
+	res := map[string]interface{}{
+		"message": str,
+	}
+	if recResp.Correct {
+		res["role"] = recResp.Role
+		res["hotels"] = recResp.Hotels
+	}
+
+	json.NewEncoder(w).Encode(res)
+}
+
+func (s *Server) userHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	ctx := r.Context()
+
+	username, password := r.URL.Query().Get("username"), r.URL.Query().Get("password")
+	if username == "" || password == "" {
+		http.Error(w, "Please specify username and password", http.StatusBadRequest)
+		return
+	}
+
+	// Check username and password
+	recResp, err := s.userClient.CheckUser(ctx, &user.Request{
+		Username: username,
+		Password: password,
+		ClientIp: clientIp(r),
+	})
+	if writeLoginError(w, err) {
+		return
+	}
+
+	str := "Login successfully!"
+	if recResp.Correct == false {
+		str = "Failed. Please check your username and password. "
+	}
+
+	res := map[string]interface{}{
+		"message": str,
+	}
+
+	json.NewEncoder(w).Encode(res)
+}
+
+func (s *Server) userRegisterHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	ctx := r.Context()
+
+	username, password, age, sex, mail, phone := r.URL.Query().Get("username"), r.URL.Query().Get("password"), r.URL.Query().Get("age"), r.URL.Query().Get("sex"), r.URL.Query().Get("mail"), r.URL.Query().Get("phone")
+	if username == "" || password == "" {
+		http.Error(w, "Please specify username and password", http.StatusBadRequest)
+		return
+	}
+
+	age_int, err := strconv.ParseInt(age, 10, 32)
+	if err != nil {
+		panic(err)
+	}
+
+	// Register
+	recResp, err := s.userClient.Register(ctx, &user.RegisterRequest{
+		Username: username,
+		Password: password,
+		Age:      int32(age_int),
+		Sex:      sex,
+		Mail:     mail,
+		Phone:    phone,
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	str := "Register successfully!"
+	if recResp.Correct == false {
+		str = "Failed. Please check your username and password. "
+	}
+
+	res := map[string]interface{}{
+		"message": str,
+	}
+
+	json.NewEncoder(w).Encode(res)
+}
+
+func (s *Server) userModifyHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	ctx := r.Context()
+
+	username, ok := s.authenticate(w, r)
+	if !ok {
+		return
+	}
+	ctx = asGuest(ctx, username)
+
+	// only the fields given are changed
+	q := r.URL.Query()
+	req := &user.UpdateUserRequest{Username: username}
+	if newPassword := q.Get("new_password"); newPassword != "" {
+		req.UpdateMask = append(req.UpdateMask, "password")
+		req.Password = newPassword
+		req.OldPassword = q.Get("old_password")
+		if req.OldPassword == "" {
+			req.OldPassword = q.Get("password")
+		}
+		if req.OldPassword == "" {
+			http.Error(w, "Please specify old_password params", http.StatusBadRequest)
+			return
+		}
+	}
+	if _, ok := q["age"]; ok {
+		age, err := strconv.ParseInt(q.Get("age"), 10, 32)
+		if err != nil {
+			http.Error(w, "Please specify a valid age params", http.StatusBadRequest)
+			return
+		}
+		req.UpdateMask = append(req.UpdateMask, "age")
+		req.Age = int32(age)
+	}
+	if _, ok := q["sex"]; ok {
+		req.UpdateMask = append(req.UpdateMask, "sex")
+		req.Sex = q.Get("sex")
+	}
+	if _, ok := q["mail"]; ok {
+		req.UpdateMask = append(req.UpdateMask, "mail")
+		req.Mail = q.Get("mail")
+	}
+	if _, ok := q["phone"]; ok {
+		req.UpdateMask = append(req.UpdateMask, "phone")
+		req.Phone = q.Get("phone")
+	}
+	if len(req.UpdateMask) == 0 {
+		http.Error(w, "Please specify new_password, age, sex, mail or phone params", http.StatusBadRequest)
+		return
 	}
 
 	// Modify
-	recResp, err := s.userClient.Modify(ctx, &user.ModifyRequest{
-		Username: username,
-		Password: password,
-		Age:      int32(age_int),
-		Sex:      sex,
-		Mail:     mail,
-		Phone:    phone,
-	})
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+	recResp, err := s.userClient.UpdateUser(ctx, req)
+	if status.Code(err) == codes.InvalidArgument {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if writeLoginError(w, err) {
 		return
 	}
 
@@ -532,6 +1169,7 @@ func (s *Server) userModifyHandler(w http.ResponseWriter, r *http.Request) {
 
 	res := map[string]interface{}{
 		"message": str,
+		"updated": recResp.Updated,
 	}
 
 	json.NewEncoder(w).Encode(res)
@@ -541,16 +1179,15 @@ func (s *Server) userDeleteHandler(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Access-Control-Allow-Origin", "*")
 	ctx := r.Context()
 
-	username, password := r.URL.Query().Get("username"), r.URL.Query().Get("password")
-	if username == "" || password == "" {
-		http.Error(w, "Please specify username and password", http.StatusBadRequest)
+	username, ok := s.authenticate(w, r)
+	if !ok {
 		return
 	}
+	ctx = asGuest(ctx, username)
 
 	// Delete user
 	recResp, err := s.userClient.Delete(ctx, &user.Request{
 		Username: username,
-		Password: password,
 	})
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
@@ -569,81 +1206,184 @@ func (s *Server) userDeleteHandler(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(res)
 }
 
-func (s *Server) userEvaluateHandler(w http.ResponseWriter, r *http.Request) {
+// userLoyaltyHandler returns the points balance and tier of the user.
+func (s *Server) userLoyaltyHandler(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Access-Control-Allow-Origin", "*")
 	ctx := r.Context()
 
-	inDate, outDate := r.URL.Query().Get("inDate"), r.URL.Query().Get("outDate")
-	if inDate == "" || outDate == "" {
-		http.Error(w, "Please specify inDate/outDate params", http.StatusBadRequest)
+	username, ok := s.authenticate(w, r)
+	if !ok {
 		return
 	}
+	ctx = asGuest(ctx, username)
 
-	if !checkDataFormat(inDate) || !checkDataFormat(outDate) {
-		http.Error(w, "Please check inDate/outDate format (YYYY-MM-DD)", http.StatusBadRequest)
+	account, err := s.userClient.GetLoyalty(ctx, &user.LoyaltyRequest{
+		Username: username,
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
-	customerName := r.URL.Query().Get("customerName")
-	if customerName == "" {
-		http.Error(w, "Please specify customerName params", http.StatusBadRequest)
+	json.NewEncoder(w).Encode(account)
+}
+
+// userLoyaltyHistoryHandler returns the points ledger of the user, newest
+// first, a page at a time. Pass the nextPageToken of a page as page_token
+// for the next.
+func (s *Server) userLoyaltyHistoryHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	ctx := r.Context()
+
+	username, ok := s.authenticate(w, r)
+	if !ok {
 		return
 	}
+	ctx = asGuest(ctx, username)
 
-	username, password := r.URL.Query().Get("username"), r.URL.Query().Get("password")
-	if username == "" || password == "" {
-		http.Error(w, "Please specify username and password", http.StatusBadRequest)
+	pageSize := 0
+	if sPageSize := r.URL.Query().Get("page_size"); sPageSize != "" {
+		var err error
+		pageSize, err = strconv.Atoi(sPageSize)
+		if err != nil || pageSize < 0 {
+			http.Error(w, "Please check page_size params", http.StatusBadRequest)
+			return
+		}
+	}
+
+	historyResp, err := s.userClient.GetLoyaltyHistory(ctx, &user.LoyaltyHistoryQuery{
+		Username:  username,
+		PageSize:  int32(pageSize),
+		PageToken: r.URL.Query().Get("page_token"),
+	})
+	if status.Code(err) == codes.InvalidArgument {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
-	// Check username and password
-	recResp, err := s.userClient.CheckUser(ctx, &user.Request{
+	entries := historyResp.Entries
+	if entries == nil {
+		entries = []*user.LedgerEntry{}
+	}
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"entries":       entries,
+		"nextPageToken": historyResp.NextPageToken,
+	})
+}
+
+// userExportHandler downloads everything kept about the user as JSON.
+func (s *Server) userExportHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	ctx := r.Context()
+
+	username, ok := s.authenticate(w, r)
+	if !ok {
+		return
+	}
+	ctx = asGuest(ctx, username)
+
+	exportResp, err := s.userClient.ExportUser(ctx, &user.UserDataRequest{
 		Username: username,
-		Password: password,
 	})
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
-	str := "Score successfully!"
-	if recResp.Correct == false {
-		str = "Failed. Please check your username and password. "
-	} else {
 
-		hotelId := r.URL.Query().Get("hotelId")
-		score := r.URL.Query().Get("score")
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{
+		"filename": username + ".json",
+	}))
+	w.Write(exportResp.Archive)
+}
 
-		score_float, err := strconv.ParseFloat(score, 64)
-		if err != nil {
-			panic(err)
-		}
+// userEraseHandler erases the user and everything kept about it, and
+// returns what was removed.
+func (s *Server) userEraseHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	ctx := r.Context()
 
-		// update order history for user
-		orderhistory := "hotelId: " + hotelId + ", inDate: " + inDate + ", outDate: " + outDate + ", score: " + score
-		orderhistoryResp, err := s.userClient.OrderHistoryUpdate(ctx, &user.OrderHistoryRequest{
-			Username:     username,
-			Orderhistory: orderhistory,
-		})
-		if err != nil {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
-			return
-		}
-		if orderhistoryResp.Correct == false {
-			str = "Failed. "
-		}
+	username, ok := s.authenticate(w, r)
+	if !ok {
+		return
+	}
+	ctx = asGuest(ctx, username)
+
+	report, err := s.userClient.EraseUser(ctx, &user.UserDataRequest{
+		Username: username,
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
 
-		// update score in profile of hotel
-		profileResp, err := s.profileClient.UpdateScore(ctx, &profile.ScoreRequest{
+	json.NewEncoder(w).Encode(report)
+}
+
+func (s *Server) userEvaluateHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	ctx := r.Context()
+
+	inDate, outDate := r.URL.Query().Get("inDate"), r.URL.Query().Get("outDate")
+	if inDate == "" || outDate == "" {
+		http.Error(w, "Please specify inDate/outDate params", http.StatusBadRequest)
+		return
+	}
+
+	if !checkDataFormat(inDate) || !checkDataFormat(outDate) {
+		http.Error(w, "Please check inDate/outDate format (YYYY-MM-DD)", http.StatusBadRequest)
+		return
+	}
+
+	username, ok := s.authenticate(w, r)
+	if !ok {
+		return
+	}
+	ctx = asGuest(ctx, username)
+
+	str := "Score successfully!"
+
+	hotelId := r.URL.Query().Get("hotelId")
+	score := r.URL.Query().Get("score")
+
+	score_float, err := strconv.ParseFloat(score, 64)
+	if err != nil {
+		panic(err)
+	}
+
+	// score the stay in the order history of the user
+	orderhistoryResp, err := s.userClient.OrderHistoryUpdate(ctx, &user.OrderHistoryRequest{
+		Username: username,
+		Order: &user.Order{
 			HotelId: hotelId,
+			InDate:  inDate,
+			OutDate: outDate,
 			Score:   float32(score_float),
-		})
-		if err != nil {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
-			return
-		}
-		if profileResp.Correct == false {
-			str = "Failed. "
-		}
+		},
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if orderhistoryResp.Correct == false {
+		str = "Failed. "
+	}
+
+	// update score in profile of hotel
+	profileResp, err := s.profileClient.UpdateScore(ctx, &profile.ScoreRequest{
+		HotelId: hotelId,
+		Score:   float32(score_float),
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if profileResp.Correct == false {
+		str = "Failed. "
 	}
 
 	res := map[string]interface{}{
@@ -673,17 +1413,11 @@ func (s *Server) reservationHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	customerName := r.URL.Query().Get("customerName")
-	if customerName == "" {
-		http.Error(w, "Please specify customerName params", http.StatusBadRequest)
-		return
-	}
-
-	username, password := r.URL.Query().Get("username"), r.URL.Query().Get("password")
-	if username == "" || password == "" {
-		http.Error(w, "Please specify username and password", http.StatusBadRequest)
+	username, ok := s.authenticate(w, r)
+	if !ok {
 		return
 	}
+	ctx = asGuest(ctx, username)
 
 	numberOfRoom := 0
 	num := r.URL.Query().Get("number")
@@ -691,39 +1425,54 @@ func (s *Server) reservationHandler(w http.ResponseWriter, r *http.Request) {
 		numberOfRoom, _ = strconv.Atoi(num)
 	}
 
-	// Check username and password
-	recResp, err := s.userClient.CheckUser(ctx, &user.Request{
-		Username: username,
-		Password: password,
+	var redeemPoints int64
+	if sPoints := r.URL.Query().Get("redeem_points"); sPoints != "" {
+		var err error
+		redeemPoints, err = strconv.ParseInt(sPoints, 10, 64)
+		if err != nil || redeemPoints < 0 {
+			http.Error(w, "Please check redeem_points params", http.StatusBadRequest)
+			return
+		}
+	}
+
+	// Make reservation
+	resResp, err := s.reservationClient.MakeReservation(ctx, &reservation.Request{
+		CustomerName: username,
+		HotelId:      []string{hotelId},
+		InDate:       inDate,
+		OutDate:      outDate,
+		RoomNumber:   int32(numberOfRoom),
+		RedeemPoints: redeemPoints,
 	})
-	if err != nil {
+	switch status.Code(err) {
+	case codes.OK:
+	case codes.InvalidArgument, codes.FailedPrecondition:
+		http.Error(w, status.Convert(err).Message(), http.StatusBadRequest)
+		return
+	case codes.Aborted:
+		http.Error(w, status.Convert(err).Message(), http.StatusConflict)
+		return
+	case codes.PermissionDenied:
+		http.Error(w, status.Convert(err).Message(), http.StatusForbidden)
+		return
+	default:
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
 	str := "Reserve successfully!"
-	if recResp.Correct == false {
-		str = "Failed. Please check your username and password. "
+	if len(resResp.HotelId) == 0 {
+		str = "Failed. Already reserved. "
 	} else {
-		// Make reservation
-		resResp, err := s.reservationClient.MakeReservation(ctx, &reservation.Request{
-			CustomerName: customerName,
-			HotelId:      []string{hotelId},
-			InDate:       inDate,
-			OutDate:      outDate,
-			RoomNumber:   int32(numberOfRoom),
-		})
-		if err != nil {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
-			return
-		}
-		if len(resResp.HotelId) == 0 {
-			str = "Failed. Already reserved. "
-		}
+		s.recordOrder(ctx, username, resResp.ReservationId, hotelId, inDate, outDate, numberOfRoom, resResp.Discount)
 	}
 	res := map[string]interface{}{
 		"message": str,
 	}
+	if resResp.PointsRedeemed > 0 {
+		res["pointsRedeemed"] = resResp.PointsRedeemed
+		res["discount"] = resResp.Discount
+	}
 	json.NewEncoder(w).Encode(res)
 }
 
@@ -748,17 +1497,11 @@ func (s *Server) cancelReservationHandler(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	customerName := r.URL.Query().Get("customerName")
-	if customerName == "" {
-		http.Error(w, "Please specify customerName params", http.StatusBadRequest)
-		return
-	}
-
-	username, password := r.URL.Query().Get("username"), r.URL.Query().Get("password")
-	if username == "" || password == "" {
-		http.Error(w, "Please specify username and password", http.StatusBadRequest)
+	username, ok := s.authenticate(w, r)
+	if !ok {
 		return
 	}
+	ctx = asGuest(ctx, username)
 
 	numberOfRoom := 0
 	num := r.URL.Query().Get("number")
@@ -766,36 +1509,26 @@ func (s *Server) cancelReservationHandler(w http.ResponseWriter, r *http.Request
 		numberOfRoom, _ = strconv.Atoi(num)
 	}
 
-	// Check username and password
-	recResp, err := s.userClient.CheckUser(ctx, &user.Request{
-		Username: username,
-		Password: password,
+	// Cancel reservation
+	resResp, err := s.reservationClient.CancelReservation(ctx, &reservation.Request{
+		CustomerName: username,
+		HotelId:      []string{hotelId},
+		InDate:       inDate,
+		OutDate:      outDate,
+		RoomNumber:   int32(numberOfRoom),
 	})
+	if status.Code(err) == codes.PermissionDenied {
+		http.Error(w, status.Convert(err).Message(), http.StatusForbidden)
+		return
+	}
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
 	str := "Cancel successfully!"
-	if recResp.Correct == false {
-		str = "Failed. Please check your username and password. "
-	} else {
-
-		// Cancel reservation
-		resResp, err := s.reservationClient.CancelReservation(ctx, &reservation.Request{
-			CustomerName: customerName,
-			HotelId:      []string{hotelId},
-			InDate:       inDate,
-			OutDate:      outDate,
-			RoomNumber:   int32(numberOfRoom),
-		})
-		if err != nil {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
-			return
-		}
-		if len(resResp.HotelId) == 0 {
-			str = "Failed. Not right reservation information."
-		}
+	if len(resResp.HotelId) == 0 {
+		str = "Failed. Not right reservation information."
 	}
 	res := map[string]interface{}{
 		"message": str,
@@ -804,22 +1537,111 @@ func (s *Server) cancelReservationHandler(w http.ResponseWriter, r *http.Request
 
 }
 
+// recordOrder adds a reservation to the order history of the user, at the
+// current nightly price of the hotel and with the discount of the points
+// redeemed on it. The reservation stands even if this fails.
+func (s *Server) recordOrder(ctx context.Context, username, reservationId, hotelId, inDate, outDate string, rooms int, discount float32) {
+	var price float32
+	profileResp, err := s.profileClient.GetProfiles(ctx, &profile.Request{
+		HotelIds:   []string{hotelId},
+		Locale:     "en",
+		Background: true,
+	})
+	if err != nil {
+		log.Println("Failed get hotel price for order: ", err)
+	} else if len(profileResp.Hotels) > 0 {
+		price = profileResp.Hotels[0].Price
+	}
+
+	_, err = s.userClient.OrderHistoryUpdate(ctx, &user.OrderHistoryRequest{
+		Username: username,
+		Order: &user.Order{
+			ReservationId: reservationId,
+			HotelId:       hotelId,
+			InDate:        inDate,
+			OutDate:       outDate,
+			RoomNumber:    int32(rooms),
+			Price:         price,
+			Discount:      discount,
+		},
+	})
+	if err != nil {
+		log.Println("Failed record order: ", err)
+	}
+}
+
+// userOrdersHandler returns the orders of the user, newest first, a page
+// at a time. Pass the nextPageToken of a page as page_token for the next.
+func (s *Server) userOrdersHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	ctx := r.Context()
+
+	username, ok := s.authenticate(w, r)
+	if !ok {
+		return
+	}
+	ctx = asGuest(ctx, username)
+
+	pageSize := 0
+	if sPageSize := r.URL.Query().Get("page_size"); sPageSize != "" {
+		var err error
+		pageSize, err = strconv.Atoi(sPageSize)
+		if err != nil || pageSize < 0 {
+			http.Error(w, "Please check page_size params", http.StatusBadRequest)
+			return
+		}
+	}
+
+	ordersResp, err := s.userClient.GetOrderHistory(ctx, &user.OrderHistoryQuery{
+		Username:  username,
+		PageSize:  int32(pageSize),
+		PageToken: r.URL.Query().Get("page_token"),
+	})
+	if status.Code(err) == codes.InvalidArgument {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	orders := ordersResp.Orders
+	if orders == nil {
+		orders = []*user.Order{}
+	}
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"orders":        orders,
+		"nextPageToken": ordersResp.NextPageToken,
+	})
+}
+
 // return a geoJSON response that allows google map to plot points directly on map
 // https://developers.google.com/maps/documentation/javascript/datalayer#sample_geojson
 func geoJSONResponse(hs []*profile.Hotel) map[string]interface{} {
+	return geoJSONResponseWith(hs, nil)
+}
+
+// geoJSONResponseWith is geoJSONResponse with extra feature properties per hotel id
+func geoJSONResponseWith(hs []*profile.Hotel, extra map[string]map[string]interface{}) map[string]interface{} {
 	fs := []interface{}{}
 
 	for _, h := range hs {
+		properties := map[string]interface{}{
+			"name":         h.Name,
+			"phone_number": h.PhoneNumber,
+			"price":        h.Price,
+			"score":        h.Score,
+			"scoreTimes":   h.ScoreTimes,
+		}
+		for k, v := range extra[h.Id] {
+			properties[k] = v
+		}
+
 		fs = append(fs, map[string]interface{}{
-			"type": "Feature",
-			"id":   h.Id,
-			"properties": map[string]interface{}{
-				"name":         h.Name,
-				"phone_number": h.PhoneNumber,
-				"price":        h.Price,
-				"score":        h.Score,
-				"scoreTimes":   h.ScoreTimes,
-			},
+			"type":       "Feature",
+			"id":         h.Id,
+			"properties": properties,
 			"geometry": map[string]interface{}{
 				"type": "Point",
 				"coordinates": []float32{
@@ -836,6 +1658,69 @@ func geoJSONResponse(hs []*profile.Hotel) map[string]interface{} {
 	}
 }
 
+// abbreviateCount shortens large cluster sizes for map labels, 1234 -> "1.2k"
+func abbreviateCount(n int32) string {
+	switch {
+	case n >= 1000000:
+		return fmt.Sprintf("%.1fM", float64(n)/1000000)
+	case n >= 10000:
+		return fmt.Sprintf("%dk", n/1000)
+	case n >= 1000:
+		return fmt.Sprintf("%.1fk", float64(n)/1000)
+	}
+	return strconv.Itoa(int(n))
+}
+
+// parseBBox parses a "minLon,minLat,maxLon,maxLat" bounding box into its SW
+// and NE corners. minLon > maxLon denotes a box across the antimeridian.
+func parseBBox(bbox string) (*geo.Point, *geo.Point, error) {
+	if bbox == "" {
+		return nil, nil, fmt.Errorf("Please specify bbox params")
+	}
+	parts := strings.Split(bbox, ",")
+	if len(parts) != 4 {
+		return nil, nil, fmt.Errorf("Please check bbox format (minLon,minLat,maxLon,maxLat)")
+	}
+
+	var v [4]float64
+	for i, part := range parts {
+		f, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+		if err != nil {
+			return nil, nil, fmt.Errorf("Please check bbox format (minLon,minLat,maxLon,maxLat)")
+		}
+		v[i] = f
+	}
+	if v[0] < -180 || v[0] > 180 || v[2] < -180 || v[2] > 180 ||
+		v[1] < -90 || v[1] > 90 || v[3] < -90 || v[3] > 90 || v[1] > v[3] {
+		return nil, nil, fmt.Errorf("Please check bbox bounds")
+	}
+
+	sw := &geo.Point{Lat: float32(v[1]), Lon: float32(v[0])}
+	ne := &geo.Point{Lat: float32(v[3]), Lon: float32(v[2])}
+	return sw, ne, nil
+}
+
+// writeCallError writes the response to a failed call, by its status
+// code, and returns true, or returns false if err is nil.
+func writeCallError(w http.ResponseWriter, err error) bool {
+	if err == nil {
+		return false
+	}
+	switch status.Code(err) {
+	case codes.InvalidArgument, codes.FailedPrecondition:
+		http.Error(w, status.Convert(err).Message(), http.StatusBadRequest)
+	case codes.NotFound:
+		http.Error(w, status.Convert(err).Message(), http.StatusNotFound)
+	case codes.AlreadyExists:
+		http.Error(w, status.Convert(err).Message(), http.StatusConflict)
+	case codes.PermissionDenied:
+		http.Error(w, status.Convert(err).Message(), http.StatusForbidden)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+	return true
+}
+
 func checkDataFormat(date string) bool {
 	if len(date) != 10 {
 		return false
@@ -0,0 +1,63 @@
+package frontend
+
+import (
+	"log"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/harlow/go-micro-services/lockout"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// clientIp returns the address of the client of a request. Forwarding
+// headers are ignored, a client could set them to dodge throttling.
+func clientIp(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// throttle rejects the requests of clients with too many failed logins
+// before they reach the user or admin service. The services count the
+// failures, in the memcached s.AddrLockout reads.
+func (s *Server) throttle(h http.Handler) http.Handler {
+	if s.AddrLockout == nil {
+		return h
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		err := s.AddrLockout.Check(clientIp(r))
+		if e, ok := err.(*lockout.Error); ok {
+			w.Header().Set("Access-Control-Allow-Origin", "*")
+			w.Header().Set("Retry-After", strconv.Itoa(int((e.RetryAfter+time.Second-1)/time.Second)))
+			http.Error(w, e.Error(), http.StatusTooManyRequests)
+			return
+		}
+		if err != nil {
+			log.Println("Failed check login failures: ", err)
+		}
+		h.ServeHTTP(w, r)
+	})
+}
+
+// writeLoginError writes the response to a failed login call and returns
+// true, or returns false if err is nil. Throttled logins get 429 and
+// locked accounts 423.
+func writeLoginError(w http.ResponseWriter, err error) bool {
+	if err == nil {
+		return false
+	}
+	switch status.Code(err) {
+	case codes.ResourceExhausted:
+		http.Error(w, status.Convert(err).Message(), http.StatusTooManyRequests)
+	case codes.FailedPrecondition:
+		http.Error(w, status.Convert(err).Message(), http.StatusLocked)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+	return true
+}
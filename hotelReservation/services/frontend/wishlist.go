@@ -0,0 +1,309 @@
+package frontend
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/harlow/go-micro-services/services/profile/proto"
+	"github.com/harlow/go-micro-services/services/reservation/proto"
+	"github.com/harlow/go-micro-services/services/user/proto"
+	"golang.org/x/net/context"
+)
+
+// hotelIdsParam returns the comma separated hotel ids of a query param.
+func hotelIdsParam(r *http.Request, name string) []string {
+	var ids []string
+	for _, id := range strings.Split(r.URL.Query().Get(name), ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// wishlistsHandler returns the wishlists of the user.
+func (s *Server) wishlistsHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	ctx := r.Context()
+
+	username, ok := s.authenticate(w, r)
+	if !ok {
+		return
+	}
+	ctx = asGuest(ctx, username)
+
+	listsResp, err := s.userClient.GetWishlists(ctx, &user.WishlistsRequest{
+		Username: username,
+	})
+	if writeCallError(w, err) {
+		return
+	}
+
+	lists := listsResp.Wishlists
+	if lists == nil {
+		lists = []*user.Wishlist{}
+	}
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"wishlists": lists,
+	})
+}
+
+// wishlistCreateHandler creates an empty wishlist named by the name param.
+func (s *Server) wishlistCreateHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	ctx := r.Context()
+
+	name := r.URL.Query().Get("name")
+	if name == "" {
+		http.Error(w, "Please specify name params", http.StatusBadRequest)
+		return
+	}
+
+	username, ok := s.authenticate(w, r)
+	if !ok {
+		return
+	}
+	ctx = asGuest(ctx, username)
+
+	list, err := s.userClient.CreateWishlist(ctx, &user.CreateWishlistRequest{
+		Username: username,
+		Name:     name,
+	})
+	if writeCallError(w, err) {
+		return
+	}
+
+	json.NewEncoder(w).Encode(list)
+}
+
+// wishlistUpdateHandler adds the comma separated hotels of the add param
+// to a wishlist, and removes those of the remove param.
+func (s *Server) wishlistUpdateHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	ctx := r.Context()
+
+	id := r.URL.Query().Get("id")
+	if id == "" {
+		http.Error(w, "Please specify id params", http.StatusBadRequest)
+		return
+	}
+	add, remove := hotelIdsParam(r, "add"), hotelIdsParam(r, "remove")
+	if len(add) == 0 && len(remove) == 0 {
+		http.Error(w, "Please specify add/remove params", http.StatusBadRequest)
+		return
+	}
+
+	username, ok := s.authenticate(w, r)
+	if !ok {
+		return
+	}
+	ctx = asGuest(ctx, username)
+
+	list, err := s.userClient.UpdateWishlist(ctx, &user.UpdateWishlistRequest{
+		Username:       username,
+		WishlistId:     id,
+		AddHotelIds:    add,
+		RemoveHotelIds: remove,
+	})
+	if writeCallError(w, err) {
+		return
+	}
+
+	json.NewEncoder(w).Encode(list)
+}
+
+// wishlistDeleteHandler deletes a wishlist.
+func (s *Server) wishlistDeleteHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	ctx := r.Context()
+
+	id := r.URL.Query().Get("id")
+	if id == "" {
+		http.Error(w, "Please specify id params", http.StatusBadRequest)
+		return
+	}
+
+	username, ok := s.authenticate(w, r)
+	if !ok {
+		return
+	}
+	ctx = asGuest(ctx, username)
+
+	deleteResp, err := s.userClient.DeleteWishlist(ctx, &user.WishlistRequest{
+		Username:   username,
+		WishlistId: id,
+	})
+	if writeCallError(w, err) {
+		return
+	}
+
+	str := "Delete successfully!"
+	if !deleteResp.Correct {
+		str = "Failed. No such wishlist."
+	}
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"message": str,
+	})
+}
+
+// wishlistShareHandler returns a read-only link to a wishlist, replacing
+// any earlier one, or with revoke=true revokes it.
+func (s *Server) wishlistShareHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	ctx := r.Context()
+
+	id := r.URL.Query().Get("id")
+	if id == "" {
+		http.Error(w, "Please specify id params", http.StatusBadRequest)
+		return
+	}
+	revoke := r.URL.Query().Get("revoke") == "true"
+
+	username, ok := s.authenticate(w, r)
+	if !ok {
+		return
+	}
+	ctx = asGuest(ctx, username)
+
+	list, err := s.userClient.ShareWishlist(ctx, &user.ShareWishlistRequest{
+		Username:   username,
+		WishlistId: id,
+		Revoke:     revoke,
+	})
+	if writeCallError(w, err) {
+		return
+	}
+
+	res := map[string]interface{}{
+		"wishlist": list,
+	}
+	if list.ShareToken != "" {
+		res["link"] = "/wishlist/shared?token=" + url.QueryEscape(list.ShareToken)
+	}
+	json.NewEncoder(w).Encode(res)
+}
+
+// wishlistHandler returns the hotels of a wishlist of the user as GeoJSON.
+func (s *Server) wishlistHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	ctx := r.Context()
+
+	id := r.URL.Query().Get("id")
+	if id == "" {
+		http.Error(w, "Please specify id params", http.StatusBadRequest)
+		return
+	}
+
+	username, ok := s.authenticate(w, r)
+	if !ok {
+		return
+	}
+	ctx = asGuest(ctx, username)
+
+	list, err := s.userClient.GetWishlist(ctx, &user.WishlistRequest{
+		Username:   username,
+		WishlistId: id,
+	})
+	if writeCallError(w, err) {
+		return
+	}
+
+	s.writeWishlistHotels(ctx, w, r, list)
+}
+
+// sharedWishlistHandler returns the hotels of the wishlist shared by the
+// token param as GeoJSON. It needs no login, the token is the credential.
+func (s *Server) sharedWishlistHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	ctx := r.Context()
+
+	token := r.URL.Query().Get("token")
+	if token == "" {
+		http.Error(w, "Please specify token params", http.StatusBadRequest)
+		return
+	}
+
+	list, err := s.userClient.GetSharedWishlist(ctx, &user.SharedWishlistRequest{
+		ShareToken: token,
+	})
+	if writeCallError(w, err) {
+		return
+	}
+
+	s.writeWishlistHotels(ctx, w, r, list)
+}
+
+// writeWishlistHotels writes the profiles of the hotels of a wishlist as
+// GeoJSON. With inDate and outDate params each hotel has an available
+// property, whether it has the rooms of the number param, 1 by default,
+// free for those dates.
+func (s *Server) writeWishlistHotels(ctx context.Context, w http.ResponseWriter, r *http.Request, list *user.Wishlist) {
+	inDate, outDate := r.URL.Query().Get("inDate"), r.URL.Query().Get("outDate")
+	if (inDate == "") != (outDate == "") {
+		http.Error(w, "Please specify inDate/outDate params", http.StatusBadRequest)
+		return
+	}
+	if inDate != "" && (!checkDataFormat(inDate) || !checkDataFormat(outDate)) {
+		http.Error(w, "Please check inDate/outDate format (YYYY-MM-DD)", http.StatusBadRequest)
+		return
+	}
+	numberOfRoom := 1
+	if num := r.URL.Query().Get("number"); num != "" {
+		var err error
+		numberOfRoom, err = strconv.Atoi(num)
+		if err != nil || numberOfRoom < 1 {
+			http.Error(w, "Please check number params", http.StatusBadRequest)
+			return
+		}
+	}
+
+	// grab locale from query params or default to en
+	locale := r.URL.Query().Get("locale")
+	if locale == "" {
+		locale = "en"
+	}
+
+	var hotels []*profile.Hotel
+	extra := make(map[string]map[string]interface{})
+	if len(list.HotelIds) > 0 {
+		profileResp, err := s.profileClient.GetProfiles(ctx, &profile.Request{
+			HotelIds:   list.HotelIds,
+			Locale:     locale,
+			Background: true,
+		})
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		hotels = profileResp.Hotels
+
+		if inDate != "" {
+			reservationResp, err := s.reservationClient.CheckAvailability(ctx, &reservation.Request{
+				CustomerName: "",
+				HotelId:      list.HotelIds,
+				InDate:       inDate,
+				OutDate:      outDate,
+				RoomNumber:   int32(numberOfRoom),
+			})
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			for _, hotelId := range list.HotelIds {
+				extra[hotelId] = map[string]interface{}{"available": false}
+			}
+			for _, hotelId := range reservationResp.HotelId {
+				if properties, ok := extra[hotelId]; ok {
+					properties["available"] = true
+				}
+			}
+		}
+	}
+
+	res := geoJSONResponseWith(hotels, extra)
+	res["name"] = list.Name
+	json.NewEncoder(w).Encode(res)
+}
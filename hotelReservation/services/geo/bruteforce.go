@@ -0,0 +1,58 @@
+package geo
+
+import (
+	"github.com/hailocab/go-geoindex"
+)
+
+// bruteForceIndex keeps the points in a slice and scans all of them on
+// every query. It is the baseline the other indexes are measured against.
+type bruteForceIndex struct {
+	points []geoindex.Point
+	// position of each id in points
+	pos map[string]int
+}
+
+func newBruteForceIndex() *bruteForceIndex {
+	return &bruteForceIndex{pos: make(map[string]int)}
+}
+
+func (b *bruteForceIndex) Add(p geoindex.Point) {
+	if i, ok := b.pos[p.Id()]; ok {
+		b.points[i] = p
+		return
+	}
+	b.pos[p.Id()] = len(b.points)
+	b.points = append(b.points, p)
+}
+
+func (b *bruteForceIndex) Remove(id string) {
+	i, ok := b.pos[id]
+	if !ok {
+		return
+	}
+	// move the last point into the hole
+	last := len(b.points) - 1
+	b.points[i] = b.points[last]
+	b.pos[b.points[i].Id()] = i
+	b.points[last] = nil
+	b.points = b.points[:last]
+	delete(b.pos, id)
+}
+
+func (b *bruteForceIndex) KNearest(center geoindex.Point, k int, maxDistance geoindex.Meters) []geoindex.Point {
+	return nearest(center, b.points, k, maxDistance)
+}
+
+func (b *bruteForceIndex) Range(minLat, minLon, maxLat, maxLon float64) []geoindex.Point {
+	points := make([]geoindex.Point, 0)
+	for _, p := range b.points {
+		if inBox(p, minLat, minLon, maxLat, maxLon) {
+			points = append(points, p)
+		}
+	}
+	return points
+}
+
+func (b *bruteForceIndex) Len() int {
+	return len(b.points)
+}
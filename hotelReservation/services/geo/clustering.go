@@ -0,0 +1,81 @@
+package geo
+
+import (
+	"github.com/hailocab/go-geoindex"
+)
+
+const (
+	// cell size (km) of the index behind viewport queries; past maxRangeCells
+	// cells it is cheaper to scan every hotel than to walk the cells
+	boundsIndexResolution = 5
+	maxRangeCells         = 10000
+)
+
+// clusteringIndex is the hailocab clustering index, used for nearest
+// hotel queries and map clusters, next to a coarser points index for
+// viewport queries.
+type clusteringIndex struct {
+	index  *geoindex.ClusteringIndex
+	bounds *geoindex.PointsIndex
+	n      int
+}
+
+func newClusteringIndex() *clusteringIndex {
+	return &clusteringIndex{
+		index:  geoindex.NewClusteringIndex(),
+		bounds: geoindex.NewPointsIndex(geoindex.Km(boundsIndexResolution)),
+	}
+}
+
+func (c *clusteringIndex) Add(p geoindex.Point) {
+	if c.bounds.Get(p.Id()) == nil {
+		c.n++
+	}
+	c.index.Add(p)
+	c.bounds.Add(p)
+}
+
+func (c *clusteringIndex) Remove(id string) {
+	if c.bounds.Get(id) != nil {
+		c.n--
+	}
+	c.index.Remove(id)
+	c.bounds.Remove(id)
+}
+
+func (c *clusteringIndex) KNearest(center geoindex.Point, k int, maxDistance geoindex.Meters) []geoindex.Point {
+	return c.index.KNearest(center, k, maxDistance, func(p geoindex.Point) bool {
+		return true
+	})
+}
+
+// Range walks the cells of the bounds index, or past maxRangeCells
+// cells scans every hotel instead.
+func (c *clusteringIndex) Range(minLat, minLon, maxLat, maxLon float64) []geoindex.Point {
+	cells := (maxLat - minLat) * 111 / boundsIndexResolution * (maxLon - minLon) * 85 / boundsIndexResolution
+	if cells <= maxRangeCells {
+		return c.bounds.Range(
+			&geoindex.GeoPoint{Pid: "", Plat: maxLat, Plon: minLon},
+			&geoindex.GeoPoint{Pid: "", Plat: minLat, Plon: maxLon},
+		)
+	}
+
+	points := make([]geoindex.Point, 0)
+	for _, p := range c.bounds.GetAll() {
+		if inBox(p, minLat, minLon, maxLat, maxLon) {
+			points = append(points, p)
+		}
+	}
+	return points
+}
+
+func (c *clusteringIndex) ClusterRange(minLat, minLon, maxLat, maxLon float64) []geoindex.Point {
+	return c.index.Range(
+		&geoindex.GeoPoint{Pid: "", Plat: maxLat, Plon: minLon},
+		&geoindex.GeoPoint{Pid: "", Plat: minLat, Plon: maxLon},
+	)
+}
+
+func (c *clusteringIndex) Len() int {
+	return c.n
+}
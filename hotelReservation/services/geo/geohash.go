@@ -0,0 +1,140 @@
+package geo
+
+import (
+	"math"
+
+	"github.com/hailocab/go-geoindex"
+)
+
+// 5 characters make cells of about 4.9 x 4.9 km at the equator
+const defaultGeohashPrecision = 5
+
+const geohashAlphabet = "0123456789bcdefghjkmnpqrstuvwxyz"
+
+// geohashIndex buckets points by the geohash of their location cut to a
+// fixed precision. Queries visit the buckets of every cell overlapping
+// the box, or every non-empty bucket when there are fewer of those.
+type geohashIndex struct {
+	precision int
+	latBits   uint
+	lonBits   uint
+	cellLat   float64
+	cellLon   float64
+	cells     map[string]map[string]geoindex.Point
+	hashes    map[string]string
+}
+
+func newGeohashIndex(precision int) *geohashIndex {
+	bits := uint(5 * precision)
+	g := &geohashIndex{
+		precision: precision,
+		lonBits:   (bits + 1) / 2,
+		latBits:   bits / 2,
+		cells:     make(map[string]map[string]geoindex.Point),
+		hashes:    make(map[string]string),
+	}
+	g.cellLat = 180 / math.Exp2(float64(g.latBits))
+	g.cellLon = 360 / math.Exp2(float64(g.lonBits))
+	return g
+}
+
+func (g *geohashIndex) Add(p geoindex.Point) {
+	g.Remove(p.Id())
+
+	h := g.hash(g.latCell(p.Lat()), g.lonCell(p.Lon()))
+	cell, ok := g.cells[h]
+	if !ok {
+		cell = make(map[string]geoindex.Point)
+		g.cells[h] = cell
+	}
+	cell[p.Id()] = p
+	g.hashes[p.Id()] = h
+}
+
+func (g *geohashIndex) Remove(id string) {
+	h, ok := g.hashes[id]
+	if !ok {
+		return
+	}
+	delete(g.cells[h], id)
+	if len(g.cells[h]) == 0 {
+		delete(g.cells, h)
+	}
+	delete(g.hashes, id)
+}
+
+func (g *geohashIndex) KNearest(center geoindex.Point, k int, maxDistance geoindex.Meters) []geoindex.Point {
+	return withinRadius(g, center, k, maxDistance)
+}
+
+func (g *geohashIndex) Range(minLat, minLon, maxLat, maxLon float64) []geoindex.Point {
+	points := make([]geoindex.Point, 0)
+
+	minY, maxY := g.latCell(minLat), g.latCell(maxLat)
+	minX, maxX := g.lonCell(minLon), g.lonCell(maxLon)
+	if (maxY-minY+1)*(maxX-minX+1) > len(g.cells) {
+		for _, cell := range g.cells {
+			for _, p := range cell {
+				if inBox(p, minLat, minLon, maxLat, maxLon) {
+					points = append(points, p)
+				}
+			}
+		}
+		return points
+	}
+
+	for y := minY; y <= maxY; y++ {
+		for x := minX; x <= maxX; x++ {
+			for _, p := range g.cells[g.hash(y, x)] {
+				if inBox(p, minLat, minLon, maxLat, maxLon) {
+					points = append(points, p)
+				}
+			}
+		}
+	}
+	return points
+}
+
+func (g *geohashIndex) Len() int {
+	return len(g.hashes)
+}
+
+func (g *geohashIndex) latCell(lat float64) int {
+	return clampCell(int(math.Floor((lat+90)/g.cellLat)), g.latBits)
+}
+
+func (g *geohashIndex) lonCell(lon float64) int {
+	return clampCell(int(math.Floor((lon+180)/g.cellLon)), g.lonBits)
+}
+
+func clampCell(i int, bits uint) int {
+	if i < 0 {
+		return 0
+	}
+	if max := 1<<bits - 1; i > max {
+		return max
+	}
+	return i
+}
+
+// hash interleaves the cell coordinates, longitude first, and encodes
+// them five bits per character as in a standard geohash.
+func (g *geohashIndex) hash(y, x int) string {
+	buf := make([]byte, g.precision)
+	latBit, lonBit := g.latBits, g.lonBits
+	for i := range buf {
+		var c byte
+		for b := 0; b < 5; b++ {
+			c <<= 1
+			if (i*5+b)%2 == 0 {
+				lonBit--
+				c |= byte(x>>lonBit) & 1
+			} else {
+				latBit--
+				c |= byte(y>>latBit) & 1
+			}
+		}
+		buf[i] = geohashAlphabet[c]
+	}
+	return string(buf)
+}
@@ -0,0 +1,125 @@
+package geo
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/hailocab/go-geoindex"
+)
+
+// Index implementations selectable with the GeoIndex config key.
+const (
+	IndexClustering = "clustering"
+	IndexGeohash    = "geohash"
+	IndexRTree      = "rtree"
+	IndexBruteForce = "bruteforce"
+)
+
+// IndexTypes lists the available index implementations.
+var IndexTypes = []string{IndexClustering, IndexGeohash, IndexRTree, IndexBruteForce}
+
+// SpatialIndex stores hotel locations and answers the queries behind the
+// geo RPCs. Implementations are not safe for concurrent use; the server
+// holds a lock around writes.
+type SpatialIndex interface {
+	// Add indexes a point, replacing any point with the same id.
+	Add(p geoindex.Point)
+	// Remove drops the point with the given id, if any.
+	Remove(id string)
+	// KNearest returns up to k points within maxDistance of center, nearest first.
+	KNearest(center geoindex.Point, k int, maxDistance geoindex.Meters) []geoindex.Point
+	// Range returns the points within a lat/lon box, minLon <= maxLon.
+	Range(minLat, minLon, maxLat, maxLon float64) []geoindex.Point
+	// Len returns the number of indexed points.
+	Len() int
+}
+
+// Clusterer is implemented by indexes that keep pre-aggregated counts
+// for large areas. ClusterRange returns individual points for small
+// boxes and *geoindex.CountPoint cell counts for larger ones.
+type Clusterer interface {
+	ClusterRange(minLat, minLon, maxLat, maxLon float64) []geoindex.Point
+}
+
+// NewSpatialIndex returns an empty index of the given type; an empty
+// type selects the clustering index.
+func NewSpatialIndex(indexType string) (SpatialIndex, error) {
+	switch indexType {
+	case "", IndexClustering:
+		return newClusteringIndex(), nil
+	case IndexGeohash:
+		return newGeohashIndex(defaultGeohashPrecision), nil
+	case IndexRTree:
+		return newRTreeIndex(), nil
+	case IndexBruteForce:
+		return newBruteForceIndex(), nil
+	}
+	return nil, fmt.Errorf("unknown geo index type %q", indexType)
+}
+
+// radiusBoxes returns lat/lon boxes covering every point within distance
+// of center. A circle crossing the antimeridian yields two boxes.
+func radiusBoxes(center geoindex.Point, distance geoindex.Meters) [][4]float64 {
+	angle := float64(distance) / float64(geoindex.Km(6371))
+	dLat := angle * 180 / math.Pi
+	minLat := math.Max(center.Lat()-dLat, -90)
+	maxLat := math.Min(center.Lat()+dLat, 90)
+
+	// the widest longitude span of the circle, or all of them near a pole
+	dLon := 180.0
+	if s := math.Sin(angle) / math.Cos(center.Lat()*math.Pi/180); minLat > -90 && maxLat < 90 && s < 1 {
+		dLon = math.Asin(s) * 180 / math.Pi
+	}
+	minLon, maxLon := center.Lon()-dLon, center.Lon()+dLon
+
+	switch {
+	case dLon >= 180:
+		return [][4]float64{{minLat, -180, maxLat, 180}}
+	case minLon < -180:
+		return [][4]float64{{minLat, minLon + 360, maxLat, 180}, {minLat, -180, maxLat, maxLon}}
+	case maxLon > 180:
+		return [][4]float64{{minLat, minLon, maxLat, 180}, {minLat, -180, maxLat, maxLon - 360}}
+	}
+	return [][4]float64{{minLat, minLon, maxLat, maxLon}}
+}
+
+// nearest returns up to k of the candidates within maxDistance of center, nearest first.
+func nearest(center geoindex.Point, candidates []geoindex.Point, k int, maxDistance geoindex.Meters) []geoindex.Point {
+	type ranked struct {
+		p geoindex.Point
+		d geoindex.Meters
+	}
+	rs := make([]ranked, 0, len(candidates))
+	for _, p := range candidates {
+		if d := geoindex.Distance(center, p); d <= maxDistance {
+			rs = append(rs, ranked{p, d})
+		}
+	}
+	sort.Slice(rs, func(i, j int) bool {
+		return rs[i].d < rs[j].d
+	})
+	if len(rs) > k {
+		rs = rs[:k]
+	}
+
+	points := make([]geoindex.Point, 0, len(rs))
+	for _, r := range rs {
+		points = append(points, r.p)
+	}
+	return points
+}
+
+// withinRadius collects the candidates of idx in the boxes around center
+// and keeps the k nearest, for indexes without a native nearest search.
+func withinRadius(idx SpatialIndex, center geoindex.Point, k int, maxDistance geoindex.Meters) []geoindex.Point {
+	var candidates []geoindex.Point
+	for _, b := range radiusBoxes(center, maxDistance) {
+		candidates = append(candidates, idx.Range(b[0], b[1], b[2], b[3])...)
+	}
+	return nearest(center, candidates, k, maxDistance)
+}
+
+func inBox(p geoindex.Point, minLat, minLon, maxLat, maxLon float64) bool {
+	return p.Lat() >= minLat && p.Lat() <= maxLat && p.Lon() >= minLon && p.Lon() <= maxLon
+}
@@ -0,0 +1,259 @@
+package geo
+
+import (
+	"encoding/json"
+	"flag"
+	"math"
+	"math/rand"
+	"reflect"
+	"sort"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hailocab/go-geoindex"
+	"github.com/harlow/go-micro-services/data"
+)
+
+var (
+	benchPoints = flag.Int("geo.points", 100000, "Number of hotels the geo benchmarks index")
+	benchSpread = flag.Float64("geo.spread", 500, "Synthetic hotels lie within this many km of a real one")
+)
+
+// synthesize returns the hotels of data/geo.json followed by random
+// hotels up to n in total, each within spread km of a real one.
+func synthesize(tb testing.TB, rnd *rand.Rand, n int, spread float64) []*geoindex.GeoPoint {
+	var hotels []struct {
+		Id  string  `json:"hotelId"`
+		Lat float64 `json:"lat"`
+		Lon float64 `json:"lon"`
+	}
+	if err := json.Unmarshal(data.MustAsset("data/geo.json"), &hotels); err != nil {
+		tb.Fatalf("Failed to parse data/geo.json: %v", err)
+	}
+
+	points := make([]*geoindex.GeoPoint, 0, n)
+	for _, h := range hotels {
+		points = append(points, &geoindex.GeoPoint{Pid: h.Id, Plat: h.Lat, Plon: h.Lon})
+	}
+	for i := len(points); i < n; i++ {
+		h := points[rnd.Intn(len(hotels))]
+		points = append(points, scatter(rnd, "synthetic-"+strconv.Itoa(i), h.Plat, h.Plon, spread))
+	}
+	return points
+}
+
+// scatter returns a point uniformly over the disc of radius km around a
+// location.
+func scatter(rnd *rand.Rand, id string, lat, lon, km float64) *geoindex.GeoPoint {
+	d := km * math.Sqrt(rnd.Float64()) / 111.2
+	a := rnd.Float64() * 2 * math.Pi
+	pLat := math.Max(-90, math.Min(90, lat+d*math.Sin(a)))
+	pLon := lon + d*math.Cos(a)/math.Cos(lat*math.Pi/180)
+	pLon = math.Mod(pLon+540, 360) - 180
+	return &geoindex.GeoPoint{Pid: id, Plat: pLat, Plon: pLon}
+}
+
+func sortedIds(points []geoindex.Point) []string {
+	res := make([]string, 0, len(points))
+	for _, p := range points {
+		res = append(res, p.Id())
+	}
+	sort.Strings(res)
+	return res
+}
+
+func orderedIds(points []geoindex.Point) []string {
+	res := make([]string, 0, len(points))
+	for _, p := range points {
+		res = append(res, p.Id())
+	}
+	return res
+}
+
+// TestIndexesMatchBruteForce checks every index against the brute force
+// baseline after adds, moves and removals, around the hotels of
+// data/geo.json and across the antimeridian. The clustering index is only
+// held to returning hotels within the distance of a nearest query.
+func TestIndexesMatchBruteForce(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	points := synthesize(t, rnd, 5000, 50)
+	for i := 0; i < 200; i++ {
+		points = append(points, scatter(rnd, "dateline-"+strconv.Itoa(i), 0, 179.95, 20))
+	}
+
+	for _, indexType := range IndexTypes {
+		if indexType == IndexBruteForce {
+			continue
+		}
+		t.Run(indexType, func(t *testing.T) {
+			rnd := rand.New(rand.NewSource(2))
+			want := newBruteForceIndex()
+			got, err := NewSpatialIndex(indexType)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, p := range points {
+				want.Add(p)
+				got.Add(p)
+			}
+			// move some hotels and remove others
+			for i := 0; i < 500; i++ {
+				p := points[rnd.Intn(len(points))]
+				moved := scatter(rnd, p.Pid, p.Plat, p.Plon, 5)
+				want.Add(moved)
+				got.Add(moved)
+			}
+			for i := 0; i < 500; i++ {
+				id := points[rnd.Intn(len(points))].Pid
+				want.Remove(id)
+				got.Remove(id)
+			}
+			got.Remove("no such hotel")
+
+			if got.Len() != want.Len() {
+				t.Fatalf("Len() = %d, want %d", got.Len(), want.Len())
+			}
+
+			for i := 0; i < 200; i++ {
+				c := points[rnd.Intn(len(points))]
+				for _, km := range []float64{1, 10, 100} {
+					nearby := got.KNearest(c, 10, geoindex.Km(km))
+					// hailocab's index only searches the cells around the
+					// center, so it may miss hotels near their edges
+					if indexType == IndexClustering {
+						for _, p := range nearby {
+							if d := geoindex.Distance(c, p); d > geoindex.Km(km) {
+								t.Errorf("KNearest(%v, %v, 10, %vkm) returned %s %vm away", c.Plat, c.Plon, km, p.Id(), d)
+							}
+						}
+						continue
+					}
+					wantIds := orderedIds(want.KNearest(c, 10, geoindex.Km(km)))
+					if gotIds := orderedIds(nearby); !reflect.DeepEqual(gotIds, wantIds) {
+						t.Errorf("KNearest(%v, %v, 10, %vkm) = %v, want %v", c.Plat, c.Plon, km, gotIds, wantIds)
+					}
+				}
+
+				d := rnd.Float64()
+				minLat, minLon, maxLat, maxLon := c.Plat-d, c.Plon-d, c.Plat+d, c.Plon+d
+				wantIds := sortedIds(want.Range(minLat, minLon, maxLat, maxLon))
+				gotIds := sortedIds(got.Range(minLat, minLon, maxLat, maxLon))
+				if !reflect.DeepEqual(gotIds, wantIds) {
+					t.Errorf("Range(%v, %v, %v, %v) has %d hotels, want %d", minLat, minLon, maxLat, maxLon, len(gotIds), len(wantIds))
+				}
+			}
+		})
+	}
+}
+
+var (
+	benchOnce sync.Once
+	benchData []*geoindex.GeoPoint
+	// benchCenters are the hotels queries are made around
+	benchCenters []*geoindex.GeoPoint
+)
+
+func benchmarkData(b *testing.B) ([]*geoindex.GeoPoint, []*geoindex.GeoPoint) {
+	benchOnce.Do(func() {
+		rnd := rand.New(rand.NewSource(1))
+		benchData = synthesize(b, rnd, *benchPoints, *benchSpread)
+		benchCenters = make([]*geoindex.GeoPoint, 1000)
+		for i := range benchCenters {
+			benchCenters[i] = benchData[rnd.Intn(len(benchData))]
+		}
+	})
+	return benchData, benchCenters
+}
+
+// loadedIndex returns an index of the given type holding the benchmark hotels.
+func loadedIndex(b *testing.B, indexType string) SpatialIndex {
+	points, _ := benchmarkData(b)
+	idx, err := NewSpatialIndex(indexType)
+	if err != nil {
+		b.Fatal(err)
+	}
+	for _, p := range points {
+		idx.Add(p)
+	}
+	return idx
+}
+
+// benchmarkOp runs op b.N times and, besides the mean, reports the
+// latency percentiles the research on tail latency is after.
+func benchmarkOp(b *testing.B, op func(i int)) {
+	latencies := make([]time.Duration, b.N)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		start := time.Now()
+		op(i)
+		latencies[i] = time.Since(start)
+	}
+	b.StopTimer()
+
+	sort.Slice(latencies, func(i, j int) bool {
+		return latencies[i] < latencies[j]
+	})
+	for _, q := range []struct {
+		name string
+		q    float64
+	}{{"p50-ns", 0.5}, {"p99-ns", 0.99}, {"p99.9-ns", 0.999}} {
+		b.ReportMetric(float64(latencies[int(q.q*float64(len(latencies)-1))]), q.name)
+	}
+}
+
+func BenchmarkLoad(b *testing.B) {
+	points, _ := benchmarkData(b)
+	for _, indexType := range IndexTypes {
+		b.Run(indexType, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				idx, _ := NewSpatialIndex(indexType)
+				for _, p := range points {
+					idx.Add(p)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkNearby(b *testing.B) {
+	_, centers := benchmarkData(b)
+	for _, indexType := range IndexTypes {
+		b.Run(indexType, func(b *testing.B) {
+			idx := loadedIndex(b, indexType)
+			benchmarkOp(b, func(i int) {
+				idx.KNearest(centers[i%len(centers)], 5, geoindex.Km(10))
+			})
+		})
+	}
+}
+
+func BenchmarkViewport(b *testing.B) {
+	_, centers := benchmarkData(b)
+	const boxKm = 10.0
+	for _, indexType := range IndexTypes {
+		b.Run(indexType, func(b *testing.B) {
+			idx := loadedIndex(b, indexType)
+			benchmarkOp(b, func(i int) {
+				c := centers[i%len(centers)]
+				dLat := boxKm / 2 / 111.2
+				dLon := dLat / math.Cos(c.Plat*math.Pi/180)
+				idx.Range(c.Plat-dLat, c.Plon-dLon, c.Plat+dLat, c.Plon+dLon)
+			})
+		})
+	}
+}
+
+func BenchmarkMove(b *testing.B) {
+	points, _ := benchmarkData(b)
+	for _, indexType := range IndexTypes {
+		b.Run(indexType, func(b *testing.B) {
+			idx := loadedIndex(b, indexType)
+			benchmarkOp(b, func(i int) {
+				p := points[i%len(points)]
+				idx.Add(&geoindex.GeoPoint{Pid: p.Pid, Plat: p.Plat + 0.001, Plon: p.Plon + 0.001})
+			})
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package geo
+
+import (
+	"github.com/harlow/go-micro-services/rbac"
+	pb "github.com/harlow/go-micro-services/services/geo/proto"
+)
+
+// policy is who may call the geo service. Anyone may look hotels up, the
+// managers of a hotel may move it.
+var policy = rbac.Policy{
+	"/geo.Geo/Nearby":        {Public: true},
+	"/geo.Geo/WithinBounds":  {Public: true},
+	"/geo.Geo/WithinPolygon": {Public: true},
+	"/geo.Geo/Clusters":      {Public: true},
+	"/geo.Geo/AddHotelLocation": {
+		Roles: []rbac.Role{rbac.HotelManager, rbac.ChainAdmin},
+		Hotels: func(req interface{}) []string {
+			return []string{req.(*pb.LocationRequest).HotelId}
+		},
+	},
+	"/geo.Geo/MoveHotelLocation": {
+		Roles: []rbac.Role{rbac.HotelManager, rbac.ChainAdmin},
+		Hotels: func(req interface{}) []string {
+			return []string{req.(*pb.LocationRequest).HotelId}
+		},
+	},
+	"/geo.Geo/RemoveHotelLocation": {
+		Roles: []rbac.Role{rbac.HotelManager, rbac.ChainAdmin},
+		Hotels: func(req interface{}) []string {
+			return []string{req.(*pb.RemoveLocationRequest).HotelId}
+		},
+	},
+}
@@ -10,6 +10,16 @@ It is generated from these files:
 It has these top-level messages:
 	Request
 	Result
+	Hotel
+	Point
+	BoundsRequest
+	PolygonRequest
+	ClusterRequest
+	ClusterResult
+	Cluster
+	LocationRequest
+	RemoveLocationRequest
+	LocationResult
 */
 package geo
 
@@ -33,10 +43,14 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
 
-// The latitude and longitude of the current location.
+// The latitude and longitude of the current location, optionally with
+// the search radius and the maximum number of hotels to return. Zero
+// values fall back to the server defaults; larger values are capped.
 type Request struct {
-	Lat float32 `protobuf:"fixed32,1,opt,name=lat" json:"lat,omitempty"`
-	Lon float32 `protobuf:"fixed32,2,opt,name=lon" json:"lon,omitempty"`
+	Lat      float32 `protobuf:"fixed32,1,opt,name=lat" json:"lat,omitempty"`
+	Lon      float32 `protobuf:"fixed32,2,opt,name=lon" json:"lon,omitempty"`
+	RadiusKm float32 `protobuf:"fixed32,3,opt,name=radiusKm" json:"radiusKm,omitempty"`
+	Limit    int32   `protobuf:"varint,4,opt,name=limit" json:"limit,omitempty"`
 }
 
 func (m *Request) Reset()                    { *m = Request{} }
@@ -58,8 +72,23 @@ func (m *Request) GetLon() float32 {
 	return 0
 }
 
+func (m *Request) GetRadiusKm() float32 {
+	if m != nil {
+		return m.RadiusKm
+	}
+	return 0
+}
+
+func (m *Request) GetLimit() int32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
 type Result struct {
 	HotelIds []string `protobuf:"bytes,1,rep,name=hotelIds" json:"hotelIds,omitempty"`
+	Hotels   []*Hotel `protobuf:"bytes,2,rep,name=hotels" json:"hotels,omitempty"`
 }
 
 func (m *Result) Reset()                    { *m = Result{} }
@@ -74,9 +103,280 @@ func (m *Result) GetHotelIds() []string {
 	return nil
 }
 
+func (m *Result) GetHotels() []*Hotel {
+	if m != nil {
+		return m.Hotels
+	}
+	return nil
+}
+
+// A hotel and its distance from the query point.
+type Hotel struct {
+	HotelId    string  `protobuf:"bytes,1,opt,name=hotelId" json:"hotelId,omitempty"`
+	DistanceKm float32 `protobuf:"fixed32,2,opt,name=distanceKm" json:"distanceKm,omitempty"`
+}
+
+func (m *Hotel) Reset()                    { *m = Hotel{} }
+func (m *Hotel) String() string            { return proto.CompactTextString(m) }
+func (*Hotel) ProtoMessage()               {}
+func (*Hotel) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{2} }
+
+func (m *Hotel) GetHotelId() string {
+	if m != nil {
+		return m.HotelId
+	}
+	return ""
+}
+
+func (m *Hotel) GetDistanceKm() float32 {
+	if m != nil {
+		return m.DistanceKm
+	}
+	return 0
+}
+
+type Point struct {
+	Lat float32 `protobuf:"fixed32,1,opt,name=lat" json:"lat,omitempty"`
+	Lon float32 `protobuf:"fixed32,2,opt,name=lon" json:"lon,omitempty"`
+}
+
+func (m *Point) Reset()                    { *m = Point{} }
+func (m *Point) String() string            { return proto.CompactTextString(m) }
+func (*Point) ProtoMessage()               {}
+func (*Point) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
+
+func (m *Point) GetLat() float32 {
+	if m != nil {
+		return m.Lat
+	}
+	return 0
+}
+
+func (m *Point) GetLon() float32 {
+	if m != nil {
+		return m.Lon
+	}
+	return 0
+}
+
+// A viewport may cross the antimeridian, in which case sw.lon > ne.lon.
+type BoundsRequest struct {
+	Sw    *Point `protobuf:"bytes,1,opt,name=sw" json:"sw,omitempty"`
+	Ne    *Point `protobuf:"bytes,2,opt,name=ne" json:"ne,omitempty"`
+	Limit int32  `protobuf:"varint,3,opt,name=limit" json:"limit,omitempty"`
+}
+
+func (m *BoundsRequest) Reset()                    { *m = BoundsRequest{} }
+func (m *BoundsRequest) String() string            { return proto.CompactTextString(m) }
+func (*BoundsRequest) ProtoMessage()               {}
+func (*BoundsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
+
+func (m *BoundsRequest) GetSw() *Point {
+	if m != nil {
+		return m.Sw
+	}
+	return nil
+}
+
+func (m *BoundsRequest) GetNe() *Point {
+	if m != nil {
+		return m.Ne
+	}
+	return nil
+}
+
+func (m *BoundsRequest) GetLimit() int32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+// The polygon is closed implicitly, the last vertex connects to the first.
+type PolygonRequest struct {
+	Vertices []*Point `protobuf:"bytes,1,rep,name=vertices" json:"vertices,omitempty"`
+	Limit    int32    `protobuf:"varint,2,opt,name=limit" json:"limit,omitempty"`
+}
+
+func (m *PolygonRequest) Reset()                    { *m = PolygonRequest{} }
+func (m *PolygonRequest) String() string            { return proto.CompactTextString(m) }
+func (*PolygonRequest) ProtoMessage()               {}
+func (*PolygonRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+
+func (m *PolygonRequest) GetVertices() []*Point {
+	if m != nil {
+		return m.Vertices
+	}
+	return nil
+}
+
+func (m *PolygonRequest) GetLimit() int32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+// zoom follows the web map convention, 0 shows the whole world.
+type ClusterRequest struct {
+	Sw   *Point `protobuf:"bytes,1,opt,name=sw" json:"sw,omitempty"`
+	Ne   *Point `protobuf:"bytes,2,opt,name=ne" json:"ne,omitempty"`
+	Zoom int32  `protobuf:"varint,3,opt,name=zoom" json:"zoom,omitempty"`
+}
+
+func (m *ClusterRequest) Reset()                    { *m = ClusterRequest{} }
+func (m *ClusterRequest) String() string            { return proto.CompactTextString(m) }
+func (*ClusterRequest) ProtoMessage()               {}
+func (*ClusterRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
+
+func (m *ClusterRequest) GetSw() *Point {
+	if m != nil {
+		return m.Sw
+	}
+	return nil
+}
+
+func (m *ClusterRequest) GetNe() *Point {
+	if m != nil {
+		return m.Ne
+	}
+	return nil
+}
+
+func (m *ClusterRequest) GetZoom() int32 {
+	if m != nil {
+		return m.Zoom
+	}
+	return 0
+}
+
+type ClusterResult struct {
+	Clusters []*Cluster `protobuf:"bytes,1,rep,name=clusters" json:"clusters,omitempty"`
+}
+
+func (m *ClusterResult) Reset()                    { *m = ClusterResult{} }
+func (m *ClusterResult) String() string            { return proto.CompactTextString(m) }
+func (*ClusterResult) ProtoMessage()               {}
+func (*ClusterResult) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
+
+func (m *ClusterResult) GetClusters() []*Cluster {
+	if m != nil {
+		return m.Clusters
+	}
+	return nil
+}
+
+// A cluster is placed at the centroid of its hotels. A cluster of
+// a single hotel carries the hotel id.
+type Cluster struct {
+	Lat     float32 `protobuf:"fixed32,1,opt,name=lat" json:"lat,omitempty"`
+	Lon     float32 `protobuf:"fixed32,2,opt,name=lon" json:"lon,omitempty"`
+	Count   int32   `protobuf:"varint,3,opt,name=count" json:"count,omitempty"`
+	HotelId string  `protobuf:"bytes,4,opt,name=hotelId" json:"hotelId,omitempty"`
+}
+
+func (m *Cluster) Reset()                    { *m = Cluster{} }
+func (m *Cluster) String() string            { return proto.CompactTextString(m) }
+func (*Cluster) ProtoMessage()               {}
+func (*Cluster) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
+
+func (m *Cluster) GetLat() float32 {
+	if m != nil {
+		return m.Lat
+	}
+	return 0
+}
+
+func (m *Cluster) GetLon() float32 {
+	if m != nil {
+		return m.Lon
+	}
+	return 0
+}
+
+func (m *Cluster) GetCount() int32 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+func (m *Cluster) GetHotelId() string {
+	if m != nil {
+		return m.HotelId
+	}
+	return ""
+}
+
+type LocationRequest struct {
+	HotelId string  `protobuf:"bytes,1,opt,name=hotelId" json:"hotelId,omitempty"`
+	Lat     float32 `protobuf:"fixed32,2,opt,name=lat" json:"lat,omitempty"`
+	Lon     float32 `protobuf:"fixed32,3,opt,name=lon" json:"lon,omitempty"`
+}
+
+func (m *LocationRequest) Reset()                    { *m = LocationRequest{} }
+func (m *LocationRequest) String() string            { return proto.CompactTextString(m) }
+func (*LocationRequest) ProtoMessage()               {}
+func (*LocationRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
+
+func (m *LocationRequest) GetHotelId() string {
+	if m != nil {
+		return m.HotelId
+	}
+	return ""
+}
+
+func (m *LocationRequest) GetLat() float32 {
+	if m != nil {
+		return m.Lat
+	}
+	return 0
+}
+
+func (m *LocationRequest) GetLon() float32 {
+	if m != nil {
+		return m.Lon
+	}
+	return 0
+}
+
+type RemoveLocationRequest struct {
+	HotelId string `protobuf:"bytes,1,opt,name=hotelId" json:"hotelId,omitempty"`
+}
+
+func (m *RemoveLocationRequest) Reset()                    { *m = RemoveLocationRequest{} }
+func (m *RemoveLocationRequest) String() string            { return proto.CompactTextString(m) }
+func (*RemoveLocationRequest) ProtoMessage()               {}
+func (*RemoveLocationRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
+
+func (m *RemoveLocationRequest) GetHotelId() string {
+	if m != nil {
+		return m.HotelId
+	}
+	return ""
+}
+
+type LocationResult struct {
+}
+
+func (m *LocationResult) Reset()                    { *m = LocationResult{} }
+func (m *LocationResult) String() string            { return proto.CompactTextString(m) }
+func (*LocationResult) ProtoMessage()               {}
+func (*LocationResult) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
+
 func init() {
 	proto.RegisterType((*Request)(nil), "geo.Request")
 	proto.RegisterType((*Result)(nil), "geo.Result")
+	proto.RegisterType((*Hotel)(nil), "geo.Hotel")
+	proto.RegisterType((*Point)(nil), "geo.Point")
+	proto.RegisterType((*BoundsRequest)(nil), "geo.BoundsRequest")
+	proto.RegisterType((*PolygonRequest)(nil), "geo.PolygonRequest")
+	proto.RegisterType((*ClusterRequest)(nil), "geo.ClusterRequest")
+	proto.RegisterType((*ClusterResult)(nil), "geo.ClusterResult")
+	proto.RegisterType((*Cluster)(nil), "geo.Cluster")
+	proto.RegisterType((*LocationRequest)(nil), "geo.LocationRequest")
+	proto.RegisterType((*RemoveLocationRequest)(nil), "geo.RemoveLocationRequest")
+	proto.RegisterType((*LocationResult)(nil), "geo.LocationResult")
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -92,6 +392,18 @@ const _ = grpc.SupportPackageIsVersion4
 type GeoClient interface {
 	// Finds the hotels contained nearby the current lat/lon.
 	Nearby(ctx context.Context, in *Request, opts ...grpc.CallOption) (*Result, error)
+	// Finds the hotels inside a map viewport given by its SW and NE corners.
+	WithinBounds(ctx context.Context, in *BoundsRequest, opts ...grpc.CallOption) (*Result, error)
+	// Finds the hotels inside a polygon.
+	WithinPolygon(ctx context.Context, in *PolygonRequest, opts ...grpc.CallOption) (*Result, error)
+	// Groups the hotels inside a map viewport into clusters for a zoom level.
+	Clusters(ctx context.Context, in *ClusterRequest, opts ...grpc.CallOption) (*ClusterResult, error)
+	// Adds a new hotel to the index.
+	AddHotelLocation(ctx context.Context, in *LocationRequest, opts ...grpc.CallOption) (*LocationResult, error)
+	// Moves an indexed hotel to a new lat/lon.
+	MoveHotelLocation(ctx context.Context, in *LocationRequest, opts ...grpc.CallOption) (*LocationResult, error)
+	// Removes a hotel from the index.
+	RemoveHotelLocation(ctx context.Context, in *RemoveLocationRequest, opts ...grpc.CallOption) (*LocationResult, error)
 }
 
 type geoClient struct {
@@ -111,11 +423,77 @@ func (c *geoClient) Nearby(ctx context.Context, in *Request, opts ...grpc.CallOp
 	return out, nil
 }
 
+func (c *geoClient) WithinBounds(ctx context.Context, in *BoundsRequest, opts ...grpc.CallOption) (*Result, error) {
+	out := new(Result)
+	err := grpc.Invoke(ctx, "/geo.Geo/WithinBounds", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *geoClient) WithinPolygon(ctx context.Context, in *PolygonRequest, opts ...grpc.CallOption) (*Result, error) {
+	out := new(Result)
+	err := grpc.Invoke(ctx, "/geo.Geo/WithinPolygon", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *geoClient) Clusters(ctx context.Context, in *ClusterRequest, opts ...grpc.CallOption) (*ClusterResult, error) {
+	out := new(ClusterResult)
+	err := grpc.Invoke(ctx, "/geo.Geo/Clusters", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *geoClient) AddHotelLocation(ctx context.Context, in *LocationRequest, opts ...grpc.CallOption) (*LocationResult, error) {
+	out := new(LocationResult)
+	err := grpc.Invoke(ctx, "/geo.Geo/AddHotelLocation", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *geoClient) MoveHotelLocation(ctx context.Context, in *LocationRequest, opts ...grpc.CallOption) (*LocationResult, error) {
+	out := new(LocationResult)
+	err := grpc.Invoke(ctx, "/geo.Geo/MoveHotelLocation", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *geoClient) RemoveHotelLocation(ctx context.Context, in *RemoveLocationRequest, opts ...grpc.CallOption) (*LocationResult, error) {
+	out := new(LocationResult)
+	err := grpc.Invoke(ctx, "/geo.Geo/RemoveHotelLocation", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for Geo service
 
 type GeoServer interface {
 	// Finds the hotels contained nearby the current lat/lon.
 	Nearby(context.Context, *Request) (*Result, error)
+	// Finds the hotels inside a map viewport given by its SW and NE corners.
+	WithinBounds(context.Context, *BoundsRequest) (*Result, error)
+	// Finds the hotels inside a polygon.
+	WithinPolygon(context.Context, *PolygonRequest) (*Result, error)
+	// Groups the hotels inside a map viewport into clusters for a zoom level.
+	Clusters(context.Context, *ClusterRequest) (*ClusterResult, error)
+	// Adds a new hotel to the index.
+	AddHotelLocation(context.Context, *LocationRequest) (*LocationResult, error)
+	// Moves an indexed hotel to a new lat/lon.
+	MoveHotelLocation(context.Context, *LocationRequest) (*LocationResult, error)
+	// Removes a hotel from the index.
+	RemoveHotelLocation(context.Context, *RemoveLocationRequest) (*LocationResult, error)
 }
 
 func RegisterGeoServer(s *grpc.Server, srv GeoServer) {
@@ -140,6 +518,114 @@ func _Geo_Nearby_Handler(srv interface{}, ctx context.Context, dec func(interfac
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Geo_WithinBounds_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BoundsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GeoServer).WithinBounds(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/geo.Geo/WithinBounds",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GeoServer).WithinBounds(ctx, req.(*BoundsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Geo_WithinPolygon_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PolygonRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GeoServer).WithinPolygon(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/geo.Geo/WithinPolygon",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GeoServer).WithinPolygon(ctx, req.(*PolygonRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Geo_Clusters_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClusterRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GeoServer).Clusters(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/geo.Geo/Clusters",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GeoServer).Clusters(ctx, req.(*ClusterRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Geo_AddHotelLocation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LocationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GeoServer).AddHotelLocation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/geo.Geo/AddHotelLocation",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GeoServer).AddHotelLocation(ctx, req.(*LocationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Geo_MoveHotelLocation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LocationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GeoServer).MoveHotelLocation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/geo.Geo/MoveHotelLocation",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GeoServer).MoveHotelLocation(ctx, req.(*LocationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Geo_RemoveHotelLocation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveLocationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GeoServer).RemoveHotelLocation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/geo.Geo/RemoveHotelLocation",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GeoServer).RemoveHotelLocation(ctx, req.(*RemoveLocationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Geo_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "geo.Geo",
 	HandlerType: (*GeoServer)(nil),
@@ -148,6 +634,30 @@ var _Geo_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Nearby",
 			Handler:    _Geo_Nearby_Handler,
 		},
+		{
+			MethodName: "WithinBounds",
+			Handler:    _Geo_WithinBounds_Handler,
+		},
+		{
+			MethodName: "WithinPolygon",
+			Handler:    _Geo_WithinPolygon_Handler,
+		},
+		{
+			MethodName: "Clusters",
+			Handler:    _Geo_Clusters_Handler,
+		},
+		{
+			MethodName: "AddHotelLocation",
+			Handler:    _Geo_AddHotelLocation_Handler,
+		},
+		{
+			MethodName: "MoveHotelLocation",
+			Handler:    _Geo_MoveHotelLocation_Handler,
+		},
+		{
+			MethodName: "RemoveHotelLocation",
+			Handler:    _Geo_RemoveHotelLocation_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "services/geo/proto/geo.proto",
@@ -156,15 +666,37 @@ var _Geo_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("services/geo/proto/geo.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 156 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x92, 0x29, 0x4e, 0x2d, 0x2a,
-	0xcb, 0x4c, 0x4e, 0x2d, 0xd6, 0x4f, 0x4f, 0xcd, 0xd7, 0x2f, 0x28, 0xca, 0x2f, 0xc9, 0x07, 0xb1,
-	0xf4, 0xc0, 0x2c, 0x21, 0xe6, 0xf4, 0xd4, 0x7c, 0x25, 0x5d, 0x2e, 0xf6, 0xa0, 0xd4, 0xc2, 0xd2,
-	0xd4, 0xe2, 0x12, 0x21, 0x01, 0x2e, 0xe6, 0x9c, 0xc4, 0x12, 0x09, 0x46, 0x05, 0x46, 0x0d, 0xa6,
-	0x20, 0x10, 0x13, 0x2c, 0x92, 0x9f, 0x27, 0xc1, 0x04, 0x15, 0xc9, 0xcf, 0x53, 0x52, 0xe1, 0x62,
-	0x0b, 0x4a, 0x2d, 0x2e, 0xcd, 0x29, 0x11, 0x92, 0xe2, 0xe2, 0xc8, 0xc8, 0x2f, 0x49, 0xcd, 0xf1,
-	0x4c, 0x29, 0x96, 0x60, 0x54, 0x60, 0xd6, 0xe0, 0x0c, 0x82, 0xf3, 0x8d, 0xb4, 0xb8, 0x98, 0xdd,
-	0x53, 0xf3, 0x85, 0x94, 0xb9, 0xd8, 0xfc, 0x52, 0x13, 0x8b, 0x92, 0x2a, 0x85, 0x78, 0xf4, 0x40,
-	0xd6, 0x42, 0x2d, 0x92, 0xe2, 0x86, 0xf2, 0x40, 0xe6, 0x24, 0xb1, 0x81, 0x1d, 0x63, 0x0c, 0x08,
-	0x00, 0x00, 0xff, 0xff, 0x1f, 0x18, 0x14, 0xc4, 0xac, 0x00, 0x00, 0x00,
+	// 508 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x54, 0xdf, 0x6b, 0xd3, 0x50,
+	0x14, 0xa6, 0xc9, 0xd2, 0x76, 0x67, 0x6b, 0xad, 0xb7, 0x15, 0x42, 0x10, 0x29, 0x57, 0x90, 0x80,
+	0xb0, 0xb2, 0xed, 0xc9, 0x07, 0x85, 0x29, 0xe8, 0x64, 0x3a, 0x47, 0x5e, 0x7c, 0x71, 0x48, 0x96,
+	0x5c, 0xba, 0x40, 0x72, 0x8f, 0xe6, 0xde, 0x74, 0xcc, 0x3f, 0xc1, 0xbf, 0x5a, 0x72, 0x72, 0xf3,
+	0x6b, 0x9b, 0x50, 0xf4, 0xed, 0x9c, 0xef, 0xdc, 0xf3, 0xf1, 0xe5, 0xfb, 0x4e, 0x0b, 0x4f, 0x95,
+	0xc8, 0x37, 0x49, 0x24, 0xd4, 0x6a, 0x2d, 0x70, 0xf5, 0x23, 0x47, 0x8d, 0x65, 0x75, 0x40, 0x15,
+	0xb3, 0xd7, 0x02, 0xf9, 0x77, 0x18, 0x05, 0xe2, 0x67, 0x21, 0x94, 0x66, 0x33, 0xb0, 0xd3, 0x50,
+	0xbb, 0x83, 0xe5, 0xc0, 0xb7, 0x82, 0xb2, 0x24, 0x04, 0xa5, 0x6b, 0x19, 0x04, 0x25, 0xf3, 0x60,
+	0x9c, 0x87, 0x71, 0x52, 0xa8, 0xb3, 0xcc, 0xb5, 0x09, 0x6e, 0x7a, 0xb6, 0x00, 0x27, 0x4d, 0xb2,
+	0x44, 0xbb, 0x3b, 0xcb, 0x81, 0xef, 0x04, 0x55, 0xc3, 0x4f, 0x61, 0x18, 0x08, 0x55, 0xa4, 0xba,
+	0xdc, 0xbd, 0x46, 0x2d, 0xd2, 0x8f, 0xb1, 0x72, 0x07, 0x4b, 0xdb, 0xdf, 0x0d, 0x9a, 0x9e, 0x71,
+	0x18, 0x52, 0xad, 0x5c, 0x6b, 0x69, 0xfb, 0x7b, 0x47, 0x70, 0x50, 0xea, 0x3c, 0x2d, 0xa1, 0xc0,
+	0x4c, 0xf8, 0x09, 0x38, 0x04, 0x30, 0x17, 0x46, 0x66, 0x91, 0xc4, 0xee, 0x06, 0x75, 0xcb, 0x9e,
+	0x01, 0xc4, 0x89, 0xd2, 0xa1, 0x8c, 0xc4, 0x59, 0x66, 0x74, 0x77, 0x10, 0xfe, 0x12, 0x9c, 0x0b,
+	0x4c, 0xe4, 0x56, 0xdf, 0xca, 0x2f, 0x61, 0xf2, 0x16, 0x0b, 0x19, 0xab, 0xda, 0x20, 0x0f, 0x2c,
+	0x75, 0x43, 0x3b, 0xb5, 0x40, 0x22, 0x0b, 0x2c, 0x75, 0x53, 0xce, 0xa4, 0x70, 0xad, 0xfb, 0x33,
+	0x29, 0x5a, 0x63, 0xec, 0xae, 0x31, 0xe7, 0x30, 0xbd, 0xc0, 0xf4, 0x76, 0x8d, 0xb2, 0xe6, 0x7f,
+	0x01, 0xe3, 0x8d, 0xc8, 0x75, 0x19, 0x18, 0x19, 0xd4, 0x67, 0x6a, 0x66, 0x2d, 0x9f, 0xd5, 0xe5,
+	0xfb, 0x06, 0xd3, 0x77, 0x69, 0xa1, 0xb4, 0xc8, 0xff, 0x57, 0x2f, 0x83, 0x9d, 0x5f, 0x88, 0x99,
+	0x91, 0x4b, 0x35, 0x7f, 0x05, 0x93, 0x86, 0x9d, 0xd2, 0xf4, 0x61, 0x1c, 0x55, 0x40, 0x2d, 0x76,
+	0x9f, 0x68, 0xea, 0x57, 0xcd, 0x94, 0x5f, 0xc2, 0xc8, 0x80, 0x5b, 0x9d, 0xd8, 0x02, 0x9c, 0x08,
+	0x0b, 0xd9, 0xb8, 0x45, 0x4d, 0x37, 0xf3, 0x9d, 0x5e, 0xe6, 0xfc, 0x0b, 0x3c, 0xfa, 0x84, 0x51,
+	0xa8, 0x93, 0xd6, 0xc8, 0xbf, 0x1f, 0x88, 0x11, 0x60, 0xdd, 0x13, 0x60, 0xb7, 0xb9, 0x1f, 0xc2,
+	0x93, 0x40, 0x64, 0xb8, 0x11, 0x5b, 0xd3, 0xf2, 0x19, 0x4c, 0xdb, 0xc7, 0xa5, 0x3d, 0x47, 0xbf,
+	0x6d, 0xb0, 0x3f, 0x08, 0x64, 0xcf, 0x61, 0x78, 0x2e, 0xc2, 0xfc, 0xea, 0x96, 0x55, 0xf6, 0x18,
+	0x2e, 0x6f, 0xcf, 0x74, 0xe4, 0xe5, 0x0a, 0xf6, 0xbf, 0x26, 0xfa, 0x3a, 0x91, 0xd5, 0xbd, 0x31,
+	0x46, 0xc3, 0xde, 0xf1, 0xf5, 0x17, 0x0e, 0x61, 0x52, 0x2d, 0x98, 0x0b, 0x62, 0x73, 0x13, 0x61,
+	0xf7, 0x9e, 0xfa, 0x2b, 0xc7, 0x30, 0x36, 0x29, 0x28, 0xf3, 0xba, 0x7f, 0x2d, 0x1e, 0xeb, 0x83,
+	0xb4, 0xf4, 0x1a, 0x66, 0x27, 0x71, 0x4c, 0xbf, 0xba, 0xfa, 0xfb, 0xd8, 0x82, 0xde, 0xdd, 0xf1,
+	0xc6, 0x9b, 0xdf, 0x41, 0x69, 0xfd, 0x0d, 0x3c, 0xfe, 0x8c, 0x1b, 0xf1, 0xcf, 0xfb, 0xef, 0x61,
+	0x5e, 0x25, 0xd1, 0x67, 0xf0, 0xcc, 0x77, 0x3d, 0x90, 0xd1, 0x83, 0x3c, 0x57, 0x43, 0xfa, 0xc3,
+	0x3b, 0xfe, 0x33, 0x00, 0xf9, 0xd8, 0x54, 0x0b, 0x10, 0x05, 0x00, 0x00,
 }
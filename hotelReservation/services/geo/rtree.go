@@ -0,0 +1,245 @@
+package geo
+
+import (
+	"math"
+
+	"github.com/hailocab/go-geoindex"
+)
+
+// node fan-out of the R-tree
+const (
+	rtreeMaxEntries = 16
+	rtreeMinEntries = 6
+)
+
+type rect struct {
+	minLat, minLon, maxLat, maxLon float64
+}
+
+func pointRect(p geoindex.Point) rect {
+	return rect{p.Lat(), p.Lon(), p.Lat(), p.Lon()}
+}
+
+func (r rect) union(o rect) rect {
+	return rect{
+		math.Min(r.minLat, o.minLat), math.Min(r.minLon, o.minLon),
+		math.Max(r.maxLat, o.maxLat), math.Max(r.maxLon, o.maxLon),
+	}
+}
+
+func (r rect) area() float64 {
+	return (r.maxLat - r.minLat) * (r.maxLon - r.minLon)
+}
+
+func (r rect) intersects(o rect) bool {
+	return r.minLat <= o.maxLat && o.minLat <= r.maxLat && r.minLon <= o.maxLon && o.minLon <= r.maxLon
+}
+
+func (r rect) contains(o rect) bool {
+	return r.minLat <= o.minLat && o.maxLat <= r.maxLat && r.minLon <= o.minLon && o.maxLon <= r.maxLon
+}
+
+type rtreeEntry struct {
+	r rect
+	// child is set in inner nodes, p in leaves
+	child *rtreeNode
+	p     geoindex.Point
+}
+
+type rtreeNode struct {
+	leaf    bool
+	entries []rtreeEntry
+}
+
+func (n *rtreeNode) bounds() rect {
+	r := n.entries[0].r
+	for _, e := range n.entries[1:] {
+		r = r.union(e.r)
+	}
+	return r
+}
+
+// rtreeIndex is a dynamic R-tree with quadratic splits. Removal
+// dissolves underfull nodes and reinserts their points.
+type rtreeIndex struct {
+	root   *rtreeNode
+	points map[string]geoindex.Point
+}
+
+func newRTreeIndex() *rtreeIndex {
+	return &rtreeIndex{
+		root:   &rtreeNode{leaf: true},
+		points: make(map[string]geoindex.Point),
+	}
+}
+
+func (t *rtreeIndex) Add(p geoindex.Point) {
+	t.Remove(p.Id())
+	t.points[p.Id()] = p
+	t.insert(p)
+}
+
+func (t *rtreeIndex) insert(p geoindex.Point) {
+	sibling := t.insertAt(t.root, rtreeEntry{r: pointRect(p), p: p})
+	if sibling != nil {
+		t.root = &rtreeNode{entries: []rtreeEntry{
+			{r: t.root.bounds(), child: t.root},
+			{r: sibling.bounds(), child: sibling},
+		}}
+	}
+}
+
+// insertAt adds e below n and returns the new sibling if n had to split.
+func (t *rtreeIndex) insertAt(n *rtreeNode, e rtreeEntry) *rtreeNode {
+	if n.leaf {
+		n.entries = append(n.entries, e)
+	} else {
+		// descend into the child whose box grows least
+		best, bestGrowth, bestArea := 0, math.Inf(1), math.Inf(1)
+		for i, c := range n.entries {
+			area := c.r.area()
+			growth := c.r.union(e.r).area() - area
+			if growth < bestGrowth || growth == bestGrowth && area < bestArea {
+				best, bestGrowth, bestArea = i, growth, area
+			}
+		}
+
+		child := n.entries[best].child
+		sibling := t.insertAt(child, e)
+		n.entries[best].r = child.bounds()
+		if sibling != nil {
+			n.entries = append(n.entries, rtreeEntry{r: sibling.bounds(), child: sibling})
+		}
+	}
+
+	if len(n.entries) > rtreeMaxEntries {
+		return n.split()
+	}
+	return nil
+}
+
+// split moves part of the entries of n into a new sibling node.
+func (n *rtreeNode) split() *rtreeNode {
+	entries := n.entries
+
+	// seed both groups with the pair that would waste the most area together
+	seedA, seedB, worst := 0, 1, math.Inf(-1)
+	for i := range entries {
+		for j := i + 1; j < len(entries); j++ {
+			waste := entries[i].r.union(entries[j].r).area() - entries[i].r.area() - entries[j].r.area()
+			if waste > worst {
+				seedA, seedB, worst = i, j, waste
+			}
+		}
+	}
+
+	a := &rtreeNode{leaf: n.leaf, entries: []rtreeEntry{entries[seedA]}}
+	b := &rtreeNode{leaf: n.leaf, entries: []rtreeEntry{entries[seedB]}}
+	ra, rb := entries[seedA].r, entries[seedB].r
+	for i, e := range entries {
+		if i == seedA || i == seedB {
+			continue
+		}
+		// once a group needs every remaining entry to reach the minimum, it gets them
+		remaining := len(entries) - len(a.entries) - len(b.entries)
+		switch {
+		case len(a.entries)+remaining <= rtreeMinEntries:
+			a.entries = append(a.entries, e)
+			ra = ra.union(e.r)
+		case len(b.entries)+remaining <= rtreeMinEntries:
+			b.entries = append(b.entries, e)
+			rb = rb.union(e.r)
+		case ra.union(e.r).area()-ra.area() <= rb.union(e.r).area()-rb.area():
+			a.entries = append(a.entries, e)
+			ra = ra.union(e.r)
+		default:
+			b.entries = append(b.entries, e)
+			rb = rb.union(e.r)
+		}
+	}
+
+	n.entries = a.entries
+	return b
+}
+
+func (t *rtreeIndex) Remove(id string) {
+	p, ok := t.points[id]
+	if !ok {
+		return
+	}
+	delete(t.points, id)
+
+	var orphans []geoindex.Point
+	t.removeAt(t.root, pointRect(p), id, &orphans)
+	for !t.root.leaf && len(t.root.entries) == 1 {
+		t.root = t.root.entries[0].child
+	}
+	for _, o := range orphans {
+		t.insert(o)
+	}
+}
+
+// removeAt drops the point id located at r from below n. Children left
+// underfull are cut off and their points collected for reinsertion.
+func (t *rtreeIndex) removeAt(n *rtreeNode, r rect, id string, orphans *[]geoindex.Point) bool {
+	if n.leaf {
+		for i, e := range n.entries {
+			if e.p.Id() == id {
+				n.entries = append(n.entries[:i], n.entries[i+1:]...)
+				return true
+			}
+		}
+		return false
+	}
+
+	for i, e := range n.entries {
+		if !e.r.contains(r) || !t.removeAt(e.child, r, id, orphans) {
+			continue
+		}
+		if len(e.child.entries) < rtreeMinEntries {
+			e.child.collect(orphans)
+			n.entries = append(n.entries[:i], n.entries[i+1:]...)
+		} else {
+			n.entries[i].r = e.child.bounds()
+		}
+		return true
+	}
+	return false
+}
+
+func (n *rtreeNode) collect(points *[]geoindex.Point) {
+	for _, e := range n.entries {
+		if n.leaf {
+			*points = append(*points, e.p)
+		} else {
+			e.child.collect(points)
+		}
+	}
+}
+
+func (t *rtreeIndex) KNearest(center geoindex.Point, k int, maxDistance geoindex.Meters) []geoindex.Point {
+	return withinRadius(t, center, k, maxDistance)
+}
+
+func (t *rtreeIndex) Range(minLat, minLon, maxLat, maxLon float64) []geoindex.Point {
+	points := make([]geoindex.Point, 0)
+	t.root.search(rect{minLat, minLon, maxLat, maxLon}, &points)
+	return points
+}
+
+func (n *rtreeNode) search(r rect, points *[]geoindex.Point) {
+	for _, e := range n.entries {
+		if !r.intersects(e.r) {
+			continue
+		}
+		if n.leaf {
+			*points = append(*points, e.p)
+		} else {
+			e.child.search(r, points)
+		}
+	}
+}
+
+func (t *rtreeIndex) Len() int {
+	return len(t.points)
+}
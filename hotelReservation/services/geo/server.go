@@ -7,36 +7,72 @@ import (
 	"gopkg.in/mgo.v2/bson"
 	// "io/ioutil"
 	"log"
+	"math"
 	"net"
 	// "os"
+	"sort"
+	"sync"
 	"time"
 
 	"github.com/grpc-ecosystem/grpc-opentracing/go/otgrpc"
 	"github.com/hailocab/go-geoindex"
+	"github.com/harlow/go-micro-services/rbac"
 	"github.com/harlow/go-micro-services/registry"
 	pb "github.com/harlow/go-micro-services/services/geo/proto"
 	opentracing "github.com/opentracing/opentracing-go"
 	"golang.org/x/net/context"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/status"
 )
 
 const (
-	name             = "srv-geo"
-	maxSearchRadius  = 10
-	maxSearchResults = 5
+	name = "srv-geo"
+
+	// used when the request leaves radius or limit unset
+	defaultSearchRadius  = 10
+	defaultSearchResults = 5
+
+	// caps on what a single request may ask for
+	maxSearchRadius  = 50
+	maxSearchResults = 100
+
+	// viewport and polygon queries
+	defaultBoundsResults = 200
+	maxBoundsResults     = 1000
+	maxPolygonVertices   = 1000
+
+	// from this zoom level on every hotel is shown on its own
+	maxClusterZoom = 15
+	// hotels closer than clusterRadius pixels on a tileSize map tile are merged
+	clusterRadius = 60
+	tileSize      = 256
+
+	// how often the indexes are rebuilt from mongodb, to pick up
+	// location updates this replica missed
+	reconcileInterval = time.Minute
 )
 
 // Server implements the geo service
 type Server struct {
-	index *geoindex.ClusteringIndex
-	uuid  string
+	// mu guards the indexes, which are updated in place by the location
+	// RPCs and swapped wholesale by reconcile; queries hold the read lock
+	mu    sync.RWMutex
+	index SpatialIndex
+	// version counts in-place index updates, see reconcile
+	version uint64
+	uuid    string
 
 	Registry *registry.Client
+	// Identity signs and verifies the identities calls are made by
+	Identity *rbac.Signer
 	Tracer   opentracing.Tracer
 	Port     int
 	IpAddr	 string
 	MongoSession 	*mgo.Session
+	// IndexType selects the SpatialIndex implementation, see IndexTypes
+	IndexType string
 }
 
 // Run starts the server
@@ -45,9 +81,18 @@ func (s *Server) Run() error {
 		return fmt.Errorf("server port must be set")
 	}
 
+	if s.Identity == nil {
+		return fmt.Errorf("identity keys must be set")
+	}
+
 	if s.index == nil {
-		s.index = newGeoIndex(s.MongoSession)
+		index, err := newGeoIndex(s.MongoSession, s.IndexType)
+		if err != nil {
+			return err
+		}
+		s.index = index
 	}
+	go s.reconcileLoop()
 
 	// opts := []grpc.ServerOption {
 	// 	grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
@@ -62,9 +107,10 @@ func (s *Server) Run() error {
 		grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy {
 			PermitWithoutStream: true,
 		}),
-		grpc.UnaryInterceptor(
+		grpc.UnaryInterceptor(rbac.ChainUnaryServer(
 			otgrpc.OpenTracingServerInterceptor(s.Tracer),
-		),
+			rbac.UnaryServerInterceptor(s.Identity, policy),
+		)),
 	)
 
 	pb.RegisterGeoServer(srv, s)
@@ -107,41 +153,434 @@ func (s *Server) Shutdown() {
 func (s *Server) Nearby(ctx context.Context, req *pb.Request) (*pb.Result, error) {
 	// fmt.Printf("In geo Nearby\n")
 
+	radius := float64(req.RadiusKm)
+	if radius <= 0 {
+		radius = defaultSearchRadius
+	}
+	if radius > maxSearchRadius {
+		radius = maxSearchRadius
+	}
+
+	limit := int(req.Limit)
+	if limit <= 0 {
+		limit = defaultSearchResults
+	}
+	if limit > maxSearchResults {
+		limit = maxSearchResults
+	}
+
 	var (
-		points = s.getNearbyPoints(ctx, float64(req.Lat), float64(req.Lon))
-		res    = &pb.Result{}
+		center = &geoindex.GeoPoint{
+			Pid:  "",
+			Plat: float64(req.Lat),
+			Plon: float64(req.Lon),
+		}
+		res = &pb.Result{}
 	)
 
+	s.mu.RLock()
+	points := s.getNearbyPoints(ctx, center, radius, limit)
+	s.mu.RUnlock()
+
 	// fmt.Printf("geo after getNearbyPoints, len = %d\n", len(points))
 
 	for _, p := range points {
 		// fmt.Printf("In geo Nearby return hotelId = %s\n", p.Id())
 		res.HotelIds = append(res.HotelIds, p.Id())
+		res.Hotels = append(res.Hotels, &pb.Hotel{
+			HotelId:    p.Id(),
+			DistanceKm: float32(geoindex.Distance(center, p) / 1000),
+		})
 	}
 
 	return res, nil
 }
 
-func (s *Server) getNearbyPoints(ctx context.Context, lat, lon float64) []geoindex.Point {
+func (s *Server) getNearbyPoints(ctx context.Context, center geoindex.Point, radius float64, limit int) []geoindex.Point {
 	// fmt.Printf("In geo getNearbyPoints, lat = %f, lon = %f\n", lat, lon)
 
+	return s.index.KNearest(center, limit, geoindex.Km(radius))
+}
+
+// WithinBounds returns the hotels inside a map viewport, nearest to its center first.
+func (s *Server) WithinBounds(ctx context.Context, req *pb.BoundsRequest) (*pb.Result, error) {
+	if req.Sw == nil || req.Ne == nil {
+		return nil, status.Error(codes.InvalidArgument, "sw and ne corners must be set")
+	}
+	if req.Sw.Lat > req.Ne.Lat {
+		return nil, status.Error(codes.InvalidArgument, "sw corner must not be north of ne corner")
+	}
+
+	s.mu.RLock()
+	points := s.rangePoints(
+		float64(req.Sw.Lat), float64(req.Sw.Lon),
+		float64(req.Ne.Lat), float64(req.Ne.Lon),
+	)
+	s.mu.RUnlock()
+
+	// the viewport may wrap around the antimeridian
+	width := float64(req.Ne.Lon - req.Sw.Lon)
+	if width < 0 {
+		width += 360
+	}
+	centerLon := float64(req.Sw.Lon) + width/2
+	if centerLon > 180 {
+		centerLon -= 360
+	}
+	center := &geoindex.GeoPoint{
+		Pid:  "",
+		Plat: float64(req.Sw.Lat+req.Ne.Lat) / 2,
+		Plon: centerLon,
+	}
+
+	return rangeResult(center, points, int(req.Limit)), nil
+}
+
+// WithinPolygon returns the hotels inside a polygon, nearest to its vertex centroid first.
+func (s *Server) WithinPolygon(ctx context.Context, req *pb.PolygonRequest) (*pb.Result, error) {
+	vs := req.Vertices
+	if len(vs) < 3 {
+		return nil, status.Error(codes.InvalidArgument, "polygon needs at least 3 vertices")
+	}
+	if len(vs) > maxPolygonVertices {
+		return nil, status.Errorf(codes.InvalidArgument, "polygon has more than %d vertices", maxPolygonVertices)
+	}
+	for _, v := range vs {
+		if v == nil {
+			return nil, status.Error(codes.InvalidArgument, "polygon vertex must be set")
+		}
+	}
+
+	minLat, minLon := float64(vs[0].Lat), float64(vs[0].Lon)
+	maxLat, maxLon := minLat, minLon
+	var sumLat, sumLon float64
+	for _, v := range vs {
+		minLat = math.Min(minLat, float64(v.Lat))
+		maxLat = math.Max(maxLat, float64(v.Lat))
+		minLon = math.Min(minLon, float64(v.Lon))
+		maxLon = math.Max(maxLon, float64(v.Lon))
+		sumLat += float64(v.Lat)
+		sumLon += float64(v.Lon)
+	}
+
+	// narrow down with the bounding box, then test each candidate
+	s.mu.RLock()
+	candidates := s.rangePoints(minLat, minLon, maxLat, maxLon)
+	s.mu.RUnlock()
+
+	var points []geoindex.Point
+	for _, p := range candidates {
+		if pointInPolygon(p.Lat(), p.Lon(), vs) {
+			points = append(points, p)
+		}
+	}
+
 	center := &geoindex.GeoPoint{
 		Pid:  "",
-		Plat: lat,
-		Plon: lon,
+		Plat: sumLat / float64(len(vs)),
+		Plon: sumLon / float64(len(vs)),
+	}
+
+	return rangeResult(center, points, int(req.Limit)), nil
+}
+
+// Clusters returns the hotels inside a map viewport grouped for the zoom level.
+// An index implementing Clusterer supplies pre-aggregated cell counts for large
+// viewports, other indexes the individual hotels; these are merged further on a grid whose cells shrink as the zoom grows.
+func (s *Server) Clusters(ctx context.Context, req *pb.ClusterRequest) (*pb.ClusterResult, error) {
+	if req.Sw == nil || req.Ne == nil {
+		return nil, status.Error(codes.InvalidArgument, "sw and ne corners must be set")
+	}
+	if req.Sw.Lat > req.Ne.Lat {
+		return nil, status.Error(codes.InvalidArgument, "sw corner must not be north of ne corner")
+	}
+	if req.Zoom < 0 {
+		return nil, status.Error(codes.InvalidArgument, "zoom must not be negative")
+	}
+
+	var (
+		minLat, minLon = float64(req.Sw.Lat), float64(req.Sw.Lon)
+		maxLat, maxLon = float64(req.Ne.Lat), float64(req.Ne.Lon)
+		res            = new(pb.ClusterResult)
+	)
+
+	if req.Zoom >= maxClusterZoom {
+		s.mu.RLock()
+		points := s.rangePoints(minLat, minLon, maxLat, maxLon)
+		s.mu.RUnlock()
+
+		for _, p := range points {
+			res.Clusters = append(res.Clusters, &pb.Cluster{
+				Lat:     float32(p.Lat()),
+				Lon:     float32(p.Lon()),
+				Count:   1,
+				HotelId: p.Id(),
+			})
+		}
+		return res, nil
+	}
+
+	type cell struct{ x, y int }
+	type cluster struct {
+		latSum, lonSum float64
+		count          int
+		hotelId        string
+	}
+
+	var (
+		cellDeg  = 360 / math.Exp2(float64(req.Zoom)) * clusterRadius / tileSize
+		clusters = make(map[cell]*cluster)
+		order    []cell
+	)
+
+	s.mu.RLock()
+	points := s.clusterRange(minLat, minLon, maxLat, maxLon)
+	s.mu.RUnlock()
+
+	for _, p := range points {
+		// street level yields hotels, coarser levels yield cell counts
+		count, hotelId := 1, p.Id()
+		if cp, ok := p.(*geoindex.CountPoint); ok {
+			count, _ = cp.Count.(int)
+			hotelId = ""
+		}
+		if count <= 0 {
+			continue
+		}
+
+		c := cell{
+			int(math.Floor((p.Lat() + 90) / cellDeg)),
+			int(math.Floor((p.Lon() + 180) / cellDeg)),
+		}
+		cl, ok := clusters[c]
+		if !ok {
+			cl = &cluster{}
+			clusters[c] = cl
+			order = append(order, c)
+		}
+		cl.latSum += p.Lat() * float64(count)
+		cl.lonSum += p.Lon() * float64(count)
+		cl.count += count
+		cl.hotelId = hotelId
+	}
+
+	for _, c := range order {
+		cl := clusters[c]
+		out := &pb.Cluster{
+			Lat:   float32(cl.latSum / float64(cl.count)),
+			Lon:   float32(cl.lonSum / float64(cl.count)),
+			Count: int32(cl.count),
+		}
+		if cl.count == 1 {
+			out.HotelId = cl.hotelId
+		}
+		res.Clusters = append(res.Clusters, out)
+	}
+
+	return res, nil
+}
+
+// AddHotelLocation stores the location of a new hotel and indexes it.
+func (s *Server) AddHotelLocation(ctx context.Context, req *pb.LocationRequest) (*pb.LocationResult, error) {
+	p, err := locationPoint(req)
+	if err != nil {
+		return nil, err
+	}
+
+	session := s.MongoSession.Copy()
+	defer session.Close()
+	c := session.DB("geo-db").C("geo")
+
+	err = c.Insert(p)
+	if mgo.IsDup(err) {
+		return nil, status.Errorf(codes.AlreadyExists, "hotel %s already has a location", p.Pid)
+	}
+	if err != nil {
+		return nil, err
 	}
 
-	return s.index.KNearest(
-		center,
-		maxSearchResults,
-		geoindex.Km(maxSearchRadius), func(p geoindex.Point) bool {
-			return true
-		},
+	s.mu.Lock()
+	s.index.Add(p)
+	s.version++
+	s.mu.Unlock()
+
+	return new(pb.LocationResult), nil
+}
+
+// MoveHotelLocation updates the location of an indexed hotel.
+func (s *Server) MoveHotelLocation(ctx context.Context, req *pb.LocationRequest) (*pb.LocationResult, error) {
+	p, err := locationPoint(req)
+	if err != nil {
+		return nil, err
+	}
+
+	session := s.MongoSession.Copy()
+	defer session.Close()
+	c := session.DB("geo-db").C("geo")
+
+	err = c.Update(
+		bson.M{"hotelId": p.Pid},
+		bson.M{"$set": bson.M{"lat": p.Plat, "lon": p.Plon}},
 	)
+	if err == mgo.ErrNotFound {
+		return nil, status.Errorf(codes.NotFound, "hotel %s has no location", p.Pid)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// indexed points are shared with in-flight results, so replace rather than mutate
+	s.mu.Lock()
+	s.index.Add(p)
+	s.version++
+	s.mu.Unlock()
+
+	return new(pb.LocationResult), nil
 }
 
-// newGeoIndex returns a geo index with points loaded
-func newGeoIndex(session *mgo.Session) *geoindex.ClusteringIndex {
+// RemoveHotelLocation deletes the location of a hotel and drops it from the index.
+func (s *Server) RemoveHotelLocation(ctx context.Context, req *pb.RemoveLocationRequest) (*pb.LocationResult, error) {
+	if req.HotelId == "" {
+		return nil, status.Error(codes.InvalidArgument, "hotel id must be set")
+	}
+
+	session := s.MongoSession.Copy()
+	defer session.Close()
+	c := session.DB("geo-db").C("geo")
+
+	_, err := c.RemoveAll(bson.M{"hotelId": req.HotelId})
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	s.index.Remove(req.HotelId)
+	s.version++
+	s.mu.Unlock()
+
+	return new(pb.LocationResult), nil
+}
+
+// locationPoint validates a location update and turns it into an index point.
+func locationPoint(req *pb.LocationRequest) (*point, error) {
+	if req.HotelId == "" {
+		return nil, status.Error(codes.InvalidArgument, "hotel id must be set")
+	}
+	if req.Lat < -90 || req.Lat > 90 || req.Lon < -180 || req.Lon > 180 {
+		return nil, status.Error(codes.InvalidArgument, "lat/lon out of range")
+	}
+	return &point{
+		Pid:  req.HotelId,
+		Plat: float64(req.Lat),
+		Plon: float64(req.Lon),
+	}, nil
+}
+
+func (s *Server) reconcileLoop() {
+	for range time.Tick(reconcileInterval) {
+		if err := s.reconcile(); err != nil {
+			log.Println("Failed reconcile geo index: ", err)
+		}
+	}
+}
+
+// reconcile rebuilds the indexes from mongodb and swaps them in. If this
+// replica applied an update while the rebuild was loading, the fresh
+// indexes may predate it, so they are dropped and the next round retries.
+func (s *Server) reconcile() error {
+	s.mu.RLock()
+	version := s.version
+	s.mu.RUnlock()
+
+	index, err := NewSpatialIndex(s.IndexType)
+	if err != nil {
+		return err
+	}
+	if err := loadGeoIndex(s.MongoSession, index); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.version != version {
+		return nil
+	}
+	s.index = index
+	return nil
+}
+
+// clusterRange asks the index for the points or cell counts within a
+// lat/lon box, splitting boxes that wrap around the antimeridian.
+func (s *Server) clusterRange(minLat, minLon, maxLat, maxLon float64) []geoindex.Point {
+	if minLon > maxLon {
+		return append(
+			s.clusterRange(minLat, minLon, maxLat, 180),
+			s.clusterRange(minLat, -180, maxLat, maxLon)...,
+		)
+	}
+	if c, ok := s.index.(Clusterer); ok {
+		return c.ClusterRange(minLat, minLon, maxLat, maxLon)
+	}
+	return s.index.Range(minLat, minLon, maxLat, maxLon)
+}
+
+// rangePoints returns the points within a lat/lon box. A box with
+// minLon > maxLon wraps around the antimeridian.
+func (s *Server) rangePoints(minLat, minLon, maxLat, maxLon float64) []geoindex.Point {
+	if minLon > maxLon {
+		return append(
+			s.rangePoints(minLat, minLon, maxLat, 180),
+			s.rangePoints(minLat, -180, maxLat, maxLon)...,
+		)
+	}
+	return s.index.Range(minLat, minLon, maxLat, maxLon)
+}
+
+// rangeResult orders points by distance from center and keeps at most limit of them.
+func rangeResult(center geoindex.Point, points []geoindex.Point, limit int) *pb.Result {
+	if limit <= 0 {
+		limit = defaultBoundsResults
+	}
+	if limit > maxBoundsResults {
+		limit = maxBoundsResults
+	}
+
+	hotels := make([]*pb.Hotel, 0, len(points))
+	for _, p := range points {
+		hotels = append(hotels, &pb.Hotel{
+			HotelId:    p.Id(),
+			DistanceKm: float32(geoindex.Distance(center, p) / 1000),
+		})
+	}
+	sort.Slice(hotels, func(i, j int) bool {
+		return hotels[i].DistanceKm < hotels[j].DistanceKm
+	})
+	if len(hotels) > limit {
+		hotels = hotels[:limit]
+	}
+
+	res := &pb.Result{Hotels: hotels}
+	for _, h := range hotels {
+		res.HotelIds = append(res.HotelIds, h.HotelId)
+	}
+	return res
+}
+
+// pointInPolygon reports whether lat/lon lies inside the polygon (even-odd rule).
+func pointInPolygon(lat, lon float64, vs []*pb.Point) bool {
+	in := false
+	for i, j := 0, len(vs)-1; i < len(vs); j, i = i, i+1 {
+		yi, xi := float64(vs[i].Lat), float64(vs[i].Lon)
+		yj, xj := float64(vs[j].Lat), float64(vs[j].Lon)
+		if (yi > lat) != (yj > lat) && lon < (xj-xi)*(lat-yi)/(yj-yi)+xi {
+			in = !in
+		}
+	}
+	return in
+}
+
+// newGeoIndex returns a geo index of the given type with points loaded
+func newGeoIndex(session *mgo.Session, indexType string) (SpatialIndex, error) {
 	// session, err := mgo.Dial("mongodb-geo")
 	// if err != nil {
 	// 	panic(err)
@@ -150,6 +589,18 @@ func newGeoIndex(session *mgo.Session) *geoindex.ClusteringIndex {
 
 	// fmt.Printf("new geo newGeoIndex\n")
 
+	index, err := NewSpatialIndex(indexType)
+	if err != nil {
+		return nil, err
+	}
+	if err := loadGeoIndex(session, index); err != nil {
+		log.Println("Failed get geo data: ", err)
+	}
+	return index, nil
+}
+
+// loadGeoIndex adds the hotel locations stored in mongodb to index
+func loadGeoIndex(session *mgo.Session, index SpatialIndex) error {
 	s := session.Copy()
 	defer s.Close()
 	c := s.DB("geo-db").C("geo")
@@ -157,18 +608,15 @@ func newGeoIndex(session *mgo.Session) *geoindex.ClusteringIndex {
 	var points []*point
 	err := c.Find(bson.M{}).All(&points)
 	if err != nil {
-		log.Println("Failed get geo data: ", err)
+		return err
 	}
 
-	fmt.Printf("newGeoIndex len(points) = %d\n", len(points))
-
 	// add points to index
-	index := geoindex.NewClusteringIndex()
 	for _, point := range points {
 		index.Add(point)
 	}
 
-	return index
+	return nil
 }
 
 type point struct {
@@ -0,0 +1,23 @@
+package geo
+
+import (
+	"testing"
+
+	pb "github.com/harlow/go-micro-services/services/geo/proto"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestWithinPolygonRejectsUnsetVertices(t *testing.T) {
+	s := &Server{index: newBruteForceIndex()}
+	vertex := &pb.Point{Lat: 37.78, Lon: -122.41}
+	for i := 0; i < 3; i++ {
+		vs := []*pb.Point{vertex, vertex, vertex}
+		vs[i] = nil
+		_, err := s.WithinPolygon(context.Background(), &pb.PolygonRequest{Vertices: vs})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("vertex %d unset: got %v, want InvalidArgument", i, err)
+		}
+	}
+}
@@ -0,0 +1,179 @@
+package profile
+
+import (
+	"log"
+	"strings"
+
+	"github.com/bradfitz/gomemcache/memcache"
+	pb "github.com/harlow/go-micro-services/services/profile/proto"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// hotelDoc is a hotel as stored in profile-db.hotels, with the keys of the
+// hotels cmd/profile seeds and UpdateScore writes.
+type hotelDoc struct {
+	Id          string      `bson:"id"`
+	Name        string      `bson:"name"`
+	PhoneNumber string      `bson:"phoneNumber"`
+	Description string      `bson:"description"`
+	Address     *addressDoc `bson:"address"`
+	Images      []*pb.Image `bson:"images,omitempty"`
+	Price       float32     `bson:"price"`
+	Score       float32     `bson:"score"`
+	ScoreTimes  int32       `bson:"scoreTimes"`
+}
+
+type addressDoc struct {
+	StreetNumber string  `bson:"streetNumber"`
+	StreetName   string  `bson:"streetName"`
+	City         string  `bson:"city"`
+	State        string  `bson:"state"`
+	Country      string  `bson:"country"`
+	PostalCode   string  `bson:"postalCode"`
+	Lat          float32 `bson:"lat"`
+	Lon          float32 `bson:"lon"`
+}
+
+// newHotelDoc returns the document of a new, unscored hotel.
+func newHotelDoc(h *pb.Hotel) *hotelDoc {
+	return &hotelDoc{
+		Id:          h.Id,
+		Name:        h.Name,
+		PhoneNumber: h.PhoneNumber,
+		Description: h.Description,
+		Address: &addressDoc{
+			StreetNumber: h.Address.StreetNumber,
+			StreetName:   h.Address.StreetName,
+			City:         h.Address.City,
+			State:        h.Address.State,
+			Country:      h.Address.Country,
+			PostalCode:   h.Address.PostalCode,
+			Lat:          h.Address.Lat,
+			Lon:          h.Address.Lon,
+		},
+		Images: h.Images,
+		Price:  h.Price,
+	}
+}
+
+// CreateProfile stores the profile of a new hotel, unscored, and adds it
+// to the suggestions.
+func (s *Server) CreateProfile(ctx context.Context, req *pb.Hotel) (*pb.ProfileResult, error) {
+	if strings.TrimSpace(req.Id) == "" || strings.TrimSpace(req.Name) == "" {
+		return nil, status.Error(codes.InvalidArgument, "hotel id and name must be set")
+	}
+	if req.Address == nil {
+		return nil, status.Error(codes.InvalidArgument, "hotel address must be set")
+	}
+	if req.Price < 0 {
+		return nil, status.Error(codes.InvalidArgument, "price must not be negative")
+	}
+
+	session := s.MongoSession.Copy()
+	defer session.Close()
+	c := session.DB("profile-db").C("hotels")
+
+	n, err := c.Find(bson.M{"id": req.Id}).Count()
+	if err != nil {
+		return nil, err
+	}
+	if n > 0 {
+		return nil, status.Errorf(codes.AlreadyExists, "hotel %s already has a profile", req.Id)
+	}
+
+	if err := c.Insert(newHotelDoc(req)); err != nil {
+		return nil, err
+	}
+
+	// a lookup of the id before it existed cached an empty profile
+	s.forgetProfile(req.Id)
+	if err := s.refreshSuggestions(); err != nil {
+		log.Println("Failed refresh suggestion index: ", err)
+	}
+	return new(pb.ProfileResult), nil
+}
+
+// UpdateProfile sets a field of the profile of a hotel, drops its cached
+// profile and refreshes the suggestions its name and address feed.
+func (s *Server) UpdateProfile(ctx context.Context, req *pb.UpdateProfileRequest) (*pb.ProfileResult, error) {
+	if req.HotelId == "" || req.Target == "" {
+		return nil, status.Error(codes.InvalidArgument, "hotel id and target must be set")
+	}
+
+	session := s.MongoSession.Copy()
+	defer session.Close()
+
+	err := session.DB("profile-db").C("hotels").Update(
+		bson.M{"id": req.HotelId},
+		bson.M{"$set": bson.M{req.Target: req.Content}},
+	)
+	if err == mgo.ErrNotFound {
+		return nil, status.Errorf(codes.NotFound, "hotel %s has no profile", req.HotelId)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	s.forgetProfile(req.HotelId)
+	if err := s.refreshSuggestions(); err != nil {
+		log.Println("Failed refresh suggestion index: ", err)
+	}
+	return new(pb.ProfileResult), nil
+}
+
+// DeleteProfile removes the profile of a hotel and drops it from the
+// suggestions.
+func (s *Server) DeleteProfile(ctx context.Context, req *pb.DeleteProfileRequest) (*pb.ProfileResult, error) {
+	if req.HotelId == "" {
+		return nil, status.Error(codes.InvalidArgument, "hotel id must be set")
+	}
+
+	session := s.MongoSession.Copy()
+	defer session.Close()
+
+	_, err := session.DB("profile-db").C("hotels").RemoveAll(bson.M{"id": req.HotelId})
+	if err != nil {
+		return nil, err
+	}
+
+	s.forgetProfile(req.HotelId)
+	if err := s.refreshSuggestions(); err != nil {
+		log.Println("Failed refresh suggestion index: ", err)
+	}
+	return new(pb.ProfileResult), nil
+}
+
+// RetireProfile marks the profile of a decommissioned hotel retired, which
+// keeps it for the stays booked there but drops it from the suggestions.
+func (s *Server) RetireProfile(ctx context.Context, req *pb.DeleteProfileRequest) (*pb.ProfileResult, error) {
+	if req.HotelId == "" {
+		return nil, status.Error(codes.InvalidArgument, "hotel id must be set")
+	}
+
+	session := s.MongoSession.Copy()
+	defer session.Close()
+
+	_, err := session.DB("profile-db").C("hotels").UpdateAll(
+		bson.M{"id": req.HotelId},
+		bson.M{"$set": bson.M{"retired": true}},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.refreshSuggestions(); err != nil {
+		log.Println("Failed refresh suggestion index: ", err)
+	}
+	return new(pb.ProfileResult), nil
+}
+
+// forgetProfile drops the cached profile of a hotel.
+func (s *Server) forgetProfile(hotelId string) {
+	if err := s.MemcClient.Delete(hotelId); err != nil && err != memcache.ErrCacheMiss {
+		log.Println("Failed drop cached profile: ", err)
+	}
+}
@@ -0,0 +1,16 @@
+package profile
+
+import (
+	"github.com/harlow/go-micro-services/rbac"
+)
+
+// self is the identity the profile service calls other services with.
+var self = &rbac.Identity{Subject: name, Role: rbac.Service}
+
+// policy is who may call the profile service. Guests score the hotels
+// they stayed at.
+var policy = rbac.Policy{
+	"/profile.Profile/GetProfiles": {Public: true},
+	"/profile.Profile/Suggest":     {Public: true},
+	"/profile.Profile/UpdateScore": {Roles: []rbac.Role{rbac.Guest}},
+}
@@ -1,11 +1,11 @@
 // Code generated by protoc-gen-go. DO NOT EDIT.
-// source: profile.proto
+// source: services/profile/proto/profile.proto
 
 /*
 Package profile is a generated protocol buffer package.
 
 It is generated from these files:
-	profile.proto
+	services/profile/proto/profile.proto
 
 It has these top-level messages:
 	Request
@@ -15,6 +15,14 @@ It has these top-level messages:
 	Image
 	ScoreRequest
 	ScoreResult
+	SuggestRequest
+	SuggestResult
+	Suggestion
+	RefreshRequest
+	RefreshResult
+	UpdateProfileRequest
+	DeleteProfileRequest
+	ProfileResult
 */
 package profile
 
@@ -38,9 +46,12 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
 
+// background requests, such as listings and cache refreshes, are not
+// counted as views of the hotels.
 type Request struct {
-	HotelIds []string `protobuf:"bytes,1,rep,name=hotelIds" json:"hotelIds,omitempty"`
-	Locale   string   `protobuf:"bytes,2,opt,name=locale" json:"locale,omitempty"`
+	HotelIds   []string `protobuf:"bytes,1,rep,name=hotelIds" json:"hotelIds,omitempty"`
+	Locale     string   `protobuf:"bytes,2,opt,name=locale" json:"locale,omitempty"`
+	Background bool     `protobuf:"varint,3,opt,name=background" json:"background,omitempty"`
 }
 
 func (m *Request) Reset()                    { *m = Request{} }
@@ -62,6 +73,13 @@ func (m *Request) GetLocale() string {
 	return ""
 }
 
+func (m *Request) GetBackground() bool {
+	if m != nil {
+		return m.Background
+	}
+	return false
+}
+
 type Result struct {
 	Hotels []*Hotel `protobuf:"bytes,1,rep,name=hotels" json:"hotels,omitempty"`
 }
@@ -294,6 +312,193 @@ func (m *ScoreResult) GetCorrect() bool {
 	return false
 }
 
+type SuggestRequest struct {
+	Prefix string `protobuf:"bytes,1,opt,name=prefix" json:"prefix,omitempty"`
+	Limit  int32  `protobuf:"varint,2,opt,name=limit" json:"limit,omitempty"`
+}
+
+func (m *SuggestRequest) Reset()                    { *m = SuggestRequest{} }
+func (m *SuggestRequest) String() string            { return proto.CompactTextString(m) }
+func (*SuggestRequest) ProtoMessage()               {}
+func (*SuggestRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
+
+func (m *SuggestRequest) GetPrefix() string {
+	if m != nil {
+		return m.Prefix
+	}
+	return ""
+}
+
+func (m *SuggestRequest) GetLimit() int32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+type SuggestResult struct {
+	Suggestions []*Suggestion `protobuf:"bytes,1,rep,name=suggestions" json:"suggestions,omitempty"`
+}
+
+func (m *SuggestResult) Reset()                    { *m = SuggestResult{} }
+func (m *SuggestResult) String() string            { return proto.CompactTextString(m) }
+func (*SuggestResult) ProtoMessage()               {}
+func (*SuggestResult) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
+
+func (m *SuggestResult) GetSuggestions() []*Suggestion {
+	if m != nil {
+		return m.Suggestions
+	}
+	return nil
+}
+
+// A suggestion is a city, a neighborhood or a hotel. Hotel suggestions
+// carry the hotel id, area suggestions the centroid of their hotels.
+type Suggestion struct {
+	Kind       string  `protobuf:"bytes,1,opt,name=kind" json:"kind,omitempty"`
+	Text       string  `protobuf:"bytes,2,opt,name=text" json:"text,omitempty"`
+	HotelId    string  `protobuf:"bytes,3,opt,name=hotelId" json:"hotelId,omitempty"`
+	Lat        float32 `protobuf:"fixed32,4,opt,name=lat" json:"lat,omitempty"`
+	Lon        float32 `protobuf:"fixed32,5,opt,name=lon" json:"lon,omitempty"`
+	HotelCount int32   `protobuf:"varint,6,opt,name=hotelCount" json:"hotelCount,omitempty"`
+}
+
+func (m *Suggestion) Reset()                    { *m = Suggestion{} }
+func (m *Suggestion) String() string            { return proto.CompactTextString(m) }
+func (*Suggestion) ProtoMessage()               {}
+func (*Suggestion) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
+
+func (m *Suggestion) GetKind() string {
+	if m != nil {
+		return m.Kind
+	}
+	return ""
+}
+
+func (m *Suggestion) GetText() string {
+	if m != nil {
+		return m.Text
+	}
+	return ""
+}
+
+func (m *Suggestion) GetHotelId() string {
+	if m != nil {
+		return m.HotelId
+	}
+	return ""
+}
+
+func (m *Suggestion) GetLat() float32 {
+	if m != nil {
+		return m.Lat
+	}
+	return 0
+}
+
+func (m *Suggestion) GetLon() float32 {
+	if m != nil {
+		return m.Lon
+	}
+	return 0
+}
+
+func (m *Suggestion) GetHotelCount() int32 {
+	if m != nil {
+		return m.HotelCount
+	}
+	return 0
+}
+
+type RefreshRequest struct {
+}
+
+func (m *RefreshRequest) Reset()                    { *m = RefreshRequest{} }
+func (m *RefreshRequest) String() string            { return proto.CompactTextString(m) }
+func (*RefreshRequest) ProtoMessage()               {}
+func (*RefreshRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
+
+type RefreshResult struct {
+	Correct bool  `protobuf:"varint,1,opt,name=correct" json:"correct,omitempty"`
+	Entries int32 `protobuf:"varint,2,opt,name=entries" json:"entries,omitempty"`
+}
+
+func (m *RefreshResult) Reset()                    { *m = RefreshResult{} }
+func (m *RefreshResult) String() string            { return proto.CompactTextString(m) }
+func (*RefreshResult) ProtoMessage()               {}
+func (*RefreshResult) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
+
+func (m *RefreshResult) GetCorrect() bool {
+	if m != nil {
+		return m.Correct
+	}
+	return false
+}
+
+func (m *RefreshResult) GetEntries() int32 {
+	if m != nil {
+		return m.Entries
+	}
+	return 0
+}
+
+// target is the stored name of the field, e.g. "address.city".
+type UpdateProfileRequest struct {
+	HotelId string `protobuf:"bytes,1,opt,name=hotelId" json:"hotelId,omitempty"`
+	Target  string `protobuf:"bytes,2,opt,name=target" json:"target,omitempty"`
+	Content string `protobuf:"bytes,3,opt,name=content" json:"content,omitempty"`
+}
+
+func (m *UpdateProfileRequest) Reset()                    { *m = UpdateProfileRequest{} }
+func (m *UpdateProfileRequest) String() string            { return proto.CompactTextString(m) }
+func (*UpdateProfileRequest) ProtoMessage()               {}
+func (*UpdateProfileRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
+
+func (m *UpdateProfileRequest) GetHotelId() string {
+	if m != nil {
+		return m.HotelId
+	}
+	return ""
+}
+
+func (m *UpdateProfileRequest) GetTarget() string {
+	if m != nil {
+		return m.Target
+	}
+	return ""
+}
+
+func (m *UpdateProfileRequest) GetContent() string {
+	if m != nil {
+		return m.Content
+	}
+	return ""
+}
+
+type DeleteProfileRequest struct {
+	HotelId string `protobuf:"bytes,1,opt,name=hotelId" json:"hotelId,omitempty"`
+}
+
+func (m *DeleteProfileRequest) Reset()                    { *m = DeleteProfileRequest{} }
+func (m *DeleteProfileRequest) String() string            { return proto.CompactTextString(m) }
+func (*DeleteProfileRequest) ProtoMessage()               {}
+func (*DeleteProfileRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
+
+func (m *DeleteProfileRequest) GetHotelId() string {
+	if m != nil {
+		return m.HotelId
+	}
+	return ""
+}
+
+type ProfileResult struct {
+}
+
+func (m *ProfileResult) Reset()                    { *m = ProfileResult{} }
+func (m *ProfileResult) String() string            { return proto.CompactTextString(m) }
+func (*ProfileResult) ProtoMessage()               {}
+func (*ProfileResult) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
+
 func init() {
 	proto.RegisterType((*Request)(nil), "profile.Request")
 	proto.RegisterType((*Result)(nil), "profile.Result")
@@ -302,6 +507,14 @@ func init() {
 	proto.RegisterType((*Image)(nil), "profile.Image")
 	proto.RegisterType((*ScoreRequest)(nil), "profile.ScoreRequest")
 	proto.RegisterType((*ScoreResult)(nil), "profile.ScoreResult")
+	proto.RegisterType((*SuggestRequest)(nil), "profile.SuggestRequest")
+	proto.RegisterType((*SuggestResult)(nil), "profile.SuggestResult")
+	proto.RegisterType((*Suggestion)(nil), "profile.Suggestion")
+	proto.RegisterType((*RefreshRequest)(nil), "profile.RefreshRequest")
+	proto.RegisterType((*RefreshResult)(nil), "profile.RefreshResult")
+	proto.RegisterType((*UpdateProfileRequest)(nil), "profile.UpdateProfileRequest")
+	proto.RegisterType((*DeleteProfileRequest)(nil), "profile.DeleteProfileRequest")
+	proto.RegisterType((*ProfileResult)(nil), "profile.ProfileResult")
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -317,6 +530,19 @@ const _ = grpc.SupportPackageIsVersion4
 type ProfileClient interface {
 	GetProfiles(ctx context.Context, in *Request, opts ...grpc.CallOption) (*Result, error)
 	UpdateScore(ctx context.Context, in *ScoreRequest, opts ...grpc.CallOption) (*ScoreResult, error)
+	// Suggest returns ranked destinations and hotels whose names start with a prefix
+	Suggest(ctx context.Context, in *SuggestRequest, opts ...grpc.CallOption) (*SuggestResult, error)
+	// RefreshSuggestions rebuilds the suggestion index from the hotel profiles
+	RefreshSuggestions(ctx context.Context, in *RefreshRequest, opts ...grpc.CallOption) (*RefreshResult, error)
+	// CreateProfile stores the profile of a new hotel
+	CreateProfile(ctx context.Context, in *Hotel, opts ...grpc.CallOption) (*ProfileResult, error)
+	// UpdateProfile sets a field of the profile of a hotel
+	UpdateProfile(ctx context.Context, in *UpdateProfileRequest, opts ...grpc.CallOption) (*ProfileResult, error)
+	// DeleteProfile removes the profile of a hotel
+	DeleteProfile(ctx context.Context, in *DeleteProfileRequest, opts ...grpc.CallOption) (*ProfileResult, error)
+	// RetireProfile keeps the profile of a decommissioned hotel for its past
+	// stays but drops it from the suggestions
+	RetireProfile(ctx context.Context, in *DeleteProfileRequest, opts ...grpc.CallOption) (*ProfileResult, error)
 }
 
 type profileClient struct {
@@ -345,11 +571,78 @@ func (c *profileClient) UpdateScore(ctx context.Context, in *ScoreRequest, opts
 	return out, nil
 }
 
+func (c *profileClient) Suggest(ctx context.Context, in *SuggestRequest, opts ...grpc.CallOption) (*SuggestResult, error) {
+	out := new(SuggestResult)
+	err := grpc.Invoke(ctx, "/profile.Profile/Suggest", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *profileClient) RefreshSuggestions(ctx context.Context, in *RefreshRequest, opts ...grpc.CallOption) (*RefreshResult, error) {
+	out := new(RefreshResult)
+	err := grpc.Invoke(ctx, "/profile.Profile/RefreshSuggestions", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *profileClient) CreateProfile(ctx context.Context, in *Hotel, opts ...grpc.CallOption) (*ProfileResult, error) {
+	out := new(ProfileResult)
+	err := grpc.Invoke(ctx, "/profile.Profile/CreateProfile", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *profileClient) UpdateProfile(ctx context.Context, in *UpdateProfileRequest, opts ...grpc.CallOption) (*ProfileResult, error) {
+	out := new(ProfileResult)
+	err := grpc.Invoke(ctx, "/profile.Profile/UpdateProfile", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *profileClient) DeleteProfile(ctx context.Context, in *DeleteProfileRequest, opts ...grpc.CallOption) (*ProfileResult, error) {
+	out := new(ProfileResult)
+	err := grpc.Invoke(ctx, "/profile.Profile/DeleteProfile", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *profileClient) RetireProfile(ctx context.Context, in *DeleteProfileRequest, opts ...grpc.CallOption) (*ProfileResult, error) {
+	out := new(ProfileResult)
+	err := grpc.Invoke(ctx, "/profile.Profile/RetireProfile", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for Profile service
 
 type ProfileServer interface {
 	GetProfiles(context.Context, *Request) (*Result, error)
 	UpdateScore(context.Context, *ScoreRequest) (*ScoreResult, error)
+	// Suggest returns ranked destinations and hotels whose names start with a prefix
+	Suggest(context.Context, *SuggestRequest) (*SuggestResult, error)
+	// RefreshSuggestions rebuilds the suggestion index from the hotel profiles
+	RefreshSuggestions(context.Context, *RefreshRequest) (*RefreshResult, error)
+	// CreateProfile stores the profile of a new hotel
+	CreateProfile(context.Context, *Hotel) (*ProfileResult, error)
+	// UpdateProfile sets a field of the profile of a hotel
+	UpdateProfile(context.Context, *UpdateProfileRequest) (*ProfileResult, error)
+	// DeleteProfile removes the profile of a hotel
+	DeleteProfile(context.Context, *DeleteProfileRequest) (*ProfileResult, error)
+	// RetireProfile keeps the profile of a decommissioned hotel for its past
+	// stays but drops it from the suggestions
+	RetireProfile(context.Context, *DeleteProfileRequest) (*ProfileResult, error)
 }
 
 func RegisterProfileServer(s *grpc.Server, srv ProfileServer) {
@@ -392,6 +685,114 @@ func _Profile_UpdateScore_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Profile_Suggest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SuggestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProfileServer).Suggest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/profile.Profile/Suggest",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProfileServer).Suggest(ctx, req.(*SuggestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Profile_RefreshSuggestions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RefreshRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProfileServer).RefreshSuggestions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/profile.Profile/RefreshSuggestions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProfileServer).RefreshSuggestions(ctx, req.(*RefreshRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Profile_CreateProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Hotel)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProfileServer).CreateProfile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/profile.Profile/CreateProfile",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProfileServer).CreateProfile(ctx, req.(*Hotel))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Profile_UpdateProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateProfileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProfileServer).UpdateProfile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/profile.Profile/UpdateProfile",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProfileServer).UpdateProfile(ctx, req.(*UpdateProfileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Profile_DeleteProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteProfileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProfileServer).DeleteProfile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/profile.Profile/DeleteProfile",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProfileServer).DeleteProfile(ctx, req.(*DeleteProfileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Profile_RetireProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteProfileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProfileServer).RetireProfile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/profile.Profile/RetireProfile",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProfileServer).RetireProfile(ctx, req.(*DeleteProfileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Profile_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "profile.Profile",
 	HandlerType: (*ProfileServer)(nil),
@@ -404,43 +805,85 @@ var _Profile_serviceDesc = grpc.ServiceDesc{
 			MethodName: "UpdateScore",
 			Handler:    _Profile_UpdateScore_Handler,
 		},
+		{
+			MethodName: "Suggest",
+			Handler:    _Profile_Suggest_Handler,
+		},
+		{
+			MethodName: "RefreshSuggestions",
+			Handler:    _Profile_RefreshSuggestions_Handler,
+		},
+		{
+			MethodName: "CreateProfile",
+			Handler:    _Profile_CreateProfile_Handler,
+		},
+		{
+			MethodName: "UpdateProfile",
+			Handler:    _Profile_UpdateProfile_Handler,
+		},
+		{
+			MethodName: "DeleteProfile",
+			Handler:    _Profile_DeleteProfile_Handler,
+		},
+		{
+			MethodName: "RetireProfile",
+			Handler:    _Profile_RetireProfile_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
-	Metadata: "profile.proto",
+	Metadata: "services/profile/proto/profile.proto",
 }
 
-func init() { proto.RegisterFile("profile.proto", fileDescriptor0) }
+func init() { proto.RegisterFile("services/profile/proto/profile.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 471 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x5c, 0x93, 0xc1, 0x6e, 0xd4, 0x30,
-	0x10, 0x86, 0x95, 0x6c, 0x93, 0xec, 0x4e, 0x4a, 0xa9, 0xac, 0x82, 0xac, 0x3d, 0xa0, 0x28, 0x07,
-	0x88, 0x38, 0x54, 0xd5, 0xf6, 0x86, 0x04, 0x12, 0xe2, 0x00, 0xbd, 0x20, 0x64, 0xe0, 0x01, 0xd2,
-	0x64, 0x4a, 0x2d, 0x79, 0xe3, 0x60, 0x3b, 0x42, 0x7d, 0x0a, 0x9e, 0x8e, 0xf7, 0x41, 0x1e, 0x3b,
-	0xbb, 0x69, 0x6f, 0xf3, 0x7f, 0xe3, 0xf1, 0xcc, 0xfc, 0x71, 0xe0, 0xd9, 0x68, 0xf4, 0x9d, 0x54,
-	0x78, 0x39, 0x1a, 0xed, 0x34, 0x2b, 0xa2, 0xac, 0xdf, 0x43, 0x21, 0xf0, 0xf7, 0x84, 0xd6, 0xb1,
-	0x2d, 0xac, 0xef, 0xb5, 0x43, 0x75, 0xd3, 0x5b, 0x9e, 0x54, 0xab, 0x66, 0x23, 0x0e, 0x9a, 0xbd,
-	0x84, 0x5c, 0xe9, 0xae, 0x55, 0xc8, 0xd3, 0x2a, 0x69, 0x36, 0x22, 0xaa, 0xfa, 0x0a, 0x72, 0x81,
-	0x76, 0x52, 0x8e, 0xbd, 0x86, 0x9c, 0x4e, 0x87, 0xda, 0x72, 0x77, 0x76, 0x39, 0x77, 0xfc, 0xe2,
-	0xb1, 0x88, 0xd9, 0xfa, 0x6f, 0x0a, 0x19, 0x11, 0x76, 0x06, 0xa9, 0xec, 0x79, 0x42, 0xf7, 0xa5,
-	0xb2, 0x67, 0x0c, 0x4e, 0x86, 0x76, 0x3f, 0x77, 0xa0, 0x98, 0x55, 0x50, 0x8e, 0xf7, 0x7a, 0xc0,
-	0xaf, 0xd3, 0xfe, 0x16, 0x0d, 0x5f, 0x51, 0x6a, 0x89, 0xfc, 0x89, 0x1e, 0x6d, 0x67, 0xe4, 0xe8,
-	0xa4, 0x1e, 0xf8, 0x49, 0x38, 0xb1, 0x40, 0xec, 0x2d, 0x14, 0x6d, 0xdf, 0x1b, 0xb4, 0x96, 0x67,
-	0x55, 0xd2, 0x94, 0xbb, 0xf3, 0xc3, 0x68, 0x1f, 0x03, 0x17, 0xf3, 0x01, 0xbf, 0x85, 0xdc, 0xb7,
-	0xbf, 0xd0, 0xf2, 0xfc, 0xc9, 0x16, 0x37, 0x1e, 0x8b, 0x98, 0x65, 0x17, 0x90, 0x8d, 0x46, 0x76,
-	0xc8, 0x8b, 0x2a, 0x69, 0x52, 0x11, 0x84, 0xa7, 0xb6, 0xd3, 0x06, 0xf9, 0x3a, 0x50, 0x12, 0xec,
-	0x15, 0x00, 0x05, 0x3f, 0xe4, 0x1e, 0x2d, 0xdf, 0x54, 0x49, 0x93, 0x89, 0x05, 0xa9, 0xff, 0x25,
-	0x50, 0xc4, 0x41, 0x58, 0x0d, 0xa7, 0xd6, 0x19, 0x44, 0x17, 0x17, 0x0e, 0xee, 0x3c, 0x62, 0x74,
-	0x5f, 0xd0, 0x47, 0xb7, 0x16, 0xc4, 0xfb, 0xd8, 0x49, 0xf7, 0x10, 0xcd, 0xa2, 0x98, 0x26, 0x73,
-	0xad, 0xc3, 0xe8, 0x4f, 0x10, 0x8c, 0x43, 0xd1, 0xe9, 0x69, 0x70, 0xe6, 0x81, 0x9c, 0xd9, 0x88,
-	0x59, 0xfa, 0x1e, 0xa3, 0xb6, 0xae, 0x55, 0x9f, 0x74, 0x8f, 0x3c, 0x0f, 0x3d, 0x8e, 0x84, 0x9d,
-	0xc3, 0x4a, 0xb5, 0x2e, 0x6e, 0xef, 0x43, 0x22, 0x7a, 0x88, 0x9b, 0xfb, 0xb0, 0xbe, 0x86, 0x8c,
-	0x4c, 0xf3, 0xa9, 0xc9, 0xa8, 0xb8, 0x8b, 0x0f, 0x7d, 0xe3, 0x1e, 0xef, 0xda, 0x49, 0x39, 0x9a,
-	0x7f, 0x2d, 0x66, 0x59, 0x7f, 0x80, 0xd3, 0xef, 0xde, 0x9a, 0xf9, 0x51, 0x72, 0x28, 0xe2, 0x23,
-	0x8c, 0xf5, 0xb3, 0x3c, 0x9a, 0x9d, 0x2e, 0xcc, 0xae, 0xdf, 0x40, 0x19, 0xeb, 0xe9, 0x55, 0xd2,
-	0x86, 0xc6, 0x60, 0xe7, 0xa8, 0x7c, 0x2d, 0x66, 0xb9, 0xfb, 0x03, 0xc5, 0xb7, 0xf0, 0x69, 0xd9,
-	0x15, 0x94, 0x9f, 0xd1, 0x45, 0x65, 0xd9, 0xf1, 0x79, 0xc4, 0x21, 0xb6, 0xcf, 0x17, 0x84, 0xae,
-	0x7d, 0x07, 0xe5, 0xcf, 0xb1, 0x6f, 0x1d, 0x52, 0x2f, 0xf6, 0xe2, 0x90, 0x5f, 0xce, 0xbe, 0xbd,
-	0x78, 0x8a, 0x7d, 0xed, 0x6d, 0x4e, 0x7f, 0xe0, 0xf5, 0xff, 0x00, 0x00, 0x00, 0xff, 0xff, 0xc2,
-	0x3d, 0xf4, 0x1b, 0x92, 0x03, 0x00, 0x00,
+	// 757 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x55, 0xcd, 0x6e, 0xdb, 0x46,
+	0x10, 0x06, 0x25, 0x91, 0x94, 0x46, 0x96, 0x6c, 0x6c, 0x55, 0x95, 0x10, 0xd0, 0x42, 0x58, 0x14,
+	0xad, 0xd0, 0x83, 0x6b, 0xd8, 0x28, 0x0a, 0xf4, 0x60, 0xa0, 0x50, 0xeb, 0xd6, 0x97, 0x20, 0x58,
+	0x27, 0xc7, 0x1c, 0x28, 0x72, 0x24, 0x2f, 0x4c, 0x91, 0xca, 0xee, 0x32, 0xb0, 0x9f, 0x21, 0x87,
+	0x00, 0x79, 0xb7, 0xbc, 0x4f, 0xb0, 0xcb, 0x5d, 0x92, 0x92, 0x0d, 0x27, 0x40, 0x4e, 0x9e, 0xef,
+	0x9b, 0x9d, 0xbf, 0x6f, 0x46, 0x34, 0xfc, 0x2c, 0x51, 0xbc, 0xe3, 0x09, 0xca, 0xdf, 0x77, 0xa2,
+	0x58, 0xf3, 0x0c, 0xf5, 0x5f, 0x55, 0x38, 0x74, 0x6a, 0x10, 0x09, 0x2d, 0xa4, 0x6f, 0x20, 0x64,
+	0xf8, 0xb6, 0x44, 0xa9, 0xc8, 0x0c, 0xfa, 0xb7, 0x85, 0xc2, 0xec, 0x3a, 0x95, 0x91, 0x37, 0xef,
+	0x2e, 0x06, 0xac, 0xc6, 0x64, 0x0a, 0x41, 0x56, 0x24, 0x71, 0x86, 0x51, 0x67, 0xee, 0x2d, 0x06,
+	0xcc, 0x22, 0xf2, 0x13, 0xc0, 0x2a, 0x4e, 0xee, 0x36, 0xa2, 0x28, 0xf3, 0x34, 0xea, 0xce, 0xbd,
+	0x45, 0x9f, 0xb5, 0x18, 0x7a, 0x06, 0x01, 0x43, 0x59, 0x66, 0x8a, 0xfc, 0x02, 0x81, 0xc9, 0x56,
+	0xe5, 0x1e, 0x9e, 0x8f, 0x4f, 0x5d, 0x47, 0xff, 0x6b, 0x9a, 0x59, 0x2f, 0xfd, 0xd0, 0x01, 0xdf,
+	0x30, 0x64, 0x0c, 0x1d, 0x9e, 0x46, 0x9e, 0xa9, 0xd7, 0xe1, 0x29, 0x21, 0xd0, 0xcb, 0xe3, 0xad,
+	0xeb, 0xc0, 0xd8, 0x64, 0x0e, 0xc3, 0xdd, 0x6d, 0x91, 0xe3, 0x8b, 0x72, 0xbb, 0x42, 0x61, 0x1a,
+	0x18, 0xb0, 0x36, 0xa5, 0x5f, 0xa4, 0x28, 0x13, 0xc1, 0x77, 0x8a, 0x17, 0x79, 0xd4, 0xab, 0x5e,
+	0xb4, 0x28, 0xf2, 0x1b, 0x84, 0x71, 0x9a, 0x0a, 0x94, 0x32, 0xf2, 0xe7, 0xde, 0x62, 0x78, 0x7e,
+	0x52, 0xb7, 0xf6, 0x77, 0xc5, 0x33, 0xf7, 0x40, 0x4f, 0xc1, 0xb7, 0xf1, 0x06, 0x65, 0x14, 0x1c,
+	0x4c, 0x71, 0xad, 0x69, 0x66, 0xbd, 0x64, 0x02, 0xfe, 0x4e, 0xf0, 0x04, 0xa3, 0x70, 0xee, 0x2d,
+	0x3a, 0xac, 0x02, 0x9a, 0x95, 0x49, 0x21, 0x30, 0xea, 0x57, 0xac, 0x01, 0x5a, 0x43, 0x63, 0xbc,
+	0xe2, 0x5b, 0x94, 0xd1, 0x60, 0xee, 0x2d, 0x7c, 0xd6, 0x62, 0xe8, 0x27, 0x0f, 0x42, 0xdb, 0x08,
+	0xa1, 0x70, 0x24, 0x95, 0x40, 0x54, 0x76, 0xe0, 0x4a, 0x9d, 0x3d, 0xce, 0xe4, 0xab, 0x70, 0xa3,
+	0x56, 0x8b, 0xd1, 0x3a, 0x26, 0x5c, 0x3d, 0x58, 0xb1, 0x8c, 0x6d, 0x3a, 0x53, 0xb1, 0x42, 0xab,
+	0x4f, 0x05, 0x48, 0x04, 0x61, 0x52, 0x94, 0xb9, 0x12, 0x0f, 0x46, 0x99, 0x01, 0x73, 0x50, 0xd7,
+	0xd8, 0x15, 0x52, 0xc5, 0xd9, 0xb2, 0x48, 0x31, 0x0a, 0xaa, 0x1a, 0x0d, 0x43, 0x4e, 0xa0, 0x9b,
+	0xc5, 0xca, 0x4e, 0xaf, 0x4d, 0xc3, 0x14, 0xb9, 0x9d, 0x5c, 0x9b, 0xf4, 0x02, 0x7c, 0x23, 0x9a,
+	0x76, 0x95, 0x22, 0xb3, 0xb3, 0x68, 0x53, 0x17, 0x4e, 0x71, 0x1d, 0x97, 0x99, 0x32, 0xfd, 0xf7,
+	0x99, 0x83, 0xf4, 0x12, 0x8e, 0x6e, 0xb4, 0x34, 0xee, 0x68, 0x23, 0x08, 0xed, 0x91, 0xda, 0x78,
+	0x07, 0x1b, 0xb1, 0x3b, 0x2d, 0xb1, 0xe9, 0xaf, 0x30, 0xb4, 0xf1, 0xe6, 0x2a, 0xcd, 0x84, 0x42,
+	0x60, 0xa2, 0x4c, 0x78, 0x9f, 0x39, 0x48, 0x2f, 0x61, 0x7c, 0x53, 0x6e, 0x36, 0x28, 0x95, 0x2b,
+	0x35, 0x85, 0x60, 0x27, 0x70, 0xcd, 0xef, 0x6d, 0x25, 0x8b, 0x74, 0xa1, 0x8c, 0x6f, 0x79, 0xd5,
+	0xaa, 0xcf, 0x2a, 0x40, 0xaf, 0x60, 0x54, 0xc7, 0x9b, 0x52, 0x7f, 0xc0, 0x50, 0x56, 0x04, 0x2f,
+	0x72, 0xf7, 0x2b, 0xf8, 0xae, 0xbe, 0x9f, 0x9b, 0xda, 0xc7, 0xda, 0xef, 0xe8, 0x47, 0x0f, 0xa0,
+	0xf1, 0xe9, 0xe5, 0xdd, 0xf1, 0xdc, 0x0d, 0x6b, 0x6c, 0xcd, 0x29, 0xbc, 0x57, 0xee, 0x87, 0xa1,
+	0xed, 0xb6, 0x2e, 0xdd, 0x7d, 0x5d, 0xec, 0x6a, 0x7a, 0x8f, 0x56, 0xe3, 0xd7, 0xab, 0xd1, 0xeb,
+	0x35, 0xcf, 0x97, 0x7a, 0xdd, 0x66, 0xbd, 0x3e, 0x6b, 0x31, 0xf4, 0x04, 0xc6, 0x0c, 0xd7, 0x02,
+	0xe5, 0xad, 0x15, 0x87, 0x2e, 0x61, 0x54, 0x33, 0xcf, 0x2b, 0xab, 0x3d, 0x98, 0x2b, 0xc1, 0x51,
+	0x5a, 0xc5, 0x1c, 0xa4, 0x2b, 0x98, 0xbc, 0xde, 0xa5, 0xb1, 0xc2, 0x97, 0x95, 0x28, 0x5f, 0x5e,
+	0xf2, 0x14, 0x02, 0x15, 0x8b, 0x0d, 0xba, 0xe1, 0x2d, 0xaa, 0xaa, 0xe7, 0x0a, 0x73, 0xe5, 0xc6,
+	0xb7, 0x90, 0x9e, 0xc1, 0xe4, 0x1f, 0xcc, 0xf0, 0xeb, 0x6b, 0xd0, 0x63, 0x18, 0xd5, 0x6f, 0xf5,
+	0x68, 0xe7, 0xef, 0x7b, 0x10, 0x5a, 0x86, 0x9c, 0xc1, 0xf0, 0x3f, 0x54, 0x16, 0x49, 0xd2, 0x7c,
+	0x3a, 0x6c, 0xde, 0xd9, 0x71, 0x8b, 0x31, 0xc2, 0xfc, 0x05, 0xc3, 0x6a, 0x48, 0x73, 0x87, 0xe4,
+	0xfb, 0xe6, 0x02, 0x5a, 0x77, 0x3d, 0x9b, 0x1c, 0xd2, 0x36, 0x36, 0xb4, 0xb7, 0x40, 0x7e, 0x38,
+	0xbc, 0x1c, 0x17, 0x39, 0x7d, 0xec, 0x30, 0xb1, 0xff, 0x02, 0xb1, 0x1b, 0x6a, 0xce, 0x49, 0xb6,
+	0xd2, 0xec, 0x2f, 0x74, 0x36, 0x7d, 0xec, 0x30, 0x69, 0xfe, 0x84, 0xd1, 0x52, 0x60, 0xb3, 0x23,
+	0x72, 0xf0, 0x21, 0x6f, 0x05, 0xee, 0xa9, 0x46, 0xae, 0x60, 0xb4, 0xb7, 0x5c, 0xf2, 0x63, 0xfd,
+	0xf0, 0xa9, 0xa5, 0x3f, 0x97, 0x67, 0x6f, 0x81, 0xad, 0x3c, 0x4f, 0x2d, 0xf6, 0xb9, 0x3c, 0x0c,
+	0x15, 0x17, 0xdf, 0x98, 0x67, 0x15, 0x98, 0xff, 0xa8, 0x17, 0x9f, 0x07, 0x00, 0x65, 0x77, 0x63,
+	0x03, 0x79, 0x07, 0x00, 0x00,
 }
@@ -9,11 +9,16 @@ import (
 	"log"
 	"net"
 	// "os"
+	"sync"
 	"time"
 
 	"github.com/grpc-ecosystem/grpc-opentracing/go/otgrpc"
+	"github.com/harlow/go-micro-services/activity"
+	"github.com/harlow/go-micro-services/dialer"
+	"github.com/harlow/go-micro-services/rbac"
 	"github.com/harlow/go-micro-services/registry"
 	pb "github.com/harlow/go-micro-services/services/profile/proto"
+	recommendation "github.com/harlow/go-micro-services/services/recommendation/proto"
 	"github.com/opentracing/opentracing-go"
 	"golang.org/x/net/context"
 	"google.golang.org/grpc"
@@ -23,7 +28,11 @@ import (
 	// "strings"
 )
 
-const name = "srv-profile"
+const (
+	name = "srv-profile"
+
+	suggestRefreshInterval = 5 * time.Minute
+)
 
 // Server implements the profile service
 type Server struct {
@@ -32,7 +41,14 @@ type Server struct {
 	IpAddr	 string
 	MongoSession	*mgo.Session
 	Registry *registry.Client
+	// Identity signs and verifies the identities calls are made by
+	Identity *rbac.Signer
 	MemcClient *memcache.Client
+
+	suggestMu   sync.RWMutex
+	suggestions *suggestIndex
+
+	recommendationClient recommendation.RecommendationClient
 }
 
 // Run starts the server
@@ -41,8 +57,17 @@ func (s *Server) Run() error {
 		return fmt.Errorf("server port must be set")
 	}
 
+	if s.Identity == nil {
+		return fmt.Errorf("identity keys must be set")
+	}
+
 	// fmt.Printf("in run s.IpAddr = %s, port = %d\n", s.IpAddr, s.Port)
 
+	if err := s.refreshSuggestions(); err != nil {
+		log.Println("Failed build suggestion index: ", err)
+	}
+	go s.refreshSuggestionsLoop()
+
 	srv := grpc.NewServer(
 		grpc.KeepaliveParams(keepalive.ServerParameters{
 			Timeout: 120 * time.Second,
@@ -50,13 +75,19 @@ func (s *Server) Run() error {
 		grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
 			PermitWithoutStream: true,
 		}),
-		grpc.UnaryInterceptor(
+		grpc.UnaryInterceptor(rbac.ChainUnaryServer(
 			otgrpc.OpenTracingServerInterceptor(s.Tracer),
-		),
+			rbac.UnaryServerInterceptor(s.Identity, policy),
+		)),
 	)
 
 	pb.RegisterProfileServer(srv, s)
 
+	// init grpc clients
+	if err := s.initRecommendationClient("srv-recommendation"); err != nil {
+		return err
+	}
+
 	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", s.Port))
 	if err != nil {
 		log.Fatalf("failed to listen: %v", err)
@@ -88,6 +119,20 @@ func (s *Server) Shutdown() {
 	s.Registry.Deregister(name)
 }
 
+func (s *Server) initRecommendationClient(name string) error {
+	conn, err := dialer.Dial(
+		name,
+		dialer.WithTracer(s.Tracer),
+		dialer.WithBalancer(s.Registry.Client),
+		dialer.WithIdentity(s.Identity, self),
+	)
+	if err != nil {
+		return fmt.Errorf("dialer error: %v", err)
+	}
+	s.recommendationClient = recommendation.NewRecommendationClient(conn)
+	return nil
+}
+
 // GetProfiles returns hotel profiles for requested IDs
 func (s *Server) GetProfiles(ctx context.Context, req *pb.Request) (*pb.Result, error) {
 	// session, err := mgo.Dial("mongodb-profile")
@@ -149,6 +194,9 @@ func (s *Server) GetProfiles(ctx context.Context, req *pb.Request) (*pb.Result,
 	}
 
 	res.Hotels = hotels
+	if !req.Background {
+		activity.Record(s.recommendationClient, recommendation.Activity_VIEW, req.HotelIds)
+	}
 	// fmt.Printf("In GetProfiles after getting resp\n")
 	return res, nil
 }
@@ -212,6 +260,72 @@ func (s *Server) UpdateScore(ctx context.Context, req *pb.ScoreRequest) (*pb.Sco
 	// write to memcached
 	s.MemcClient.Set(&memcache.Item{Key: hotel_id, Value: []byte(memc_str)})
 
+	// let "rate" recommendations see the new score
+	_, err = s.recommendationClient.Refresh(ctx, &recommendation.RefreshRequest{
+		HotelIds: []string{hotel_id},
+	})
+	if err != nil {
+		log.Println("Failed refresh recommendations: ", err)
+	}
+
 	res.Correct = true
 	return res, nil
-}
\ No newline at end of file
+}
+
+// Suggest returns ranked city, neighborhood and hotel names starting with the prefix
+func (s *Server) Suggest(ctx context.Context, req *pb.SuggestRequest) (*pb.SuggestResult, error) {
+	res := new(pb.SuggestResult)
+
+	limit := int(req.Limit)
+	if limit <= 0 {
+		limit = defaultSuggestLimit
+	}
+	if limit > maxSuggestLimit {
+		limit = maxSuggestLimit
+	}
+
+	s.suggestMu.RLock()
+	idx := s.suggestions
+	s.suggestMu.RUnlock()
+
+	if idx != nil {
+		res.Suggestions = idx.lookup(req.Prefix, limit)
+	}
+	return res, nil
+}
+
+// RefreshSuggestions rebuilds the suggestion index, e.g. after admins add hotels
+func (s *Server) RefreshSuggestions(ctx context.Context, req *pb.RefreshRequest) (*pb.RefreshResult, error) {
+	res := new(pb.RefreshResult)
+	if err := s.refreshSuggestions(); err != nil {
+		log.Println("Failed refresh suggestion index: ", err)
+		return res, nil
+	}
+
+	s.suggestMu.RLock()
+	res.Entries = int32(len(s.suggestions.entries))
+	s.suggestMu.RUnlock()
+	res.Correct = true
+	return res, nil
+}
+
+func (s *Server) refreshSuggestions() error {
+	idx, err := loadSuggestIndex(s.MongoSession)
+	if err != nil {
+		return err
+	}
+
+	s.suggestMu.Lock()
+	s.suggestions = idx
+	s.suggestMu.Unlock()
+	return nil
+}
+
+// refreshSuggestionsLoop picks up hotel changes made directly in mongodb
+func (s *Server) refreshSuggestionsLoop() {
+	for range time.Tick(suggestRefreshInterval) {
+		if err := s.refreshSuggestions(); err != nil {
+			log.Println("Failed refresh suggestion index: ", err)
+		}
+	}
+}
@@ -0,0 +1,242 @@
+package profile
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	pb "github.com/harlow/go-micro-services/services/profile/proto"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+const (
+	kindCity         = "city"
+	kindNeighborhood = "neighborhood"
+	kindHotel        = "hotel"
+
+	defaultSuggestLimit = 10
+	maxSuggestLimit     = 25
+)
+
+// kindRank orders suggestion kinds when two entries match equally well.
+var kindRank = map[string]int{
+	kindCity:         0,
+	kindNeighborhood: 1,
+	kindHotel:        2,
+}
+
+// suggestHotel is the subset of a hotel profile the suggestion index needs.
+type suggestHotel struct {
+	Id      string  `bson:"id"`
+	Name    string  `bson:"name"`
+	Score   float32 `bson:"score"`
+	Address struct {
+		StreetName string  `bson:"streetName"`
+		City       string  `bson:"city"`
+		State      string  `bson:"state"`
+		Lat        float32 `bson:"lat"`
+		Lon        float32 `bson:"lon"`
+	} `bson:"address"`
+}
+
+type suggestEntry struct {
+	suggestion *pb.Suggestion
+	key        string
+	rating     float32
+}
+
+type trieNode struct {
+	children map[rune]*trieNode
+	// entries whose key, or one of its words, ends at this node
+	entries []int
+}
+
+// suggestIndex is an immutable prefix trie over city, neighborhood and
+// hotel names. It is rebuilt from scratch and swapped in on refresh.
+type suggestIndex struct {
+	root    *trieNode
+	entries []suggestEntry
+}
+
+// loadSuggestIndex builds a suggestion index from the hotel profiles in mongodb.
+//
+// Suggestions are placed at the coordinates of the profile addresses, not
+// the locations in geo-db: onboarding writes the same coordinates to both,
+// and the frontend maps hotels at their profile addresses, so a suggestion
+// centers the map where the hotels it stands for are drawn. A hotel moved in
+// geo-db alone is suggested at its profile address until that is moved too.
+func loadSuggestIndex(session *mgo.Session) (*suggestIndex, error) {
+	s := session.Copy()
+	defer s.Close()
+	c := s.DB("profile-db").C("hotels")
+
+	// decommissioned hotels can not be booked, so are not suggested
+	var hotels []suggestHotel
+	if err := c.Find(bson.M{"retired": bson.M{"$ne": true}}).All(&hotels); err != nil {
+		return nil, err
+	}
+	return newSuggestIndex(hotels), nil
+}
+
+func newSuggestIndex(hotels []suggestHotel) *suggestIndex {
+	idx := &suggestIndex{root: &trieNode{}}
+
+	// areas are placed at the centroid of the hotels they contain
+	type area struct {
+		kind, text string
+		lat, lon   float64
+		count      int32
+	}
+	areas := make(map[string]*area)
+	addArea := func(kind, text string, h *suggestHotel) {
+		if strings.TrimSpace(text) == "" {
+			return
+		}
+		a, ok := areas[kind+"|"+text]
+		if !ok {
+			a = &area{kind: kind, text: text}
+			areas[kind+"|"+text] = a
+		}
+		a.lat += float64(h.Address.Lat)
+		a.lon += float64(h.Address.Lon)
+		a.count++
+	}
+
+	for i := range hotels {
+		h := &hotels[i]
+		idx.add(suggestEntry{
+			suggestion: &pb.Suggestion{
+				Kind:       kindHotel,
+				Text:       h.Name,
+				HotelId:    h.Id,
+				Lat:        h.Address.Lat,
+				Lon:        h.Address.Lon,
+				HotelCount: 1,
+			},
+			rating: h.Score,
+		})
+
+		city := h.Address.City
+		if city != "" && h.Address.State != "" {
+			city += ", " + h.Address.State
+		}
+		addArea(kindCity, city, h)
+		// profiles carry no district, so the street stands in for the neighborhood
+		if h.Address.StreetName != "" && h.Address.City != "" {
+			addArea(kindNeighborhood, h.Address.StreetName+", "+h.Address.City, h)
+		}
+	}
+
+	for _, a := range areas {
+		idx.add(suggestEntry{
+			suggestion: &pb.Suggestion{
+				Kind:       a.kind,
+				Text:       a.text,
+				Lat:        float32(a.lat / float64(a.count)),
+				Lon:        float32(a.lon / float64(a.count)),
+				HotelCount: a.count,
+			},
+		})
+	}
+
+	return idx
+}
+
+// add inserts the entry under its full text and under every word it
+// contains, so "fran" finds "San Francisco".
+func (idx *suggestIndex) add(e suggestEntry) {
+	words := normalize(e.suggestion.Text)
+	if len(words) == 0 {
+		return
+	}
+	e.key = strings.Join(words, " ")
+	id := len(idx.entries)
+	idx.entries = append(idx.entries, e)
+
+	for i := range words {
+		node := idx.root
+		for _, r := range strings.Join(words[i:], " ") {
+			child, ok := node.children[r]
+			if !ok {
+				if node.children == nil {
+					node.children = make(map[rune]*trieNode)
+				}
+				child = &trieNode{}
+				node.children[r] = child
+			}
+			node = child
+		}
+		node.entries = append(node.entries, id)
+	}
+}
+
+// lookup returns at most limit suggestions matching prefix, best first.
+func (idx *suggestIndex) lookup(prefix string, limit int) []*pb.Suggestion {
+	key := strings.Join(normalize(prefix), " ")
+	if key == "" {
+		return nil
+	}
+
+	node := idx.root
+	for _, r := range key {
+		node = node.children[r]
+		if node == nil {
+			return nil
+		}
+	}
+
+	seen := make(map[int]bool)
+	var collect func(n *trieNode)
+	collect = func(n *trieNode) {
+		for _, id := range n.entries {
+			seen[id] = true
+		}
+		for _, child := range n.children {
+			collect(child)
+		}
+	}
+	collect(node)
+
+	matches := make([]suggestEntry, 0, len(seen))
+	for id := range seen {
+		matches = append(matches, idx.entries[id])
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		a, b := matches[i], matches[j]
+		// a match at the start of the name beats a match on a later word
+		if ap, bp := strings.HasPrefix(a.key, key), strings.HasPrefix(b.key, key); ap != bp {
+			return ap
+		}
+		if kindRank[a.suggestion.Kind] != kindRank[b.suggestion.Kind] {
+			return kindRank[a.suggestion.Kind] < kindRank[b.suggestion.Kind]
+		}
+		if a.suggestion.HotelCount != b.suggestion.HotelCount {
+			return a.suggestion.HotelCount > b.suggestion.HotelCount
+		}
+		if a.rating != b.rating {
+			return a.rating > b.rating
+		}
+		if a.suggestion.Text != b.suggestion.Text {
+			return a.suggestion.Text < b.suggestion.Text
+		}
+		return a.suggestion.HotelId < b.suggestion.HotelId
+	})
+
+	if len(matches) > limit {
+		matches = matches[:limit]
+	}
+	res := make([]*pb.Suggestion, 0, len(matches))
+	for _, m := range matches {
+		res = append(res, m.suggestion)
+	}
+	return res
+}
+
+// normalize lower-cases s and splits it into words, dropping punctuation.
+func normalize(s string) []string {
+	return strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
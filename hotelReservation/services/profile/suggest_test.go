@@ -0,0 +1,112 @@
+package profile
+
+import (
+	"reflect"
+	"testing"
+)
+
+func suggestHotels() []suggestHotel {
+	hotel := func(id, name, street, city string, score, lat, lon float32) suggestHotel {
+		h := suggestHotel{Id: id, Name: name, Score: score}
+		h.Address.StreetName = street
+		h.Address.City = city
+		h.Address.State = "CA"
+		h.Address.Lat = lat
+		h.Address.Lon = lon
+		return h
+	}
+	return []suggestHotel{
+		hotel("1", "Hotel Zetta", "Mission St", "San Francisco", 4, 37, -122),
+		hotel("2", "St. Regis San Francisco", "3rd St", "San Francisco", 4.5, 38, -123),
+		hotel("3", "San Remo Hotel", "Mason St", "San Francisco", 3, 37.5, -122.5),
+		hotel("4", "Sandman Inn", "El Camino Real", "Santa Clara", 4, 37.3, -121.9),
+	}
+}
+
+func TestSuggestLookup(t *testing.T) {
+	idx := newSuggestIndex(suggestHotels())
+
+	tests := []struct {
+		prefix string
+		limit  int
+		want   []string
+	}{
+		{
+			// names that start with the prefix come first, then cities
+			// before neighborhoods before hotels, then the bigger area
+			// and the better rated hotel
+			prefix: "san",
+			limit:  maxSuggestLimit,
+			want: []string{
+				"San Francisco, CA",
+				"Santa Clara, CA",
+				"Sandman Inn",
+				"San Remo Hotel",
+				"3rd St, San Francisco",
+				"El Camino Real, Santa Clara",
+				"Mason St, San Francisco",
+				"Mission St, San Francisco",
+				"St. Regis San Francisco",
+			},
+		},
+		{
+			prefix: "san",
+			limit:  3,
+			want:   []string{"San Francisco, CA", "Santa Clara, CA", "Sandman Inn"},
+		},
+		{
+			prefix: "San Fran",
+			limit:  maxSuggestLimit,
+			want: []string{
+				"San Francisco, CA",
+				"3rd St, San Francisco",
+				"Mason St, San Francisco",
+				"Mission St, San Francisco",
+				"St. Regis San Francisco",
+			},
+		},
+		{
+			// punctuation is dropped
+			prefix: "st regis",
+			limit:  maxSuggestLimit,
+			want:   []string{"St. Regis San Francisco"},
+		},
+		{
+			prefix: "ZETTA",
+			limit:  maxSuggestLimit,
+			want:   []string{"Hotel Zetta"},
+		},
+		{prefix: "oakland", limit: maxSuggestLimit},
+		{prefix: " ,", limit: maxSuggestLimit},
+	}
+
+	for _, tt := range tests {
+		var got []string
+		for _, s := range idx.lookup(tt.prefix, tt.limit) {
+			got = append(got, s.Text)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("lookup(%q, %d) = %q, want %q", tt.prefix, tt.limit, got, tt.want)
+		}
+	}
+}
+
+func TestSuggestAreas(t *testing.T) {
+	idx := newSuggestIndex(suggestHotels())
+
+	got := idx.lookup("san francisco", 1)
+	if len(got) != 1 {
+		t.Fatalf("got %d suggestions, want 1", len(got))
+	}
+	city := got[0]
+	// an area is placed at the centroid of its hotels
+	if city.Kind != kindCity || city.HotelId != "" || city.HotelCount != 3 ||
+		city.Lat != 37.5 || city.Lon != -122.5 {
+		t.Errorf("got %v, want the city of 3 hotels at 37.5,-122.5", city)
+	}
+
+	got = idx.lookup("zetta", 1)
+	if len(got) != 1 || got[0].Kind != kindHotel || got[0].HotelId != "1" || got[0].HotelCount != 1 {
+		t.Errorf("got %v, want hotel 1", got)
+	}
+}
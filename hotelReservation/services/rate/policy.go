@@ -0,0 +1,10 @@
+package rate
+
+import (
+	"github.com/harlow/go-micro-services/rbac"
+)
+
+// policy is who may call the rate service.
+var policy = rbac.Policy{
+	"/rate.Rate/GetRates": {Public: true},
+}
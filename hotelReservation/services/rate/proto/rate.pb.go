@@ -12,6 +12,8 @@ It has these top-level messages:
 	Result
 	RatePlan
 	RoomType
+	RatePlansRequest
+	RatePlansResult
 */
 package rate
 
@@ -68,7 +70,7 @@ func (m *Request) GetOutDate() string {
 }
 
 type Result struct {
-	RatePlans []*RatePlan `protobuf:"bytes,1,rep,name=ratePlans" bson:"ratePlans,omitempty"`
+	RatePlans []*RatePlan `protobuf:"bytes,1,rep,name=ratePlans" json:"ratePlans,omitempty"`
 }
 
 func (m *Result) Reset()                    { *m = Result{} }
@@ -187,11 +189,46 @@ func (m *RoomType) GetRoomDescription() string {
 	return ""
 }
 
+// ratePlans are all for hotelId; DeleteRatePlans ignores them.
+type RatePlansRequest struct {
+	HotelId   string      `protobuf:"bytes,1,opt,name=hotelId" json:"hotelId,omitempty"`
+	RatePlans []*RatePlan `protobuf:"bytes,2,rep,name=ratePlans" json:"ratePlans,omitempty"`
+}
+
+func (m *RatePlansRequest) Reset()                    { *m = RatePlansRequest{} }
+func (m *RatePlansRequest) String() string            { return proto.CompactTextString(m) }
+func (*RatePlansRequest) ProtoMessage()               {}
+func (*RatePlansRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
+
+func (m *RatePlansRequest) GetHotelId() string {
+	if m != nil {
+		return m.HotelId
+	}
+	return ""
+}
+
+func (m *RatePlansRequest) GetRatePlans() []*RatePlan {
+	if m != nil {
+		return m.RatePlans
+	}
+	return nil
+}
+
+type RatePlansResult struct {
+}
+
+func (m *RatePlansResult) Reset()                    { *m = RatePlansResult{} }
+func (m *RatePlansResult) String() string            { return proto.CompactTextString(m) }
+func (*RatePlansResult) ProtoMessage()               {}
+func (*RatePlansResult) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+
 func init() {
 	proto.RegisterType((*Request)(nil), "rate.Request")
 	proto.RegisterType((*Result)(nil), "rate.Result")
 	proto.RegisterType((*RatePlan)(nil), "rate.RatePlan")
 	proto.RegisterType((*RoomType)(nil), "rate.RoomType")
+	proto.RegisterType((*RatePlansRequest)(nil), "rate.RatePlansRequest")
+	proto.RegisterType((*RatePlansResult)(nil), "rate.RatePlansResult")
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -207,6 +244,10 @@ const _ = grpc.SupportPackageIsVersion4
 type RateClient interface {
 	// GetRates returns rate codes for hotels for a given date range
 	GetRates(ctx context.Context, in *Request, opts ...grpc.CallOption) (*Result, error)
+	// AddRatePlans stores the rate plans of a new hotel
+	AddRatePlans(ctx context.Context, in *RatePlansRequest, opts ...grpc.CallOption) (*RatePlansResult, error)
+	// DeleteRatePlans removes every rate plan of a hotel
+	DeleteRatePlans(ctx context.Context, in *RatePlansRequest, opts ...grpc.CallOption) (*RatePlansResult, error)
 }
 
 type rateClient struct {
@@ -226,11 +267,33 @@ func (c *rateClient) GetRates(ctx context.Context, in *Request, opts ...grpc.Cal
 	return out, nil
 }
 
+func (c *rateClient) AddRatePlans(ctx context.Context, in *RatePlansRequest, opts ...grpc.CallOption) (*RatePlansResult, error) {
+	out := new(RatePlansResult)
+	err := grpc.Invoke(ctx, "/rate.Rate/AddRatePlans", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rateClient) DeleteRatePlans(ctx context.Context, in *RatePlansRequest, opts ...grpc.CallOption) (*RatePlansResult, error) {
+	out := new(RatePlansResult)
+	err := grpc.Invoke(ctx, "/rate.Rate/DeleteRatePlans", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for Rate service
 
 type RateServer interface {
 	// GetRates returns rate codes for hotels for a given date range
 	GetRates(context.Context, *Request) (*Result, error)
+	// AddRatePlans stores the rate plans of a new hotel
+	AddRatePlans(context.Context, *RatePlansRequest) (*RatePlansResult, error)
+	// DeleteRatePlans removes every rate plan of a hotel
+	DeleteRatePlans(context.Context, *RatePlansRequest) (*RatePlansResult, error)
 }
 
 func RegisterRateServer(s *grpc.Server, srv RateServer) {
@@ -255,6 +318,42 @@ func _Rate_GetRates_Handler(srv interface{}, ctx context.Context, dec func(inter
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Rate_AddRatePlans_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RatePlansRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RateServer).AddRatePlans(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rate.Rate/AddRatePlans",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RateServer).AddRatePlans(ctx, req.(*RatePlansRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Rate_DeleteRatePlans_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RatePlansRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RateServer).DeleteRatePlans(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rate.Rate/DeleteRatePlans",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RateServer).DeleteRatePlans(ctx, req.(*RatePlansRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Rate_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "rate.Rate",
 	HandlerType: (*RateServer)(nil),
@@ -263,6 +362,14 @@ var _Rate_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetRates",
 			Handler:    _Rate_GetRates_Handler,
 		},
+		{
+			MethodName: "AddRatePlans",
+			Handler:    _Rate_AddRatePlans_Handler,
+		},
+		{
+			MethodName: "DeleteRatePlans",
+			Handler:    _Rate_DeleteRatePlans_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "services/rate/proto/rate.proto",
@@ -271,26 +378,30 @@ var _Rate_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("services/rate/proto/rate.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 327 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x92, 0xdd, 0x4a, 0xc3, 0x30,
-	0x14, 0xc7, 0x89, 0xab, 0x5d, 0x7b, 0x9c, 0x0a, 0xe7, 0x42, 0xca, 0x10, 0x19, 0xbd, 0x71, 0x88,
-	0x6c, 0x30, 0xc1, 0x27, 0x18, 0xc8, 0xee, 0xe4, 0x20, 0x78, 0xdd, 0x75, 0x07, 0x2c, 0xd6, 0x66,
-	0x26, 0xe9, 0x60, 0x2f, 0xe2, 0xa3, 0xf9, 0x3c, 0x92, 0x34, 0xed, 0x3a, 0xd1, 0xbb, 0xff, 0x47,
-	0x38, 0xfd, 0x25, 0x3d, 0x70, 0xa3, 0x59, 0xed, 0x8a, 0x9c, 0xf5, 0x5c, 0x65, 0x86, 0xe7, 0x5b,
-	0x25, 0x8d, 0x74, 0x72, 0xe6, 0x24, 0x06, 0x56, 0xa7, 0xaf, 0x30, 0x24, 0xfe, 0xac, 0x59, 0x1b,
-	0x1c, 0x43, 0xf4, 0x26, 0x0d, 0x97, 0xab, 0x8d, 0x4e, 0xc4, 0x64, 0x30, 0x8d, 0xa9, 0xf3, 0x78,
-	0x05, 0x61, 0x51, 0x2d, 0x33, 0xc3, 0xc9, 0xc9, 0x44, 0x4c, 0x63, 0xf2, 0x0e, 0x13, 0x18, 0xca,
-	0xda, 0xb8, 0x62, 0xe0, 0x8a, 0xd6, 0xa6, 0x8f, 0x10, 0x12, 0xeb, 0xba, 0x34, 0x78, 0x0f, 0xb1,
-	0xfd, 0xd4, 0x73, 0x99, 0x55, 0xcd, 0xe0, 0xb3, 0xc5, 0xc5, 0xcc, 0x81, 0x90, 0x8f, 0xe9, 0x70,
-	0x20, 0xfd, 0x12, 0x10, 0xb5, 0xb9, 0x1d, 0xef, 0x11, 0x12, 0xd1, 0x8c, 0xf7, 0x16, 0x11, 0x82,
-	0x5c, 0x6e, 0x5a, 0x1c, 0xa7, 0x7b, 0x90, 0x83, 0xff, 0x20, 0x83, 0x23, 0x48, 0xbc, 0x83, 0x48,
-	0x49, 0xf9, 0xf1, 0xb2, 0xdf, 0x72, 0x72, 0x3a, 0x11, 0x3d, 0x32, 0x9f, 0x52, 0xd7, 0xa7, 0xdf,
-	0x16, 0xcc, 0x1b, 0x4c, 0x61, 0xb4, 0x96, 0xf2, 0x3d, 0x5b, 0x97, 0x6c, 0x61, 0x1d, 0x9d, 0xa0,
-	0xa3, 0x0c, 0xaf, 0x21, 0x36, 0xd2, 0x64, 0x25, 0xb5, 0xcf, 0x26, 0xe8, 0x10, 0xe0, 0x0c, 0xb0,
-	0x33, 0xab, 0x2a, 0x2f, 0x6b, 0x5d, 0xec, 0x1a, 0x70, 0x41, 0x7f, 0x34, 0xdd, 0x85, 0x83, 0xde,
-	0x85, 0xc7, 0x10, 0xe5, 0xb5, 0x52, 0x5c, 0xe5, 0x7b, 0x87, 0x1f, 0x53, 0xe7, 0x71, 0x0a, 0x97,
-	0x16, 0x7d, 0xc9, 0x3a, 0x57, 0xc5, 0xd6, 0x14, 0xb2, 0x4a, 0x42, 0x77, 0xe4, 0x77, 0xbc, 0x98,
-	0x43, 0xe0, 0x88, 0x6e, 0x21, 0x7a, 0x62, 0x63, 0xa5, 0xc6, 0x73, 0xff, 0x0c, 0xcd, 0x6a, 0x8c,
-	0x47, 0xad, 0xb5, 0x3f, 0x74, 0x1d, 0xba, 0x05, 0x7a, 0xf8, 0x09, 0x00, 0x00, 0xff, 0xff, 0x71,
-	0x7f, 0xc7, 0x74, 0x62, 0x02, 0x00, 0x00,
+	// 385 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x93, 0xd1, 0x4a, 0xfb, 0x30,
+	0x14, 0xc6, 0xc9, 0xd6, 0x7f, 0xd7, 0x9e, 0xff, 0x74, 0x1a, 0x70, 0x94, 0x22, 0x32, 0x7a, 0xe3,
+	0x10, 0xd9, 0x60, 0x82, 0x77, 0x82, 0xc2, 0x40, 0x76, 0x27, 0x41, 0x10, 0xbc, 0xeb, 0xda, 0x03,
+	0x16, 0x6b, 0x33, 0x9b, 0x74, 0xb0, 0x17, 0xf1, 0x39, 0x7c, 0x1a, 0x9f, 0x47, 0x92, 0x36, 0x5d,
+	0xab, 0x0e, 0xc1, 0xbb, 0xf3, 0x7d, 0x27, 0xfd, 0xf2, 0xcb, 0x49, 0x0a, 0x27, 0x02, 0xf3, 0x75,
+	0x12, 0xa1, 0x98, 0xe6, 0xa1, 0xc4, 0xe9, 0x2a, 0xe7, 0x92, 0xeb, 0x72, 0xa2, 0x4b, 0x6a, 0xa9,
+	0x3a, 0x78, 0x80, 0x1e, 0xc3, 0xd7, 0x02, 0x85, 0xa4, 0x3e, 0x38, 0x4f, 0x5c, 0x62, 0xba, 0x88,
+	0x85, 0x47, 0x46, 0xdd, 0xb1, 0xcb, 0x6a, 0x4d, 0x87, 0x60, 0x27, 0xd9, 0x3c, 0x94, 0xe8, 0x75,
+	0x46, 0x64, 0xec, 0xb2, 0x4a, 0x51, 0x0f, 0x7a, 0xbc, 0x90, 0xba, 0xd1, 0xd5, 0x0d, 0x23, 0x83,
+	0x4b, 0xb0, 0x19, 0x8a, 0x22, 0x95, 0xf4, 0x1c, 0x5c, 0xb5, 0xd5, 0x5d, 0x1a, 0x66, 0x65, 0xf0,
+	0xff, 0xd9, 0xfe, 0x44, 0x83, 0xb0, 0xca, 0x66, 0xdb, 0x05, 0xc1, 0x1b, 0x01, 0xc7, 0xf8, 0x2a,
+	0xbe, 0x42, 0xf0, 0x48, 0x19, 0x5f, 0x49, 0x4a, 0xc1, 0x8a, 0x78, 0x6c, 0x70, 0x74, 0xdd, 0x80,
+	0xec, 0xee, 0x82, 0xb4, 0x5a, 0x90, 0xf4, 0x0c, 0x9c, 0x9c, 0xf3, 0x97, 0xfb, 0xcd, 0x0a, 0xbd,
+	0x7f, 0x23, 0xd2, 0x20, 0xab, 0x5c, 0x56, 0xf7, 0x83, 0x0f, 0x05, 0x56, 0x09, 0x1a, 0x40, 0x7f,
+	0xc9, 0xf9, 0x73, 0xb8, 0x4c, 0x51, 0xc1, 0x6a, 0x3a, 0xc2, 0x5a, 0x1e, 0x3d, 0x06, 0x57, 0x72,
+	0x19, 0xa6, 0xcc, 0x8c, 0x8d, 0xb0, 0xad, 0x41, 0x27, 0x40, 0x6b, 0xb1, 0xc8, 0xa2, 0xb4, 0x10,
+	0xc9, 0xba, 0x04, 0x27, 0xec, 0x87, 0x4e, 0x7d, 0x60, 0xab, 0x71, 0x60, 0x1f, 0x9c, 0xa8, 0xc8,
+	0x73, 0xcc, 0xa2, 0x8d, 0xc6, 0x77, 0x59, 0xad, 0xe9, 0x18, 0x06, 0x0a, 0x7d, 0x8e, 0x22, 0xca,
+	0x93, 0x95, 0x4c, 0x78, 0xe6, 0xd9, 0x7a, 0xc9, 0x57, 0x3b, 0x78, 0x84, 0x03, 0x33, 0x70, 0x61,
+	0xde, 0xc2, 0xee, 0xc1, 0xb7, 0x6e, 0xb3, 0xf3, 0xdb, 0x6d, 0x1e, 0xc2, 0xa0, 0x91, 0xad, 0x9e,
+	0xc3, 0xec, 0x9d, 0x80, 0xa5, 0x27, 0x70, 0x0a, 0xce, 0x2d, 0x4a, 0x55, 0x0a, 0xba, 0x57, 0x45,
+	0x94, 0xdb, 0xfb, 0x7d, 0x23, 0xf5, 0x03, 0xba, 0x82, 0xfe, 0x4d, 0x1c, 0xd7, 0x39, 0x74, 0xd8,
+	0xde, 0xcf, 0x40, 0xfb, 0x47, 0xdf, 0x7c, 0xfd, 0xf9, 0x35, 0x0c, 0xe6, 0x98, 0xa2, 0xc4, 0xbf,
+	0x26, 0x2c, 0x6d, 0xfd, 0xc7, 0x5c, 0x7c, 0x0e, 0x00, 0x0b, 0xb4, 0x83, 0x0a, 0x53, 0x03, 0x00,
+	0x00,
 }
@@ -13,12 +13,15 @@ import (
 	"time"
 
 	"github.com/grpc-ecosystem/grpc-opentracing/go/otgrpc"
+	"github.com/harlow/go-micro-services/rbac"
 	"github.com/harlow/go-micro-services/registry"
 	pb "github.com/harlow/go-micro-services/services/rate/proto"
 	"github.com/opentracing/opentracing-go"
 	"golang.org/x/net/context"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/status"
 
 	"github.com/bradfitz/gomemcache/memcache"
 	"strings"
@@ -33,6 +36,8 @@ type Server struct {
 	IpAddr	 string
 	MongoSession 	*mgo.Session
 	Registry  *registry.Client
+	// Identity signs and verifies the identities calls are made by
+	Identity *rbac.Signer
 	MemcClient *memcache.Client
 }
 
@@ -42,6 +47,10 @@ func (s *Server) Run() error {
 		return fmt.Errorf("server port must be set")
 	}
 
+	if s.Identity == nil {
+		return fmt.Errorf("identity keys must be set")
+	}
+
 	srv := grpc.NewServer(
 		grpc.KeepaliveParams(keepalive.ServerParameters{
 			Timeout: 120 * time.Second,
@@ -49,9 +58,10 @@ func (s *Server) Run() error {
 		grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
 			PermitWithoutStream: true,
 		}),
-		grpc.UnaryInterceptor(
+		grpc.UnaryInterceptor(rbac.ChainUnaryServer(
 			otgrpc.OpenTracingServerInterceptor(s.Tracer),
-		),
+			rbac.UnaryServerInterceptor(s.Identity, policy),
+		)),
 	)
 
 	pb.RegisterRateServer(srv, s)
@@ -111,7 +121,7 @@ func (s *Server) GetRates(ctx context.Context, req *pb.Request) (*pb.Result, err
 			for _, rate_str := range rate_strs {
 				if len(rate_str) != 0 {
 					rate_p := new(pb.RatePlan)
-					json.Unmarshal(item.Value, rate_p)
+					json.Unmarshal([]byte(rate_str), rate_p)
 					ratePlans = append(ratePlans, rate_p)
 				}
 			}
@@ -156,6 +166,91 @@ func (s *Server) GetRates(ctx context.Context, req *pb.Request) (*pb.Result, err
 	return res, nil
 }
 
+// AddRatePlans stores the rate plans of a hotel that has none.
+func (s *Server) AddRatePlans(ctx context.Context, req *pb.RatePlansRequest) (*pb.RatePlansResult, error) {
+	if req.HotelId == "" {
+		return nil, status.Error(codes.InvalidArgument, "hotel id must be set")
+	}
+	plans := make([]interface{}, 0, len(req.RatePlans))
+	for _, p := range req.RatePlans {
+		if err := checkRatePlan(req.HotelId, p); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		plans = append(plans, p)
+	}
+
+	session := s.MongoSession.Copy()
+	defer session.Close()
+	c := session.DB("rate-db").C("inventory")
+
+	n, err := c.Find(&bson.M{"hotelId": req.HotelId}).Count()
+	if err != nil {
+		return nil, err
+	}
+	if n > 0 {
+		return nil, status.Errorf(codes.AlreadyExists, "hotel %s already has rate plans", req.HotelId)
+	}
+	if len(plans) > 0 {
+		if err := c.Insert(plans...); err != nil {
+			return nil, err
+		}
+	}
+
+	s.forgetRatePlans(req.HotelId)
+	return new(pb.RatePlansResult), nil
+}
+
+// DeleteRatePlans removes every rate plan of a hotel.
+func (s *Server) DeleteRatePlans(ctx context.Context, req *pb.RatePlansRequest) (*pb.RatePlansResult, error) {
+	if req.HotelId == "" {
+		return nil, status.Error(codes.InvalidArgument, "hotel id must be set")
+	}
+
+	session := s.MongoSession.Copy()
+	defer session.Close()
+
+	_, err := session.DB("rate-db").C("inventory").RemoveAll(&bson.M{"hotelId": req.HotelId})
+	if err != nil {
+		return nil, err
+	}
+
+	s.forgetRatePlans(req.HotelId)
+	return new(pb.RatePlansResult), nil
+}
+
+// checkRatePlan validates a rate plan of a hotel.
+func checkRatePlan(hotelId string, p *pb.RatePlan) error {
+	if p.HotelId != hotelId {
+		return fmt.Errorf("rate plan %q is not for hotel %s", p.Code, hotelId)
+	}
+	if p.Code == "" || p.RoomType == nil {
+		return fmt.Errorf("rate plans must have a code and a room type")
+	}
+	in, err := time.Parse("2006-01-02", p.InDate)
+	if err != nil {
+		return fmt.Errorf("rate plan %q: inDate must be YYYY-MM-DD", p.Code)
+	}
+	out, err := time.Parse("2006-01-02", p.OutDate)
+	if err != nil {
+		return fmt.Errorf("rate plan %q: outDate must be YYYY-MM-DD", p.Code)
+	}
+	if !in.Before(out) {
+		return fmt.Errorf("rate plan %q: outDate must be after inDate", p.Code)
+	}
+	rt := p.RoomType
+	if rt.BookableRate <= 0 || rt.TotalRate <= 0 || rt.TotalRateInclusive < rt.TotalRate {
+		return fmt.Errorf("rate plan %q: rates must be positive, the inclusive one no lower than the total", p.Code)
+	}
+	return nil
+}
+
+// forgetRatePlans drops the cached rate plans of a hotel.
+func (s *Server) forgetRatePlans(hotelId string) {
+	if err := s.MemcClient.Delete(hotelId); err != nil && err != memcache.ErrCacheMiss {
+		log.Println("Failed drop cached rate plans: ", err)
+	}
+}
+
 type RatePlans []*pb.RatePlan
 
 func (r RatePlans) Len() int {
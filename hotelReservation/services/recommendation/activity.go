@@ -0,0 +1,164 @@
+package recommendation
+
+import (
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	pb "github.com/harlow/go-micro-services/services/recommendation/proto"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// an activity counts half as much after its half-life
+	bookingHalfLife    = 24 * time.Hour
+	impressionHalfLife = 6 * time.Hour
+	viewHalfLife       = 6 * time.Hour
+
+	bookingWeight    = 10.0
+	viewWeight       = 2.0
+	impressionWeight = 1.0
+
+	// hotels whose weighted activity decayed below this are forgotten
+	forgetScore = 0.01
+
+	defaultTrendingRadiusKm = 10.0
+)
+
+// decayed is a count that halves every half-life.
+type decayed struct {
+	value float64
+	at    time.Time
+}
+
+func (d decayed) valueAt(now time.Time, halfLife time.Duration) float64 {
+	if d.value == 0 {
+		return 0
+	}
+	return d.value * math.Exp2(-float64(now.Sub(d.at))/float64(halfLife))
+}
+
+func (d *decayed) add(now time.Time, halfLife time.Duration, n float64) {
+	d.value = d.valueAt(now, halfLife) + n
+	d.at = now
+}
+
+// activity is the recent activity of one hotel.
+type activity struct {
+	bookings, impressions, views decayed
+}
+
+func (a *activity) score(now time.Time) float64 {
+	return bookingWeight*a.bookings.valueAt(now, bookingHalfLife) +
+		impressionWeight*a.impressions.valueAt(now, impressionHalfLife) +
+		viewWeight*a.views.valueAt(now, viewHalfLife)
+}
+
+// tracker keeps the recent activity of hotels in memory. The zero value
+// is ready to use; activity is lost on restart.
+type tracker struct {
+	mu     sync.Mutex
+	hotels map[string]*activity
+}
+
+func (t *tracker) record(kind pb.Activity, hotelIds []string, now time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.hotels == nil {
+		t.hotels = make(map[string]*activity)
+	}
+	for _, hotelId := range hotelIds {
+		a, ok := t.hotels[hotelId]
+		if !ok {
+			a = new(activity)
+			t.hotels[hotelId] = a
+		}
+		switch kind {
+		case pb.Activity_BOOKING:
+			a.bookings.add(now, bookingHalfLife, 1)
+		case pb.Activity_IMPRESSION:
+			a.impressions.add(now, impressionHalfLife, 1)
+		case pb.Activity_VIEW:
+			a.views.add(now, viewHalfLife, 1)
+		}
+	}
+}
+
+// forget drops the hotels without recent activity.
+func (t *tracker) forget(now time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for hotelId, a := range t.hotels {
+		if a.score(now) < forgetScore {
+			delete(t.hotels, hotelId)
+		}
+	}
+}
+
+// snapshot returns the activity of every tracked hotel as of now.
+func (t *tracker) snapshot(now time.Time) map[string]*pb.TrendingHotel {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	res := make(map[string]*pb.TrendingHotel, len(t.hotels))
+	for hotelId, a := range t.hotels {
+		res[hotelId] = &pb.TrendingHotel{
+			HotelId:     hotelId,
+			Score:       a.score(now),
+			Bookings:    a.bookings.valueAt(now, bookingHalfLife),
+			Impressions: a.impressions.valueAt(now, impressionHalfLife),
+			Views:       a.views.valueAt(now, viewHalfLife),
+		}
+	}
+	return res
+}
+
+// RecordActivity counts an activity for each of the given hotels.
+func (s *Server) RecordActivity(ctx context.Context, req *pb.ActivityRequest) (*pb.ActivityResult, error) {
+	if req.Activity == pb.Activity_UNKNOWN {
+		return nil, status.Error(codes.InvalidArgument, "activity must be set")
+	}
+	s.activity.record(req.Activity, req.HotelIds, time.Now())
+	return new(pb.ActivityResult), nil
+}
+
+// Trending returns the hotels within the radius with the highest
+// recent activity.
+func (s *Server) Trending(ctx context.Context, req *pb.TrendingRequest) (*pb.TrendingResult, error) {
+	if req.RadiusKm < 0 || math.IsNaN(req.RadiusKm) {
+		return nil, status.Error(codes.InvalidArgument, "radius must not be negative")
+	}
+	radiusKm := req.RadiusKm
+	if radiusKm == 0 {
+		radiusKm = defaultTrendingRadiusKm
+	}
+
+	hotels := s.catalog()
+	res := new(pb.TrendingResult)
+	for hotelId, t := range s.activity.snapshot(time.Now()) {
+		hotel, ok := hotels[hotelId]
+		if !ok {
+			continue
+		}
+		t.DistanceKm = distanceKm(req.Lat, req.Lon, hotel)
+		if t.DistanceKm <= radiusKm {
+			res.Hotels = append(res.Hotels, t)
+		}
+	}
+
+	sort.Slice(res.Hotels, func(i, j int) bool {
+		if res.Hotels[i].Score != res.Hotels[j].Score {
+			return res.Hotels[i].Score > res.Hotels[j].Score
+		}
+		return res.Hotels[i].HotelId < res.Hotels[j].HotelId
+	})
+	if k := resultCount(req.K); len(res.Hotels) > k {
+		res.Hotels = res.Hotels[:k]
+	}
+	return res, nil
+}
@@ -0,0 +1,41 @@
+package recommendation
+
+import (
+	"math"
+
+	pb "github.com/harlow/go-micro-services/services/recommendation/proto"
+	"golang.org/x/net/context"
+)
+
+func init() {
+	registerStrategy("cheapest", newCheapest)
+	registerStrategy("price", newCheapest)
+}
+
+// cheapest scores the price relative to the cheapest hotel.
+type cheapest struct{}
+
+func newCheapest(s *Server) Strategy {
+	return cheapest{}
+}
+
+func (cheapest) Rank(ctx context.Context, q *Query) ([]*pb.RankedHotel, error) {
+	min := math.MaxFloat64
+	for _, hotel := range q.Hotels {
+		if hotel.HPrice > 0 {
+			min = math.Min(min, hotel.HPrice)
+		}
+	}
+
+	res := make([]*pb.RankedHotel, 0, len(q.Hotels))
+	for _, hotel := range q.Hotels {
+		price := 0.0
+		if hotel.HPrice > 0 {
+			price = min / hotel.HPrice
+		}
+		res = append(res, ranked(hotel.HId, &pb.Factors{
+			Price: price,
+		}, hotelPhrases(hotel, distanceKm(q.Lat, q.Lon, hotel))))
+	}
+	return topK(res, q.K), nil
+}
@@ -0,0 +1,74 @@
+package recommendation
+
+import (
+	"math"
+
+	pb "github.com/harlow/go-micro-services/services/recommendation/proto"
+	"golang.org/x/net/context"
+)
+
+const (
+	// diverseLambda trades relevance (1) against novelty (0)
+	diverseLambda = 0.7
+	// hotels this far apart are 1/e alike by location
+	diverseRadiusKm = 1.0
+)
+
+func init() {
+	registerStrategy("diverse", newDiverse)
+}
+
+// diverse picks hotels by maximal marginal relevance: each next hotel is
+// the one whose mix score, less its likeness to the hotels already picked,
+// is highest. Hotels keep their mix score, so the scores of the result
+// need not be ordered.
+type diverse struct{}
+
+func newDiverse(s *Server) Strategy {
+	return diverse{}
+}
+
+func (diverse) Rank(ctx context.Context, q *Query) ([]*pb.RankedHotel, error) {
+	w, err := mixWeights(q.Weights)
+	if err != nil {
+		return nil, err
+	}
+	// start from the mix order so equal picks fall to the better hotel
+	candidates := topK(mixScores(q.Hotels, q.Lat, q.Lon, w), len(q.Hotels))
+	if len(candidates) == 0 {
+		return candidates, nil
+	}
+	best := candidates[0].Score
+
+	res := make([]*pb.RankedHotel, 0, q.K)
+	for len(res) < q.K && len(candidates) > 0 {
+		pick, pickScore := 0, math.Inf(-1)
+		for i, c := range candidates {
+			relevance := 0.0
+			if best > 0 {
+				relevance = c.Score / best
+			}
+			likeness := 0.0
+			for _, r := range res {
+				likeness = math.Max(likeness, similarity(q.Hotels[c.HotelId], q.Hotels[r.HotelId]))
+			}
+			if mmr := diverseLambda*relevance - (1-diverseLambda)*likeness; mmr > pickScore {
+				pick, pickScore = i, mmr
+			}
+		}
+		res = append(res, candidates[pick])
+		candidates = append(candidates[:pick], candidates[pick+1:]...)
+	}
+	return res, nil
+}
+
+// similarity is 1 for hotels at the same place and price, falling towards
+// 0 as they move apart or their prices differ.
+func similarity(a, b Hotel) float64 {
+	location := math.Exp(-distanceKm(a.HLat, a.HLon, b) / diverseRadiusKm)
+	price := 1.0
+	if hi := math.Max(a.HPrice, b.HPrice); hi > 0 {
+		price = math.Min(a.HPrice, b.HPrice) / hi
+	}
+	return (location + price) / 2
+}
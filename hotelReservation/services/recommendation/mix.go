@@ -0,0 +1,101 @@
+package recommendation
+
+import (
+	"math"
+
+	pb "github.com/harlow/go-micro-services/services/recommendation/proto"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// defaultMixWeights are used when the request sets no weights
+var defaultMixWeights = pb.Factors{Distance: 0.5, Rate: 0.3, Price: 0.2}
+
+func init() {
+	registerStrategy("mix", newMix)
+}
+
+// mix weighs closeness, rate and cheapness by the query weights.
+type mix struct{}
+
+func newMix(s *Server) Strategy {
+	return mix{}
+}
+
+func (mix) Rank(ctx context.Context, q *Query) ([]*pb.RankedHotel, error) {
+	w, err := mixWeights(q.Weights)
+	if err != nil {
+		return nil, err
+	}
+	return topK(mixScores(q.Hotels, q.Lat, q.Lon, w), q.K), nil
+}
+
+// mixWeights validates the requested mix weights and scales them to sum to
+// 1, so only their proportions matter.
+func mixWeights(w *pb.Factors) (*pb.Factors, error) {
+	if w == nil || w.Distance == 0 && w.Rate == 0 && w.Price == 0 {
+		w = &defaultMixWeights
+	}
+	for _, v := range []float64{w.Distance, w.Rate, w.Price} {
+		if v < 0 || math.IsNaN(v) || math.IsInf(v, 0) {
+			return nil, status.Error(codes.InvalidArgument, "mix weights must be finite and not negative")
+		}
+	}
+	sum := w.Distance + w.Rate + w.Price
+	return &pb.Factors{
+		Distance: w.Distance / sum,
+		Rate:     w.Rate / sum,
+		Price:    w.Price / sum,
+	}, nil
+}
+
+// mixScores scores every hotel by closeness, rate and cheapness, each
+// relative to its average over all hotels, so an average hotel scores
+// about 1. The result is unordered.
+func mixScores(hotels map[string]Hotel, lat, lon float64, w *pb.Factors) []*pb.RankedHotel {
+	hotelScores := make([]HotelScore, 0, len(hotels))
+	kms := make(map[string]float64, len(hotels))
+	distanceScoreSum := 0.0
+	rateScoreSum := 0.0
+	priceScoreSum := 0.0
+
+	for _, hotel := range hotels {
+		tmp := distanceKm(lat, lon, hotel)
+		kms[hotel.HId] = tmp
+
+		var hotelScore HotelScore
+		hotelScore.HId = hotel.HId
+		if tmp > 1 {
+			hotelScore.HDis = 1 / tmp
+		} else {
+			hotelScore.HDis = 1
+		}
+		hotelScore.HRate = hotel.HRate
+		if hotel.HPrice > 0 {
+			hotelScore.HPrice = 1 / hotel.HPrice
+		}
+		distanceScoreSum += hotelScore.HDis
+		rateScoreSum += hotelScore.HRate
+		priceScoreSum += hotelScore.HPrice
+		hotelScores = append(hotelScores, hotelScore)
+	}
+
+	n := float64(len(hotelScores))
+	share := func(v, sum float64) float64 {
+		if sum == 0 {
+			return 0
+		}
+		return v / sum * n
+	}
+
+	res := make([]*pb.RankedHotel, 0, len(hotelScores))
+	for _, hotelScore := range hotelScores {
+		res = append(res, ranked(hotelScore.HId, &pb.Factors{
+			Distance: w.Distance * share(hotelScore.HDis, distanceScoreSum),
+			Rate:     w.Rate * share(hotelScore.HRate, rateScoreSum),
+			Price:    w.Price * share(hotelScore.HPrice, priceScoreSum),
+		}, hotelPhrases(hotels[hotelScore.HId], kms[hotelScore.HId])))
+	}
+	return res
+}
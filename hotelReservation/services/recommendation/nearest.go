@@ -0,0 +1,29 @@
+package recommendation
+
+import (
+	pb "github.com/harlow/go-micro-services/services/recommendation/proto"
+	"golang.org/x/net/context"
+)
+
+func init() {
+	registerStrategy("nearest", newNearest)
+	registerStrategy("dis", newNearest)
+}
+
+// nearest scores closeness, 1 right at the location.
+type nearest struct{}
+
+func newNearest(s *Server) Strategy {
+	return nearest{}
+}
+
+func (nearest) Rank(ctx context.Context, q *Query) ([]*pb.RankedHotel, error) {
+	res := make([]*pb.RankedHotel, 0, len(q.Hotels))
+	for _, hotel := range q.Hotels {
+		km := distanceKm(q.Lat, q.Lon, hotel)
+		res = append(res, ranked(hotel.HId, &pb.Factors{
+			Distance: 1 / (1 + km),
+		}, hotelPhrases(hotel, km)))
+	}
+	return topK(res, q.K), nil
+}
@@ -0,0 +1,240 @@
+package recommendation
+
+import (
+	"math"
+	"time"
+
+	"github.com/hailocab/go-geoindex"
+	pb "github.com/harlow/go-micro-services/services/recommendation/proto"
+	reservation "github.com/harlow/go-micro-services/services/reservation/proto"
+	user "github.com/harlow/go-micro-services/services/user/proto"
+	"golang.org/x/net/context"
+)
+
+const (
+	// a hotel this far from a past stay scores 1/e for the area factor
+	neighborhoodKm = 2.0
+	// hotels the user scored at or below this are never recommended
+	dislikedScore = 2.0
+
+	personalPriceWeight  = 0.3
+	personalAreaWeight   = 0.3
+	personalRatingWeight = 0.2
+	personalPeerWeight   = 0.2
+)
+
+func init() {
+	registerStrategy("personal", newPersonal)
+}
+
+// personal ranks hotels for a user from their booking and review history.
+// Users without a history get the default mix.
+type personal struct {
+	s *Server
+}
+
+func newPersonal(s *Server) Strategy {
+	return personal{s: s}
+}
+
+func (p personal) Rank(ctx context.Context, q *Query) ([]*pb.RankedHotel, error) {
+	hotels, err := p.s.rankPersonal(ctx, q.Hotels, q.Username)
+	if err != nil {
+		return nil, err
+	}
+	if len(hotels) == 0 {
+		hotels = mixScores(q.Hotels, q.Lat, q.Lon, &defaultMixWeights)
+	}
+	return topK(hotels, q.K), nil
+}
+
+// history is what the personal strategy knows about a user: how much weight
+// each hotel they stayed at or reviewed carries, and which they disliked.
+type history struct {
+	weights  map[string]float64
+	disliked map[string]bool
+}
+
+// loadHistory combines the stays booked under username with the scores
+// the user gave. A stay weighs its number of nights; a review scales the
+// weight of the hotel by how the score compares to the user's average.
+func (s *Server) loadHistory(ctx context.Context, username string) (*history, error) {
+	stays, err := s.reservationClient.ListReservations(ctx, &reservation.ListRequest{
+		CustomerName: username,
+	})
+	if err != nil {
+		return nil, err
+	}
+	reviews, err := s.userClient.GetReviews(ctx, &user.ReviewsRequest{
+		Username: username,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	h := &history{
+		weights:  make(map[string]float64),
+		disliked: make(map[string]bool),
+	}
+	for _, stay := range stays.Stays {
+		h.weights[stay.HotelId] += nights(stay.InDate, stay.OutDate)
+	}
+
+	if len(reviews.Reviews) == 0 {
+		return h, nil
+	}
+	// some users score everything high, so compare against their own mean
+	scores := make(map[string][]float64)
+	mean := 0.0
+	for _, r := range reviews.Reviews {
+		scores[r.HotelId] = append(scores[r.HotelId], float64(r.Score))
+		mean += float64(r.Score)
+	}
+	mean /= float64(len(reviews.Reviews))
+
+	for hotelId, ss := range scores {
+		score := 0.0
+		for _, s := range ss {
+			score += s
+		}
+		score /= float64(len(ss))
+
+		if score <= dislikedScore {
+			h.disliked[hotelId] = true
+			delete(h.weights, hotelId)
+			continue
+		}
+		if h.weights[hotelId] == 0 {
+			h.weights[hotelId] = 1
+		}
+		h.weights[hotelId] *= math.Max(0.1, 1+(score-mean)/5)
+	}
+
+	return h, nil
+}
+
+// nights returns the length of a stay, at least one night.
+func nights(inDate, outDate string) float64 {
+	in, err1 := time.Parse("2006-01-02", inDate)
+	out, err2 := time.Parse("2006-01-02", outDate)
+	if err1 != nil || err2 != nil || !out.After(in) {
+		return 1
+	}
+	return math.Ceil(out.Sub(in).Hours() / 24)
+}
+
+// rankPersonal scores hotels for a user by how close they come to the price
+// band and neighborhoods of the user's past stays, by rating, and by how
+// often other guests who stayed at the same hotels booked them.
+// It returns nil if the user has no usable history.
+func (s *Server) rankPersonal(ctx context.Context, hotels map[string]Hotel, username string) ([]*pb.RankedHotel, error) {
+	h, err := s.loadHistory(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		visited              []Hotel
+		weightSum, priceMean float64
+		maxRate              float64
+	)
+	for _, hotel := range hotels {
+		if hotel.HRate > maxRate {
+			maxRate = hotel.HRate
+		}
+		if w := h.weights[hotel.HId]; w > 0 {
+			visited = append(visited, hotel)
+			weightSum += w
+			priceMean += w * hotel.HPrice
+		}
+	}
+	if len(visited) == 0 {
+		return nil, nil
+	}
+	priceMean /= weightSum
+
+	// the band is at least 20% of the mean, so one stay does not pin a single price
+	priceVar := 0.0
+	for _, hotel := range visited {
+		d := hotel.HPrice - priceMean
+		priceVar += h.weights[hotel.HId] * d * d
+	}
+	band := math.Max(math.Sqrt(priceVar/weightSum), 0.2*priceMean)
+
+	peers, err := s.coBookings(ctx, username, visited)
+	if err != nil {
+		return nil, err
+	}
+	maxPeers := 0.0
+	for _, count := range peers {
+		maxPeers = math.Max(maxPeers, count)
+	}
+
+	res := make([]*pb.RankedHotel, 0, len(hotels))
+	for _, hotel := range hotels {
+		if h.disliked[hotel.HId] {
+			continue
+		}
+
+		d := (hotel.HPrice - priceMean) / band
+		price := math.Exp(-d * d / 2)
+
+		nearest := math.MaxFloat64
+		p := &geoindex.GeoPoint{Pid: "", Plat: hotel.HLat, Plon: hotel.HLon}
+		for _, v := range visited {
+			km := float64(geoindex.Distance(p, &geoindex.GeoPoint{Pid: "", Plat: v.HLat, Plon: v.HLon})) / 1000
+			nearest = math.Min(nearest, km)
+		}
+		area := math.Exp(-nearest / neighborhoodKm)
+
+		rating := 0.0
+		if maxRate > 0 {
+			rating = hotel.HRate / maxRate
+		}
+
+		peer := 0.0
+		if maxPeers > 0 {
+			peer = peers[hotel.HId] / maxPeers
+		}
+
+		phrases := hotelPhrases(hotel, nearest)
+		phrases["distance"] = "near where you stayed before"
+		if h.weights[hotel.HId] > 0 {
+			phrases["distance"] = "a hotel you stayed at"
+		}
+		phrases["price"] = "in your usual price range"
+		phrases["peers"] = "booked by guests who stayed where you did"
+
+		res = append(res, ranked(hotel.HId, &pb.Factors{
+			Distance: personalAreaWeight * area,
+			Rate:     personalRatingWeight * rating,
+			Price:    personalPriceWeight * price,
+			Peers:    personalPeerWeight * peer,
+		}, phrases))
+	}
+
+	return res, nil
+}
+
+// coBookings returns, per hotel, how many other guests who stayed at one of
+// the visited hotels also booked it.
+func (s *Server) coBookings(ctx context.Context, username string, visited []Hotel) (map[string]float64, error) {
+	hotelIds := make([]string, 0, len(visited))
+	for _, hotel := range visited {
+		hotelIds = append(hotelIds, hotel.HId)
+	}
+
+	resp, err := s.reservationClient.CoBookings(ctx, &reservation.CoBookingRequest{
+		HotelId:      hotelIds,
+		CustomerName: username,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]float64)
+	for _, c := range resp.Counts {
+		counts[c.HotelId] = float64(c.Count)
+	}
+	return counts, nil
+}
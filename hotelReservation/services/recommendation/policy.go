@@ -0,0 +1,30 @@
+package recommendation
+
+import (
+	"github.com/harlow/go-micro-services/rbac"
+	pb "github.com/harlow/go-micro-services/services/recommendation/proto"
+)
+
+// self is the identity the recommendation service calls other services
+// with.
+var self = &rbac.Identity{Subject: name, Role: rbac.Service}
+
+// policy is who may call the recommendation service. Personal
+// recommendations are only for the user they are made for.
+var policy = rbac.Policy{
+	"/recommendation.Recommendation/GetRecommendations": {
+		Public: true,
+		Owner: func(req interface{}) string {
+			return req.(*pb.Request).Username
+		},
+	},
+	"/recommendation.Recommendation/Trending": {Public: true},
+	// editing a hotel changes how it ranks; the profile service refreshes
+	// the hotels guests score
+	"/recommendation.Recommendation/Refresh": {
+		Roles: []rbac.Role{rbac.HotelManager, rbac.ChainAdmin},
+		Hotels: func(req interface{}) []string {
+			return req.(*pb.RefreshRequest).HotelIds
+		},
+	},
+}
@@ -0,0 +1,32 @@
+package recommendation
+
+import (
+	"testing"
+
+	"github.com/harlow/go-micro-services/rbac"
+	pb "github.com/harlow/go-micro-services/services/recommendation/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRefreshPolicy(t *testing.T) {
+	const method = "/recommendation.Recommendation/Refresh"
+	req := &pb.RefreshRequest{HotelIds: []string{"1"}}
+
+	tests := []struct {
+		id   *rbac.Identity
+		want codes.Code
+	}{
+		{nil, codes.Unauthenticated},
+		{&rbac.Identity{Subject: "alice", Role: rbac.Guest}, codes.PermissionDenied},
+		{&rbac.Identity{Subject: "manager@example.com", Role: rbac.HotelManager, Hotels: []string{"2"}}, codes.PermissionDenied},
+		{&rbac.Identity{Subject: "manager@example.com", Role: rbac.HotelManager, Hotels: []string{"1"}}, codes.OK},
+		{&rbac.Identity{Subject: "srv-profile", Role: rbac.Service}, codes.OK},
+	}
+
+	for _, tt := range tests {
+		if got := status.Code(policy.Authorize(method, tt.id, req)); got != tt.want {
+			t.Errorf("%v: got %v, want %v", tt.id, got, tt.want)
+		}
+	}
+}
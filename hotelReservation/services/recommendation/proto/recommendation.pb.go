@@ -1,14 +1,35 @@
 // Code generated by protoc-gen-go. DO NOT EDIT.
-// source: recommendation.proto
+// source: services/recommendation/proto/recommendation.proto
 
+/*
+Package recommendation is a generated protocol buffer package.
+
+It is generated from these files:
+	services/recommendation/proto/recommendation.proto
+
+It has these top-level messages:
+	Request
+	Result
+	RankedHotel
+	Factors
+	RefreshRequest
+	RefreshResult
+	ActivityRequest
+	ActivityResult
+	TrendingRequest
+	TrendingResult
+	TrendingHotel
+	HotelRequest
+*/
 package recommendation
 
+import proto "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+
 import (
-	context "context"
-	fmt "fmt"
-	proto "github.com/golang/protobuf/proto"
+	context "golang.org/x/net/context"
 	grpc "google.golang.org/grpc"
-	math "math"
 )
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -22,11 +43,47 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
 
-// The requirement of the recommendation.
+type Activity int32
+
+const (
+	Activity_UNKNOWN    Activity = 0
+	Activity_BOOKING    Activity = 1
+	Activity_IMPRESSION Activity = 2
+	Activity_VIEW       Activity = 3
+)
+
+var Activity_name = map[int32]string{
+	0: "UNKNOWN",
+	1: "BOOKING",
+	2: "IMPRESSION",
+	3: "VIEW",
+}
+var Activity_value = map[string]int32{
+	"UNKNOWN":    0,
+	"BOOKING":    1,
+	"IMPRESSION": 2,
+	"VIEW":       3,
+}
+
+func (x Activity) String() string {
+	return proto.EnumName(Activity_name, int32(x))
+}
+func (Activity) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{0} }
+
+// The requirement of the recommendation names the strategy ranking the
+// hotels: nearest, rated, cheapest, mix, personal, trending or diverse.
+// The personal requirement ranks hotels for the given username from its
+// booking history.
+// k bounds the number of hotels returned, 0 selects the server default.
+// weights tunes the mix and diverse requirements; only their proportions
+// matter, and none may be negative.
 type Request struct {
-	Require string   `protobuf:"bytes,1,opt,name=require" json:"require,omitempty"`
-	Lat     float64  `protobuf:"fixed64,2,opt,name=lat" json:"lat,omitempty"`
-	Lon     float64  `protobuf:"fixed64,3,opt,name=lon" json:"lon,omitempty"`
+	Require  string   `protobuf:"bytes,1,opt,name=require" json:"require,omitempty"`
+	Lat      float64  `protobuf:"fixed64,2,opt,name=lat" json:"lat,omitempty"`
+	Lon      float64  `protobuf:"fixed64,3,opt,name=lon" json:"lon,omitempty"`
+	Username string   `protobuf:"bytes,4,opt,name=username" json:"username,omitempty"`
+	K        int32    `protobuf:"varint,5,opt,name=k" json:"k,omitempty"`
+	Weights  *Factors `protobuf:"bytes,6,opt,name=weights" json:"weights,omitempty"`
 }
 
 func (m *Request) Reset()                    { *m = Request{} }
@@ -55,8 +112,34 @@ func (m *Request) GetLon() float64 {
 	return 0
 }
 
+func (m *Request) GetUsername() string {
+	if m != nil {
+		return m.Username
+	}
+	return ""
+}
+
+func (m *Request) GetK() int32 {
+	if m != nil {
+		return m.K
+	}
+	return 0
+}
+
+func (m *Request) GetWeights() *Factors {
+	if m != nil {
+		return m.Weights
+	}
+	return nil
+}
+
+// HotelIds and recommendations are ranked best first. Their scores fall
+// in that order for every requirement but diverse, which lists hotels in
+// the order it picked them and keeps the mix score of each, so a hotel
+// picked for being unlike the earlier ones may score above them.
 type Result struct {
-	HotelIds []string `protobuf:"bytes,1,rep,name=HotelIds" json:"HotelIds,omitempty"`
+	HotelIds        []string       `protobuf:"bytes,1,rep,name=HotelIds" json:"HotelIds,omitempty"`
+	Recommendations []*RankedHotel `protobuf:"bytes,2,rep,name=recommendations" json:"recommendations,omitempty"`
 }
 
 func (m *Result) Reset()                    { *m = Result{} }
@@ -71,9 +154,334 @@ func (m *Result) GetHotelIds() []string {
 	return nil
 }
 
+func (m *Result) GetRecommendations() []*RankedHotel {
+	if m != nil {
+		return m.Recommendations
+	}
+	return nil
+}
+
+type RankedHotel struct {
+	HotelId string  `protobuf:"bytes,1,opt,name=hotelId" json:"hotelId,omitempty"`
+	Score   float64 `protobuf:"fixed64,2,opt,name=score" json:"score,omitempty"`
+	// what each factor adds to the score
+	Contributions *Factors `protobuf:"bytes,3,opt,name=contributions" json:"contributions,omitempty"`
+	Reason        string   `protobuf:"bytes,4,opt,name=reason" json:"reason,omitempty"`
+}
+
+func (m *RankedHotel) Reset()                    { *m = RankedHotel{} }
+func (m *RankedHotel) String() string            { return proto.CompactTextString(m) }
+func (*RankedHotel) ProtoMessage()               {}
+func (*RankedHotel) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{2} }
+
+func (m *RankedHotel) GetHotelId() string {
+	if m != nil {
+		return m.HotelId
+	}
+	return ""
+}
+
+func (m *RankedHotel) GetScore() float64 {
+	if m != nil {
+		return m.Score
+	}
+	return 0
+}
+
+func (m *RankedHotel) GetContributions() *Factors {
+	if m != nil {
+		return m.Contributions
+	}
+	return nil
+}
+
+func (m *RankedHotel) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+// peers only applies to personal recommendations: how often guests with
+// a similar history booked the hotel. popularity only applies to trending
+// recommendations: how often the hotel was booked lately.
+type Factors struct {
+	Distance   float64 `protobuf:"fixed64,1,opt,name=distance" json:"distance,omitempty"`
+	Rate       float64 `protobuf:"fixed64,2,opt,name=rate" json:"rate,omitempty"`
+	Price      float64 `protobuf:"fixed64,3,opt,name=price" json:"price,omitempty"`
+	Peers      float64 `protobuf:"fixed64,4,opt,name=peers" json:"peers,omitempty"`
+	Popularity float64 `protobuf:"fixed64,5,opt,name=popularity" json:"popularity,omitempty"`
+}
+
+func (m *Factors) Reset()                    { *m = Factors{} }
+func (m *Factors) String() string            { return proto.CompactTextString(m) }
+func (*Factors) ProtoMessage()               {}
+func (*Factors) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
+
+func (m *Factors) GetDistance() float64 {
+	if m != nil {
+		return m.Distance
+	}
+	return 0
+}
+
+func (m *Factors) GetRate() float64 {
+	if m != nil {
+		return m.Rate
+	}
+	return 0
+}
+
+func (m *Factors) GetPrice() float64 {
+	if m != nil {
+		return m.Price
+	}
+	return 0
+}
+
+func (m *Factors) GetPeers() float64 {
+	if m != nil {
+		return m.Peers
+	}
+	return 0
+}
+
+func (m *Factors) GetPopularity() float64 {
+	if m != nil {
+		return m.Popularity
+	}
+	return 0
+}
+
+type RefreshRequest struct {
+	HotelIds []string `protobuf:"bytes,1,rep,name=hotelIds" json:"hotelIds,omitempty"`
+}
+
+func (m *RefreshRequest) Reset()                    { *m = RefreshRequest{} }
+func (m *RefreshRequest) String() string            { return proto.CompactTextString(m) }
+func (*RefreshRequest) ProtoMessage()               {}
+func (*RefreshRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
+
+func (m *RefreshRequest) GetHotelIds() []string {
+	if m != nil {
+		return m.HotelIds
+	}
+	return nil
+}
+
+// hotels is the number of hotels known after the refresh.
+type RefreshResult struct {
+	Hotels int32 `protobuf:"varint,1,opt,name=hotels" json:"hotels,omitempty"`
+}
+
+func (m *RefreshResult) Reset()                    { *m = RefreshResult{} }
+func (m *RefreshResult) String() string            { return proto.CompactTextString(m) }
+func (*RefreshResult) ProtoMessage()               {}
+func (*RefreshResult) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+
+func (m *RefreshResult) GetHotels() int32 {
+	if m != nil {
+		return m.Hotels
+	}
+	return 0
+}
+
+// Every hotel listed counts one activity of the given kind.
+type ActivityRequest struct {
+	Activity Activity `protobuf:"varint,1,opt,name=activity,enum=recommendation.Activity" json:"activity,omitempty"`
+	HotelIds []string `protobuf:"bytes,2,rep,name=hotelIds" json:"hotelIds,omitempty"`
+}
+
+func (m *ActivityRequest) Reset()                    { *m = ActivityRequest{} }
+func (m *ActivityRequest) String() string            { return proto.CompactTextString(m) }
+func (*ActivityRequest) ProtoMessage()               {}
+func (*ActivityRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
+
+func (m *ActivityRequest) GetActivity() Activity {
+	if m != nil {
+		return m.Activity
+	}
+	return Activity_UNKNOWN
+}
+
+func (m *ActivityRequest) GetHotelIds() []string {
+	if m != nil {
+		return m.HotelIds
+	}
+	return nil
+}
+
+type ActivityResult struct {
+}
+
+func (m *ActivityResult) Reset()                    { *m = ActivityResult{} }
+func (m *ActivityResult) String() string            { return proto.CompactTextString(m) }
+func (*ActivityResult) ProtoMessage()               {}
+func (*ActivityResult) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
+
+// radiusKm and k of 0 select the server defaults.
+type TrendingRequest struct {
+	Lat      float64 `protobuf:"fixed64,1,opt,name=lat" json:"lat,omitempty"`
+	Lon      float64 `protobuf:"fixed64,2,opt,name=lon" json:"lon,omitempty"`
+	RadiusKm float64 `protobuf:"fixed64,3,opt,name=radiusKm" json:"radiusKm,omitempty"`
+	K        int32   `protobuf:"varint,4,opt,name=k" json:"k,omitempty"`
+}
+
+func (m *TrendingRequest) Reset()                    { *m = TrendingRequest{} }
+func (m *TrendingRequest) String() string            { return proto.CompactTextString(m) }
+func (*TrendingRequest) ProtoMessage()               {}
+func (*TrendingRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
+
+func (m *TrendingRequest) GetLat() float64 {
+	if m != nil {
+		return m.Lat
+	}
+	return 0
+}
+
+func (m *TrendingRequest) GetLon() float64 {
+	if m != nil {
+		return m.Lon
+	}
+	return 0
+}
+
+func (m *TrendingRequest) GetRadiusKm() float64 {
+	if m != nil {
+		return m.RadiusKm
+	}
+	return 0
+}
+
+func (m *TrendingRequest) GetK() int32 {
+	if m != nil {
+		return m.K
+	}
+	return 0
+}
+
+// hotels are ranked best first.
+type TrendingResult struct {
+	Hotels []*TrendingHotel `protobuf:"bytes,1,rep,name=hotels" json:"hotels,omitempty"`
+}
+
+func (m *TrendingResult) Reset()                    { *m = TrendingResult{} }
+func (m *TrendingResult) String() string            { return proto.CompactTextString(m) }
+func (*TrendingResult) ProtoMessage()               {}
+func (*TrendingResult) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
+
+func (m *TrendingResult) GetHotels() []*TrendingHotel {
+	if m != nil {
+		return m.Hotels
+	}
+	return nil
+}
+
+// bookings, impressions and views are decayed counts, so recent activity
+// weighs more than old activity.
+type TrendingHotel struct {
+	HotelId     string  `protobuf:"bytes,1,opt,name=hotelId" json:"hotelId,omitempty"`
+	Score       float64 `protobuf:"fixed64,2,opt,name=score" json:"score,omitempty"`
+	Bookings    float64 `protobuf:"fixed64,3,opt,name=bookings" json:"bookings,omitempty"`
+	Impressions float64 `protobuf:"fixed64,4,opt,name=impressions" json:"impressions,omitempty"`
+	Views       float64 `protobuf:"fixed64,5,opt,name=views" json:"views,omitempty"`
+	DistanceKm  float64 `protobuf:"fixed64,6,opt,name=distanceKm" json:"distanceKm,omitempty"`
+}
+
+func (m *TrendingHotel) Reset()                    { *m = TrendingHotel{} }
+func (m *TrendingHotel) String() string            { return proto.CompactTextString(m) }
+func (*TrendingHotel) ProtoMessage()               {}
+func (*TrendingHotel) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
+
+func (m *TrendingHotel) GetHotelId() string {
+	if m != nil {
+		return m.HotelId
+	}
+	return ""
+}
+
+func (m *TrendingHotel) GetScore() float64 {
+	if m != nil {
+		return m.Score
+	}
+	return 0
+}
+
+func (m *TrendingHotel) GetBookings() float64 {
+	if m != nil {
+		return m.Bookings
+	}
+	return 0
+}
+
+func (m *TrendingHotel) GetImpressions() float64 {
+	if m != nil {
+		return m.Impressions
+	}
+	return 0
+}
+
+func (m *TrendingHotel) GetViews() float64 {
+	if m != nil {
+		return m.Views
+	}
+	return 0
+}
+
+func (m *TrendingHotel) GetDistanceKm() float64 {
+	if m != nil {
+		return m.DistanceKm
+	}
+	return 0
+}
+
+// lat and lon are ignored by RemoveHotel.
+type HotelRequest struct {
+	HotelId string  `protobuf:"bytes,1,opt,name=hotelId" json:"hotelId,omitempty"`
+	Lat     float64 `protobuf:"fixed64,2,opt,name=lat" json:"lat,omitempty"`
+	Lon     float64 `protobuf:"fixed64,3,opt,name=lon" json:"lon,omitempty"`
+}
+
+func (m *HotelRequest) Reset()                    { *m = HotelRequest{} }
+func (m *HotelRequest) String() string            { return proto.CompactTextString(m) }
+func (*HotelRequest) ProtoMessage()               {}
+func (*HotelRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
+
+func (m *HotelRequest) GetHotelId() string {
+	if m != nil {
+		return m.HotelId
+	}
+	return ""
+}
+
+func (m *HotelRequest) GetLat() float64 {
+	if m != nil {
+		return m.Lat
+	}
+	return 0
+}
+
+func (m *HotelRequest) GetLon() float64 {
+	if m != nil {
+		return m.Lon
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*Request)(nil), "recommendation.Request")
 	proto.RegisterType((*Result)(nil), "recommendation.Result")
+	proto.RegisterType((*RankedHotel)(nil), "recommendation.RankedHotel")
+	proto.RegisterType((*Factors)(nil), "recommendation.Factors")
+	proto.RegisterType((*RefreshRequest)(nil), "recommendation.RefreshRequest")
+	proto.RegisterType((*RefreshResult)(nil), "recommendation.RefreshResult")
+	proto.RegisterType((*ActivityRequest)(nil), "recommendation.ActivityRequest")
+	proto.RegisterType((*ActivityResult)(nil), "recommendation.ActivityResult")
+	proto.RegisterType((*TrendingRequest)(nil), "recommendation.TrendingRequest")
+	proto.RegisterType((*TrendingResult)(nil), "recommendation.TrendingResult")
+	proto.RegisterType((*TrendingHotel)(nil), "recommendation.TrendingHotel")
+	proto.RegisterType((*HotelRequest)(nil), "recommendation.HotelRequest")
+	proto.RegisterEnum("recommendation.Activity", Activity_name, Activity_value)
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -84,12 +492,24 @@ var _ grpc.ClientConn
 // is compatible with the grpc package it is being compiled against.
 const _ = grpc.SupportPackageIsVersion4
 
-// RecommendationClient is the client API for Recommendation service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+// Client API for Recommendation service
+
 type RecommendationClient interface {
 	// GetRecommendations returns recommended hotels for a given requirement
 	GetRecommendations(ctx context.Context, in *Request, opts ...grpc.CallOption) (*Result, error)
+	// Refresh reloads the given hotels from the profile service, or
+	// everything when no hotel is given
+	Refresh(ctx context.Context, in *RefreshRequest, opts ...grpc.CallOption) (*RefreshResult, error)
+	// RecordActivity counts bookings, search impressions or profile views
+	// of hotels towards their trending score
+	RecordActivity(ctx context.Context, in *ActivityRequest, opts ...grpc.CallOption) (*ActivityResult, error)
+	// Trending returns the hotels with the most recent activity near a location
+	Trending(ctx context.Context, in *TrendingRequest, opts ...grpc.CallOption) (*TrendingResult, error)
+	// AddHotel adds a new hotel at a location, with the score and price of
+	// its profile
+	AddHotel(ctx context.Context, in *HotelRequest, opts ...grpc.CallOption) (*RefreshResult, error)
+	// RemoveHotel drops a hotel
+	RemoveHotel(ctx context.Context, in *HotelRequest, opts ...grpc.CallOption) (*RefreshResult, error)
 }
 
 type recommendationClient struct {
@@ -102,17 +522,76 @@ func NewRecommendationClient(cc *grpc.ClientConn) RecommendationClient {
 
 func (c *recommendationClient) GetRecommendations(ctx context.Context, in *Request, opts ...grpc.CallOption) (*Result, error) {
 	out := new(Result)
-	err := c.cc.Invoke(ctx, "/recommendation.Recommendation/GetRecommendations", in, out, opts...)
+	err := grpc.Invoke(ctx, "/recommendation.Recommendation/GetRecommendations", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *recommendationClient) Refresh(ctx context.Context, in *RefreshRequest, opts ...grpc.CallOption) (*RefreshResult, error) {
+	out := new(RefreshResult)
+	err := grpc.Invoke(ctx, "/recommendation.Recommendation/Refresh", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *recommendationClient) RecordActivity(ctx context.Context, in *ActivityRequest, opts ...grpc.CallOption) (*ActivityResult, error) {
+	out := new(ActivityResult)
+	err := grpc.Invoke(ctx, "/recommendation.Recommendation/RecordActivity", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-// RecommendationServer is the server API for Recommendation service.
+func (c *recommendationClient) Trending(ctx context.Context, in *TrendingRequest, opts ...grpc.CallOption) (*TrendingResult, error) {
+	out := new(TrendingResult)
+	err := grpc.Invoke(ctx, "/recommendation.Recommendation/Trending", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *recommendationClient) AddHotel(ctx context.Context, in *HotelRequest, opts ...grpc.CallOption) (*RefreshResult, error) {
+	out := new(RefreshResult)
+	err := grpc.Invoke(ctx, "/recommendation.Recommendation/AddHotel", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *recommendationClient) RemoveHotel(ctx context.Context, in *HotelRequest, opts ...grpc.CallOption) (*RefreshResult, error) {
+	out := new(RefreshResult)
+	err := grpc.Invoke(ctx, "/recommendation.Recommendation/RemoveHotel", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for Recommendation service
+
 type RecommendationServer interface {
 	// GetRecommendations returns recommended hotels for a given requirement
 	GetRecommendations(context.Context, *Request) (*Result, error)
+	// Refresh reloads the given hotels from the profile service, or
+	// everything when no hotel is given
+	Refresh(context.Context, *RefreshRequest) (*RefreshResult, error)
+	// RecordActivity counts bookings, search impressions or profile views
+	// of hotels towards their trending score
+	RecordActivity(context.Context, *ActivityRequest) (*ActivityResult, error)
+	// Trending returns the hotels with the most recent activity near a location
+	Trending(context.Context, *TrendingRequest) (*TrendingResult, error)
+	// AddHotel adds a new hotel at a location, with the score and price of
+	// its profile
+	AddHotel(context.Context, *HotelRequest) (*RefreshResult, error)
+	// RemoveHotel drops a hotel
+	RemoveHotel(context.Context, *HotelRequest) (*RefreshResult, error)
 }
 
 func RegisterRecommendationServer(s *grpc.Server, srv RecommendationServer) {
@@ -137,6 +616,96 @@ func _Recommendation_GetRecommendations_Handler(srv interface{}, ctx context.Con
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Recommendation_Refresh_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RefreshRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RecommendationServer).Refresh(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/recommendation.Recommendation/Refresh",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RecommendationServer).Refresh(ctx, req.(*RefreshRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Recommendation_RecordActivity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ActivityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RecommendationServer).RecordActivity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/recommendation.Recommendation/RecordActivity",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RecommendationServer).RecordActivity(ctx, req.(*ActivityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Recommendation_Trending_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TrendingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RecommendationServer).Trending(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/recommendation.Recommendation/Trending",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RecommendationServer).Trending(ctx, req.(*TrendingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Recommendation_AddHotel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HotelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RecommendationServer).AddHotel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/recommendation.Recommendation/AddHotel",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RecommendationServer).AddHotel(ctx, req.(*HotelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Recommendation_RemoveHotel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HotelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RecommendationServer).RemoveHotel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/recommendation.Recommendation/RemoveHotel",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RecommendationServer).RemoveHotel(ctx, req.(*HotelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Recommendation_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "recommendation.Recommendation",
 	HandlerType: (*RecommendationServer)(nil),
@@ -145,24 +714,80 @@ var _Recommendation_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetRecommendations",
 			Handler:    _Recommendation_GetRecommendations_Handler,
 		},
+		{
+			MethodName: "Refresh",
+			Handler:    _Recommendation_Refresh_Handler,
+		},
+		{
+			MethodName: "RecordActivity",
+			Handler:    _Recommendation_RecordActivity_Handler,
+		},
+		{
+			MethodName: "Trending",
+			Handler:    _Recommendation_Trending_Handler,
+		},
+		{
+			MethodName: "AddHotel",
+			Handler:    _Recommendation_AddHotel_Handler,
+		},
+		{
+			MethodName: "RemoveHotel",
+			Handler:    _Recommendation_RemoveHotel_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
-	Metadata: "recommendation.proto",
+	Metadata: "services/recommendation/proto/recommendation.proto",
 }
 
-func init() { proto.RegisterFile("recommendation.proto", fileDescriptor0) }
+func init() {
+	proto.RegisterFile("services/recommendation/proto/recommendation.proto", fileDescriptor0)
+}
 
 var fileDescriptor0 = []byte{
-	// 173 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x64, 0x8f, 0x31, 0xcf, 0x82, 0x40,
-	0x0c, 0x40, 0x73, 0x1f, 0x09, 0x7c, 0x74, 0x20, 0xa6, 0x31, 0x7a, 0x61, 0x22, 0xc4, 0x81, 0x89,
-	0x41, 0x7f, 0x83, 0x41, 0xd7, 0x5b, 0x9c, 0x51, 0x3a, 0x90, 0xc0, 0x55, 0xee, 0xca, 0xff, 0x37,
-	0x10, 0x34, 0x41, 0xb7, 0xbe, 0xd7, 0xa1, 0xaf, 0xb0, 0x75, 0xf4, 0xe0, 0xbe, 0x27, 0xdb, 0xd4,
-	0xd2, 0xb2, 0x2d, 0x9f, 0x8e, 0x85, 0x31, 0x59, 0xdb, 0xbc, 0x82, 0xc8, 0xd0, 0x30, 0x92, 0x17,
-	0xd4, 0x10, 0x39, 0x1a, 0xc6, 0xd6, 0x91, 0x56, 0x99, 0x2a, 0x62, 0xf3, 0x46, 0xdc, 0x40, 0xd0,
-	0xd5, 0xa2, 0xff, 0x32, 0x55, 0x28, 0x33, 0x8d, 0xb3, 0x61, 0xab, 0x83, 0xc5, 0xb0, 0xcd, 0x0f,
-	0x10, 0x1a, 0xf2, 0x63, 0x27, 0x98, 0xc2, 0xff, 0x85, 0x85, 0xba, 0x6b, 0xe3, 0xb5, 0xca, 0x82,
-	0x22, 0x36, 0x1f, 0x3e, 0xde, 0x20, 0x31, 0xab, 0x00, 0x3c, 0x03, 0x56, 0x24, 0x6b, 0xe9, 0x71,
-	0x5f, 0x7e, 0xd5, 0x2f, 0x91, 0xe9, 0xee, 0x77, 0x31, 0x1d, 0xbd, 0x87, 0xf3, 0x7b, 0xa7, 0x57,
-	0x00, 0x00, 0x00, 0xff, 0xff, 0x11, 0x5f, 0xf9, 0xa7, 0xf6, 0x00, 0x00, 0x00,
-}
\ No newline at end of file
+	// 711 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x55, 0xdf, 0x6e, 0xd3, 0x3e,
+	0x14, 0xfe, 0xb9, 0x7f, 0xf3, 0x3b, 0xdd, 0xba, 0xca, 0x42, 0x23, 0x2a, 0xac, 0x54, 0xb9, 0xa1,
+	0x42, 0x68, 0x13, 0x05, 0x2e, 0x41, 0x1a, 0x52, 0xe9, 0x4a, 0xa1, 0x05, 0x0f, 0xd8, 0x25, 0xca,
+	0x12, 0xb3, 0x5a, 0x6d, 0xe3, 0xce, 0x76, 0x3b, 0xed, 0x01, 0x78, 0x03, 0x1e, 0x81, 0x37, 0xe0,
+	0x5d, 0x78, 0x1e, 0x14, 0xc7, 0xee, 0x92, 0x54, 0xe5, 0x8f, 0xb8, 0xcb, 0xf7, 0xf9, 0xf8, 0x9c,
+	0xcf, 0xe7, 0x7c, 0x76, 0xa0, 0x2b, 0xa9, 0x58, 0xb1, 0x80, 0xca, 0x23, 0x41, 0x03, 0x3e, 0x9f,
+	0xd3, 0x28, 0xf4, 0x15, 0xe3, 0xd1, 0xd1, 0x42, 0x70, 0xc5, 0x73, 0xe4, 0xa1, 0x26, 0x71, 0x3d,
+	0xcb, 0x7a, 0xdf, 0x10, 0x54, 0x09, 0xbd, 0x5c, 0x52, 0xa9, 0xb0, 0x0b, 0x55, 0x41, 0x2f, 0x97,
+	0x4c, 0x50, 0x17, 0xb5, 0x51, 0xe7, 0x7f, 0x62, 0x21, 0x6e, 0x40, 0x71, 0xe6, 0x2b, 0xb7, 0xd0,
+	0x46, 0x1d, 0x44, 0xe2, 0x4f, 0xcd, 0xf0, 0xc8, 0x2d, 0x1a, 0x86, 0x47, 0xb8, 0x09, 0xce, 0x52,
+	0x52, 0x11, 0xf9, 0x73, 0xea, 0x96, 0xf4, 0xf6, 0x35, 0xc6, 0x3b, 0x80, 0xa6, 0x6e, 0xb9, 0x8d,
+	0x3a, 0x65, 0x82, 0xa6, 0xf8, 0x11, 0x54, 0xaf, 0x28, 0xbb, 0x98, 0x28, 0xe9, 0x56, 0xda, 0xa8,
+	0x53, 0xeb, 0xde, 0x3e, 0xcc, 0x69, 0x7d, 0xe9, 0x07, 0x8a, 0x0b, 0x49, 0x6c, 0x9c, 0x37, 0x85,
+	0x0a, 0xa1, 0x72, 0x39, 0x53, 0x71, 0x99, 0x13, 0xae, 0xe8, 0x6c, 0x10, 0x4a, 0x17, 0xb5, 0x8b,
+	0x71, 0x19, 0x8b, 0x71, 0x0f, 0xf6, 0xb2, 0x89, 0xa4, 0x5b, 0x68, 0x17, 0x3b, 0xb5, 0xee, 0x9d,
+	0x7c, 0x01, 0xe2, 0x47, 0x53, 0x1a, 0xea, 0x8d, 0x24, 0xbf, 0xc7, 0xfb, 0x8a, 0xa0, 0x96, 0x0a,
+	0x88, 0xfb, 0x32, 0x49, 0x4a, 0xd8, 0xbe, 0x18, 0x88, 0x6f, 0x41, 0x59, 0x06, 0x5c, 0x50, 0xd3,
+	0x99, 0x04, 0xe0, 0x67, 0xb0, 0x1b, 0xf0, 0x48, 0x09, 0x76, 0xbe, 0x4c, 0x44, 0x14, 0x7f, 0x7d,
+	0xca, 0x6c, 0x34, 0xde, 0x87, 0x8a, 0xa0, 0xbe, 0xe4, 0x91, 0x69, 0xa3, 0x41, 0xde, 0x17, 0x04,
+	0x55, 0xb3, 0x25, 0xee, 0x42, 0xc8, 0xa4, 0xf2, 0xa3, 0x20, 0x99, 0x15, 0x22, 0x6b, 0x8c, 0x31,
+	0x94, 0x84, 0xaf, 0xac, 0x26, 0xfd, 0x1d, 0x0b, 0x5d, 0x08, 0x16, 0x50, 0x33, 0xb0, 0x04, 0x68,
+	0x96, 0x52, 0x21, 0xdd, 0x92, 0x61, 0x63, 0x80, 0x5b, 0x00, 0x0b, 0xbe, 0x58, 0xce, 0x7c, 0xc1,
+	0xd4, 0xb5, 0x9e, 0x1a, 0x22, 0x29, 0xc6, 0x7b, 0x08, 0x75, 0x42, 0x3f, 0x0b, 0x2a, 0x27, 0xd6,
+	0x38, 0x4d, 0x70, 0x26, 0xb9, 0x99, 0x58, 0xec, 0xdd, 0x87, 0xdd, 0x75, 0xb4, 0x1e, 0xe0, 0x3e,
+	0x54, 0xf4, 0xa2, 0xd4, 0xc2, 0xcb, 0xc4, 0x20, 0x2f, 0x80, 0xbd, 0xe3, 0x40, 0xb1, 0x15, 0x53,
+	0xd7, 0x36, 0xef, 0x13, 0x70, 0x7c, 0x43, 0xe9, 0xe0, 0x7a, 0xd7, 0xcd, 0xf7, 0x70, 0xbd, 0x65,
+	0x1d, 0x99, 0x51, 0x53, 0xc8, 0xa9, 0x69, 0x40, 0xfd, 0xa6, 0x48, 0x2c, 0xc7, 0xfb, 0x04, 0x7b,
+	0xef, 0x05, 0x8d, 0x42, 0x16, 0x5d, 0xd8, 0xb2, 0xc6, 0xed, 0x68, 0xc3, 0xed, 0x85, 0x8c, 0xdb,
+	0x85, 0x1f, 0xb2, 0xa5, 0x1c, 0xce, 0x4d, 0x4f, 0xd7, 0x38, 0x71, 0x7b, 0xc9, 0xb8, 0xdd, 0xeb,
+	0x43, 0xfd, 0xa6, 0x80, 0xee, 0xc0, 0xd3, 0x54, 0x07, 0x62, 0x77, 0x1e, 0xe4, 0x0f, 0x65, 0xe3,
+	0x13, 0x7f, 0xda, 0x06, 0x7d, 0x47, 0xb0, 0x9b, 0x59, 0xf9, 0x6b, 0x63, 0x36, 0xc1, 0x39, 0xe7,
+	0x7c, 0xca, 0xa2, 0x0b, 0x69, 0x45, 0x5b, 0x8c, 0xdb, 0x50, 0x63, 0xf3, 0x85, 0xa0, 0x52, 0x6a,
+	0xcb, 0x26, 0x8e, 0x48, 0x53, 0x71, 0xce, 0x15, 0xa3, 0x57, 0xd2, 0x58, 0x22, 0x01, 0xb1, 0x5b,
+	0xac, 0xf3, 0x86, 0x73, 0x7d, 0x9f, 0x11, 0x49, 0x31, 0xde, 0x6b, 0xd8, 0x49, 0x8e, 0x71, 0xf3,
+	0xc8, 0x6c, 0xd1, 0xfc, 0x07, 0x8f, 0xcc, 0x83, 0xe7, 0xe0, 0xd8, 0xf9, 0xe1, 0x1a, 0x54, 0x3f,
+	0x8c, 0x86, 0xa3, 0xf1, 0xd9, 0xa8, 0xf1, 0x5f, 0x0c, 0x5e, 0x8c, 0xc7, 0xc3, 0xc1, 0xa8, 0xdf,
+	0x40, 0xb8, 0x0e, 0x30, 0x78, 0xf3, 0x96, 0xf4, 0x4e, 0x4f, 0x07, 0xe3, 0x51, 0xa3, 0x80, 0x1d,
+	0x28, 0x7d, 0x1c, 0xf4, 0xce, 0x1a, 0xc5, 0xee, 0x8f, 0x62, 0x6c, 0xde, 0x74, 0xb3, 0x71, 0x0f,
+	0x70, 0x9f, 0xaa, 0x2c, 0x29, 0xf1, 0xc6, 0x65, 0x35, 0xfa, 0x9b, 0xfb, 0x9b, 0x0b, 0x7a, 0xa8,
+	0x27, 0x50, 0x35, 0x3e, 0xc7, 0xad, 0xcd, 0x90, 0xf4, 0x75, 0x69, 0x1e, 0x6c, 0x5d, 0xd7, 0x99,
+	0xde, 0x25, 0x12, 0x45, 0xb8, 0x3e, 0xe9, 0xbd, 0xad, 0xae, 0x37, 0x19, 0x5b, 0xdb, 0x03, 0x74,
+	0xca, 0x21, 0x38, 0xd6, 0x39, 0x9b, 0xc9, 0x72, 0xf6, 0x6f, 0xb6, 0xb6, 0x07, 0xe8, 0x64, 0x7d,
+	0x70, 0x8e, 0x43, 0xf3, 0x34, 0xde, 0xcd, 0xc7, 0xa6, 0x67, 0xfd, 0xbb, 0x83, 0xbe, 0x82, 0x1a,
+	0xa1, 0x73, 0xbe, 0xa2, 0xff, 0x9e, 0xeb, 0xbc, 0xa2, 0x7f, 0x6f, 0x8f, 0x7f, 0x0e, 0x00, 0xfe,
+	0x4d, 0x5a, 0xdb, 0x14, 0x07, 0x00, 0x00,
+}
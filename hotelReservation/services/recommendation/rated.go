@@ -0,0 +1,39 @@
+package recommendation
+
+import (
+	"math"
+
+	pb "github.com/harlow/go-micro-services/services/recommendation/proto"
+	"golang.org/x/net/context"
+)
+
+func init() {
+	registerStrategy("rated", newRated)
+	registerStrategy("rate", newRated)
+}
+
+// rated scores the rate relative to the best rated hotel.
+type rated struct{}
+
+func newRated(s *Server) Strategy {
+	return rated{}
+}
+
+func (rated) Rank(ctx context.Context, q *Query) ([]*pb.RankedHotel, error) {
+	max := 0.0
+	for _, hotel := range q.Hotels {
+		max = math.Max(max, hotel.HRate)
+	}
+
+	res := make([]*pb.RankedHotel, 0, len(q.Hotels))
+	for _, hotel := range q.Hotels {
+		rate := 0.0
+		if max > 0 {
+			rate = hotel.HRate / max
+		}
+		res = append(res, ranked(hotel.HId, &pb.Factors{
+			Rate: rate,
+		}, hotelPhrases(hotel, distanceKm(q.Lat, q.Lon, hotel))))
+	}
+	return topK(res, q.K), nil
+}
@@ -0,0 +1,207 @@
+package recommendation
+
+import (
+	"log"
+	"time"
+
+	profile "github.com/harlow/go-micro-services/services/profile/proto"
+	pb "github.com/harlow/go-micro-services/services/recommendation/proto"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gopkg.in/mgo.v2/bson"
+)
+
+const (
+	// how often the hotels are reloaded from mongodb and the profile service
+	refreshInterval = time.Minute
+	// hotels asked of the profile service per call
+	profileBatchSize = 100
+)
+
+// catalog returns the current hotels. The map must not be modified;
+// refreshes build a new one and swap it in.
+func (s *Server) catalog() map[string]Hotel {
+	s.hotelsMu.RLock()
+	defer s.hotelsMu.RUnlock()
+	return s.hotels
+}
+
+func (s *Server) swap(hotels map[string]Hotel) {
+	s.hotelsMu.Lock()
+	s.hotels = hotels
+	s.hotelsMu.Unlock()
+}
+
+// Refresh reloads the given hotels, or all of them.
+func (s *Server) Refresh(ctx context.Context, req *pb.RefreshRequest) (*pb.RefreshResult, error) {
+	var err error
+	if len(req.HotelIds) == 0 {
+		err = s.reload(ctx)
+	} else {
+		err = s.refreshHotels(ctx, req.HotelIds)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &pb.RefreshResult{Hotels: int32(len(s.catalog()))}, nil
+}
+
+func (s *Server) refreshLoop() {
+	for range time.Tick(refreshInterval) {
+		if err := s.reload(context.Background()); err != nil {
+			log.Println("Failed refresh hotels data: ", err)
+		}
+		s.activity.forget(time.Now())
+	}
+}
+
+// reload reads every hotel from recommendation-db and takes its current
+// score and price from the profile service.
+func (s *Server) reload(ctx context.Context) error {
+	s.refreshMu.Lock()
+	defer s.refreshMu.Unlock()
+
+	hotels, err := loadRecommendations(s.MongoSession)
+	if err != nil {
+		return err
+	}
+
+	hotelIds := make([]string, 0, len(hotels))
+	for hotelId := range hotels {
+		hotelIds = append(hotelIds, hotelId)
+	}
+	// the stored rate and price still beat no hotels at all
+	if err := s.overlayProfiles(ctx, hotels, hotelIds); err != nil {
+		log.Println("Failed get profiles for recommendations: ", err)
+	}
+
+	s.swap(hotels)
+	return nil
+}
+
+// refreshHotels updates the score and price of some hotels in a copy of
+// the catalog and swaps it in.
+func (s *Server) refreshHotels(ctx context.Context, hotelIds []string) error {
+	s.refreshMu.Lock()
+	defer s.refreshMu.Unlock()
+
+	hotels := s.copyCatalog()
+	if err := s.overlayProfiles(ctx, hotels, hotelIds); err != nil {
+		return err
+	}
+
+	s.swap(hotels)
+	return nil
+}
+
+// overlayProfiles replaces the rate and price of the given hotels with the
+// guest score and price their profiles hold now. Profiles of hotels not in
+// the map are ignored.
+func (s *Server) overlayProfiles(ctx context.Context, hotels map[string]Hotel, hotelIds []string) error {
+	for start := 0; start < len(hotelIds); start += profileBatchSize {
+		end := start + profileBatchSize
+		if end > len(hotelIds) {
+			end = len(hotelIds)
+		}
+
+		profileResp, err := s.profileClient.GetProfiles(ctx, &profile.Request{
+			HotelIds:   hotelIds[start:end],
+			Locale:     "en",
+			Background: true,
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, p := range profileResp.Hotels {
+			hotel, ok := hotels[p.Id]
+			if !ok {
+				continue
+			}
+			hotel.HRate = float64(p.Score)
+			hotel.HPrice = float64(p.Price)
+			hotels[p.Id] = hotel
+		}
+	}
+	return nil
+}
+
+// AddHotel stores a new hotel and adds it to the catalog of this replica;
+// the others pick it up on their next reload.
+func (s *Server) AddHotel(ctx context.Context, req *pb.HotelRequest) (*pb.RefreshResult, error) {
+	if req.HotelId == "" {
+		return nil, status.Error(codes.InvalidArgument, "hotel id must be set")
+	}
+	if req.Lat < -90 || req.Lat > 90 || req.Lon < -180 || req.Lon > 180 {
+		return nil, status.Error(codes.InvalidArgument, "lat/lon out of range")
+	}
+
+	session := s.MongoSession.Copy()
+	defer session.Close()
+	c := session.DB("recommendation-db").C("recommendation")
+
+	n, err := c.Find(bson.M{"hotelId": req.HotelId}).Count()
+	if err != nil {
+		return nil, err
+	}
+	if n > 0 {
+		return nil, status.Errorf(codes.AlreadyExists, "hotel %s is already known", req.HotelId)
+	}
+	hotel := Hotel{
+		ID:   bson.NewObjectId(),
+		HId:  req.HotelId,
+		HLat: req.Lat,
+		HLon: req.Lon,
+	}
+	if err := c.Insert(&hotel); err != nil {
+		return nil, err
+	}
+
+	s.refreshMu.Lock()
+	defer s.refreshMu.Unlock()
+
+	hotels := s.copyCatalog()
+	hotels[hotel.HId] = hotel
+	if err := s.overlayProfiles(ctx, hotels, []string{hotel.HId}); err != nil {
+		log.Println("Failed get profiles for recommendations: ", err)
+	}
+	s.swap(hotels)
+
+	return &pb.RefreshResult{Hotels: int32(len(hotels))}, nil
+}
+
+// RemoveHotel deletes a hotel and drops it from the catalog of this
+// replica; the others drop it on their next reload.
+func (s *Server) RemoveHotel(ctx context.Context, req *pb.HotelRequest) (*pb.RefreshResult, error) {
+	if req.HotelId == "" {
+		return nil, status.Error(codes.InvalidArgument, "hotel id must be set")
+	}
+
+	session := s.MongoSession.Copy()
+	defer session.Close()
+
+	_, err := session.DB("recommendation-db").C("recommendation").RemoveAll(bson.M{"hotelId": req.HotelId})
+	if err != nil {
+		return nil, err
+	}
+
+	s.refreshMu.Lock()
+	defer s.refreshMu.Unlock()
+
+	hotels := s.copyCatalog()
+	delete(hotels, req.HotelId)
+	s.swap(hotels)
+
+	return &pb.RefreshResult{Hotels: int32(len(hotels))}, nil
+}
+
+// copyCatalog returns a copy of the catalog to modify and swap in.
+func (s *Server) copyCatalog() map[string]Hotel {
+	current := s.catalog()
+	hotels := make(map[string]Hotel, len(current)+1)
+	for hotelId, hotel := range current {
+		hotels[hotelId] = hotel
+	}
+	return hotels
+}
@@ -4,20 +4,26 @@ import (
 	// "encoding/json"
 	"fmt"
 	"github.com/grpc-ecosystem/grpc-opentracing/go/otgrpc"
-	"github.com/hailocab/go-geoindex"
+	"github.com/harlow/go-micro-services/dialer"
+	"github.com/harlow/go-micro-services/rbac"
 	"github.com/harlow/go-micro-services/registry"
+	profile "github.com/harlow/go-micro-services/services/profile/proto"
 	pb "github.com/harlow/go-micro-services/services/recommendation/proto"
+	reservation "github.com/harlow/go-micro-services/services/reservation/proto"
+	user "github.com/harlow/go-micro-services/services/user/proto"
 	"github.com/opentracing/opentracing-go"
 	"golang.org/x/net/context"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/status"
 	"gopkg.in/mgo.v2"
 	"gopkg.in/mgo.v2/bson"
 	// "io/ioutil"
 	"log"
-	"math"
 	"net"
 	// "os"
+	"sync"
 	"time"
 
 	// "strings"
@@ -27,12 +33,24 @@ const name = "srv-recommendation"
 
 // Server implements the recommendation service
 type Server struct {
-	hotels map[string]Hotel
+	// hotels is replaced, never modified, on refresh; see catalog
+	hotels    map[string]Hotel
+	hotelsMu  sync.RWMutex
+	refreshMu sync.Mutex
+
+	activity tracker
+
+	profileClient     profile.ProfileClient
+	reservationClient reservation.ReservationClient
+	userClient        user.UserClient
+
 	Tracer   opentracing.Tracer
 	Port     int
 	IpAddr	 string
 	MongoSession	*mgo.Session
 	Registry *registry.Client
+	// Identity signs and verifies the identities calls are made by
+	Identity *rbac.Signer
 }
 
 // Run starts the server
@@ -41,8 +59,8 @@ func (s *Server) Run() error {
 		return fmt.Errorf("server port must be set")
 	}
 
-	if s.hotels == nil {
-		s.hotels = loadRecommendations(s.MongoSession)
+	if s.Identity == nil {
+		return fmt.Errorf("identity keys must be set")
 	}
 
 	srv := grpc.NewServer(
@@ -52,13 +70,32 @@ func (s *Server) Run() error {
 		grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
 			PermitWithoutStream: true,
 		}),
-		grpc.UnaryInterceptor(
+		grpc.UnaryInterceptor(rbac.ChainUnaryServer(
 			otgrpc.OpenTracingServerInterceptor(s.Tracer),
-		),
+			rbac.UnaryServerInterceptor(s.Identity, policy),
+		)),
 	)
 
 	pb.RegisterRecommendationServer(srv, s)
 
+	// init grpc clients
+	if err := s.initReservationClient("srv-reservation"); err != nil {
+		return err
+	}
+	if err := s.initUserClient("srv-user"); err != nil {
+		return err
+	}
+	if err := s.initProfileClient("srv-profile"); err != nil {
+		return err
+	}
+
+	if s.catalog() == nil {
+		if err := s.reload(context.Background()); err != nil {
+			log.Println("Failed get hotels data: ", err)
+		}
+	}
+	go s.refreshLoop()
+
 	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", s.Port))
 	if err != nil {
 		log.Fatalf("failed to listen: %v", err)
@@ -90,119 +127,83 @@ func (s *Server) Shutdown() {
 	s.Registry.Deregister(name)
 }
 
-// GiveRecommendation returns recommendations within a given requirement.
+func (s *Server) initReservationClient(name string) error {
+	conn, err := dialer.Dial(
+		name,
+		dialer.WithTracer(s.Tracer),
+		dialer.WithBalancer(s.Registry.Client),
+		dialer.WithIdentity(s.Identity, self),
+	)
+	if err != nil {
+		return fmt.Errorf("dialer error: %v", err)
+	}
+	s.reservationClient = reservation.NewReservationClient(conn)
+	return nil
+}
+
+func (s *Server) initUserClient(name string) error {
+	conn, err := dialer.Dial(
+		name,
+		dialer.WithTracer(s.Tracer),
+		dialer.WithBalancer(s.Registry.Client),
+		dialer.WithIdentity(s.Identity, self),
+	)
+	if err != nil {
+		return fmt.Errorf("dialer error: %v", err)
+	}
+	s.userClient = user.NewUserClient(conn)
+	return nil
+}
+
+func (s *Server) initProfileClient(name string) error {
+	conn, err := dialer.Dial(
+		name,
+		dialer.WithTracer(s.Tracer),
+		dialer.WithBalancer(s.Registry.Client),
+		dialer.WithIdentity(s.Identity, self),
+	)
+	if err != nil {
+		return fmt.Errorf("dialer error: %v", err)
+	}
+	s.profileClient = profile.NewProfileClient(conn)
+	return nil
+}
+
+// GetRecommendations returns the top-k hotels for a given requirement,
+// with their scores and the reasons for them. The requirement names the
+// strategy to rank by.
 func (s *Server) GetRecommendations(ctx context.Context, req *pb.Request) (*pb.Result, error) {
 	res := new(pb.Result)
 	fmt.Printf("GetRecommendations\n")
 	require := req.Require
-	if require == "dis" {
-		p1 := &geoindex.GeoPoint{
-			Pid:  "",
-			Plat: req.Lat,
-			Plon: req.Lon,
-		}
-		min := math.MaxFloat64
-		for _, hotel := range s.hotels {
-			tmp := float64(geoindex.Distance(p1, &geoindex.GeoPoint{
-				Pid:  "",
-				Plat: hotel.HLat,
-				Plon: hotel.HLon,
-			})) / 1000
-			if tmp < min {
-				min = tmp
-			}
-		}
-		for _, hotel := range s.hotels {
-			tmp := float64(geoindex.Distance(p1, &geoindex.GeoPoint{
-				Pid:  "",
-				Plat: hotel.HLat,
-				Plon: hotel.HLon,
-			})) / 1000
-			if tmp == min {
-				res.HotelIds = append(res.HotelIds, hotel.HId)
-			}
-		}
-	} else if require == "rate" {
-		max := 0.0
-		for _, hotel := range s.hotels {
-			if hotel.HRate > max {
-				max = hotel.HRate
-			}
-		}
-		for _, hotel := range s.hotels {
-			if hotel.HRate == max {
-				res.HotelIds = append(res.HotelIds, hotel.HId)
-			}
-		}
-	} else if require == "price" {
-		min := math.MaxFloat64
-		for _, hotel := range s.hotels {
-			if hotel.HPrice < min {
-				min = hotel.HPrice
-			}
-		}
-		for _, hotel := range s.hotels {
-			if hotel.HPrice == min {
-				res.HotelIds = append(res.HotelIds, hotel.HId)
-			}
-		}
-	} else if require == "mix" {
 
-		p1 := &geoindex.GeoPoint{
-			Pid:  "",
-			Plat: req.Lat,
-			Plon: req.Lon,
-		}
-
-		hotelScores := make(map[string]HotelScore)
-		distanceScoreSum := 0.0
-		rateScoreSum := 0.0
-		priceScoreSum := 0.0
-
-		for _, hotel := range s.hotels {
-			tmp := float64(geoindex.Distance(p1, &geoindex.GeoPoint{
-				Pid:  "",
-				Plat: hotel.HLat,
-				Plon: hotel.HLon,
-			})) / 1000
-			
-			var hotelScore HotelScore
-			hotelScore.HId = hotel.HId
-			if tmp > 1 {
-				hotelScore.HDis = 1 / tmp
-			} else {
-				hotelScore.HDis = 1
-			}
-			// hotelScore.HDis = 1 / math.max(10, tmp)
-			hotelScore.HRate = hotel.HRate
-			hotelScore.HPrice = 1 / hotel.HPrice
-			distanceScoreSum += hotelScore.HDis
-			rateScoreSum += hotelScore.HRate
-			priceScoreSum += hotelScore.HPrice
-			hotelScores[hotel.HId] = hotelScore
-			
-		}
+	newStrategy, ok := strategies[require]
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "unknown recommendation strategy %q", require)
+	}
 
-		max := 0.0
-		var resId string  
-		for _, hotelScore := range hotelScores {
-			mixScore := 0.5 * hotelScore.HDis / distanceScoreSum + 0.3 * hotelScore.HRate / rateScoreSum + 0.2 * hotelScore.HPrice / priceScoreSum
-			if mixScore > max {
-				resId = hotelScore.HId
-				max = mixScore
-			}
-		}
-		res.HotelIds = append(res.HotelIds, resId)
+	hotels, err := newStrategy(s).Rank(ctx, &Query{
+		Lat:      req.Lat,
+		Lon:      req.Lon,
+		Username: req.Username,
+		Weights:  req.Weights,
+		K:        resultCount(req.K),
+		Hotels:   s.catalog(),
+	})
+	if err != nil {
+		return nil, err
+	}
 
-	} else {
-		log.Println("Wrong parameter: ", require)
+	res.Recommendations = hotels
+	for _, hotel := range res.Recommendations {
+		res.HotelIds = append(res.HotelIds, hotel.HotelId)
 	}
 
 	return res, nil
 }
 
 // loadRecommendations loads hotel recommendations from mongodb.
-func loadRecommendations(session *mgo.Session) map[string]Hotel {
+func loadRecommendations(session *mgo.Session) (map[string]Hotel, error) {
 	// session, err := mgo.Dial("mongodb-recommendation")
 	// if err != nil {
 	// 	panic(err)
@@ -217,7 +218,7 @@ func loadRecommendations(session *mgo.Session) map[string]Hotel {
 	var hotels []Hotel
 	err := c.Find(bson.M{}).All(&hotels)
 	if err != nil {
-		log.Println("Failed get hotels data: ", err)
+		return nil, err
 	}
 
 	profiles := make(map[string]Hotel)
@@ -225,7 +226,7 @@ func loadRecommendations(session *mgo.Session) map[string]Hotel {
 		profiles[hotel.HId] = hotel
 	}
 
-	return profiles
+	return profiles, nil
 }
 
 type Hotel struct {
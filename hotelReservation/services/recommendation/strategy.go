@@ -0,0 +1,127 @@
+package recommendation
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hailocab/go-geoindex"
+	pb "github.com/harlow/go-micro-services/services/recommendation/proto"
+	"golang.org/x/net/context"
+)
+
+const (
+	defaultResults = 5
+	maxResults     = 50
+)
+
+// Query is what a strategy ranks hotels for.
+type Query struct {
+	Lat, Lon float64
+	Username string
+	// Weights tunes strategies that mix factors, nil selects their defaults
+	Weights *pb.Factors
+	// K is the number of hotels to return
+	K int
+	// Hotels is the catalog to rank, it must not be modified
+	Hotels map[string]Hotel
+}
+
+// Strategy ranks the hotels of a query.
+type Strategy interface {
+	// Rank returns at most q.K hotels, best first. Scores fall in that
+	// order unless the strategy documents otherwise.
+	Rank(ctx context.Context, q *Query) ([]*pb.RankedHotel, error)
+}
+
+// strategies maps the require values of a request to the strategies
+// serving them. Each strategy registers itself from its own file.
+var strategies = make(map[string]func(s *Server) Strategy)
+
+func registerStrategy(name string, newStrategy func(s *Server) Strategy) {
+	if _, dup := strategies[name]; dup {
+		panic("recommendation: strategy registered twice: " + name)
+	}
+	strategies[name] = newStrategy
+}
+
+// resultCount returns the number of hotels to return for a requested k.
+func resultCount(k int32) int {
+	if k <= 0 {
+		return defaultResults
+	}
+	if k > maxResults {
+		return maxResults
+	}
+	return int(k)
+}
+
+func distanceKm(lat, lon float64, hotel Hotel) float64 {
+	return float64(geoindex.Distance(
+		&geoindex.GeoPoint{Pid: "", Plat: lat, Plon: lon},
+		&geoindex.GeoPoint{Pid: "", Plat: hotel.HLat, Plon: hotel.HLon},
+	)) / 1000
+}
+
+// ranked builds a ranked hotel whose score is the sum of its contributions.
+func ranked(hotelId string, c *pb.Factors, phrases map[string]string) *pb.RankedHotel {
+	return &pb.RankedHotel{
+		HotelId:       hotelId,
+		Score:         c.Distance + c.Rate + c.Price + c.Peers + c.Popularity,
+		Contributions: c,
+		Reason:        reason(c, phrases),
+	}
+}
+
+// hotelPhrases describes a hotel by each factor, for use in reasons.
+func hotelPhrases(hotel Hotel, km float64) map[string]string {
+	return map[string]string{
+		"distance": fmt.Sprintf("%.1f km away", km),
+		"rate":     fmt.Sprintf("rated %g", hotel.HRate),
+		"price":    fmt.Sprintf("%.2f per night", hotel.HPrice),
+	}
+}
+
+// reason joins the phrases of the two factors that add the most to the score.
+func reason(c *pb.Factors, phrases map[string]string) string {
+	type part struct {
+		contribution float64
+		phrase       string
+	}
+	parts := []part{
+		{c.Distance, phrases["distance"]},
+		{c.Rate, phrases["rate"]},
+		{c.Price, phrases["price"]},
+		{c.Peers, phrases["peers"]},
+		{c.Popularity, phrases["popularity"]},
+	}
+	sort.SliceStable(parts, func(i, j int) bool {
+		return parts[i].contribution > parts[j].contribution
+	})
+
+	var out []string
+	for _, p := range parts {
+		if p.contribution > 0 && p.phrase != "" && len(out) < 2 {
+			out = append(out, p.phrase)
+		}
+	}
+	r := strings.Join(out, " and ")
+	if r == "" {
+		return r
+	}
+	return strings.ToUpper(r[:1]) + r[1:]
+}
+
+// topK orders hotels best first, ties by hotel id, and keeps k of them.
+func topK(hotels []*pb.RankedHotel, k int) []*pb.RankedHotel {
+	sort.Slice(hotels, func(i, j int) bool {
+		if hotels[i].Score != hotels[j].Score {
+			return hotels[i].Score > hotels[j].Score
+		}
+		return hotels[i].HotelId < hotels[j].HotelId
+	})
+	if len(hotels) > k {
+		hotels = hotels[:k]
+	}
+	return hotels
+}
@@ -0,0 +1,136 @@
+package recommendation
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	pb "github.com/harlow/go-micro-services/services/recommendation/proto"
+	reservation "github.com/harlow/go-micro-services/services/reservation/proto"
+	user "github.com/harlow/go-micro-services/services/user/proto"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// testHotels is the catalog every strategy is tested on. Queries are made
+// at the location of hotel 1; hotel 2 is about 3 km away and hotel 3
+// about 9 km.
+var testHotels = map[string]Hotel{
+	"1": {HId: "1", HLat: 37.7749, HLon: -122.4194, HRate: 4, HPrice: 100},
+	"2": {HId: "2", HLat: 37.8000, HLon: -122.4100, HRate: 5, HPrice: 200},
+	"3": {HId: "3", HLat: 37.7000, HLon: -122.4500, HRate: 3, HPrice: 50},
+}
+
+// fakeReservation answers the reservation calls of the trending and
+// personal strategies; the other methods are not called.
+type fakeReservation struct {
+	reservation.ReservationClient
+}
+
+func (fakeReservation) BookingCounts(ctx context.Context, in *reservation.BookingCountRequest, opts ...grpc.CallOption) (*reservation.BookingCountResult, error) {
+	return &reservation.BookingCountResult{Counts: []*reservation.HotelCount{
+		{HotelId: "3", Count: 5},
+		{HotelId: "2", Count: 1},
+	}}, nil
+}
+
+func (fakeReservation) ListReservations(ctx context.Context, in *reservation.ListRequest, opts ...grpc.CallOption) (*reservation.ListResult, error) {
+	if in.CustomerName != "alice" {
+		return new(reservation.ListResult), nil
+	}
+	return &reservation.ListResult{Stays: []*reservation.Stay{
+		{HotelId: "3", InDate: "2015-04-09", OutDate: "2015-04-11", RoomNumber: 1},
+	}}, nil
+}
+
+func (fakeReservation) CoBookings(ctx context.Context, in *reservation.CoBookingRequest, opts ...grpc.CallOption) (*reservation.CoBookingResult, error) {
+	return &reservation.CoBookingResult{Counts: []*reservation.HotelCount{
+		{HotelId: "1", Count: 2},
+	}}, nil
+}
+
+// fakeUser has alice dislike hotel 2.
+type fakeUser struct {
+	user.UserClient
+}
+
+func (fakeUser) GetReviews(ctx context.Context, in *user.ReviewsRequest, opts ...grpc.CallOption) (*user.ReviewsResult, error) {
+	if in.Username != "alice" {
+		return new(user.ReviewsResult), nil
+	}
+	return &user.ReviewsResult{Reviews: []*user.Review{
+		{HotelId: "2", Score: 1},
+	}}, nil
+}
+
+func TestStrategies(t *testing.T) {
+	s := &Server{
+		hotels:            testHotels,
+		reservationClient: fakeReservation{},
+		userClient:        fakeUser{},
+	}
+
+	tests := []struct {
+		require  string
+		username string
+		weights  *pb.Factors
+		k        int32
+		want     []string
+		code     codes.Code
+	}{
+		{require: "nearest", want: []string{"1", "2", "3"}},
+		{require: "dis", k: 2, want: []string{"1", "2"}},
+		{require: "cheapest", want: []string{"3", "1", "2"}},
+		{require: "price", k: 1, want: []string{"3"}},
+		{require: "rated", want: []string{"2", "1", "3"}},
+		{require: "rate", k: 1, want: []string{"2"}},
+		{require: "mix", want: []string{"1", "2", "3"}},
+		{require: "mix", weights: &pb.Factors{Rate: 1}, want: []string{"2", "1", "3"}},
+		{require: "mix", weights: &pb.Factors{Price: 2}, want: []string{"3", "1", "2"}},
+		{require: "mix", weights: &pb.Factors{Price: -1}, code: codes.InvalidArgument},
+		{require: "mix", weights: &pb.Factors{Rate: math.Inf(1)}, code: codes.InvalidArgument},
+		{require: "diverse", want: []string{"1", "2", "3"}},
+		{require: "diverse", weights: &pb.Factors{Rate: -1}, code: codes.InvalidArgument},
+		{require: "trending", want: []string{"3", "2", "1"}},
+		{require: "personal", username: "alice", want: []string{"3", "1"}},
+		{require: "personal", username: "bob", want: []string{"1", "2", "3"}},
+		{require: "unknown", code: codes.InvalidArgument},
+		{require: "", code: codes.InvalidArgument},
+	}
+
+	tested := make(map[string]bool)
+	for _, tt := range tests {
+		tested[tt.require] = true
+		res, err := s.GetRecommendations(context.Background(), &pb.Request{
+			Require:  tt.require,
+			Lat:      37.7749,
+			Lon:      -122.4194,
+			Username: tt.username,
+			K:        tt.k,
+			Weights:  tt.weights,
+		})
+		if code := status.Code(err); code != tt.code {
+			t.Errorf("%s %s: got code %v (%v), want %v", tt.require, tt.username, code, err, tt.code)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if !reflect.DeepEqual(res.HotelIds, tt.want) {
+			t.Errorf("%s %s: got %v, want %v", tt.require, tt.username, res.HotelIds, tt.want)
+		}
+		for i, r := range res.Recommendations {
+			if r.HotelId != res.HotelIds[i] {
+				t.Errorf("%s %s: recommendation %d is %s, hotel id %s", tt.require, tt.username, i, r.HotelId, res.HotelIds[i])
+			}
+		}
+	}
+
+	for name := range strategies {
+		if !tested[name] {
+			t.Errorf("strategy %s has no test case", name)
+		}
+	}
+}
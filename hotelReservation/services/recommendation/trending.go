@@ -0,0 +1,68 @@
+package recommendation
+
+import (
+	"fmt"
+	"time"
+
+	pb "github.com/harlow/go-micro-services/services/recommendation/proto"
+	reservation "github.com/harlow/go-micro-services/services/reservation/proto"
+	"golang.org/x/net/context"
+)
+
+const (
+	// bookings older than this do not count towards trending
+	trendingWindow = 7 * 24 * time.Hour
+
+	trendingPopularityWeight = 0.9
+	// closeness only breaks ties between equally booked hotels
+	trendingDistanceWeight = 0.1
+)
+
+func init() {
+	registerStrategy("trending", newTrending)
+}
+
+// trending ranks hotels by how often they were booked this week.
+type trending struct {
+	s *Server
+}
+
+func newTrending(s *Server) Strategy {
+	return trending{s: s}
+}
+
+func (t trending) Rank(ctx context.Context, q *Query) ([]*pb.RankedHotel, error) {
+	resp, err := t.s.reservationClient.BookingCounts(ctx, &reservation.BookingCountRequest{
+		Since: time.Now().Add(-trendingWindow).Unix(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int32)
+	var max int32
+	for _, c := range resp.Counts {
+		counts[c.HotelId] = c.Count
+		if c.Count > max {
+			max = c.Count
+		}
+	}
+
+	res := make([]*pb.RankedHotel, 0, len(q.Hotels))
+	for _, hotel := range q.Hotels {
+		popularity := 0.0
+		if max > 0 {
+			popularity = float64(counts[hotel.HId]) / float64(max)
+		}
+		km := distanceKm(q.Lat, q.Lon, hotel)
+
+		phrases := hotelPhrases(hotel, km)
+		phrases["popularity"] = fmt.Sprintf("booked %d times this week", counts[hotel.HId])
+
+		res = append(res, ranked(hotel.HId, &pb.Factors{
+			Distance:   trendingDistanceWeight / (1 + km),
+			Popularity: trendingPopularityWeight * popularity,
+		}, phrases))
+	}
+	return topK(res, q.K), nil
+}
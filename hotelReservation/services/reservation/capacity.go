@@ -0,0 +1,144 @@
+package reservation
+
+import (
+	"log"
+	"time"
+
+	"github.com/bradfitz/gomemcache/memcache"
+	pb "github.com/harlow/go-micro-services/services/reservation/proto"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// AddCapacity sets the number of rooms of a hotel that has none yet.
+func (s *Server) AddCapacity(ctx context.Context, req *pb.CapacityRequest) (*pb.CapacityResult, error) {
+	if req.HotelId == "" {
+		return nil, status.Error(codes.InvalidArgument, "hotel id must be set")
+	}
+	if req.NumberOfRoom <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "number of rooms must be positive")
+	}
+
+	session := s.MongoSession.Copy()
+	defer session.Close()
+	c := session.DB("reservation-db").C("number")
+
+	n, err := c.Find(&bson.M{"hotelId": req.HotelId}).Count()
+	if err != nil {
+		return nil, err
+	}
+	if n > 0 {
+		return nil, status.Errorf(codes.AlreadyExists, "hotel %s already has a number of rooms", req.HotelId)
+	}
+	if err := c.Insert(&number{HotelId: req.HotelId, Number: int(req.NumberOfRoom)}); err != nil {
+		return nil, err
+	}
+
+	s.forgetCapacity(req.HotelId)
+	return new(pb.CapacityResult), nil
+}
+
+// RemoveCapacity removes the number of rooms of a hotel. It fails with
+// FailedPrecondition while stays are booked that have not ended, which
+// would lose their rooms.
+func (s *Server) RemoveCapacity(ctx context.Context, req *pb.CapacityRequest) (*pb.CapacityResult, error) {
+	if req.HotelId == "" {
+		return nil, status.Error(codes.InvalidArgument, "hotel id must be set")
+	}
+
+	session := s.MongoSession.Copy()
+	defer session.Close()
+	db := session.DB("reservation-db")
+
+	booked, err := upcomingNights(db, req.HotelId)
+	if err != nil {
+		return nil, err
+	}
+	if booked > 0 {
+		return nil, status.Errorf(codes.FailedPrecondition, "hotel %s has %d nights booked", req.HotelId, booked)
+	}
+
+	if _, err := db.C("number").RemoveAll(&bson.M{"hotelId": req.HotelId}); err != nil {
+		return nil, err
+	}
+
+	s.forgetCapacity(req.HotelId)
+	return new(pb.CapacityResult), nil
+}
+
+// DecommissionHotel sets the number of rooms of a hotel to zero for good,
+// keeping its reservations. It fails with FailedPrecondition while stays
+// are booked that have not ended; decommissioning again does nothing.
+func (s *Server) DecommissionHotel(ctx context.Context, req *pb.CapacityRequest) (*pb.CapacityResult, error) {
+	if req.HotelId == "" {
+		return nil, status.Error(codes.InvalidArgument, "hotel id must be set")
+	}
+
+	session := s.MongoSession.Copy()
+	defer session.Close()
+	db := session.DB("reservation-db")
+	c := db.C("number")
+
+	var num number
+	err := c.Find(&bson.M{"hotelId": req.HotelId}).One(&num)
+	if err == mgo.ErrNotFound {
+		return nil, status.Errorf(codes.NotFound, "hotel %s has no number of rooms", req.HotelId)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !num.Decommissioned.IsZero() {
+		return new(pb.CapacityResult), nil
+	}
+
+	// no rooms first, so nothing is booked while the stays are counted
+	now := time.Now()
+	err = c.Update(
+		&bson.M{"hotelId": req.HotelId, "numberOfRoom": num.Number, "decommissioned": bson.M{"$exists": false}},
+		&bson.M{"$set": bson.M{"numberOfRoom": 0, "formerNumberOfRoom": num.Number, "decommissioned": now}},
+	)
+	if err == mgo.ErrNotFound {
+		return nil, status.Errorf(codes.Aborted, "the rooms of hotel %s changed, try again", req.HotelId)
+	}
+	if err != nil {
+		return nil, err
+	}
+	s.forgetCapacity(req.HotelId)
+
+	booked, err := upcomingNights(db, req.HotelId)
+	if err == nil && booked == 0 {
+		return new(pb.CapacityResult), nil
+	}
+
+	// reopen the hotel as it was
+	uerr := c.Update(
+		&bson.M{"hotelId": req.HotelId, "decommissioned": now},
+		&bson.M{"$set": bson.M{"numberOfRoom": num.Number}, "$unset": bson.M{"formerNumberOfRoom": "", "decommissioned": ""}},
+	)
+	if uerr != nil {
+		log.Println("Failed restore capacity: ", uerr)
+	}
+	s.forgetCapacity(req.HotelId)
+	if err != nil {
+		return nil, err
+	}
+	return nil, status.Errorf(codes.FailedPrecondition, "hotel %s has %d nights booked", req.HotelId, booked)
+}
+
+// upcomingNights counts the nights booked at a hotel from today on.
+func upcomingNights(db *mgo.Database, hotelId string) (int, error) {
+	return db.C("reservation").Find(&bson.M{
+		"hotelId": hotelId,
+		"inDate":  bson.M{"$gte": time.Now().UTC().Format("2006-01-02")},
+	}).Count()
+}
+
+// forgetCapacity drops the cached number of rooms of a hotel.
+func (s *Server) forgetCapacity(hotelId string) {
+	if err := s.MemcClient.Delete(hotelId + "_cap"); err != nil && err != memcache.ErrCacheMiss {
+		log.Println("Failed drop cached capacity: ", err)
+	}
+}
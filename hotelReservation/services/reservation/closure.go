@@ -0,0 +1,202 @@
+package reservation
+
+import (
+	"log"
+	"time"
+
+	"github.com/harlow/go-micro-services/rbac"
+	pb "github.com/harlow/go-micro-services/services/reservation/proto"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// maxClosureReasonBytes bounds the reason given for a closure
+const maxClosureReasonBytes = 200
+
+// closure is a date range a hotel is closed for, in reservation-db.closures.
+// The hotel is closed on the nights from InDate up to OutDate.
+type closure struct {
+	ID        bson.ObjectId `bson:"_id"`
+	HotelId   string        `bson:"hotelId"`
+	InDate    string        `bson:"inDate"`
+	OutDate   string        `bson:"outDate"`
+	Reason    string        `bson:"reason"`
+	Created   time.Time     `bson:"created"`
+	CreatedBy string        `bson:"createdBy,omitempty"`
+}
+
+func (c *closure) proto() *pb.Closure {
+	return &pb.Closure{
+		Id:        c.ID.Hex(),
+		HotelId:   c.HotelId,
+		InDate:    c.InDate,
+		OutDate:   c.OutDate,
+		Reason:    c.Reason,
+		Created:   c.Created.Unix(),
+		CreatedBy: c.CreatedBy,
+	}
+}
+
+// closedHotels returns which of the hotels are closed on a night from
+// inDate up to outDate.
+func closedHotels(db *mgo.Database, hotelIds []string, inDate, outDate string) (map[string]bool, error) {
+	closed := make(map[string]bool)
+	if len(hotelIds) == 0 {
+		return closed, nil
+	}
+
+	var closures []closure
+	err := db.C("closures").Find(&bson.M{
+		"hotelId": bson.M{"$in": hotelIds},
+		"inDate":  bson.M{"$lt": outDate},
+		"outDate": bson.M{"$gt": inDate},
+	}).Select(bson.M{"hotelId": 1}).All(&closures)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range closures {
+		closed[c.HotelId] = true
+	}
+	return closed, nil
+}
+
+// AddClosure closes a hotel from inDate up to outDate. It fails with
+// FailedPrecondition if nights of that range are booked; they have to be
+// cancelled first. Like DecommissionHotel it closes the hotel before
+// counting the nights and reopens it if there are any, and MakeReservation
+// checks for closures again after booking, so a closure and a booking
+// made at the same time do not both stand.
+func (s *Server) AddClosure(ctx context.Context, req *pb.Closure) (*pb.Closure, error) {
+	if req.HotelId == "" || req.Reason == "" {
+		return nil, status.Error(codes.InvalidArgument, "hotel id and reason must be set")
+	}
+	if len(req.Reason) > maxClosureReasonBytes {
+		return nil, status.Errorf(codes.InvalidArgument, "reason must be at most %d bytes", maxClosureReasonBytes)
+	}
+	in, err := time.Parse("2006-01-02", req.InDate)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "inDate must be YYYY-MM-DD")
+	}
+	out, err := time.Parse("2006-01-02", req.OutDate)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "outDate must be YYYY-MM-DD")
+	}
+	if !in.Before(out) {
+		return nil, status.Error(codes.InvalidArgument, "outDate must be after inDate")
+	}
+	if req.OutDate <= time.Now().UTC().Format("2006-01-02") {
+		return nil, status.Error(codes.InvalidArgument, "the closure must not have ended")
+	}
+
+	session := s.MongoSession.Copy()
+	defer session.Close()
+	db := session.DB("reservation-db")
+
+	c := &closure{
+		ID:      bson.NewObjectId(),
+		HotelId: req.HotelId,
+		InDate:  req.InDate,
+		OutDate: req.OutDate,
+		Reason:  req.Reason,
+		Created: time.Now(),
+	}
+	if caller := rbac.FromContext(ctx); caller != nil {
+		c.CreatedBy = caller.Subject
+	}
+	// closed first, so nothing is booked while the nights are counted; a
+	// booking made meanwhile sees the closure and is undone
+	if err := db.C("closures").Insert(c); err != nil {
+		return nil, err
+	}
+
+	booked, err := db.C("reservation").Find(&bson.M{
+		"hotelId": req.HotelId,
+		"inDate":  bson.M{"$gte": req.InDate, "$lt": req.OutDate},
+	}).Count()
+	if err == nil && booked == 0 {
+		return c.proto(), nil
+	}
+
+	if rerr := db.C("closures").RemoveId(c.ID); rerr != nil {
+		log.Println("Failed remove closure: ", rerr)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return nil, status.Errorf(codes.FailedPrecondition, "hotel %s has %d nights booked from %s to %s", req.HotelId, booked, req.InDate, req.OutDate)
+}
+
+// RemoveClosure reopens a hotel for the range of a closure.
+func (s *Server) RemoveClosure(ctx context.Context, req *pb.ClosureRequest) (*pb.ClosureResult, error) {
+	res := new(pb.ClosureResult)
+	if req.HotelId == "" || !bson.IsObjectIdHex(req.ClosureId) {
+		return nil, status.Error(codes.InvalidArgument, "hotel id and a valid closure id must be set")
+	}
+
+	session := s.MongoSession.Copy()
+	defer session.Close()
+
+	// the hotel is part of the selector, the policy checked it
+	err := session.DB("reservation-db").C("closures").Remove(&bson.M{
+		"_id":     bson.ObjectIdHex(req.ClosureId),
+		"hotelId": req.HotelId,
+	})
+	if err == mgo.ErrNotFound {
+		return res, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	res.Removed = true
+	return res, nil
+}
+
+// ListClosures returns the closures of a hotel that have not ended,
+// soonest first.
+func (s *Server) ListClosures(ctx context.Context, req *pb.ClosureRequest) (*pb.ClosureList, error) {
+	res := new(pb.ClosureList)
+	if req.HotelId == "" {
+		return nil, status.Error(codes.InvalidArgument, "hotel id must be set")
+	}
+
+	session := s.MongoSession.Copy()
+	defer session.Close()
+
+	var closures []closure
+	err := session.DB("reservation-db").C("closures").Find(&bson.M{
+		"hotelId": req.HotelId,
+		"outDate": bson.M{"$gt": time.Now().UTC().Format("2006-01-02")},
+	}).Sort("inDate").All(&closures)
+	if err != nil {
+		return nil, err
+	}
+	for i := range closures {
+		res.Closures = append(res.Closures, closures[i].proto())
+	}
+	return res, nil
+}
+
+// ClosedHotels returns the given hotels that are closed on a night from
+// inDate up to outDate, in the order given.
+func (s *Server) ClosedHotels(ctx context.Context, req *pb.Request) (*pb.Result, error) {
+	res := new(pb.Result)
+	res.HotelId = make([]string, 0)
+
+	session := s.MongoSession.Copy()
+	defer session.Close()
+
+	closed, err := closedHotels(session.DB("reservation-db"), req.HotelId, req.InDate, req.OutDate)
+	if err != nil {
+		return nil, err
+	}
+	for _, hotelId := range req.HotelId {
+		if closed[hotelId] {
+			res.HotelId = append(res.HotelId, hotelId)
+			delete(closed, hotelId)
+		}
+	}
+	return res, nil
+}
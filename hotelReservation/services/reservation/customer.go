@@ -0,0 +1,71 @@
+package reservation
+
+import (
+	pb "github.com/harlow/go-micro-services/services/reservation/proto"
+	"github.com/segmentio/ksuid"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// ExportCustomer returns every night booked under a customer name.
+func (s *Server) ExportCustomer(ctx context.Context, req *pb.CustomerRequest) (*pb.CustomerReservations, error) {
+	res := new(pb.CustomerReservations)
+	if req.CustomerName == "" {
+		return nil, status.Error(codes.InvalidArgument, "customer name must be set")
+	}
+
+	session := s.MongoSession.Copy()
+	defer session.Close()
+
+	c := session.DB("reservation-db").C("reservation")
+
+	reserve := make([]reservation, 0)
+	err := c.Find(&bson.M{"customerName": req.CustomerName}).Sort("inDate", "hotelId").All(&reserve)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, r := range reserve {
+		night := &pb.Night{
+			ReservationId: r.ReservationId,
+			HotelId:       r.HotelId,
+			InDate:        r.InDate,
+			OutDate:       r.OutDate,
+			Number:        int32(r.Number),
+		}
+		if !r.Created.IsZero() {
+			night.Created = r.Created.Unix()
+		}
+		res.Nights = append(res.Nights, night)
+	}
+	return res, nil
+}
+
+// EraseCustomer replaces a customer name on its bookings by a pseudonym.
+// The bookings stay, as the rooms are still taken and the booking counts
+// must not change, but can no longer be tied to the customer, nor be
+// cancelled by name.
+func (s *Server) EraseCustomer(ctx context.Context, req *pb.CustomerRequest) (*pb.EraseCustomerResult, error) {
+	res := new(pb.EraseCustomerResult)
+	if req.CustomerName == "" {
+		return nil, status.Error(codes.InvalidArgument, "customer name must be set")
+	}
+
+	session := s.MongoSession.Copy()
+	defer session.Close()
+
+	c := session.DB("reservation-db").C("reservation")
+
+	// one pseudonym per customer keeps co-booking counts right
+	info, err := c.UpdateAll(
+		&bson.M{"customerName": req.CustomerName},
+		&bson.M{"$set": bson.M{"customerName": "erased:" + ksuid.New().String()}},
+	)
+	if err != nil {
+		return nil, err
+	}
+	res.Anonymized = int32(info.Updated)
+	return res, nil
+}
@@ -0,0 +1,139 @@
+package reservation
+
+import (
+	"log"
+	"time"
+
+	user "github.com/harlow/go-micro-services/services/user/proto"
+	"golang.org/x/net/context"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// outboxInterval is how often changes the user service missed are
+// reported again.
+const outboxInterval = 30 * time.Second
+
+// pendingChange is a reservation change the user service has not taken
+// yet, in reservation-db.outbox.
+type pendingChange struct {
+	ID            bson.ObjectId `bson:"_id"`
+	Event         int32         `bson:"event"`
+	Username      string        `bson:"username"`
+	ReservationId string        `bson:"reservationId"`
+	HotelId       string        `bson:"hotelId"`
+	InDate        string        `bson:"inDate"`
+	OutDate       string        `bson:"outDate"`
+	RoomNumber    int32         `bson:"roomNumber"`
+	Nights        int32         `bson:"nights"`
+	Created       time.Time     `bson:"created"`
+	Attempts      int           `bson:"attempts"`
+}
+
+func (p *pendingChange) proto() *user.ReservationChange {
+	return &user.ReservationChange{
+		Event:         user.ReservationChange_Event(p.Event),
+		Username:      p.Username,
+		ReservationId: p.ReservationId,
+		HotelId:       p.HotelId,
+		InDate:        p.InDate,
+		OutDate:       p.OutDate,
+		RoomNumber:    p.RoomNumber,
+		Nights:        p.Nights,
+	}
+}
+
+// reservationChanged reports a booked or cancelled reservation to the
+// user service, which keeps the customer's loyalty points in step. The
+// change is kept in the outbox until the user service takes it, so a
+// failed report is retried by outboxLoop; the reservation stands either
+// way.
+func (s *Server) reservationChanged(ctx context.Context, change *user.ReservationChange) {
+	if change.ReservationId == "" {
+		return
+	}
+
+	session := s.MongoSession.Copy()
+	defer session.Close()
+	c := session.DB("reservation-db").C("outbox")
+
+	err := c.Insert(&pendingChange{
+		ID:            bson.NewObjectId(),
+		Event:         int32(change.Event),
+		Username:      change.Username,
+		ReservationId: change.ReservationId,
+		HotelId:       change.HotelId,
+		InDate:        change.InDate,
+		OutDate:       change.OutDate,
+		RoomNumber:    change.RoomNumber,
+		Nights:        change.Nights,
+		Created:       time.Now(),
+	})
+	if err != nil {
+		// report it anyway, only the retry is lost
+		log.Println("Failed queue reservation change: ", err)
+		if _, err := s.userClient.ReservationChanged(ctx, change); err != nil {
+			log.Println("Failed update loyalty points: ", err)
+		}
+		return
+	}
+	if err := s.deliverChanges(ctx, c, change.ReservationId); err != nil {
+		log.Println("Failed update loyalty points: ", err)
+	}
+}
+
+// deliverChanges reports the pending changes of a reservation in the
+// order they were made, and drops each once the user service took it. It
+// stops at the first failure, as a later change must not overtake it. The
+// user service applies repeated changes once, so reporting a change twice
+// is safe.
+func (s *Server) deliverChanges(ctx context.Context, c *mgo.Collection, reservationId string) error {
+	var pending []pendingChange
+	err := c.Find(&bson.M{"reservationId": reservationId}).Sort("_id").All(&pending)
+	if err != nil {
+		return err
+	}
+	for i := range pending {
+		p := &pending[i]
+		if _, err := s.userClient.ReservationChanged(ctx, p.proto()); err != nil {
+			if uerr := c.UpdateId(p.ID, &bson.M{"$inc": bson.M{"attempts": 1}}); uerr != nil {
+				log.Println("Failed count reservation change attempt: ", uerr)
+			}
+			return err
+		}
+		if err := c.RemoveId(p.ID); err != nil && err != mgo.ErrNotFound {
+			return err
+		}
+	}
+	return nil
+}
+
+// outboxLoop reports the changes that are still pending a while after
+// they were made.
+func (s *Server) outboxLoop() {
+	for range time.Tick(outboxInterval) {
+		if err := s.retryChanges(); err != nil {
+			log.Println("Failed retry reservation changes: ", err)
+		}
+	}
+}
+
+func (s *Server) retryChanges() error {
+	session := s.MongoSession.Copy()
+	defer session.Close()
+	c := session.DB("reservation-db").C("outbox")
+
+	var reservationIds []string
+	err := c.Find(&bson.M{
+		"created": bson.M{"$lt": time.Now().Add(-outboxInterval)},
+	}).Distinct("reservationId", &reservationIds)
+	if err != nil {
+		return err
+	}
+	for _, id := range reservationIds {
+		if err := s.deliverChanges(context.Background(), c, id); err != nil {
+			log.Println("Failed update loyalty points: ", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+package reservation
+
+import (
+	"github.com/harlow/go-micro-services/rbac"
+	pb "github.com/harlow/go-micro-services/services/reservation/proto"
+)
+
+// self is the identity the reservation service calls other services with.
+var self = &rbac.Identity{Subject: name, Role: rbac.Service}
+
+func customer(req interface{}) string {
+	return req.(*pb.Request).CustomerName
+}
+
+func closureHotel(req interface{}) []string {
+	switch req := req.(type) {
+	case *pb.Closure:
+		return []string{req.HotelId}
+	case *pb.ClosureRequest:
+		return []string{req.HotelId}
+	}
+	return nil
+}
+
+// policy is who may call the reservation service. Guests book and cancel
+// their own stays, the managers of a hotel close it.
+var policy = rbac.Policy{
+	"/reservation.Reservation/CheckAvailability": {Public: true},
+	"/reservation.Reservation/ClosedHotels":      {Public: true},
+	"/reservation.Reservation/MakeReservation":   {Roles: []rbac.Role{rbac.Guest}, Owner: customer},
+	"/reservation.Reservation/CancelReservation": {Roles: []rbac.Role{rbac.Guest}, Owner: customer},
+	"/reservation.Reservation/AddClosure":        {Roles: []rbac.Role{rbac.HotelManager, rbac.ChainAdmin}, Hotels: closureHotel},
+	"/reservation.Reservation/RemoveClosure":     {Roles: []rbac.Role{rbac.HotelManager, rbac.ChainAdmin}, Hotels: closureHotel},
+	"/reservation.Reservation/ListClosures":      {Roles: []rbac.Role{rbac.HotelManager, rbac.ChainAdmin}, Hotels: closureHotel},
+}
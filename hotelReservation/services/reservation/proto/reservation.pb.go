@@ -1,15 +1,36 @@
 // Code generated by protoc-gen-go. DO NOT EDIT.
-// source: reservation.proto
+// source: services/reservation/proto/reservation.proto
 
 /*
 Package reservation is a generated protocol buffer package.
 
 It is generated from these files:
-	reservation.proto
+	services/reservation/proto/reservation.proto
 
 It has these top-level messages:
 	Request
 	Result
+	ListRequest
+	ListResult
+	Stay
+	CoBookingRequest
+	CoBookingResult
+	HotelCount
+	BookingCountRequest
+	BookingCountResult
+	CustomerRequest
+	CustomerReservations
+	Night
+	EraseCustomerResult
+	CapacityRequest
+	CapacityResult
+	Closure
+	ClosureRequest
+	ClosureResult
+	ClosureList
+	BookingReport
+	HotelBookings
+	NightSales
 */
 package reservation
 
@@ -33,12 +54,16 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
 
+// redeemPoints are loyalty points of the customer to spend as a discount
+// on a reservation made by MakeReservation. Only as many are spent as the
+// stay costs at the price of the hotel.
 type Request struct {
 	CustomerName string   `protobuf:"bytes,1,opt,name=customerName" json:"customerName,omitempty"`
 	HotelId      []string `protobuf:"bytes,2,rep,name=hotelId" json:"hotelId,omitempty"`
 	InDate       string   `protobuf:"bytes,3,opt,name=inDate" json:"inDate,omitempty"`
 	OutDate      string   `protobuf:"bytes,4,opt,name=outDate" json:"outDate,omitempty"`
 	RoomNumber   int32    `protobuf:"varint,5,opt,name=roomNumber" json:"roomNumber,omitempty"`
+	RedeemPoints int64    `protobuf:"varint,6,opt,name=redeemPoints" json:"redeemPoints,omitempty"`
 }
 
 func (m *Request) Reset()                    { *m = Request{} }
@@ -81,8 +106,20 @@ func (m *Request) GetRoomNumber() int32 {
 	return 0
 }
 
+func (m *Request) GetRedeemPoints() int64 {
+	if m != nil {
+		return m.RedeemPoints
+	}
+	return 0
+}
+
+// reservationId identifies a booking made by MakeReservation, and discount
+// is what its redeemed points are worth.
 type Result struct {
-	HotelId []string `protobuf:"bytes,1,rep,name=hotelId" json:"hotelId,omitempty"`
+	HotelId        []string `protobuf:"bytes,1,rep,name=hotelId" json:"hotelId,omitempty"`
+	ReservationId  string   `protobuf:"bytes,2,opt,name=reservationId" json:"reservationId,omitempty"`
+	PointsRedeemed int64    `protobuf:"varint,3,opt,name=pointsRedeemed" json:"pointsRedeemed,omitempty"`
+	Discount       float32  `protobuf:"fixed32,4,opt,name=discount" json:"discount,omitempty"`
 }
 
 func (m *Result) Reset()                    { *m = Result{} }
@@ -94,62 +131,800 @@ func (m *Result) GetHotelId() []string {
 	if m != nil {
 		return m.HotelId
 	}
-	return nil
+	return nil
+}
+
+func (m *Result) GetReservationId() string {
+	if m != nil {
+		return m.ReservationId
+	}
+	return ""
+}
+
+func (m *Result) GetPointsRedeemed() int64 {
+	if m != nil {
+		return m.PointsRedeemed
+	}
+	return 0
+}
+
+func (m *Result) GetDiscount() float32 {
+	if m != nil {
+		return m.Discount
+	}
+	return 0
+}
+
+type ListRequest struct {
+	CustomerName string `protobuf:"bytes,1,opt,name=customerName" json:"customerName,omitempty"`
+}
+
+func (m *ListRequest) Reset()                    { *m = ListRequest{} }
+func (m *ListRequest) String() string            { return proto.CompactTextString(m) }
+func (*ListRequest) ProtoMessage()               {}
+func (*ListRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{2} }
+
+func (m *ListRequest) GetCustomerName() string {
+	if m != nil {
+		return m.CustomerName
+	}
+	return ""
+}
+
+type ListResult struct {
+	Stays []*Stay `protobuf:"bytes,1,rep,name=stays" json:"stays,omitempty"`
+}
+
+func (m *ListResult) Reset()                    { *m = ListResult{} }
+func (m *ListResult) String() string            { return proto.CompactTextString(m) }
+func (*ListResult) ProtoMessage()               {}
+func (*ListResult) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
+
+func (m *ListResult) GetStays() []*Stay {
+	if m != nil {
+		return m.Stays
+	}
+	return nil
+}
+
+// A stay covers consecutive nights booked at the same hotel.
+type Stay struct {
+	HotelId    string `protobuf:"bytes,1,opt,name=hotelId" json:"hotelId,omitempty"`
+	InDate     string `protobuf:"bytes,2,opt,name=inDate" json:"inDate,omitempty"`
+	OutDate    string `protobuf:"bytes,3,opt,name=outDate" json:"outDate,omitempty"`
+	RoomNumber int32  `protobuf:"varint,4,opt,name=roomNumber" json:"roomNumber,omitempty"`
+}
+
+func (m *Stay) Reset()                    { *m = Stay{} }
+func (m *Stay) String() string            { return proto.CompactTextString(m) }
+func (*Stay) ProtoMessage()               {}
+func (*Stay) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
+
+func (m *Stay) GetHotelId() string {
+	if m != nil {
+		return m.HotelId
+	}
+	return ""
+}
+
+func (m *Stay) GetInDate() string {
+	if m != nil {
+		return m.InDate
+	}
+	return ""
+}
+
+func (m *Stay) GetOutDate() string {
+	if m != nil {
+		return m.OutDate
+	}
+	return ""
+}
+
+func (m *Stay) GetRoomNumber() int32 {
+	if m != nil {
+		return m.RoomNumber
+	}
+	return 0
+}
+
+// customerName is left out of the counts.
+type CoBookingRequest struct {
+	HotelId      []string `protobuf:"bytes,1,rep,name=hotelId" json:"hotelId,omitempty"`
+	CustomerName string   `protobuf:"bytes,2,opt,name=customerName" json:"customerName,omitempty"`
+}
+
+func (m *CoBookingRequest) Reset()                    { *m = CoBookingRequest{} }
+func (m *CoBookingRequest) String() string            { return proto.CompactTextString(m) }
+func (*CoBookingRequest) ProtoMessage()               {}
+func (*CoBookingRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+
+func (m *CoBookingRequest) GetHotelId() []string {
+	if m != nil {
+		return m.HotelId
+	}
+	return nil
+}
+
+func (m *CoBookingRequest) GetCustomerName() string {
+	if m != nil {
+		return m.CustomerName
+	}
+	return ""
+}
+
+type CoBookingResult struct {
+	Counts []*HotelCount `protobuf:"bytes,1,rep,name=counts" json:"counts,omitempty"`
+}
+
+func (m *CoBookingResult) Reset()                    { *m = CoBookingResult{} }
+func (m *CoBookingResult) String() string            { return proto.CompactTextString(m) }
+func (*CoBookingResult) ProtoMessage()               {}
+func (*CoBookingResult) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
+
+func (m *CoBookingResult) GetCounts() []*HotelCount {
+	if m != nil {
+		return m.Counts
+	}
+	return nil
+}
+
+type HotelCount struct {
+	HotelId string `protobuf:"bytes,1,opt,name=hotelId" json:"hotelId,omitempty"`
+	Count   int32  `protobuf:"varint,2,opt,name=count" json:"count,omitempty"`
+}
+
+func (m *HotelCount) Reset()                    { *m = HotelCount{} }
+func (m *HotelCount) String() string            { return proto.CompactTextString(m) }
+func (*HotelCount) ProtoMessage()               {}
+func (*HotelCount) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
+
+func (m *HotelCount) GetHotelId() string {
+	if m != nil {
+		return m.HotelId
+	}
+	return ""
+}
+
+func (m *HotelCount) GetCount() int32 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+// since is in unix seconds. Reservations made before bookings recorded
+// their creation time are not counted.
+type BookingCountRequest struct {
+	Since int64 `protobuf:"varint,1,opt,name=since" json:"since,omitempty"`
+}
+
+func (m *BookingCountRequest) Reset()                    { *m = BookingCountRequest{} }
+func (m *BookingCountRequest) String() string            { return proto.CompactTextString(m) }
+func (*BookingCountRequest) ProtoMessage()               {}
+func (*BookingCountRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
+
+func (m *BookingCountRequest) GetSince() int64 {
+	if m != nil {
+		return m.Since
+	}
+	return 0
+}
+
+type BookingCountResult struct {
+	Counts []*HotelCount `protobuf:"bytes,1,rep,name=counts" json:"counts,omitempty"`
+}
+
+func (m *BookingCountResult) Reset()                    { *m = BookingCountResult{} }
+func (m *BookingCountResult) String() string            { return proto.CompactTextString(m) }
+func (*BookingCountResult) ProtoMessage()               {}
+func (*BookingCountResult) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
+
+func (m *BookingCountResult) GetCounts() []*HotelCount {
+	if m != nil {
+		return m.Counts
+	}
+	return nil
+}
+
+type CustomerRequest struct {
+	CustomerName string `protobuf:"bytes,1,opt,name=customerName" json:"customerName,omitempty"`
+}
+
+func (m *CustomerRequest) Reset()                    { *m = CustomerRequest{} }
+func (m *CustomerRequest) String() string            { return proto.CompactTextString(m) }
+func (*CustomerRequest) ProtoMessage()               {}
+func (*CustomerRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
+
+func (m *CustomerRequest) GetCustomerName() string {
+	if m != nil {
+		return m.CustomerName
+	}
+	return ""
+}
+
+type CustomerReservations struct {
+	Nights []*Night `protobuf:"bytes,1,rep,name=nights" json:"nights,omitempty"`
+}
+
+func (m *CustomerReservations) Reset()                    { *m = CustomerReservations{} }
+func (m *CustomerReservations) String() string            { return proto.CompactTextString(m) }
+func (*CustomerReservations) ProtoMessage()               {}
+func (*CustomerReservations) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
+
+func (m *CustomerReservations) GetNights() []*Night {
+	if m != nil {
+		return m.Nights
+	}
+	return nil
+}
+
+// A night booked at a hotel. created is in unix seconds, 0 for nights
+// booked before it was recorded.
+type Night struct {
+	ReservationId string `protobuf:"bytes,1,opt,name=reservationId" json:"reservationId,omitempty"`
+	HotelId       string `protobuf:"bytes,2,opt,name=hotelId" json:"hotelId,omitempty"`
+	InDate        string `protobuf:"bytes,3,opt,name=inDate" json:"inDate,omitempty"`
+	OutDate       string `protobuf:"bytes,4,opt,name=outDate" json:"outDate,omitempty"`
+	Number        int32  `protobuf:"varint,5,opt,name=number" json:"number,omitempty"`
+	Created       int64  `protobuf:"varint,6,opt,name=created" json:"created,omitempty"`
+}
+
+func (m *Night) Reset()                    { *m = Night{} }
+func (m *Night) String() string            { return proto.CompactTextString(m) }
+func (*Night) ProtoMessage()               {}
+func (*Night) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
+
+func (m *Night) GetReservationId() string {
+	if m != nil {
+		return m.ReservationId
+	}
+	return ""
+}
+
+func (m *Night) GetHotelId() string {
+	if m != nil {
+		return m.HotelId
+	}
+	return ""
+}
+
+func (m *Night) GetInDate() string {
+	if m != nil {
+		return m.InDate
+	}
+	return ""
+}
+
+func (m *Night) GetOutDate() string {
+	if m != nil {
+		return m.OutDate
+	}
+	return ""
+}
+
+func (m *Night) GetNumber() int32 {
+	if m != nil {
+		return m.Number
+	}
+	return 0
+}
+
+func (m *Night) GetCreated() int64 {
+	if m != nil {
+		return m.Created
+	}
+	return 0
+}
+
+type EraseCustomerResult struct {
+	Anonymized int32 `protobuf:"varint,1,opt,name=anonymized" json:"anonymized,omitempty"`
+}
+
+func (m *EraseCustomerResult) Reset()                    { *m = EraseCustomerResult{} }
+func (m *EraseCustomerResult) String() string            { return proto.CompactTextString(m) }
+func (*EraseCustomerResult) ProtoMessage()               {}
+func (*EraseCustomerResult) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
+
+func (m *EraseCustomerResult) GetAnonymized() int32 {
+	if m != nil {
+		return m.Anonymized
+	}
+	return 0
+}
+
+// numberOfRoom is ignored by RemoveCapacity.
+type CapacityRequest struct {
+	HotelId      string `protobuf:"bytes,1,opt,name=hotelId" json:"hotelId,omitempty"`
+	NumberOfRoom int32  `protobuf:"varint,2,opt,name=numberOfRoom" json:"numberOfRoom,omitempty"`
+}
+
+func (m *CapacityRequest) Reset()                    { *m = CapacityRequest{} }
+func (m *CapacityRequest) String() string            { return proto.CompactTextString(m) }
+func (*CapacityRequest) ProtoMessage()               {}
+func (*CapacityRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
+
+func (m *CapacityRequest) GetHotelId() string {
+	if m != nil {
+		return m.HotelId
+	}
+	return ""
+}
+
+func (m *CapacityRequest) GetNumberOfRoom() int32 {
+	if m != nil {
+		return m.NumberOfRoom
+	}
+	return 0
+}
+
+type CapacityResult struct {
+}
+
+func (m *CapacityResult) Reset()                    { *m = CapacityResult{} }
+func (m *CapacityResult) String() string            { return proto.CompactTextString(m) }
+func (*CapacityResult) ProtoMessage()               {}
+func (*CapacityResult) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
+
+// A hotel is closed on the nights from inDate up to outDate. id, created
+// and createdBy are set by AddClosure.
+type Closure struct {
+	Id        string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	HotelId   string `protobuf:"bytes,2,opt,name=hotelId" json:"hotelId,omitempty"`
+	InDate    string `protobuf:"bytes,3,opt,name=inDate" json:"inDate,omitempty"`
+	OutDate   string `protobuf:"bytes,4,opt,name=outDate" json:"outDate,omitempty"`
+	Reason    string `protobuf:"bytes,5,opt,name=reason" json:"reason,omitempty"`
+	Created   int64  `protobuf:"varint,6,opt,name=created" json:"created,omitempty"`
+	CreatedBy string `protobuf:"bytes,7,opt,name=createdBy" json:"createdBy,omitempty"`
+}
+
+func (m *Closure) Reset()                    { *m = Closure{} }
+func (m *Closure) String() string            { return proto.CompactTextString(m) }
+func (*Closure) ProtoMessage()               {}
+func (*Closure) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
+
+func (m *Closure) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Closure) GetHotelId() string {
+	if m != nil {
+		return m.HotelId
+	}
+	return ""
+}
+
+func (m *Closure) GetInDate() string {
+	if m != nil {
+		return m.InDate
+	}
+	return ""
+}
+
+func (m *Closure) GetOutDate() string {
+	if m != nil {
+		return m.OutDate
+	}
+	return ""
+}
+
+func (m *Closure) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+func (m *Closure) GetCreated() int64 {
+	if m != nil {
+		return m.Created
+	}
+	return 0
+}
+
+func (m *Closure) GetCreatedBy() string {
+	if m != nil {
+		return m.CreatedBy
+	}
+	return ""
+}
+
+// closureId is ignored by ListClosures.
+type ClosureRequest struct {
+	HotelId   string `protobuf:"bytes,1,opt,name=hotelId" json:"hotelId,omitempty"`
+	ClosureId string `protobuf:"bytes,2,opt,name=closureId" json:"closureId,omitempty"`
+}
+
+func (m *ClosureRequest) Reset()                    { *m = ClosureRequest{} }
+func (m *ClosureRequest) String() string            { return proto.CompactTextString(m) }
+func (*ClosureRequest) ProtoMessage()               {}
+func (*ClosureRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
+
+func (m *ClosureRequest) GetHotelId() string {
+	if m != nil {
+		return m.HotelId
+	}
+	return ""
+}
+
+func (m *ClosureRequest) GetClosureId() string {
+	if m != nil {
+		return m.ClosureId
+	}
+	return ""
+}
+
+type ClosureResult struct {
+	Removed bool `protobuf:"varint,1,opt,name=removed" json:"removed,omitempty"`
+}
+
+func (m *ClosureResult) Reset()                    { *m = ClosureResult{} }
+func (m *ClosureResult) String() string            { return proto.CompactTextString(m) }
+func (*ClosureResult) ProtoMessage()               {}
+func (*ClosureResult) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
+
+func (m *ClosureResult) GetRemoved() bool {
+	if m != nil {
+		return m.Removed
+	}
+	return false
+}
+
+type ClosureList struct {
+	Closures []*Closure `protobuf:"bytes,1,rep,name=closures" json:"closures,omitempty"`
+}
+
+func (m *ClosureList) Reset()                    { *m = ClosureList{} }
+func (m *ClosureList) String() string            { return proto.CompactTextString(m) }
+func (*ClosureList) ProtoMessage()               {}
+func (*ClosureList) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{19} }
+
+func (m *ClosureList) GetClosures() []*Closure {
+	if m != nil {
+		return m.Closures
+	}
+	return nil
+}
+
+// hotels has an entry for each hotel asked for, in order.
+type BookingReport struct {
+	Hotels []*HotelBookings `protobuf:"bytes,1,rep,name=hotels" json:"hotels,omitempty"`
+}
+
+func (m *BookingReport) Reset()                    { *m = BookingReport{} }
+func (m *BookingReport) String() string            { return proto.CompactTextString(m) }
+func (*BookingReport) ProtoMessage()               {}
+func (*BookingReport) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{20} }
+
+func (m *BookingReport) GetHotels() []*HotelBookings {
+	if m != nil {
+		return m.Hotels
+	}
+	return nil
+}
+
+// Closed nights have no rooms available. cancellations and stays are of
+// the stays arriving from inDate up to outDate; stays counts those booked
+// since bookings recorded their creation time, and leadDays sums the days
+// each of them was booked ahead of arrival.
+type HotelBookings struct {
+	HotelId             string        `protobuf:"bytes,1,opt,name=hotelId" json:"hotelId,omitempty"`
+	Nights              []*NightSales `protobuf:"bytes,2,rep,name=nights" json:"nights,omitempty"`
+	Cancellations       int32         `protobuf:"varint,3,opt,name=cancellations" json:"cancellations,omitempty"`
+	CancelledRoomNights int32         `protobuf:"varint,4,opt,name=cancelledRoomNights" json:"cancelledRoomNights,omitempty"`
+	Stays               int32         `protobuf:"varint,5,opt,name=stays" json:"stays,omitempty"`
+	LeadDays            int64         `protobuf:"varint,6,opt,name=leadDays" json:"leadDays,omitempty"`
+}
+
+func (m *HotelBookings) Reset()                    { *m = HotelBookings{} }
+func (m *HotelBookings) String() string            { return proto.CompactTextString(m) }
+func (*HotelBookings) ProtoMessage()               {}
+func (*HotelBookings) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{21} }
+
+func (m *HotelBookings) GetHotelId() string {
+	if m != nil {
+		return m.HotelId
+	}
+	return ""
+}
+
+func (m *HotelBookings) GetNights() []*NightSales {
+	if m != nil {
+		return m.Nights
+	}
+	return nil
+}
+
+func (m *HotelBookings) GetCancellations() int32 {
+	if m != nil {
+		return m.Cancellations
+	}
+	return 0
+}
+
+func (m *HotelBookings) GetCancelledRoomNights() int32 {
+	if m != nil {
+		return m.CancelledRoomNights
+	}
+	return 0
+}
+
+func (m *HotelBookings) GetStays() int32 {
+	if m != nil {
+		return m.Stays
+	}
+	return 0
+}
+
+func (m *HotelBookings) GetLeadDays() int64 {
+	if m != nil {
+		return m.LeadDays
+	}
+	return 0
+}
+
+// date is the night from date to the next day.
+type NightSales struct {
+	Date           string `protobuf:"bytes,1,opt,name=date" json:"date,omitempty"`
+	RoomsSold      int32  `protobuf:"varint,2,opt,name=roomsSold" json:"roomsSold,omitempty"`
+	RoomsAvailable int32  `protobuf:"varint,3,opt,name=roomsAvailable" json:"roomsAvailable,omitempty"`
+}
+
+func (m *NightSales) Reset()                    { *m = NightSales{} }
+func (m *NightSales) String() string            { return proto.CompactTextString(m) }
+func (*NightSales) ProtoMessage()               {}
+func (*NightSales) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{22} }
+
+func (m *NightSales) GetDate() string {
+	if m != nil {
+		return m.Date
+	}
+	return ""
+}
+
+func (m *NightSales) GetRoomsSold() int32 {
+	if m != nil {
+		return m.RoomsSold
+	}
+	return 0
+}
+
+func (m *NightSales) GetRoomsAvailable() int32 {
+	if m != nil {
+		return m.RoomsAvailable
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterType((*Request)(nil), "reservation.Request")
+	proto.RegisterType((*Result)(nil), "reservation.Result")
+	proto.RegisterType((*ListRequest)(nil), "reservation.ListRequest")
+	proto.RegisterType((*ListResult)(nil), "reservation.ListResult")
+	proto.RegisterType((*Stay)(nil), "reservation.Stay")
+	proto.RegisterType((*CoBookingRequest)(nil), "reservation.CoBookingRequest")
+	proto.RegisterType((*CoBookingResult)(nil), "reservation.CoBookingResult")
+	proto.RegisterType((*HotelCount)(nil), "reservation.HotelCount")
+	proto.RegisterType((*BookingCountRequest)(nil), "reservation.BookingCountRequest")
+	proto.RegisterType((*BookingCountResult)(nil), "reservation.BookingCountResult")
+	proto.RegisterType((*CustomerRequest)(nil), "reservation.CustomerRequest")
+	proto.RegisterType((*CustomerReservations)(nil), "reservation.CustomerReservations")
+	proto.RegisterType((*Night)(nil), "reservation.Night")
+	proto.RegisterType((*EraseCustomerResult)(nil), "reservation.EraseCustomerResult")
+	proto.RegisterType((*CapacityRequest)(nil), "reservation.CapacityRequest")
+	proto.RegisterType((*CapacityResult)(nil), "reservation.CapacityResult")
+	proto.RegisterType((*Closure)(nil), "reservation.Closure")
+	proto.RegisterType((*ClosureRequest)(nil), "reservation.ClosureRequest")
+	proto.RegisterType((*ClosureResult)(nil), "reservation.ClosureResult")
+	proto.RegisterType((*ClosureList)(nil), "reservation.ClosureList")
+	proto.RegisterType((*BookingReport)(nil), "reservation.BookingReport")
+	proto.RegisterType((*HotelBookings)(nil), "reservation.HotelBookings")
+	proto.RegisterType((*NightSales)(nil), "reservation.NightSales")
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// Client API for Reservation service
+
+type ReservationClient interface {
+	// MakeReservation makes a reservation based on given information
+	MakeReservation(ctx context.Context, in *Request, opts ...grpc.CallOption) (*Result, error)
+	// CancelReservation Cancels a reservation based on given information
+	CancelReservation(ctx context.Context, in *Request, opts ...grpc.CallOption) (*Result, error)
+	// CheckAvailability checks if given information is available
+	CheckAvailability(ctx context.Context, in *Request, opts ...grpc.CallOption) (*Result, error)
+	// ListReservations returns the stays booked under a customer name
+	ListReservations(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListResult, error)
+	// CoBookings counts, for every other hotel, the customers who booked it
+	// and at least one of the given hotels
+	CoBookings(ctx context.Context, in *CoBookingRequest, opts ...grpc.CallOption) (*CoBookingResult, error)
+	// BookingCounts counts the bookings made at each hotel since a given time
+	BookingCounts(ctx context.Context, in *BookingCountRequest, opts ...grpc.CallOption) (*BookingCountResult, error)
+	// ExportCustomer returns every night booked under a customer name
+	ExportCustomer(ctx context.Context, in *CustomerRequest, opts ...grpc.CallOption) (*CustomerReservations, error)
+	// EraseCustomer replaces a customer name on its bookings by a pseudonym,
+	// keeping the rooms booked
+	EraseCustomer(ctx context.Context, in *CustomerRequest, opts ...grpc.CallOption) (*EraseCustomerResult, error)
+	// AddCapacity sets the number of rooms of a new hotel
+	AddCapacity(ctx context.Context, in *CapacityRequest, opts ...grpc.CallOption) (*CapacityResult, error)
+	// RemoveCapacity removes the number of rooms of a hotel, which can then
+	// not be booked
+	RemoveCapacity(ctx context.Context, in *CapacityRequest, opts ...grpc.CallOption) (*CapacityResult, error)
+	// DecommissionHotel takes a hotel out of service for good, keeping its
+	// past reservations
+	DecommissionHotel(ctx context.Context, in *CapacityRequest, opts ...grpc.CallOption) (*CapacityResult, error)
+	// AddClosure closes a hotel for a date range, during which it has no
+	// rooms to book
+	AddClosure(ctx context.Context, in *Closure, opts ...grpc.CallOption) (*Closure, error)
+	// RemoveClosure reopens a hotel closed by AddClosure
+	RemoveClosure(ctx context.Context, in *ClosureRequest, opts ...grpc.CallOption) (*ClosureResult, error)
+	// ListClosures returns the closures of a hotel that have not ended,
+	// soonest first
+	ListClosures(ctx context.Context, in *ClosureRequest, opts ...grpc.CallOption) (*ClosureList, error)
+	// ClosedHotels returns which of the given hotels are closed on some night
+	// between inDate and outDate
+	ClosedHotels(ctx context.Context, in *Request, opts ...grpc.CallOption) (*Result, error)
+	// HotelBookings returns the rooms sold and available at each of the
+	// given hotels on every night from inDate up to outDate, with the
+	// cancellations and booking lead times of the stays arriving then
+	HotelBookings(ctx context.Context, in *Request, opts ...grpc.CallOption) (*BookingReport, error)
+}
+
+type reservationClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewReservationClient(cc *grpc.ClientConn) ReservationClient {
+	return &reservationClient{cc}
+}
+
+func (c *reservationClient) MakeReservation(ctx context.Context, in *Request, opts ...grpc.CallOption) (*Result, error) {
+	out := new(Result)
+	err := grpc.Invoke(ctx, "/reservation.Reservation/MakeReservation", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reservationClient) CancelReservation(ctx context.Context, in *Request, opts ...grpc.CallOption) (*Result, error) {
+	out := new(Result)
+	err := grpc.Invoke(ctx, "/reservation.Reservation/CancelReservation", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reservationClient) CheckAvailability(ctx context.Context, in *Request, opts ...grpc.CallOption) (*Result, error) {
+	out := new(Result)
+	err := grpc.Invoke(ctx, "/reservation.Reservation/CheckAvailability", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reservationClient) ListReservations(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListResult, error) {
+	out := new(ListResult)
+	err := grpc.Invoke(ctx, "/reservation.Reservation/ListReservations", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reservationClient) CoBookings(ctx context.Context, in *CoBookingRequest, opts ...grpc.CallOption) (*CoBookingResult, error) {
+	out := new(CoBookingResult)
+	err := grpc.Invoke(ctx, "/reservation.Reservation/CoBookings", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reservationClient) BookingCounts(ctx context.Context, in *BookingCountRequest, opts ...grpc.CallOption) (*BookingCountResult, error) {
+	out := new(BookingCountResult)
+	err := grpc.Invoke(ctx, "/reservation.Reservation/BookingCounts", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reservationClient) ExportCustomer(ctx context.Context, in *CustomerRequest, opts ...grpc.CallOption) (*CustomerReservations, error) {
+	out := new(CustomerReservations)
+	err := grpc.Invoke(ctx, "/reservation.Reservation/ExportCustomer", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-func init() {
-	proto.RegisterType((*Request)(nil), "reservation.Request")
-	proto.RegisterType((*Result)(nil), "reservation.Result")
+func (c *reservationClient) EraseCustomer(ctx context.Context, in *CustomerRequest, opts ...grpc.CallOption) (*EraseCustomerResult, error) {
+	out := new(EraseCustomerResult)
+	err := grpc.Invoke(ctx, "/reservation.Reservation/EraseCustomer", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
-
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
+func (c *reservationClient) AddCapacity(ctx context.Context, in *CapacityRequest, opts ...grpc.CallOption) (*CapacityResult, error) {
+	out := new(CapacityResult)
+	err := grpc.Invoke(ctx, "/reservation.Reservation/AddCapacity", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
 
-// Client API for Reservation service
+func (c *reservationClient) RemoveCapacity(ctx context.Context, in *CapacityRequest, opts ...grpc.CallOption) (*CapacityResult, error) {
+	out := new(CapacityResult)
+	err := grpc.Invoke(ctx, "/reservation.Reservation/RemoveCapacity", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
 
-type ReservationClient interface {
-	// MakeReservation makes a reservation based on given information
-	MakeReservation(ctx context.Context, in *Request, opts ...grpc.CallOption) (*Result, error)
-	// CancelReservation Cancels a reservation based on given information
-	CancelReservation(ctx context.Context, in *Request, opts ...grpc.CallOption) (*Result, error)
-	// CheckAvailability checks if given information is available
-	CheckAvailability(ctx context.Context, in *Request, opts ...grpc.CallOption) (*Result, error)
+func (c *reservationClient) DecommissionHotel(ctx context.Context, in *CapacityRequest, opts ...grpc.CallOption) (*CapacityResult, error) {
+	out := new(CapacityResult)
+	err := grpc.Invoke(ctx, "/reservation.Reservation/DecommissionHotel", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-type reservationClient struct {
-	cc *grpc.ClientConn
+func (c *reservationClient) AddClosure(ctx context.Context, in *Closure, opts ...grpc.CallOption) (*Closure, error) {
+	out := new(Closure)
+	err := grpc.Invoke(ctx, "/reservation.Reservation/AddClosure", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-func NewReservationClient(cc *grpc.ClientConn) ReservationClient {
-	return &reservationClient{cc}
+func (c *reservationClient) RemoveClosure(ctx context.Context, in *ClosureRequest, opts ...grpc.CallOption) (*ClosureResult, error) {
+	out := new(ClosureResult)
+	err := grpc.Invoke(ctx, "/reservation.Reservation/RemoveClosure", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-func (c *reservationClient) MakeReservation(ctx context.Context, in *Request, opts ...grpc.CallOption) (*Result, error) {
-	out := new(Result)
-	err := grpc.Invoke(ctx, "/reservation.Reservation/MakeReservation", in, out, c.cc, opts...)
+func (c *reservationClient) ListClosures(ctx context.Context, in *ClosureRequest, opts ...grpc.CallOption) (*ClosureList, error) {
+	out := new(ClosureList)
+	err := grpc.Invoke(ctx, "/reservation.Reservation/ListClosures", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *reservationClient) CancelReservation(ctx context.Context, in *Request, opts ...grpc.CallOption) (*Result, error) {
+func (c *reservationClient) ClosedHotels(ctx context.Context, in *Request, opts ...grpc.CallOption) (*Result, error) {
 	out := new(Result)
-	err := grpc.Invoke(ctx, "/reservation.Reservation/CancelReservation", in, out, c.cc, opts...)
+	err := grpc.Invoke(ctx, "/reservation.Reservation/ClosedHotels", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *reservationClient) CheckAvailability(ctx context.Context, in *Request, opts ...grpc.CallOption) (*Result, error) {
-	out := new(Result)
-	err := grpc.Invoke(ctx, "/reservation.Reservation/CheckAvailability", in, out, c.cc, opts...)
+func (c *reservationClient) HotelBookings(ctx context.Context, in *Request, opts ...grpc.CallOption) (*BookingReport, error) {
+	out := new(BookingReport)
+	err := grpc.Invoke(ctx, "/reservation.Reservation/HotelBookings", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -165,6 +940,41 @@ type ReservationServer interface {
 	CancelReservation(context.Context, *Request) (*Result, error)
 	// CheckAvailability checks if given information is available
 	CheckAvailability(context.Context, *Request) (*Result, error)
+	// ListReservations returns the stays booked under a customer name
+	ListReservations(context.Context, *ListRequest) (*ListResult, error)
+	// CoBookings counts, for every other hotel, the customers who booked it
+	// and at least one of the given hotels
+	CoBookings(context.Context, *CoBookingRequest) (*CoBookingResult, error)
+	// BookingCounts counts the bookings made at each hotel since a given time
+	BookingCounts(context.Context, *BookingCountRequest) (*BookingCountResult, error)
+	// ExportCustomer returns every night booked under a customer name
+	ExportCustomer(context.Context, *CustomerRequest) (*CustomerReservations, error)
+	// EraseCustomer replaces a customer name on its bookings by a pseudonym,
+	// keeping the rooms booked
+	EraseCustomer(context.Context, *CustomerRequest) (*EraseCustomerResult, error)
+	// AddCapacity sets the number of rooms of a new hotel
+	AddCapacity(context.Context, *CapacityRequest) (*CapacityResult, error)
+	// RemoveCapacity removes the number of rooms of a hotel, which can then
+	// not be booked
+	RemoveCapacity(context.Context, *CapacityRequest) (*CapacityResult, error)
+	// DecommissionHotel takes a hotel out of service for good, keeping its
+	// past reservations
+	DecommissionHotel(context.Context, *CapacityRequest) (*CapacityResult, error)
+	// AddClosure closes a hotel for a date range, during which it has no
+	// rooms to book
+	AddClosure(context.Context, *Closure) (*Closure, error)
+	// RemoveClosure reopens a hotel closed by AddClosure
+	RemoveClosure(context.Context, *ClosureRequest) (*ClosureResult, error)
+	// ListClosures returns the closures of a hotel that have not ended,
+	// soonest first
+	ListClosures(context.Context, *ClosureRequest) (*ClosureList, error)
+	// ClosedHotels returns which of the given hotels are closed on some night
+	// between inDate and outDate
+	ClosedHotels(context.Context, *Request) (*Result, error)
+	// HotelBookings returns the rooms sold and available at each of the
+	// given hotels on every night from inDate up to outDate, with the
+	// cancellations and booking lead times of the stays arriving then
+	HotelBookings(context.Context, *Request) (*BookingReport, error)
 }
 
 func RegisterReservationServer(s *grpc.Server, srv ReservationServer) {
@@ -225,6 +1035,240 @@ func _Reservation_CheckAvailability_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Reservation_ListReservations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReservationServer).ListReservations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/reservation.Reservation/ListReservations",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReservationServer).ListReservations(ctx, req.(*ListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Reservation_CoBookings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CoBookingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReservationServer).CoBookings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/reservation.Reservation/CoBookings",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReservationServer).CoBookings(ctx, req.(*CoBookingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Reservation_BookingCounts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BookingCountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReservationServer).BookingCounts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/reservation.Reservation/BookingCounts",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReservationServer).BookingCounts(ctx, req.(*BookingCountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Reservation_ExportCustomer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CustomerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReservationServer).ExportCustomer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/reservation.Reservation/ExportCustomer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReservationServer).ExportCustomer(ctx, req.(*CustomerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Reservation_EraseCustomer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CustomerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReservationServer).EraseCustomer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/reservation.Reservation/EraseCustomer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReservationServer).EraseCustomer(ctx, req.(*CustomerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Reservation_AddCapacity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CapacityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReservationServer).AddCapacity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/reservation.Reservation/AddCapacity",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReservationServer).AddCapacity(ctx, req.(*CapacityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Reservation_RemoveCapacity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CapacityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReservationServer).RemoveCapacity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/reservation.Reservation/RemoveCapacity",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReservationServer).RemoveCapacity(ctx, req.(*CapacityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Reservation_DecommissionHotel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CapacityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReservationServer).DecommissionHotel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/reservation.Reservation/DecommissionHotel",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReservationServer).DecommissionHotel(ctx, req.(*CapacityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Reservation_AddClosure_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Closure)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReservationServer).AddClosure(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/reservation.Reservation/AddClosure",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReservationServer).AddClosure(ctx, req.(*Closure))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Reservation_RemoveClosure_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClosureRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReservationServer).RemoveClosure(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/reservation.Reservation/RemoveClosure",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReservationServer).RemoveClosure(ctx, req.(*ClosureRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Reservation_ListClosures_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClosureRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReservationServer).ListClosures(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/reservation.Reservation/ListClosures",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReservationServer).ListClosures(ctx, req.(*ClosureRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Reservation_ClosedHotels_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Request)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReservationServer).ClosedHotels(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/reservation.Reservation/ClosedHotels",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReservationServer).ClosedHotels(ctx, req.(*Request))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Reservation_HotelBookings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Request)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReservationServer).HotelBookings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/reservation.Reservation/HotelBookings",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReservationServer).HotelBookings(ctx, req.(*Request))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Reservation_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "reservation.Reservation",
 	HandlerType: (*ReservationServer)(nil),
@@ -241,28 +1285,128 @@ var _Reservation_serviceDesc = grpc.ServiceDesc{
 			MethodName: "CheckAvailability",
 			Handler:    _Reservation_CheckAvailability_Handler,
 		},
+		{
+			MethodName: "ListReservations",
+			Handler:    _Reservation_ListReservations_Handler,
+		},
+		{
+			MethodName: "CoBookings",
+			Handler:    _Reservation_CoBookings_Handler,
+		},
+		{
+			MethodName: "BookingCounts",
+			Handler:    _Reservation_BookingCounts_Handler,
+		},
+		{
+			MethodName: "ExportCustomer",
+			Handler:    _Reservation_ExportCustomer_Handler,
+		},
+		{
+			MethodName: "EraseCustomer",
+			Handler:    _Reservation_EraseCustomer_Handler,
+		},
+		{
+			MethodName: "AddCapacity",
+			Handler:    _Reservation_AddCapacity_Handler,
+		},
+		{
+			MethodName: "RemoveCapacity",
+			Handler:    _Reservation_RemoveCapacity_Handler,
+		},
+		{
+			MethodName: "DecommissionHotel",
+			Handler:    _Reservation_DecommissionHotel_Handler,
+		},
+		{
+			MethodName: "AddClosure",
+			Handler:    _Reservation_AddClosure_Handler,
+		},
+		{
+			MethodName: "RemoveClosure",
+			Handler:    _Reservation_RemoveClosure_Handler,
+		},
+		{
+			MethodName: "ListClosures",
+			Handler:    _Reservation_ListClosures_Handler,
+		},
+		{
+			MethodName: "ClosedHotels",
+			Handler:    _Reservation_ClosedHotels_Handler,
+		},
+		{
+			MethodName: "HotelBookings",
+			Handler:    _Reservation_HotelBookings_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
-	Metadata: "reservation.proto",
+	Metadata: "services/reservation/proto/reservation.proto",
 }
 
-func init() { proto.RegisterFile("reservation.proto", fileDescriptor0) }
+func init() { proto.RegisterFile("services/reservation/proto/reservation.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 235 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x91, 0xc1, 0x4a, 0x03, 0x31,
-	0x10, 0x86, 0x89, 0xb5, 0x5b, 0x3a, 0x15, 0xa4, 0x51, 0x24, 0x78, 0x90, 0x65, 0x4f, 0x7b, 0xea,
-	0x41, 0xaf, 0x22, 0x88, 0x5e, 0x3c, 0xd8, 0x43, 0xde, 0x20, 0xbb, 0x0e, 0x34, 0x34, 0xd9, 0xd1,
-	0x64, 0x52, 0xf0, 0x51, 0x7c, 0x26, 0x5f, 0x4a, 0x0c, 0x2d, 0x66, 0xbd, 0x89, 0xc7, 0xff, 0x1b,
-	0x3e, 0x7e, 0xfe, 0x04, 0x96, 0x01, 0x23, 0x86, 0x9d, 0x61, 0x4b, 0xc3, 0xea, 0x35, 0x10, 0x93,
-	0x5c, 0x14, 0xa8, 0xf9, 0x10, 0x30, 0xd3, 0xf8, 0x96, 0x30, 0xb2, 0x6c, 0xe0, 0xa4, 0x4f, 0x91,
-	0xc9, 0x63, 0x58, 0x1b, 0x8f, 0x4a, 0xd4, 0xa2, 0x9d, 0xeb, 0x11, 0x93, 0x0a, 0x66, 0x1b, 0x62,
-	0x74, 0x4f, 0x2f, 0xea, 0xa8, 0x9e, 0xb4, 0x73, 0x7d, 0x88, 0xf2, 0x02, 0x2a, 0x3b, 0x3c, 0x1a,
-	0x46, 0x35, 0xc9, 0xde, 0x3e, 0x7d, 0x1b, 0x94, 0x38, 0x1f, 0x8e, 0xf3, 0xe1, 0x10, 0xe5, 0x15,
-	0x40, 0x20, 0xf2, 0xeb, 0xe4, 0x3b, 0x0c, 0x6a, 0x5a, 0x8b, 0x76, 0xaa, 0x0b, 0xd2, 0x34, 0x50,
-	0x69, 0x8c, 0xc9, 0x71, 0xd9, 0x2a, 0x46, 0xad, 0xd7, 0x9f, 0x02, 0x16, 0xfa, 0x67, 0x8f, 0xbc,
-	0x85, 0xd3, 0x67, 0xb3, 0xc5, 0x12, 0x9d, 0xaf, 0xca, 0x37, 0xd8, 0x8f, 0xbd, 0x3c, 0xfb, 0x45,
-	0x73, 0xcf, 0x1d, 0x2c, 0x1f, 0xcc, 0xd0, 0xa3, 0xfb, 0x87, 0xbf, 0xc1, 0x7e, 0x7b, 0xbf, 0x33,
-	0xd6, 0x99, 0xce, 0x3a, 0xcb, 0xef, 0x7f, 0xf0, 0xbb, 0x2a, 0xff, 0xd0, 0xcd, 0x57, 0x00, 0x00,
-	0x00, 0xff, 0xff, 0xd7, 0x75, 0x5e, 0xc5, 0xb6, 0x01, 0x00, 0x00,
+	// 1005 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x57, 0xdd, 0x6e, 0xe3, 0x44,
+	0x14, 0x96, 0x93, 0x26, 0x6d, 0x4e, 0x9a, 0xb4, 0x9d, 0x56, 0xbb, 0x96, 0x77, 0x59, 0xc2, 0x08,
+	0x41, 0xf9, 0xd1, 0x76, 0x29, 0xaa, 0x04, 0xd2, 0x0a, 0xd4, 0xa6, 0x95, 0xba, 0x02, 0xd2, 0x65,
+	0xfa, 0x04, 0xae, 0x3d, 0xbb, 0x1d, 0xd5, 0xf6, 0x14, 0x8f, 0x53, 0x11, 0xee, 0xb9, 0xe3, 0x4d,
+	0xb8, 0xe6, 0x92, 0xb7, 0xe1, 0x41, 0xd0, 0x1c, 0x8f, 0x1d, 0x8f, 0xd7, 0xc9, 0x2a, 0xdb, 0xde,
+	0xf9, 0x7c, 0xe7, 0x67, 0xce, 0xff, 0x91, 0xe1, 0x6b, 0xc5, 0xd3, 0x3b, 0x11, 0x70, 0x75, 0x90,
+	0x72, 0xfd, 0xe9, 0x67, 0x42, 0x26, 0x07, 0xb7, 0xa9, 0xcc, 0x64, 0x15, 0x79, 0x8e, 0x08, 0xe9,
+	0x57, 0x20, 0xfa, 0xaf, 0x03, 0xeb, 0x8c, 0xff, 0x36, 0xe5, 0x2a, 0x23, 0x14, 0x36, 0x83, 0xa9,
+	0xca, 0x64, 0xcc, 0xd3, 0x89, 0x1f, 0x73, 0xd7, 0x19, 0x39, 0xfb, 0x3d, 0x66, 0x61, 0xc4, 0x85,
+	0xf5, 0x6b, 0x99, 0xf1, 0xe8, 0x55, 0xe8, 0xb6, 0x46, 0xed, 0xfd, 0x1e, 0x2b, 0x48, 0xf2, 0x08,
+	0xba, 0x22, 0x39, 0xf5, 0x33, 0xee, 0xb6, 0x51, 0xcf, 0x50, 0x5a, 0x43, 0x4e, 0x33, 0x64, 0xac,
+	0x21, 0xa3, 0x20, 0xc9, 0x33, 0x80, 0x54, 0xca, 0x78, 0x32, 0x8d, 0xaf, 0x78, 0xea, 0x76, 0x46,
+	0xce, 0x7e, 0x87, 0x55, 0x10, 0xed, 0x4f, 0xca, 0x43, 0xce, 0xe3, 0xd7, 0x52, 0x24, 0x99, 0x72,
+	0xbb, 0x23, 0x67, 0xbf, 0xcd, 0x2c, 0x8c, 0xfe, 0xe5, 0x40, 0x97, 0x71, 0x35, 0x8d, 0xb2, 0xaa,
+	0x6b, 0x8e, 0xed, 0xda, 0xa7, 0x30, 0xa8, 0xc4, 0x8c, 0xae, 0x6b, 0x47, 0x6c, 0x90, 0x7c, 0x06,
+	0xc3, 0x5b, 0x34, 0xca, 0xf0, 0x01, 0x1e, 0x62, 0x20, 0x6d, 0x56, 0x43, 0x89, 0x07, 0x1b, 0xa1,
+	0x50, 0x81, 0x9c, 0x26, 0x19, 0x46, 0xd4, 0x62, 0x25, 0x4d, 0xbf, 0x81, 0xfe, 0xcf, 0x42, 0x65,
+	0x2b, 0x64, 0x94, 0x1e, 0x01, 0xe4, 0x2a, 0x18, 0xc4, 0xe7, 0xd0, 0x51, 0x99, 0x3f, 0x53, 0x18,
+	0x42, 0xff, 0x70, 0xe7, 0x79, 0xb5, 0x7e, 0x97, 0x99, 0x3f, 0x63, 0x39, 0x9f, 0xa6, 0xb0, 0xa6,
+	0x49, 0x3b, 0x6a, 0xa7, 0xb9, 0x20, 0xad, 0x45, 0x05, 0x69, 0x2f, 0x2b, 0xc8, 0x5a, 0xbd, 0x20,
+	0xf4, 0x35, 0x6c, 0x8f, 0xe5, 0x89, 0x94, 0x37, 0x22, 0x79, 0x5b, 0x84, 0xb8, 0x38, 0xeb, 0xf5,
+	0xe0, 0x5b, 0x0d, 0xc1, 0x9f, 0xc0, 0x56, 0xc5, 0x22, 0x66, 0xe0, 0x00, 0xba, 0x98, 0xcb, 0x22,
+	0x05, 0x8f, 0xad, 0x14, 0x9c, 0x6b, 0xe3, 0x63, 0xcd, 0x67, 0x46, 0x8c, 0xbe, 0x04, 0x98, 0xa3,
+	0x4b, 0xf2, 0xb1, 0x07, 0x9d, 0xbc, 0x68, 0x2d, 0x0c, 0x2c, 0x27, 0xe8, 0x57, 0xb0, 0x6b, 0xde,
+	0xcf, 0xad, 0x9a, 0xb0, 0xf6, 0xa0, 0xa3, 0x44, 0x12, 0xe4, 0x25, 0x6b, 0xb3, 0x9c, 0xa0, 0x67,
+	0x40, 0x6c, 0xe1, 0x0f, 0xf3, 0xf8, 0x08, 0xb6, 0xc6, 0x26, 0x0b, 0xab, 0x74, 0xca, 0x09, 0xec,
+	0xcd, 0xd5, 0xca, 0x07, 0x14, 0xf9, 0x12, 0xba, 0x89, 0x78, 0x7b, 0x5d, 0xbe, 0x4f, 0xac, 0xf7,
+	0x27, 0x9a, 0xc5, 0x8c, 0x04, 0xfd, 0xdb, 0x81, 0x0e, 0x22, 0xef, 0x0e, 0x85, 0xd3, 0x34, 0x14,
+	0xd6, 0xbc, 0x3b, 0xf7, 0x9b, 0xf7, 0x47, 0xd0, 0x4d, 0xaa, 0xb3, 0x6e, 0x28, 0xad, 0x11, 0xa4,
+	0xdc, 0xcf, 0x78, 0x68, 0x46, 0xbc, 0x20, 0xe9, 0x11, 0xec, 0x9e, 0xa5, 0xbe, 0xe2, 0x95, 0xb0,
+	0x75, 0xc2, 0x9f, 0x01, 0xf8, 0x89, 0x4c, 0x66, 0xb1, 0xf8, 0x83, 0xe7, 0x7e, 0x77, 0x58, 0x05,
+	0xa1, 0x17, 0xb0, 0x35, 0xf6, 0x6f, 0xfd, 0x40, 0x64, 0xb3, 0xc6, 0x36, 0x75, 0x6a, 0x6d, 0x9a,
+	0xfb, 0x71, 0xf1, 0x86, 0x49, 0x19, 0x9b, 0xee, 0xb0, 0x30, 0xba, 0x0d, 0xc3, 0xb9, 0x41, 0xed,
+	0x02, 0xfd, 0xc7, 0x81, 0xf5, 0x71, 0x24, 0xd5, 0x34, 0xe5, 0x64, 0x08, 0x2d, 0x51, 0x98, 0x6d,
+	0x89, 0x07, 0xcf, 0x59, 0xca, 0x7d, 0x25, 0x13, 0xcc, 0x59, 0x8f, 0x19, 0x6a, 0x71, 0xce, 0xc8,
+	0x53, 0xe8, 0x99, 0xcf, 0x93, 0x99, 0xbb, 0x8e, 0x4a, 0x73, 0x80, 0x9e, 0xc3, 0xd0, 0xb8, 0xfd,
+	0xfe, 0xcc, 0x68, 0x4b, 0xb9, 0x6c, 0x19, 0xc9, 0x1c, 0xa0, 0x5f, 0xc0, 0xa0, 0xb4, 0x54, 0xec,
+	0xdf, 0x94, 0xc7, 0xf2, 0xce, 0x94, 0x64, 0x83, 0x15, 0x24, 0xfd, 0x11, 0xfa, 0x46, 0x54, 0x6f,
+	0x3a, 0xf2, 0x02, 0x36, 0x8c, 0x99, 0xa2, 0x63, 0xf7, 0xac, 0x8e, 0x2d, 0xcc, 0x96, 0x52, 0x74,
+	0x0c, 0x83, 0x72, 0x49, 0xdc, 0xca, 0x34, 0x23, 0x87, 0xd0, 0x45, 0x2f, 0x0b, 0x03, 0xde, 0xbb,
+	0x23, 0x67, 0x14, 0x14, 0x33, 0x92, 0xf4, 0x3f, 0x07, 0x06, 0x16, 0x67, 0x49, 0xe8, 0x07, 0xe5,
+	0x48, 0xb5, 0x1a, 0x46, 0x1a, 0x07, 0xe8, 0xd2, 0x8f, 0xb8, 0x2a, 0xe6, 0x4a, 0x4f, 0x53, 0xe0,
+	0x27, 0x01, 0x8f, 0x22, 0x14, 0x51, 0x58, 0xe0, 0x0e, 0xb3, 0x41, 0xf2, 0x02, 0x76, 0x0d, 0xc0,
+	0x43, 0xdd, 0x58, 0x93, 0xfc, 0x8d, 0x7c, 0xd3, 0x36, 0xb1, 0x70, 0x0f, 0xe1, 0x3d, 0xc8, 0x47,
+	0x26, 0x27, 0xf4, 0x09, 0x8a, 0xb8, 0x1f, 0x9e, 0x6a, 0x46, 0x5e, 0xfe, 0x92, 0xa6, 0x6f, 0x00,
+	0xe6, 0xfe, 0x11, 0x02, 0x6b, 0xa1, 0x6e, 0xab, 0x3c, 0x3e, 0xfc, 0xd6, 0x75, 0xd5, 0x4b, 0x5d,
+	0x5d, 0xca, 0x28, 0x34, 0xed, 0x3e, 0x07, 0xf4, 0x19, 0x44, 0xe2, 0xf8, 0xce, 0x17, 0x91, 0x7f,
+	0x15, 0x71, 0x13, 0x4a, 0x0d, 0x3d, 0xfc, 0xb3, 0x07, 0xfd, 0xca, 0x1a, 0x22, 0x2f, 0x61, 0xeb,
+	0x17, 0xff, 0x86, 0x57, 0x21, 0xbb, 0xac, 0xa6, 0xe1, 0xbc, 0xdd, 0x1a, 0x8a, 0xcd, 0xf3, 0x03,
+	0xec, 0x8c, 0x31, 0xfc, 0x7b, 0xe8, 0x5f, 0xf3, 0xe0, 0xc6, 0xf8, 0x27, 0x22, 0x91, 0xcd, 0x56,
+	0xd1, 0x3f, 0x83, 0x6d, 0x73, 0x85, 0xe7, 0x7b, 0xd5, 0xb5, 0x04, 0x2b, 0x77, 0xdd, 0x7b, 0xdc,
+	0xc0, 0x41, 0x33, 0xaf, 0x00, 0xca, 0x7b, 0xa6, 0xc8, 0x47, 0x76, 0x5b, 0xd7, 0x4e, 0xa7, 0xf7,
+	0x74, 0x11, 0x1b, 0x4d, 0xb1, 0xb2, 0xe7, 0xf1, 0x78, 0x28, 0x32, 0xb2, 0xc4, 0x1b, 0x8e, 0x96,
+	0xf7, 0xf1, 0x12, 0x09, 0xb4, 0xf9, 0x2b, 0x0c, 0xcf, 0x7e, 0xd7, 0x03, 0x54, 0x2c, 0x54, 0x52,
+	0xf3, 0xc1, 0xbe, 0x4a, 0xde, 0x27, 0x0b, 0xb8, 0x95, 0x24, 0x5d, 0xc0, 0xc0, 0x5a, 0xd1, 0xef,
+	0xb1, 0x68, 0x07, 0xd1, 0xb4, 0xdc, 0xcf, 0xa1, 0x7f, 0x1c, 0x86, 0xc5, 0xba, 0xad, 0x9b, 0xb3,
+	0xd7, 0xba, 0xf7, 0x64, 0x01, 0x17, 0x2d, 0xfd, 0x04, 0x43, 0x86, 0x1b, 0xe8, 0x21, 0x8c, 0x4d,
+	0x60, 0xe7, 0x94, 0x07, 0x32, 0x8e, 0x85, 0x52, 0x42, 0x26, 0xb8, 0x48, 0xee, 0x63, 0xef, 0x3b,
+	0x00, 0x1d, 0xa6, 0x39, 0x21, 0x8d, 0x0b, 0xd0, 0x6b, 0x44, 0xc9, 0x39, 0x0c, 0x4c, 0x58, 0x06,
+	0x78, 0xd2, 0x24, 0x56, 0x38, 0xe1, 0x35, 0x33, 0x4d, 0xd3, 0x6f, 0xea, 0xde, 0x35, 0xa0, 0x5a,
+	0x6e, 0xc8, 0x6d, 0x62, 0x6a, 0x75, 0xf2, 0x3d, 0x6c, 0x6a, 0x92, 0x87, 0x98, 0x14, 0xb5, 0xca,
+	0xd8, 0x1d, 0xd7, 0x57, 0x72, 0xb3, 0xae, 0xd7, 0xd4, 0xd8, 0xf9, 0x29, 0xb8, 0xea, 0xe2, 0x5f,
+	0xcd, 0xb7, 0xff, 0x0f, 0x00, 0x0a, 0x0e, 0xd6, 0x1f, 0x05, 0x0d, 0x00, 0x00,
 }
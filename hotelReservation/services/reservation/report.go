@@ -0,0 +1,226 @@
+package reservation
+
+import (
+	"log"
+	"time"
+
+	pb "github.com/harlow/go-micro-services/services/reservation/proto"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// HotelBookings reports on at most maxReportHotels hotels over at most
+// maxReportNights nights.
+const (
+	maxReportHotels = 200
+	maxReportNights = 366
+)
+
+// cancellation is a stay cancelled by CancelReservation, in
+// reservation-db.cancellations. It keeps no customer name, so there is
+// nothing to erase.
+type cancellation struct {
+	HotelId   string    `bson:"hotelId"`
+	InDate    string    `bson:"inDate"`
+	OutDate   string    `bson:"outDate"`
+	Number    int       `bson:"number"`
+	Nights    int       `bson:"nights"`
+	Cancelled time.Time `bson:"cancelled"`
+}
+
+// recordCancellation logs a cancelled stay for the booking reports. The
+// stay is cancelled already, so failing to log it is not an error.
+func recordCancellation(db *mgo.Database, hotelId, inDate, outDate string, number int) {
+	nights := 0
+	in, err := time.Parse("2006-01-02", inDate)
+	if err == nil {
+		if out, err := time.Parse("2006-01-02", outDate); err == nil {
+			nights = int(out.Sub(in).Hours() / 24)
+		}
+	}
+
+	err = db.C("cancellations").Insert(&cancellation{
+		HotelId:   hotelId,
+		InDate:    inDate,
+		OutDate:   outDate,
+		Number:    number,
+		Nights:    nights,
+		Cancelled: time.Now(),
+	})
+	if err != nil {
+		log.Println("Failed record cancellation: ", err)
+	}
+}
+
+// setRoomsAvailable sets the rooms available on the nights of a hotel to
+// its number of rooms, except for the nights it is closed and those from
+// the day it was decommissioned on, which have none.
+func setRoomsAvailable(nights []*pb.NightSales, num number, closures []closure) {
+	rooms, until := num.Number, ""
+	if !num.Decommissioned.IsZero() {
+		rooms, until = num.FormerNumber, num.Decommissioned.UTC().Format("2006-01-02")
+	}
+	for _, n := range nights {
+		n.RoomsAvailable = 0
+		if until != "" && n.Date >= until {
+			continue
+		}
+		open := true
+		for _, c := range closures {
+			if n.Date >= c.InDate && n.Date < c.OutDate {
+				open = false
+			}
+		}
+		if open {
+			n.RoomsAvailable = int32(rooms)
+		}
+	}
+}
+
+// HotelBookings returns the rooms sold and available at each hotel on the
+// nights from inDate up to outDate, and the cancellations and lead times
+// of the stays arriving then.
+func (s *Server) HotelBookings(ctx context.Context, req *pb.Request) (*pb.BookingReport, error) {
+	if len(req.HotelId) == 0 || len(req.HotelId) > maxReportHotels {
+		return nil, status.Errorf(codes.InvalidArgument, "between 1 and %d hotel ids must be set", maxReportHotels)
+	}
+	in, err := time.Parse("2006-01-02", req.InDate)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "inDate must be YYYY-MM-DD")
+	}
+	out, err := time.Parse("2006-01-02", req.OutDate)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "outDate must be YYYY-MM-DD")
+	}
+	if !in.Before(out) {
+		return nil, status.Error(codes.InvalidArgument, "outDate must be after inDate")
+	}
+	if out.Sub(in) > maxReportNights*24*time.Hour {
+		return nil, status.Errorf(codes.InvalidArgument, "the report may cover at most %d nights", maxReportNights)
+	}
+
+	var dates []string
+	night := make(map[string]int)
+	for d := in; d.Before(out); d = d.AddDate(0, 0, 1) {
+		night[d.Format("2006-01-02")] = len(dates)
+		dates = append(dates, d.Format("2006-01-02"))
+	}
+
+	res := new(pb.BookingReport)
+	hotels := make(map[string]*pb.HotelBookings)
+	for _, hotelId := range req.HotelId {
+		if hotels[hotelId] != nil {
+			continue
+		}
+		h := &pb.HotelBookings{HotelId: hotelId}
+		for _, date := range dates {
+			h.Nights = append(h.Nights, &pb.NightSales{Date: date})
+		}
+		hotels[hotelId] = h
+		res.Hotels = append(res.Hotels, h)
+	}
+
+	session := s.MongoSession.Copy()
+	defer session.Close()
+	db := session.DB("reservation-db")
+	inHotels := bson.M{"$in": req.HotelId}
+
+	var numbers []number
+	if err := db.C("number").Find(&bson.M{"hotelId": inHotels}).All(&numbers); err != nil {
+		return nil, err
+	}
+	var closures []closure
+	err = db.C("closures").Find(&bson.M{
+		"hotelId": inHotels,
+		"inDate":  bson.M{"$lt": req.OutDate},
+		"outDate": bson.M{"$gt": req.InDate},
+	}).All(&closures)
+	if err != nil {
+		return nil, err
+	}
+	closed := make(map[string][]closure)
+	for _, c := range closures {
+		closed[c.HotelId] = append(closed[c.HotelId], c)
+	}
+	for _, num := range numbers {
+		setRoomsAvailable(hotels[num.HotelId].Nights, num, closed[num.HotelId])
+	}
+
+	// every night booked is a record of its own
+	var nights []reservation
+	err = db.C("reservation").Find(&bson.M{
+		"hotelId": inHotels,
+		"inDate":  bson.M{"$gte": req.InDate, "$lt": req.OutDate},
+	}).Select(bson.M{"hotelId": 1, "inDate": 1, "number": 1, "created": 1, "reservationId": 1}).All(&nights)
+	if err != nil {
+		return nil, err
+	}
+
+	type stay struct {
+		hotelId, arrival string
+		created          time.Time
+	}
+	stays := make(map[string]*stay)
+	for _, r := range nights {
+		hotels[r.HotelId].Nights[night[r.InDate]].RoomsSold += int32(r.Number)
+
+		if r.ReservationId == "" || r.Created.IsZero() {
+			continue
+		}
+		st, ok := stays[r.ReservationId]
+		if !ok {
+			stays[r.ReservationId] = &stay{hotelId: r.HotelId, arrival: r.InDate, created: r.Created}
+		} else if r.InDate < st.arrival {
+			st.arrival = r.InDate
+		}
+	}
+
+	// stays that arrived before the range only carry over into it
+	if len(stays) > 0 {
+		ids := make([]string, 0, len(stays))
+		for id := range stays {
+			ids = append(ids, id)
+		}
+		var earlier []string
+		err = db.C("reservation").Find(&bson.M{
+			"reservationId": bson.M{"$in": ids},
+			"inDate":        bson.M{"$lt": req.InDate},
+		}).Distinct("reservationId", &earlier)
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range earlier {
+			delete(stays, id)
+		}
+	}
+	for _, st := range stays {
+		arrival, _ := time.Parse("2006-01-02", st.arrival)
+		booked, _ := time.Parse("2006-01-02", st.created.UTC().Format("2006-01-02"))
+		lead := int64(arrival.Sub(booked).Hours() / 24)
+		if lead < 0 {
+			lead = 0
+		}
+		h := hotels[st.hotelId]
+		h.Stays++
+		h.LeadDays += lead
+	}
+
+	var cancellations []cancellation
+	err = db.C("cancellations").Find(&bson.M{
+		"hotelId": inHotels,
+		"inDate":  bson.M{"$gte": req.InDate, "$lt": req.OutDate},
+	}).All(&cancellations)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range cancellations {
+		h := hotels[c.HotelId]
+		h.Cancellations++
+		h.CancelledRoomNights += int32(c.Number * c.Nights)
+	}
+
+	return res, nil
+}
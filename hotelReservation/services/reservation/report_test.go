@@ -0,0 +1,69 @@
+package reservation
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	pb "github.com/harlow/go-micro-services/services/reservation/proto"
+)
+
+func TestSetRoomsAvailable(t *testing.T) {
+	dates := []string{"2015-04-09", "2015-04-10", "2015-04-11", "2015-04-12", "2015-04-13"}
+	decommissioned := time.Date(2015, 4, 12, 9, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		num      number
+		closures []closure
+		want     []int32
+	}{
+		{
+			name: "open",
+			num:  number{Number: 10},
+			want: []int32{10, 10, 10, 10, 10},
+		},
+		{
+			name:     "closed",
+			num:      number{Number: 10},
+			closures: []closure{{InDate: "2015-04-10", OutDate: "2015-04-12"}},
+			want:     []int32{10, 0, 0, 10, 10},
+		},
+		{
+			name: "overlapping closures",
+			num:  number{Number: 10},
+			closures: []closure{
+				{InDate: "2015-04-01", OutDate: "2015-04-10"},
+				{InDate: "2015-04-09", OutDate: "2015-04-11"},
+			},
+			want: []int32{0, 0, 10, 10, 10},
+		},
+		{
+			name: "decommissioned",
+			num:  number{Number: 0, FormerNumber: 10, Decommissioned: decommissioned},
+			want: []int32{10, 10, 10, 0, 0},
+		},
+		{
+			name:     "closed and decommissioned",
+			num:      number{Number: 0, FormerNumber: 10, Decommissioned: decommissioned},
+			closures: []closure{{InDate: "2015-04-09", OutDate: "2015-04-10"}},
+			want:     []int32{0, 10, 10, 0, 0},
+		},
+	}
+
+	for _, tt := range tests {
+		nights := make([]*pb.NightSales, len(dates))
+		for i, date := range dates {
+			nights[i] = &pb.NightSales{Date: date}
+		}
+		setRoomsAvailable(nights, tt.num, tt.closures)
+
+		got := make([]int32, len(nights))
+		for i, n := range nights {
+			got[i] = n.RoomsAvailable
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
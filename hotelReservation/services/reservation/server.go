@@ -4,12 +4,20 @@ import (
 	// "encoding/json"
 	"fmt"
 	"github.com/grpc-ecosystem/grpc-opentracing/go/otgrpc"
+	"github.com/harlow/go-micro-services/activity"
+	"github.com/harlow/go-micro-services/dialer"
+	"github.com/harlow/go-micro-services/rbac"
 	"github.com/harlow/go-micro-services/registry"
+	profile "github.com/harlow/go-micro-services/services/profile/proto"
+	recommendation "github.com/harlow/go-micro-services/services/recommendation/proto"
 	pb "github.com/harlow/go-micro-services/services/reservation/proto"
+	user "github.com/harlow/go-micro-services/services/user/proto"
 	"github.com/opentracing/opentracing-go"
 	"golang.org/x/net/context"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/status"
 	"gopkg.in/mgo.v2"
 	"gopkg.in/mgo.v2/bson"
 	// "io/ioutil"
@@ -19,19 +27,29 @@ import (
 	"time"
 
 	"github.com/bradfitz/gomemcache/memcache"
+	"github.com/segmentio/ksuid"
 	// "strings"
 	"strconv"
 )
 
 const name = "srv-reservation"
 
+// CoBookings looks at no more customers than this
+const maxCoBookingCustomers = 1000
+
 // Server implements the user service
 type Server struct {
+	recommendationClient recommendation.RecommendationClient
+	userClient           user.UserClient
+	profileClient        profile.ProfileClient
+
 	Tracer   opentracing.Tracer
 	Port     int
 	IpAddr	 string
 	MongoSession	*mgo.Session
 	Registry *registry.Client
+	// Identity signs and verifies the identities calls are made by
+	Identity *rbac.Signer
 	MemcClient *memcache.Client
 }
 
@@ -41,6 +59,10 @@ func (s *Server) Run() error {
 		return fmt.Errorf("server port must be set")
 	}
 
+	if s.Identity == nil {
+		return fmt.Errorf("identity keys must be set")
+	}
+
 	srv := grpc.NewServer(
 		grpc.KeepaliveParams(keepalive.ServerParameters{
 			Timeout: 120 * time.Second,
@@ -48,13 +70,26 @@ func (s *Server) Run() error {
 		grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
 			PermitWithoutStream: true,
 		}),
-		grpc.UnaryInterceptor(
+		grpc.UnaryInterceptor(rbac.ChainUnaryServer(
 			otgrpc.OpenTracingServerInterceptor(s.Tracer),
-		),
+			rbac.UnaryServerInterceptor(s.Identity, policy),
+		)),
 	)
 
 	pb.RegisterReservationServer(srv, s)
 
+	// init grpc clients
+	if err := s.initRecommendationClient("srv-recommendation"); err != nil {
+		return err
+	}
+	if err := s.initUserClient("srv-user"); err != nil {
+		return err
+	}
+	if err := s.initProfileClient("srv-profile"); err != nil {
+		return err
+	}
+	go s.outboxLoop()
+
 	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", s.Port))
 	if err != nil {
 		log.Fatalf("failed to listen: %v", err)
@@ -88,6 +123,90 @@ func (s *Server) Shutdown() {
 	s.Registry.Deregister(name)
 }
 
+func (s *Server) initRecommendationClient(name string) error {
+	conn, err := dialer.Dial(
+		name,
+		dialer.WithTracer(s.Tracer),
+		dialer.WithBalancer(s.Registry.Client),
+		dialer.WithIdentity(s.Identity, self),
+	)
+	if err != nil {
+		return fmt.Errorf("dialer error: %v", err)
+	}
+	s.recommendationClient = recommendation.NewRecommendationClient(conn)
+	return nil
+}
+
+func (s *Server) initUserClient(name string) error {
+	conn, err := dialer.Dial(
+		name,
+		dialer.WithTracer(s.Tracer),
+		dialer.WithBalancer(s.Registry.Client),
+		dialer.WithIdentity(s.Identity, self),
+	)
+	if err != nil {
+		return fmt.Errorf("dialer error: %v", err)
+	}
+	s.userClient = user.NewUserClient(conn)
+	return nil
+}
+
+func (s *Server) initProfileClient(name string) error {
+	conn, err := dialer.Dial(
+		name,
+		dialer.WithTracer(s.Tracer),
+		dialer.WithBalancer(s.Registry.Client),
+		dialer.WithIdentity(s.Identity, self),
+	)
+	if err != nil {
+		return fmt.Errorf("dialer error: %v", err)
+	}
+	s.profileClient = profile.NewProfileClient(conn)
+	return nil
+}
+
+// stayPrice returns what a stay costs at the price of the hotel, which is
+// per room and night.
+func (s *Server) stayPrice(ctx context.Context, hotelId string, nights int, rooms int32) (float32, error) {
+	profileResp, err := s.profileClient.GetProfiles(ctx, &profile.Request{
+		HotelIds:   []string{hotelId},
+		Locale:     "en",
+		Background: true,
+	})
+	if err != nil {
+		return 0, err
+	}
+	if len(profileResp.Hotels) == 0 {
+		return 0, status.Errorf(codes.FailedPrecondition, "hotel %s has no price", hotelId)
+	}
+	return profileResp.Hotels[0].Price * float32(nights) * float32(rooms), nil
+}
+
+// abandonReservation undoes a booking that failed or gave way to a
+// closure: it removes the nights inserted, drops the cached counts that
+// included them and refunds the points redeemed for it.
+func (s *Server) abandonReservation(ctx context.Context, c *mgo.Collection, req *pb.Request, reservationId string, memcKeys map[string]int, redeemed int64) {
+	if _, err := c.RemoveAll(&bson.M{"reservationId": reservationId}); err != nil {
+		log.Println("Failed remove abandoned reservation: ", err)
+	}
+	for key := range memcKeys {
+		s.MemcClient.Delete(key)
+	}
+	if redeemed > 0 {
+		// with no nights left every redeemed point is refunded
+		s.reservationChanged(ctx, &user.ReservationChange{
+			Event:         user.ReservationChange_CANCELLED,
+			Username:      req.CustomerName,
+			ReservationId: reservationId,
+			HotelId:       req.HotelId[0],
+			InDate:        req.InDate,
+			OutDate:       req.OutDate,
+			RoomNumber:    req.RoomNumber,
+			Nights:        0,
+		})
+	}
+}
+
 // MakeReservation makes a reservation based on given information
 func (s *Server) MakeReservation(ctx context.Context, req *pb.Request) (*pb.Result, error) {
 	res := new(pb.Result)
@@ -112,6 +231,16 @@ func (s *Server) MakeReservation(ctx context.Context, req *pb.Request) (*pb.Resu
 		time.RFC3339,
 		req.OutDate + "T12:00:00+00:00")
 	hotelId := req.HotelId[0]
+	stayNights := int(outDate.Sub(inDate).Hours() / 24)
+
+	// a closed hotel has no rooms
+	closed, err := closedHotels(session.DB("reservation-db"), req.HotelId[:1], req.InDate, req.OutDate)
+	if err != nil {
+		return nil, err
+	}
+	if closed[hotelId] {
+		return res, nil
+	}
 
 	indate := inDate.String()[0:10]
 
@@ -183,6 +312,30 @@ func (s *Server) MakeReservation(ctx context.Context, req *pb.Request) (*pb.Resu
 		indate = outdate
 	}
 
+	// every night of the booking shares its id and creation time
+	reservationId := ksuid.New().String()
+	created := time.Now()
+
+	// points are spent before the booking, so there is none without them;
+	// a booking that fails after this refunds them
+	if req.RedeemPoints > 0 {
+		price, err := s.stayPrice(ctx, hotelId, stayNights, req.RoomNumber)
+		if err != nil {
+			return nil, err
+		}
+		redeemResp, err := s.userClient.RedeemPoints(ctx, &user.RedeemRequest{
+			Username:      req.CustomerName,
+			Points:        req.RedeemPoints,
+			ReservationId: reservationId,
+			MaxDiscount:   price,
+		})
+		if err != nil {
+			return nil, err
+		}
+		res.PointsRedeemed = redeemResp.Points
+		res.Discount = redeemResp.Discount
+	}
+
 	// only update reservation number cache after check succeeds
 	for key, val := range memc_date_num_map {
 		s.MemcClient.Set(&memcache.Item{Key: key, Value: []byte(strconv.Itoa(val))})
@@ -194,22 +347,49 @@ func (s *Server) MakeReservation(ctx context.Context, req *pb.Request) (*pb.Resu
 
 	indate = inDate.String()[0:10]
 
+	nights := 0
 	for inDate.Before(outDate) {
+		nights++
 		inDate = inDate.AddDate(0, 0, 1)
 		outdate := inDate.String()[0:10]
 		err := c.Insert(&reservation{
-			HotelId:      hotelId,
-			CustomerName: req.CustomerName,
-			InDate:       indate,
-			OutDate:      outdate,
-			Number:       int(req.RoomNumber),})
+			HotelId:       hotelId,
+			CustomerName:  req.CustomerName,
+			InDate:        indate,
+			OutDate:       outdate,
+			Number:        int(req.RoomNumber),
+			Created:       created,
+			ReservationId: reservationId,})
 		if err != nil {
-			panic(err)
+			s.abandonReservation(ctx, c, req, reservationId, memc_date_num_map, res.PointsRedeemed)
+			return nil, err
 		}
 		indate = outdate
 	}
 
+	// a closure added while booking counted no nights, so the booking gives way
+	closed, err = closedHotels(session.DB("reservation-db"), req.HotelId[:1], req.InDate, req.OutDate)
+	if err != nil || closed[hotelId] {
+		s.abandonReservation(ctx, c, req, reservationId, memc_date_num_map, res.PointsRedeemed)
+		if err != nil {
+			return nil, err
+		}
+		return &pb.Result{HotelId: make([]string, 0)}, nil
+	}
+
 	res.HotelId = append(res.HotelId, hotelId)
+	res.ReservationId = reservationId
+	activity.Record(s.recommendationClient, recommendation.Activity_BOOKING, res.HotelId)
+	s.reservationChanged(ctx, &user.ReservationChange{
+		Event:         user.ReservationChange_BOOKED,
+		Username:      req.CustomerName,
+		ReservationId: reservationId,
+		HotelId:       hotelId,
+		InDate:        req.InDate,
+		OutDate:       req.OutDate,
+		RoomNumber:    req.RoomNumber,
+		Nights:        int32(nights),
+	})
 
 	return res, nil
 }
@@ -310,6 +490,19 @@ func (s *Server) CancelReservation(ctx context.Context, req *pb.Request) (*pb.Re
 
 	indate = inDate.String()[0:10]
 
+	// the reservations losing nights have their loyalty points reversed
+	var reservationIds []string
+	err := c.Find(&bson.M{
+		"customerName": CustomerName,
+		"hotelId":      hotelId,
+		"inDate":       bson.M{"$gte": indate},
+		"outDate":      bson.M{"$lte": req.OutDate},
+		"number":       Number,
+	}).Distinct("reservationId", &reservationIds)
+	if err != nil {
+		panic(err)
+	}
+
 	for inDate.Before(outDate) {
 		inDate = inDate.AddDate(0, 0, 1)
 		outdate := inDate.String()[0:10]
@@ -323,6 +516,25 @@ func (s *Server) CancelReservation(ctx context.Context, req *pb.Request) (*pb.Re
 	}
 
 	res.HotelId = append(res.HotelId, hotelId)
+	recordCancellation(session.DB("reservation-db"), hotelId, req.InDate, req.OutDate, Number)
+
+	for _, reservationId := range reservationIds {
+		remaining, err := c.Find(&bson.M{"reservationId": reservationId}).Count()
+		if err != nil {
+			log.Println("Failed count reservation nights: ", err)
+			continue
+		}
+		s.reservationChanged(ctx, &user.ReservationChange{
+			Event:         user.ReservationChange_CANCELLED,
+			Username:      CustomerName,
+			ReservationId: reservationId,
+			HotelId:       hotelId,
+			InDate:        req.InDate,
+			OutDate:       req.OutDate,
+			RoomNumber:    req.RoomNumber,
+			Nights:        int32(remaining),
+		})
+	}
 
 	return res, nil
 }
@@ -343,7 +555,16 @@ func (s *Server) CheckAvailability(ctx context.Context, req *pb.Request) (*pb.Re
 	c := session.DB("reservation-db").C("reservation")
 	c1 := session.DB("reservation-db").C("number")
 
+	// closed hotels have no rooms
+	closed, err := closedHotels(session.DB("reservation-db"), req.HotelId, req.InDate, req.OutDate)
+	if err != nil {
+		return nil, err
+	}
+
 	for _, hotelId := range req.HotelId {
+		if closed[hotelId] {
+			continue
+		}
 		// fmt.Printf("reservation check hotel %s\n", hotelId)
 		inDate, _ := time.Parse(
 			time.RFC3339,
@@ -427,15 +648,151 @@ func (s *Server) CheckAvailability(ctx context.Context, req *pb.Request) (*pb.Re
 	return res, nil
 }
 
+// ListReservations returns the stays booked under a customer name,
+// merging the per-night reservation records of each stay.
+func (s *Server) ListReservations(ctx context.Context, req *pb.ListRequest) (*pb.ListResult, error) {
+	res := new(pb.ListResult)
+
+	session := s.MongoSession.Copy()
+	defer session.Close()
+
+	c := session.DB("reservation-db").C("reservation")
+
+	reserve := make([]reservation, 0)
+	err := c.Find(&bson.M{"customerName": req.CustomerName}).Sort("hotelId", "inDate").All(&reserve)
+	if err != nil {
+		return nil, err
+	}
+
+	var last *pb.Stay
+	for _, r := range reserve {
+		if last != nil && last.HotelId == r.HotelId && last.OutDate == r.InDate && last.RoomNumber == int32(r.Number) {
+			last.OutDate = r.OutDate
+			continue
+		}
+		last = &pb.Stay{
+			HotelId:    r.HotelId,
+			InDate:     r.InDate,
+			OutDate:    r.OutDate,
+			RoomNumber: int32(r.Number),
+		}
+		res.Stays = append(res.Stays, last)
+	}
+
+	return res, nil
+}
+
+// CoBookings counts, for every hotel not in the request, the other
+// customers who booked it and at least one of the requested hotels.
+func (s *Server) CoBookings(ctx context.Context, req *pb.CoBookingRequest) (*pb.CoBookingResult, error) {
+	res := new(pb.CoBookingResult)
+	if len(req.HotelId) == 0 {
+		return res, nil
+	}
+
+	session := s.MongoSession.Copy()
+	defer session.Close()
+
+	c := session.DB("reservation-db").C("reservation")
+
+	var customers []string
+	err := c.Find(&bson.M{
+		"hotelId":      bson.M{"$in": req.HotelId},
+		"customerName": bson.M{"$ne": req.CustomerName},
+	}).Distinct("customerName", &customers)
+	if err != nil {
+		return nil, err
+	}
+	if len(customers) > maxCoBookingCustomers {
+		customers = customers[:maxCoBookingCustomers]
+	}
+
+	reserve := make([]reservation, 0)
+	err = c.Find(&bson.M{
+		"customerName": bson.M{"$in": customers},
+		"hotelId":      bson.M{"$nin": req.HotelId},
+	}).Select(bson.M{"hotelId": 1, "customerName": 1}).All(&reserve)
+	if err != nil {
+		return nil, err
+	}
+
+	// a customer counts once per hotel, however many nights they booked
+	seen := make(map[string]bool)
+	counts := make(map[string]int32)
+	for _, r := range reserve {
+		key := r.HotelId + "_" + r.CustomerName
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		counts[r.HotelId]++
+	}
+	for hotelId, count := range counts {
+		res.Counts = append(res.Counts, &pb.HotelCount{
+			HotelId: hotelId,
+			Count:   count,
+		})
+	}
+
+	return res, nil
+}
+
+// BookingCounts counts, per hotel, the bookings made since the requested
+// time. A booking is one call to MakeReservation, however many nights it
+// covers.
+func (s *Server) BookingCounts(ctx context.Context, req *pb.BookingCountRequest) (*pb.BookingCountResult, error) {
+	res := new(pb.BookingCountResult)
+
+	session := s.MongoSession.Copy()
+	defer session.Close()
+
+	c := session.DB("reservation-db").C("reservation")
+
+	reserve := make([]reservation, 0)
+	err := c.Find(&bson.M{
+		"created": bson.M{"$gte": time.Unix(req.Since, 0)},
+	}).Select(bson.M{"hotelId": 1, "customerName": 1, "created": 1}).All(&reserve)
+	if err != nil {
+		return nil, err
+	}
+
+	// the nights of a booking share customer and creation time
+	seen := make(map[string]bool)
+	counts := make(map[string]int32)
+	for _, r := range reserve {
+		key := r.HotelId + "_" + r.CustomerName + "_" + strconv.FormatInt(r.Created.UnixNano(), 10)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		counts[r.HotelId]++
+	}
+	for hotelId, count := range counts {
+		res.Counts = append(res.Counts, &pb.HotelCount{
+			HotelId: hotelId,
+			Count:   count,
+		})
+	}
+
+	return res, nil
+}
+
 type reservation struct {
-	HotelId      string `bson:"hotelId"`
-	CustomerName string `bson:"customerName"`
-	InDate       string `bson:"inDate"`
-	OutDate      string `bson:"outDate"`
-	Number       int    `bson:"number"`
+	HotelId       string    `bson:"hotelId"`
+	CustomerName  string    `bson:"customerName"`
+	InDate        string    `bson:"inDate"`
+	OutDate       string    `bson:"outDate"`
+	Number        int       `bson:"number"`
+	Created       time.Time `bson:"created,omitempty"`
+	ReservationId string    `bson:"reservationId,omitempty"`
 }
 
 type number struct {
-	HotelId      string `bson:"hotelId"`
-	Number       int    `bson:"numberOfRoom"`
+	HotelId string `bson:"hotelId"`
+	Number  int    `bson:"numberOfRoom"`
+
+	// Decommissioned is when the hotel was taken out of service, with no
+	// rooms since; FormerNumber is how many it had
+	Decommissioned time.Time `bson:"decommissioned,omitempty"`
+	FormerNumber   int       `bson:"formerNumberOfRoom,omitempty"`
 }
\ No newline at end of file
@@ -0,0 +1,13 @@
+package search
+
+import (
+	"github.com/harlow/go-micro-services/rbac"
+)
+
+// self is the identity the search service calls other services with.
+var self = &rbac.Identity{Subject: name, Role: rbac.Service}
+
+// policy is who may call the search service.
+var policy = rbac.Policy{
+	"/search.Search/Nearby": {Public: true},
+}
@@ -10,6 +10,7 @@ It is generated from these files:
 It has these top-level messages:
 	NearbyRequest
 	SearchResult
+	Hotel
 */
 package search
 
@@ -34,10 +35,12 @@ var _ = math.Inf
 const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
 
 type NearbyRequest struct {
-	Lat     float32 `protobuf:"fixed32,1,opt,name=lat" json:"lat,omitempty"`
-	Lon     float32 `protobuf:"fixed32,2,opt,name=lon" json:"lon,omitempty"`
-	InDate  string  `protobuf:"bytes,3,opt,name=inDate" json:"inDate,omitempty"`
-	OutDate string  `protobuf:"bytes,4,opt,name=outDate" json:"outDate,omitempty"`
+	Lat      float32 `protobuf:"fixed32,1,opt,name=lat" json:"lat,omitempty"`
+	Lon      float32 `protobuf:"fixed32,2,opt,name=lon" json:"lon,omitempty"`
+	InDate   string  `protobuf:"bytes,3,opt,name=inDate" json:"inDate,omitempty"`
+	OutDate  string  `protobuf:"bytes,4,opt,name=outDate" json:"outDate,omitempty"`
+	RadiusKm float32 `protobuf:"fixed32,5,opt,name=radiusKm" json:"radiusKm,omitempty"`
+	Limit    int32   `protobuf:"varint,6,opt,name=limit" json:"limit,omitempty"`
 }
 
 func (m *NearbyRequest) Reset()                    { *m = NearbyRequest{} }
@@ -73,8 +76,23 @@ func (m *NearbyRequest) GetOutDate() string {
 	return ""
 }
 
+func (m *NearbyRequest) GetRadiusKm() float32 {
+	if m != nil {
+		return m.RadiusKm
+	}
+	return 0
+}
+
+func (m *NearbyRequest) GetLimit() int32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
 type SearchResult struct {
 	HotelIds []string `protobuf:"bytes,1,rep,name=hotelIds" json:"hotelIds,omitempty"`
+	Hotels   []*Hotel `protobuf:"bytes,2,rep,name=hotels" json:"hotels,omitempty"`
 }
 
 func (m *SearchResult) Reset()                    { *m = SearchResult{} }
@@ -89,9 +107,42 @@ func (m *SearchResult) GetHotelIds() []string {
 	return nil
 }
 
+func (m *SearchResult) GetHotels() []*Hotel {
+	if m != nil {
+		return m.Hotels
+	}
+	return nil
+}
+
+// A hotel and its distance from the query point.
+type Hotel struct {
+	HotelId    string  `protobuf:"bytes,1,opt,name=hotelId" json:"hotelId,omitempty"`
+	DistanceKm float32 `protobuf:"fixed32,2,opt,name=distanceKm" json:"distanceKm,omitempty"`
+}
+
+func (m *Hotel) Reset()                    { *m = Hotel{} }
+func (m *Hotel) String() string            { return proto.CompactTextString(m) }
+func (*Hotel) ProtoMessage()               {}
+func (*Hotel) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{2} }
+
+func (m *Hotel) GetHotelId() string {
+	if m != nil {
+		return m.HotelId
+	}
+	return ""
+}
+
+func (m *Hotel) GetDistanceKm() float32 {
+	if m != nil {
+		return m.DistanceKm
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*NearbyRequest)(nil), "search.NearbyRequest")
 	proto.RegisterType((*SearchResult)(nil), "search.SearchResult")
+	proto.RegisterType((*Hotel)(nil), "search.Hotel")
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -169,18 +220,22 @@ var _Search_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("services/search/proto/search.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 193 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x54, 0x8f, 0xb1, 0x0a, 0xc2, 0x30,
-	0x10, 0x86, 0x49, 0x2b, 0xd1, 0x1e, 0x0a, 0x12, 0x54, 0x42, 0xa7, 0xd2, 0xa9, 0x38, 0xb4, 0xa0,
-	0x38, 0xbb, 0xb8, 0xb8, 0x38, 0xc4, 0x27, 0x48, 0xeb, 0x41, 0x0b, 0xa5, 0xd1, 0x24, 0x15, 0x7c,
-	0x7b, 0x31, 0x4d, 0x45, 0xb7, 0x7c, 0xff, 0x1f, 0xee, 0xbe, 0x83, 0xd4, 0xa0, 0x7e, 0x36, 0x15,
-	0x9a, 0xc2, 0xa0, 0xd4, 0x55, 0x5d, 0xdc, 0xb5, 0xb2, 0xca, 0x43, 0xee, 0x80, 0xd1, 0x81, 0x52,
-	0x84, 0xc5, 0x05, 0xa5, 0x2e, 0x5f, 0x02, 0x1f, 0x3d, 0x1a, 0xcb, 0x96, 0x10, 0xb6, 0xd2, 0x72,
-	0x92, 0x90, 0x2c, 0x10, 0x9f, 0xa7, 0x4b, 0x54, 0xc7, 0x03, 0x9f, 0xa8, 0x8e, 0x6d, 0x80, 0x36,
-	0xdd, 0x49, 0x5a, 0xe4, 0x61, 0x42, 0xb2, 0x48, 0x78, 0x62, 0x1c, 0xa6, 0xaa, 0xb7, 0xae, 0x98,
-	0xb8, 0x62, 0xc4, 0x74, 0x0b, 0xf3, 0xab, 0x5b, 0x28, 0xd0, 0xf4, 0xad, 0x65, 0x31, 0xcc, 0x6a,
-	0x65, 0xb1, 0x3d, 0xdf, 0x0c, 0x27, 0x49, 0x98, 0x45, 0xe2, 0xcb, 0xbb, 0x23, 0xd0, 0xe1, 0x2f,
-	0x3b, 0x00, 0x1d, 0xe4, 0xd8, 0x3a, 0xf7, 0xf6, 0x7f, 0xb2, 0xf1, 0x6a, 0x8c, 0x7f, 0x87, 0x97,
-	0xd4, 0x9d, 0xb8, 0x7f, 0x07, 0x00, 0x00, 0xff, 0xff, 0x55, 0xd9, 0x68, 0x1c, 0x08, 0x01, 0x00,
-	0x00,
+	// 269 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x54, 0x90, 0xc1, 0x4a, 0xf4, 0x30,
+	0x10, 0xc7, 0x49, 0xfb, 0x35, 0x9f, 0x1d, 0x5d, 0x90, 0xb0, 0x4a, 0xd8, 0x83, 0x94, 0x82, 0xd0,
+	0xd3, 0x2e, 0xac, 0x78, 0x16, 0xc1, 0x83, 0xb2, 0x20, 0x18, 0x9f, 0x20, 0xdb, 0x0e, 0x6c, 0xa0,
+	0x6d, 0x34, 0x49, 0x05, 0x9f, 0xc4, 0xd7, 0x95, 0x4e, 0xd3, 0x65, 0xbd, 0xcd, 0xef, 0x37, 0x64,
+	0x32, 0xff, 0x81, 0xd2, 0xa3, 0xfb, 0x32, 0x35, 0xfa, 0x8d, 0x47, 0xed, 0xea, 0xc3, 0xe6, 0xc3,
+	0xd9, 0x60, 0x23, 0xac, 0x09, 0x04, 0x9f, 0xa8, 0xfc, 0x61, 0xb0, 0x78, 0x45, 0xed, 0xf6, 0xdf,
+	0x0a, 0x3f, 0x07, 0xf4, 0x41, 0x5c, 0x42, 0xda, 0xea, 0x20, 0x59, 0xc1, 0xaa, 0x44, 0x8d, 0x25,
+	0x19, 0xdb, 0xcb, 0x24, 0x1a, 0xdb, 0x8b, 0x6b, 0xe0, 0xa6, 0x7f, 0xd2, 0x01, 0x65, 0x5a, 0xb0,
+	0x2a, 0x57, 0x91, 0x84, 0x84, 0xff, 0x76, 0x08, 0xd4, 0xf8, 0x47, 0x8d, 0x19, 0xc5, 0x0a, 0xce,
+	0x9c, 0x6e, 0xcc, 0xe0, 0x77, 0x9d, 0xcc, 0x68, 0xd0, 0x91, 0xc5, 0x12, 0xb2, 0xd6, 0x74, 0x26,
+	0x48, 0x5e, 0xb0, 0x2a, 0x53, 0x13, 0x94, 0x6f, 0x70, 0xf1, 0x4e, 0x3b, 0x2a, 0xf4, 0x43, 0x1b,
+	0xc6, 0x09, 0x07, 0x1b, 0xb0, 0x7d, 0x69, 0xbc, 0x64, 0x45, 0x5a, 0xe5, 0xea, 0xc8, 0xe2, 0x16,
+	0x38, 0xd5, 0x5e, 0x26, 0x45, 0x5a, 0x9d, 0x6f, 0x17, 0xeb, 0x18, 0xf6, 0x79, 0xb4, 0x2a, 0x36,
+	0xcb, 0x47, 0xc8, 0x48, 0x8c, 0x7b, 0xc6, 0xb7, 0x94, 0x33, 0x57, 0x33, 0x8a, 0x1b, 0x80, 0xc6,
+	0xf8, 0xa0, 0xfb, 0x1a, 0x77, 0x5d, 0x8c, 0x7c, 0x62, 0xb6, 0x0f, 0xc0, 0xa7, 0xad, 0xc4, 0x3d,
+	0xf0, 0xe9, 0x70, 0xe2, 0x6a, 0xfe, 0xed, 0xcf, 0x21, 0x57, 0xcb, 0x59, 0x9f, 0xc6, 0xd8, 0x73,
+	0xba, 0xff, 0xdd, 0xef, 0x00, 0x84, 0xb9, 0xda, 0x75, 0xa5, 0x01, 0x00, 0x00,
 }
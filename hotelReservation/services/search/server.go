@@ -10,10 +10,14 @@ import (
 	"time"
 
 	"github.com/grpc-ecosystem/grpc-opentracing/go/otgrpc"
+	"github.com/harlow/go-micro-services/activity"
 	"github.com/harlow/go-micro-services/dialer"
+	"github.com/harlow/go-micro-services/rbac"
 	"github.com/harlow/go-micro-services/registry"
 	geo "github.com/harlow/go-micro-services/services/geo/proto"
 	rate "github.com/harlow/go-micro-services/services/rate/proto"
+	recommendation "github.com/harlow/go-micro-services/services/recommendation/proto"
+	reservation "github.com/harlow/go-micro-services/services/reservation/proto"
 	pb "github.com/harlow/go-micro-services/services/search/proto"
 	opentracing "github.com/opentracing/opentracing-go"
 	context "golang.org/x/net/context"
@@ -25,13 +29,17 @@ const name = "srv-search"
 
 // Server implments the search service
 type Server struct {
-	geoClient  geo.GeoClient
-	rateClient rate.RateClient
+	geoClient            geo.GeoClient
+	rateClient           rate.RateClient
+	recommendationClient recommendation.RecommendationClient
+	reservationClient    reservation.ReservationClient
 
 	Tracer   opentracing.Tracer
 	Port     int
 	IpAddr	 string
 	Registry *registry.Client
+	// Identity signs and verifies the identities calls are made by
+	Identity *rbac.Signer
 }
 
 // Run starts the server
@@ -40,6 +48,10 @@ func (s *Server) Run() error {
 		return fmt.Errorf("server port must be set")
 	}
 
+	if s.Identity == nil {
+		return fmt.Errorf("identity keys must be set")
+	}
+
 	srv := grpc.NewServer(
 		grpc.KeepaliveParams(keepalive.ServerParameters{
 			Timeout: 120 * time.Second,
@@ -47,9 +59,10 @@ func (s *Server) Run() error {
 		grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
 			PermitWithoutStream: true,
 		}),
-		grpc.UnaryInterceptor(
+		grpc.UnaryInterceptor(rbac.ChainUnaryServer(
 			otgrpc.OpenTracingServerInterceptor(s.Tracer),
-		),
+			rbac.UnaryServerInterceptor(s.Identity, policy),
+		)),
 	)
 	pb.RegisterSearchServer(srv, s)
 
@@ -60,6 +73,12 @@ func (s *Server) Run() error {
 	if err := s.initRateClient("srv-rate"); err != nil {
 		return err
 	}
+	if err := s.initRecommendationClient("srv-recommendation"); err != nil {
+		return err
+	}
+	if err := s.initReservationClient("srv-reservation"); err != nil {
+		return err
+	}
 
 	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", s.Port))
 	if err != nil {
@@ -97,6 +116,7 @@ func (s *Server) initGeoClient(name string) error {
 		name,
 		dialer.WithTracer(s.Tracer),
 		dialer.WithBalancer(s.Registry.Client),
+		dialer.WithIdentity(s.Identity, self),
 	)
 	if err != nil {
 		return fmt.Errorf("dialer error: %v", err)
@@ -110,6 +130,7 @@ func (s *Server) initRateClient(name string) error {
 		name,
 		dialer.WithTracer(s.Tracer),
 		dialer.WithBalancer(s.Registry.Client),
+		dialer.WithIdentity(s.Identity, self),
 	)
 	if err != nil {
 		return fmt.Errorf("dialer error: %v", err)
@@ -118,6 +139,34 @@ func (s *Server) initRateClient(name string) error {
 	return nil
 }
 
+func (s *Server) initRecommendationClient(name string) error {
+	conn, err := dialer.Dial(
+		name,
+		dialer.WithTracer(s.Tracer),
+		dialer.WithBalancer(s.Registry.Client),
+		dialer.WithIdentity(s.Identity, self),
+	)
+	if err != nil {
+		return fmt.Errorf("dialer error: %v", err)
+	}
+	s.recommendationClient = recommendation.NewRecommendationClient(conn)
+	return nil
+}
+
+func (s *Server) initReservationClient(name string) error {
+	conn, err := dialer.Dial(
+		name,
+		dialer.WithTracer(s.Tracer),
+		dialer.WithBalancer(s.Registry.Client),
+		dialer.WithIdentity(s.Identity, self),
+	)
+	if err != nil {
+		return fmt.Errorf("dialer error: %v", err)
+	}
+	s.reservationClient = reservation.NewReservationClient(conn)
+	return nil
+}
+
 // Nearby returns ids of nearby hotels ordered by ranking algo
 func (s *Server) Nearby(ctx context.Context, req *pb.NearbyRequest) (*pb.SearchResult, error) {
 	// find nearby hotels
@@ -127,8 +176,10 @@ func (s *Server) Nearby(ctx context.Context, req *pb.NearbyRequest) (*pb.SearchR
 	// fmt.Printf("nearby lon = %f\n", req.Lon)
 
 	nearby, err := s.geoClient.Nearby(ctx, &geo.Request{
-		Lat: req.Lat,
-		Lon: req.Lon,
+		Lat:      req.Lat,
+		Lon:      req.Lon,
+		RadiusKm: req.RadiusKm,
+		Limit:    req.Limit,
 	})
 	if err != nil {
 		log.Fatalf("nearby error: %v", err)
@@ -138,6 +189,19 @@ func (s *Server) Nearby(ctx context.Context, req *pb.NearbyRequest) (*pb.SearchR
 	// 	fmt.Printf("get Nearby hotelId = %s\n", hid)
 	// }
 
+	// hotels closed for the dates are left out
+	if req.InDate != "" && req.OutDate != "" && len(nearby.HotelIds) > 0 {
+		closedResp, err := s.reservationClient.ClosedHotels(ctx, &reservation.Request{
+			HotelId: nearby.HotelIds,
+			InDate:  req.InDate,
+			OutDate: req.OutDate,
+		})
+		if err != nil {
+			return nil, err
+		}
+		nearby.HotelIds = withoutHotels(nearby.HotelIds, closedResp.HotelId)
+	}
+
 	// find rates for hotels
 	rates, err := s.rateClient.GetRates(ctx, &rate.Request{
 		HotelIds: nearby.HotelIds,
@@ -153,11 +217,43 @@ func (s *Server) Nearby(ctx context.Context, req *pb.NearbyRequest) (*pb.SearchR
 	// * price (best discount?)
 	// * reviews
 
+	distances := make(map[string]float32)
+	for _, h := range nearby.Hotels {
+		distances[h.HotelId] = h.DistanceKm
+	}
+
 	// build the response
 	res := new(pb.SearchResult)
 	for _, ratePlan := range rates.RatePlans {
 		// fmt.Printf("get RatePlan HotelId = %s, Code = %s\n", ratePlan.HotelId, ratePlan.Code)
 		res.HotelIds = append(res.HotelIds, ratePlan.HotelId)
+		if d, ok := distances[ratePlan.HotelId]; ok {
+			res.Hotels = append(res.Hotels, &pb.Hotel{
+				HotelId:    ratePlan.HotelId,
+				DistanceKm: d,
+			})
+			// a hotel may have several rate plans, report it once
+			delete(distances, ratePlan.HotelId)
+		}
 	}
+	activity.Record(s.recommendationClient, recommendation.Activity_IMPRESSION, res.HotelIds)
 	return res, nil
 }
+
+// withoutHotels returns hotelIds without those of drop.
+func withoutHotels(hotelIds, drop []string) []string {
+	if len(drop) == 0 {
+		return hotelIds
+	}
+	dropped := make(map[string]bool, len(drop))
+	for _, hotelId := range drop {
+		dropped[hotelId] = true
+	}
+	kept := make([]string, 0, len(hotelIds))
+	for _, hotelId := range hotelIds {
+		if !dropped[hotelId] {
+			kept = append(kept, hotelId)
+		}
+	}
+	return kept
+}
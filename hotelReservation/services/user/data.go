@@ -0,0 +1,254 @@
+package user
+
+import (
+	"encoding/json"
+	"log"
+	"time"
+
+	alert "github.com/harlow/go-micro-services/services/alert/proto"
+	reservation "github.com/harlow/go-micro-services/services/reservation/proto"
+	pb "github.com/harlow/go-micro-services/services/user/proto"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// archive is the export of everything kept about a user.
+type archive struct {
+	Username   string    `json:"username"`
+	ExportedAt time.Time `json:"exportedAt"`
+	// Profile is nil if the user no longer exists
+	Profile        *archiveProfile      `json:"profile"`
+	Orders         []*pb.Order          `json:"orders"`
+	Reviews        []*pb.Review         `json:"reviews"`
+	ProfileChanges []archiveChange      `json:"profileChanges"`
+	LoyaltyPoints  []*pb.LedgerEntry    `json:"loyaltyPoints"`
+	Wishlists      []*pb.Wishlist       `json:"wishlists"`
+	Alerts         []*alert.Watch       `json:"alerts"`
+	Reservations   []*reservation.Night `json:"reservations"`
+}
+
+// archiveProfile is a user without the password hash, which is no use to
+// its owner.
+type archiveProfile struct {
+	Age   int32  `json:"age"`
+	Sex   string `json:"sex"`
+	Mail  string `json:"mail"`
+	Phone string `json:"phone"`
+}
+
+type archiveChange struct {
+	Action string    `json:"action"`
+	Fields []string  `json:"fields"`
+	At     time.Time `json:"at"`
+}
+
+// revokedSessions revokes every session token of a user issued before a
+// time, kept in user-db.revoked until those tokens would have expired.
+type revokedSessions struct {
+	Subject string    `bson:"sub"`
+	Before  time.Time `bson:"before"`
+	Expires time.Time `bson:"expires"`
+}
+
+// ExportUser returns the profile, orders, reviews, profile changes,
+// loyalty points, wishlists, alerts and reservations of a user as one
+// JSON document.
+func (s *Server) ExportUser(ctx context.Context, req *pb.UserDataRequest) (*pb.UserArchive, error) {
+	if req.Username == "" {
+		return nil, status.Error(codes.InvalidArgument, "username must be set")
+	}
+
+	session := s.MongoSession.Copy()
+	defer session.Close()
+
+	db := session.DB("user-db")
+	a := &archive{
+		Username:       req.Username,
+		ExportedAt:     time.Now().UTC(),
+		Orders:         []*pb.Order{},
+		Reviews:        []*pb.Review{},
+		ProfileChanges: []archiveChange{},
+		LoyaltyPoints:  []*pb.LedgerEntry{},
+		Wishlists:      []*pb.Wishlist{},
+		Alerts:         []*alert.Watch{},
+		Reservations:   []*reservation.Night{},
+	}
+
+	var user User
+	err := db.C("user").Find(&bson.M{"username": req.Username}).One(&user)
+	if err != nil && err != mgo.ErrNotFound {
+		return nil, err
+	}
+	if err == nil {
+		a.Profile = &archiveProfile{Age: user.Age, Sex: user.Sex, Mail: user.Mail, Phone: user.Phone}
+	}
+
+	var orders []order
+	err = db.C("orders").Find(&bson.M{"username": req.Username}).Sort("_id").All(&orders)
+	if err != nil {
+		return nil, err
+	}
+	for i := range orders {
+		o := orders[i].proto()
+		a.Orders = append(a.Orders, o)
+		if o.Score > 0 {
+			a.Reviews = append(a.Reviews, &pb.Review{HotelId: o.HotelId, InDate: o.InDate, OutDate: o.OutDate, Score: o.Score})
+		}
+	}
+
+	var changes []audit
+	err = db.C("audit").Find(&bson.M{"username": req.Username}).Sort("at").All(&changes)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range changes {
+		a.ProfileChanges = append(a.ProfileChanges, archiveChange{Action: c.Action, Fields: c.Fields, At: c.At})
+	}
+
+	var entries []ledgerEntry
+	err = db.C("points").Find(&bson.M{"username": req.Username}).Sort("_id").All(&entries)
+	if err != nil {
+		return nil, err
+	}
+	for i := range entries {
+		a.LoyaltyPoints = append(a.LoyaltyPoints, entries[i].proto())
+	}
+
+	var lists []wishlist
+	err = db.C("wishlists").Find(&bson.M{"username": req.Username}).Sort("_id").All(&lists)
+	if err != nil {
+		return nil, err
+	}
+	for i := range lists {
+		a.Wishlists = append(a.Wishlists, lists[i].proto())
+	}
+
+	alertResp, err := s.alertClient.ListAlerts(ctx, &alert.ListRequest{
+		Username: req.Username,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if alertResp.Alerts != nil {
+		a.Alerts = alertResp.Alerts
+	}
+
+	// reservations are booked under the username
+	reserveResp, err := s.reservationClient.ExportCustomer(ctx, &reservation.CustomerRequest{
+		CustomerName: req.Username,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if reserveResp.Nights != nil {
+		a.Reservations = reserveResp.Nights
+	}
+
+	b, err := json.MarshalIndent(a, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return &pb.UserArchive{Archive: b}, nil
+}
+
+// EraseUser deletes a user with its orders, reviews, profile changes,
+// loyalty points, wishlists and alerts, revokes its sessions and
+// anonymizes its reservations.
+func (s *Server) EraseUser(ctx context.Context, req *pb.UserDataRequest) (*pb.EraseReport, error) {
+	if req.Username == "" {
+		return nil, status.Error(codes.InvalidArgument, "username must be set")
+	}
+
+	session := s.MongoSession.Copy()
+	defer session.Close()
+
+	return s.erase(ctx, session, req.Username)
+}
+
+// erase erases the data of a user, see EraseUser. It can be run again
+// after a failure; the reservations go first so the user is only gone
+// once nothing is left behind.
+func (s *Server) erase(ctx context.Context, session *mgo.Session, username string) (*pb.EraseReport, error) {
+	report := new(pb.EraseReport)
+	db := session.DB("user-db")
+
+	reserveResp, err := s.reservationClient.EraseCustomer(ctx, &reservation.CustomerRequest{
+		CustomerName: username,
+	})
+	if err != nil {
+		return nil, err
+	}
+	report.ReservationsAnonymized = reserveResp.Anonymized
+
+	alertResp, err := s.alertClient.EraseAlerts(ctx, &alert.ListRequest{
+		Username: username,
+	})
+	if err != nil {
+		return nil, err
+	}
+	report.Alerts = alertResp.Erased
+
+	reviews, err := db.C("orders").Find(&bson.M{"username": username, "score": bson.M{"$gt": 0}}).Count()
+	if err != nil {
+		return nil, err
+	}
+	info, err := db.C("orders").RemoveAll(&bson.M{"username": username})
+	if err != nil {
+		return nil, err
+	}
+	report.Orders = int32(info.Removed)
+	report.Reviews = int32(reviews)
+
+	info, err = db.C("audit").RemoveAll(&bson.M{"username": username})
+	if err != nil {
+		return nil, err
+	}
+	report.AuditRecords = int32(info.Removed)
+
+	info, err = db.C("points").RemoveAll(&bson.M{"username": username})
+	if err != nil {
+		return nil, err
+	}
+	report.LoyaltyEntries = int32(info.Removed)
+	if _, err := db.C("loyalty").RemoveAll(&bson.M{"username": username}); err != nil {
+		return nil, err
+	}
+
+	// with their share tokens
+	info, err = db.C("wishlists").RemoveAll(&bson.M{"username": username})
+	if err != nil {
+		return nil, err
+	}
+	report.Wishlists = int32(info.Removed)
+
+	// a user of the same name registered later must not get the sessions
+	now := time.Now()
+	err = db.C("revoked").Insert(&revokedSessions{
+		Subject: username,
+		Before:  now,
+		Expires: now.Add(s.Tokens.TTL),
+	})
+	if err != nil {
+		return nil, err
+	}
+	report.SessionsRevoked = true
+
+	info, err = db.C("user").RemoveAll(&bson.M{"username": username})
+	if err != nil {
+		return nil, err
+	}
+	report.Found = info.Removed > 0
+
+	if s.Lockout != nil {
+		if err := s.Lockout.Reset(username); err != nil {
+			log.Println("Failed reset login failures: ", err)
+		}
+	}
+
+	log.Printf("Erased user data: %d orders, %d profile changes, %d reservations anonymized\n",
+		report.Orders, report.AuditRecords, report.ReservationsAnonymized)
+	return report, nil
+}
@@ -0,0 +1,49 @@
+package user
+
+import (
+	"log"
+
+	"github.com/harlow/go-micro-services/lockout"
+	"gopkg.in/mgo.v2"
+)
+
+// login checks a username and password like checkUser, throttling the
+// failures of the account and of the client. A blocked account or client
+// gets the status error of a *lockout.Error without its password being
+// checked.
+func (s *Server) login(c *mgo.Collection, username, password, clientIp string) (bool, error) {
+	if err := s.checkLockout(username, clientIp); err != nil {
+		return false, err
+	}
+
+	correct, err := checkUser(c, username, password)
+	if err != nil {
+		return false, err
+	}
+
+	if correct {
+		// the client may still be failing for other accounts
+		if s.Lockout != nil {
+			if err := s.Lockout.Reset(username); err != nil {
+				log.Println("Failed reset login failures: ", err)
+			}
+		}
+		return true, nil
+	}
+	s.loginFailed(username, clientIp)
+	return false, nil
+}
+
+// loginFailed counts a wrong password for the account and the client.
+func (s *Server) loginFailed(username, clientIp string) {
+	lockout.FailAll(lockout.Keys(s.Lockout, username, s.AddrLockout, clientIp))
+}
+
+// checkLockout returns the status error of an account or client that may
+// not log in now. Logins are let through if memcached fails.
+func (s *Server) checkLockout(username, clientIp string) error {
+	if e := lockout.CheckAll(lockout.Keys(s.Lockout, username, s.AddrLockout, clientIp)); e != nil {
+		return e.Status()
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package user
+
+import (
+	"flag"
+	"math/rand"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+var (
+	benchMongo  = flag.String("user.mongo", "", "User MongoDB address the login benchmark runs against; it is skipped if unset")
+	benchPool   = flag.Int("user.pool", 128, "Session pool limit of the login benchmark, 0 for the mgo default")
+	benchUsers  = flag.Int("user.users", 500, "Number of seeded users the login benchmark logs in")
+	benchVerify = flag.Bool("user.verify", false, "Also verify the password hash, which dwarfs the database time with bcrypt")
+)
+
+// BenchmarkLogin compares the database path of a login when every login
+// dials MongoDB, as the service used to, against copying a session of the
+// shared pool. It logs in the users seeded by cmd/user, Cornell_<i> with
+// password <i> repeated ten times; run it with -cpu to vary the
+// concurrent logins.
+func BenchmarkLogin(b *testing.B) {
+	if *benchMongo == "" {
+		b.Skip("set -user.mongo to the address of a seeded user MongoDB")
+	}
+
+	pool, err := mgo.Dial(*benchMongo)
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer pool.Close()
+	if *benchPool > 0 {
+		pool.SetPoolLimit(*benchPool)
+	}
+
+	modes := []struct {
+		name    string
+		session func() (*mgo.Session, error)
+	}{
+		{"dial", func() (*mgo.Session, error) { return mgo.Dial(*benchMongo) }},
+		{"pool", func() (*mgo.Session, error) { return pool.Copy(), nil }},
+	}
+	for _, mode := range modes {
+		b.Run(mode.name, func(b *testing.B) {
+			var seed, failed int64
+			b.RunParallel(func(pb *testing.PB) {
+				rnd := rand.New(rand.NewSource(atomic.AddInt64(&seed, 1)))
+				for pb.Next() {
+					suffix := strconv.Itoa(rnd.Intn(*benchUsers))
+					if !benchLogin(mode.session, "Cornell_"+suffix, strings.Repeat(suffix, 10)) {
+						atomic.AddInt64(&failed, 1)
+					}
+				}
+			})
+			if failed > 0 {
+				b.Errorf("%d of %d logins failed", failed, b.N)
+			}
+		})
+	}
+}
+
+// benchLogin does what CheckUser does to look up a user, and with
+// -user.verify to check the password.
+func benchLogin(session func() (*mgo.Session, error), username, password string) bool {
+	s, err := session()
+	if err != nil {
+		return false
+	}
+	defer s.Close()
+	c := s.DB("user-db").C("user")
+
+	if *benchVerify {
+		correct, err := checkUser(c, username, password)
+		return err == nil && correct
+	}
+	var user User
+	return c.Find(&bson.M{"username": username}).One(&user) == nil
+}
@@ -0,0 +1,435 @@
+package user
+
+import (
+	"time"
+
+	pb "github.com/harlow/go-micro-services/services/user/proto"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+const (
+	// pointsPerNight are earned per room and night, before the tier bonus
+	pointsPerNight = 100
+	// pointsPerUnit are worth one unit of the hotel price currency when
+	// redeemed
+	pointsPerUnit = 100
+	// tierPeriod is how long stayed nights count towards a tier
+	tierPeriod = 365 * 24 * time.Hour
+
+	defaultLedgerPageSize = 20
+	maxLedgerPageSize     = 100
+)
+
+// the kinds of ledger entries
+const (
+	kindEarn    = "earn"
+	kindRedeem  = "redeem"
+	kindReverse = "reverse"
+	kindRefund  = "refund"
+)
+
+// tier is a loyalty level, reached by the room nights stayed over
+// tierPeriod, that earns a bonus on the points of new stays.
+type tier struct {
+	Name   string
+	Nights int32
+	// Bonus is in percent of the points earned
+	Bonus int64
+}
+
+// tiers are in ascending order of nights.
+var tiers = []tier{
+	{Name: "member", Nights: 0, Bonus: 0},
+	{Name: "silver", Nights: 10, Bonus: 25},
+	{Name: "gold", Nights: 25, Bonus: 50},
+	{Name: "platinum", Nights: 50, Bonus: 100},
+}
+
+// tierOf returns the index in tiers of the tier reached by nights.
+func tierOf(nights int32) int {
+	t := 0
+	for i := range tiers {
+		if nights >= tiers[i].Nights {
+			t = i
+		}
+	}
+	return t
+}
+
+// ledgerEntry is a change of the points of a user in user-db.points. The
+// points of a stay are earned when it is booked but only available once
+// it is completed, at noon of the day it ends.
+type ledgerEntry struct {
+	ID            bson.ObjectId `bson:"_id"`
+	Username      string        `bson:"username"`
+	Kind          string        `bson:"kind"`
+	Points        int64         `bson:"points"`
+	ReservationId string        `bson:"reservationId"`
+	HotelId       string        `bson:"hotelId,omitempty"`
+	// Nights are room nights, Rooms are only kept on earn entries
+	Nights      int32     `bson:"nights"`
+	Rooms       int32     `bson:"rooms,omitempty"`
+	Created     time.Time `bson:"created"`
+	AvailableAt time.Time `bson:"availableAt"`
+}
+
+func (e *ledgerEntry) proto() *pb.LedgerEntry {
+	return &pb.LedgerEntry{
+		Kind:          e.Kind,
+		Points:        e.Points,
+		ReservationId: e.ReservationId,
+		HotelId:       e.HotelId,
+		Nights:        e.Nights,
+		Created:       e.Created.Unix(),
+		AvailableAt:   e.AvailableAt.Unix(),
+	}
+}
+
+// loyaltyAccount in user-db.loyalty serializes the redemptions of a user:
+// each one bumps the version it read the balance at.
+type loyaltyAccount struct {
+	Username string `bson:"username"`
+	Version  int64  `bson:"version"`
+}
+
+// loyaltyStanding is the points of a user at a time.
+type loyaltyStanding struct {
+	Balance int64
+	Pending int64
+	// Nights are the room nights stayed over tierPeriod
+	Nights int32
+}
+
+// standing sums the ledger of a user at now.
+func standing(db *mgo.Database, username string, now time.Time) (*loyaltyStanding, error) {
+	var entries []ledgerEntry
+	err := db.C("points").Find(bson.M{"username": username}).
+		Select(bson.M{"kind": 1, "points": 1, "nights": 1, "availableAt": 1}).All(&entries)
+	if err != nil {
+		return nil, err
+	}
+
+	st := new(loyaltyStanding)
+	for _, e := range entries {
+		if e.AvailableAt.After(now) {
+			st.Pending += e.Points
+			continue
+		}
+		st.Balance += e.Points
+		if (e.Kind == kindEarn || e.Kind == kindReverse) && e.AvailableAt.After(now.Add(-tierPeriod)) {
+			st.Nights += e.Nights
+		}
+	}
+	return st, nil
+}
+
+// GetLoyalty returns the points balance and tier of a user.
+func (s *Server) GetLoyalty(ctx context.Context, req *pb.LoyaltyRequest) (*pb.LoyaltyAccount, error) {
+	if req.Username == "" {
+		return nil, status.Error(codes.InvalidArgument, "username must be set")
+	}
+
+	session := s.MongoSession.Copy()
+	defer session.Close()
+
+	st, err := standing(session.DB("user-db"), req.Username, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	t := tierOf(st.Nights)
+	res := &pb.LoyaltyAccount{
+		Username:   req.Username,
+		Balance:    st.Balance,
+		Pending:    st.Pending,
+		Tier:       tiers[t].Name,
+		TierNights: st.Nights,
+	}
+	if t+1 < len(tiers) {
+		res.NextTier = tiers[t+1].Name
+		res.NightsToNextTier = tiers[t+1].Nights - st.Nights
+	}
+	return res, nil
+}
+
+// GetLoyaltyHistory returns the points ledger of a user, newest first. The
+// page token is the id of the last entry of the previous page.
+func (s *Server) GetLoyaltyHistory(ctx context.Context, req *pb.LoyaltyHistoryQuery) (*pb.LoyaltyHistoryPage, error) {
+	res := new(pb.LoyaltyHistoryPage)
+
+	pageSize := int(req.PageSize)
+	if pageSize <= 0 {
+		pageSize = defaultLedgerPageSize
+	}
+	if pageSize > maxLedgerPageSize {
+		pageSize = maxLedgerPageSize
+	}
+
+	query := bson.M{"username": req.Username}
+	if req.PageToken != "" {
+		if !bson.IsObjectIdHex(req.PageToken) {
+			return nil, status.Error(codes.InvalidArgument, "invalid page token")
+		}
+		query["_id"] = bson.M{"$lt": bson.ObjectIdHex(req.PageToken)}
+	}
+
+	session := s.MongoSession.Copy()
+	defer session.Close()
+
+	var entries []ledgerEntry
+	err := session.DB("user-db").C("points").Find(query).Sort("-_id").Limit(pageSize + 1).All(&entries)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(entries) > pageSize {
+		entries = entries[:pageSize]
+		res.NextPageToken = entries[pageSize-1].ID.Hex()
+	}
+	for i := range entries {
+		res.Entries = append(res.Entries, entries[i].proto())
+	}
+	return res, nil
+}
+
+// RedeemPoints spends points of a user as a discount on a reservation,
+// no more than the reservation costs. Redeeming again for the same
+// reservation returns the first redemption.
+func (s *Server) RedeemPoints(ctx context.Context, req *pb.RedeemRequest) (*pb.RedeemResult, error) {
+	if req.Username == "" || req.ReservationId == "" || req.Points <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "username, reservation id and a positive number of points must be set")
+	}
+	spend := req.Points
+	if max := int64(float64(req.MaxDiscount) * pointsPerUnit); spend > max {
+		spend = max
+	}
+	if spend <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "the reservation costs too little to redeem points on")
+	}
+
+	session := s.MongoSession.Copy()
+	defer session.Close()
+
+	db := session.DB("user-db")
+	points := db.C("points")
+	accounts := db.C("loyalty")
+
+	_, err := accounts.Upsert(bson.M{"username": req.Username}, bson.M{"$setOnInsert": bson.M{"version": 0}})
+	if err != nil && !mgo.IsDup(err) {
+		return nil, err
+	}
+	var account loyaltyAccount
+	err = accounts.Find(bson.M{"username": req.Username}).One(&account)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	st, err := standing(db, req.Username, now)
+	if err != nil {
+		return nil, err
+	}
+
+	var redeemed ledgerEntry
+	err = points.Find(bson.M{"username": req.Username, "reservationId": req.ReservationId, "kind": kindRedeem}).One(&redeemed)
+	if err == nil {
+		return &pb.RedeemResult{
+			Points:   -redeemed.Points,
+			Discount: float32(-redeemed.Points) / pointsPerUnit,
+			Balance:  st.Balance,
+		}, nil
+	}
+	if err != mgo.ErrNotFound {
+		return nil, err
+	}
+
+	if st.Balance < spend {
+		return nil, status.Errorf(codes.FailedPrecondition, "only %d points may be redeemed", st.Balance)
+	}
+
+	entry := &ledgerEntry{
+		ID:            bson.NewObjectId(),
+		Username:      req.Username,
+		Kind:          kindRedeem,
+		Points:        -spend,
+		ReservationId: req.ReservationId,
+		Created:       now,
+		AvailableAt:   now,
+	}
+	if err := points.Insert(entry); err != nil {
+		return nil, err
+	}
+
+	// a redemption that read the balance at the same version may have
+	// spent it in the meantime
+	err = accounts.Update(
+		bson.M{"username": req.Username, "version": account.Version},
+		bson.M{"$inc": bson.M{"version": 1}},
+	)
+	if err != nil {
+		if rmErr := points.RemoveId(entry.ID); rmErr != nil {
+			return nil, rmErr
+		}
+		if err == mgo.ErrNotFound {
+			return nil, status.Error(codes.Aborted, "points redeemed concurrently, try again")
+		}
+		return nil, err
+	}
+
+	return &pb.RedeemResult{
+		Points:   spend,
+		Discount: float32(spend) / pointsPerUnit,
+		Balance:  st.Balance - spend,
+	}, nil
+}
+
+// ReservationChanged keeps the points of a user in step with its
+// reservations. A booked reservation earns points for its room nights, at
+// the bonus of the user's tier. Cancelled nights reverse their share of
+// the points earned; the redeemed points are refunded once every night is
+// cancelled. Repeated changes are applied once.
+func (s *Server) ReservationChanged(ctx context.Context, req *pb.ReservationChange) (*pb.ReservationChangeResult, error) {
+	if req.Username == "" || req.ReservationId == "" || req.Nights < 0 {
+		return nil, status.Error(codes.InvalidArgument, "username and reservation id must be set")
+	}
+
+	session := s.MongoSession.Copy()
+	defer session.Close()
+
+	db := session.DB("user-db")
+	switch req.Event {
+	case pb.ReservationChange_BOOKED:
+		return earnPoints(db, req, time.Now())
+	case pb.ReservationChange_CANCELLED:
+		return reversePoints(db, req, time.Now())
+	}
+	return nil, status.Errorf(codes.InvalidArgument, "unknown event %v", req.Event)
+}
+
+func earnPoints(db *mgo.Database, req *pb.ReservationChange, now time.Time) (*pb.ReservationChangeResult, error) {
+	res := new(pb.ReservationChangeResult)
+	c := db.C("points")
+
+	count, err := c.Find(bson.M{"username": req.Username, "reservationId": req.ReservationId, "kind": kindEarn}).Count()
+	if err != nil {
+		return nil, err
+	}
+	if count > 0 {
+		return res, nil
+	}
+
+	outDate, err := time.Parse(time.RFC3339, req.OutDate+"T12:00:00+00:00")
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "outDate must be YYYY-MM-DD")
+	}
+	nights := req.Nights * req.RoomNumber
+	if nights <= 0 {
+		return res, nil
+	}
+
+	st, err := standing(db, req.Username, now)
+	if err != nil {
+		return nil, err
+	}
+	bonus := tiers[tierOf(st.Nights)].Bonus
+
+	res.Points = int64(nights) * pointsPerNight * (100 + bonus) / 100
+	err = c.Insert(&ledgerEntry{
+		ID:            bson.NewObjectId(),
+		Username:      req.Username,
+		Kind:          kindEarn,
+		Points:        res.Points,
+		ReservationId: req.ReservationId,
+		HotelId:       req.HotelId,
+		Nights:        nights,
+		Rooms:         req.RoomNumber,
+		Created:       now,
+		AvailableAt:   outDate,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func reversePoints(db *mgo.Database, req *pb.ReservationChange, now time.Time) (*pb.ReservationChangeResult, error) {
+	res := new(pb.ReservationChangeResult)
+	c := db.C("points")
+
+	var entries []ledgerEntry
+	err := c.Find(bson.M{"username": req.Username, "reservationId": req.ReservationId}).All(&entries)
+	if err != nil {
+		return nil, err
+	}
+
+	var earned, redeemed *ledgerEntry
+	var reversedPoints, refunded int64
+	var reversedNights int32
+	for i := range entries {
+		switch e := &entries[i]; e.Kind {
+		case kindEarn:
+			earned = e
+		case kindRedeem:
+			redeemed = e
+		case kindReverse:
+			reversedPoints += e.Points
+			reversedNights += e.Nights
+		case kindRefund:
+			refunded += e.Points
+		}
+	}
+
+	if earned != nil && earned.Rooms > 0 {
+		booked := earned.Nights / earned.Rooms
+		remaining := req.Nights
+		if remaining > booked {
+			remaining = booked
+		}
+		// what the cancelled nights should have reversed, less what was
+		cancelled := booked - remaining
+		points := -earned.Points*int64(cancelled)/int64(booked) - reversedPoints
+		nights := -earned.Rooms*cancelled - reversedNights
+		if points != 0 || nights != 0 {
+			err := c.Insert(&ledgerEntry{
+				ID:            bson.NewObjectId(),
+				Username:      req.Username,
+				Kind:          kindReverse,
+				Points:        points,
+				ReservationId: req.ReservationId,
+				HotelId:       earned.HotelId,
+				Nights:        nights,
+				Created:       now,
+				// a reversal counts when the points it reverses did
+				AvailableAt: earned.AvailableAt,
+			})
+			if err != nil {
+				return nil, err
+			}
+			res.Points += points
+		}
+	}
+
+	if redeemed != nil && req.Nights == 0 {
+		if points := -redeemed.Points - refunded; points != 0 {
+			err := c.Insert(&ledgerEntry{
+				ID:            bson.NewObjectId(),
+				Username:      req.Username,
+				Kind:          kindRefund,
+				Points:        points,
+				ReservationId: req.ReservationId,
+				Created:       now,
+				AvailableAt:   now,
+			})
+			if err != nil {
+				return nil, err
+			}
+			res.Points += points
+		}
+	}
+
+	return res, nil
+}
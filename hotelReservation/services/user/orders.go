@@ -0,0 +1,274 @@
+package user
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	pb "github.com/harlow/go-micro-services/services/user/proto"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+const (
+	defaultOrderPageSize = 20
+	maxOrderPageSize     = 100
+)
+
+// order is an order of a user in user-db.orders.
+type order struct {
+	ID            bson.ObjectId `bson:"_id"`
+	Username      string        `bson:"username"`
+	ReservationId string        `bson:"reservationId,omitempty"`
+	HotelId       string        `bson:"hotelId"`
+	InDate        string        `bson:"inDate"`
+	OutDate       string        `bson:"outDate"`
+	RoomNumber    int32         `bson:"roomNumber"`
+	Price         float32       `bson:"price"`
+	Discount      float32       `bson:"discount,omitempty"`
+	Score         float32       `bson:"score"`
+	Created       time.Time     `bson:"created"`
+	Updated       time.Time     `bson:"updated"`
+}
+
+func (o *order) proto() *pb.Order {
+	return &pb.Order{
+		ReservationId: o.ReservationId,
+		HotelId:       o.HotelId,
+		InDate:        o.InDate,
+		OutDate:       o.OutDate,
+		RoomNumber:    o.RoomNumber,
+		Price:         o.Price,
+		Discount:      o.Discount,
+		Score:         o.Score,
+		Created:       o.Created.Unix(),
+		Updated:       o.Updated.Unix(),
+	}
+}
+
+// OrderHistoryUpdate records an order of a user, or updates the order it
+// refers to. Only the fields set in the request are updated.
+func (s *Server) OrderHistoryUpdate(ctx context.Context, req *pb.OrderHistoryRequest) (*pb.OrderHistoryResult, error) {
+	res := new(pb.OrderHistoryResult)
+
+	o := req.Order
+	if req.Username == "" || o == nil {
+		return nil, status.Error(codes.InvalidArgument, "username and order must be set")
+	}
+	if o.ReservationId == "" && (o.HotelId == "" || o.InDate == "" || o.OutDate == "") {
+		return nil, status.Error(codes.InvalidArgument, "order must have a reservation id, or a hotel and dates")
+	}
+
+	session := s.MongoSession.Copy()
+	defer session.Close()
+
+	c := session.DB("user-db").C("orders")
+
+	selector := bson.M{"username": req.Username, "reservationId": o.ReservationId}
+	if o.ReservationId == "" {
+		selector = bson.M{"username": req.Username, "hotelId": o.HotelId, "inDate": o.InDate, "outDate": o.OutDate}
+	}
+
+	now := time.Now()
+	var existing order
+	err := c.Find(selector).Sort("-_id").One(&existing)
+	if err == mgo.ErrNotFound {
+		err = c.Insert(&order{
+			ID:            bson.NewObjectId(),
+			Username:      req.Username,
+			ReservationId: o.ReservationId,
+			HotelId:       o.HotelId,
+			InDate:        o.InDate,
+			OutDate:       o.OutDate,
+			RoomNumber:    o.RoomNumber,
+			Price:         o.Price,
+			Discount:      o.Discount,
+			Score:         o.Score,
+			Created:       now,
+			Updated:       now,
+		})
+	} else if err == nil {
+		err = c.UpdateId(existing.ID, bson.M{"$set": orderChanges(o, now)})
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	res.Correct = true
+	return res, nil
+}
+
+// orderChanges returns the fields of an order update that are set.
+func orderChanges(o *pb.Order, now time.Time) bson.M {
+	set := bson.M{"updated": now}
+	if o.HotelId != "" {
+		set["hotelId"] = o.HotelId
+	}
+	if o.InDate != "" {
+		set["inDate"] = o.InDate
+	}
+	if o.OutDate != "" {
+		set["outDate"] = o.OutDate
+	}
+	if o.RoomNumber != 0 {
+		set["roomNumber"] = o.RoomNumber
+	}
+	if o.Price != 0 {
+		set["price"] = o.Price
+	}
+	if o.Discount != 0 {
+		set["discount"] = o.Discount
+	}
+	if o.Score != 0 {
+		set["score"] = o.Score
+	}
+	return set
+}
+
+// GetOrderHistory returns the orders of a user, newest first. The page
+// token is the id of the last order of the previous page.
+func (s *Server) GetOrderHistory(ctx context.Context, req *pb.OrderHistoryQuery) (*pb.OrderHistoryPage, error) {
+	res := new(pb.OrderHistoryPage)
+
+	pageSize := int(req.PageSize)
+	if pageSize <= 0 {
+		pageSize = defaultOrderPageSize
+	}
+	if pageSize > maxOrderPageSize {
+		pageSize = maxOrderPageSize
+	}
+
+	query := bson.M{"username": req.Username}
+	if req.PageToken != "" {
+		if !bson.IsObjectIdHex(req.PageToken) {
+			return nil, status.Error(codes.InvalidArgument, "invalid page token")
+		}
+		query["_id"] = bson.M{"$lt": bson.ObjectIdHex(req.PageToken)}
+	}
+
+	session := s.MongoSession.Copy()
+	defer session.Close()
+
+	var orders []order
+	err := session.DB("user-db").C("orders").Find(query).Sort("-_id").Limit(pageSize + 1).All(&orders)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(orders) > pageSize {
+		orders = orders[:pageSize]
+		res.NextPageToken = orders[pageSize-1].ID.Hex()
+	}
+	for i := range orders {
+		res.Orders = append(res.Orders, orders[i].proto())
+	}
+	return res, nil
+}
+
+// GetReviews returns the hotel scores a user gave, from the scored orders.
+func (s *Server) GetReviews(ctx context.Context, req *pb.ReviewsRequest) (*pb.ReviewsResult, error) {
+	res := new(pb.ReviewsResult)
+
+	session := s.MongoSession.Copy()
+	defer session.Close()
+
+	var orders []order
+	err := session.DB("user-db").C("orders").Find(bson.M{
+		"username": req.Username,
+		"score":    bson.M{"$gt": 0},
+	}).All(&orders)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, o := range orders {
+		res.Reviews = append(res.Reviews, &pb.Review{
+			HotelId: o.HotelId,
+			InDate:  o.InDate,
+			OutDate: o.OutDate,
+			Score:   o.Score,
+		})
+	}
+	return res, nil
+}
+
+// MigrateOrderHistory moves the order histories users kept as a single
+// string into user-db.orders and returns the number of orders moved. It
+// is safe to run again, also after a failed run.
+func MigrateOrderHistory(session *mgo.Session) (int, error) {
+	s := session.Copy()
+	defer s.Close()
+
+	users := s.DB("user-db").C("user")
+	orders := s.DB("user-db").C("orders")
+
+	var legacy []struct {
+		Username     string `bson:"username"`
+		Orderhistory string `bson:"orderhistory"`
+	}
+	err := users.Find(bson.M{"orderhistory": bson.M{"$exists": true}}).
+		Select(bson.M{"username": 1, "orderhistory": 1}).All(&legacy)
+	if err != nil {
+		return 0, err
+	}
+
+	migrated := 0
+	now := time.Now()
+	for _, u := range legacy {
+		for _, o := range parseOrderHistory(u.Orderhistory) {
+			// upserts, so orders of a failed run are not added twice
+			_, err := orders.Upsert(
+				bson.M{"username": u.Username, "hotelId": o.HotelId, "inDate": o.InDate, "outDate": o.OutDate, "score": o.Score},
+				bson.M{"$setOnInsert": bson.M{"created": now, "updated": now, "roomNumber": 0, "price": 0}},
+			)
+			if err != nil {
+				return migrated, err
+			}
+			migrated++
+		}
+
+		err := users.Update(
+			bson.M{"username": u.Username},
+			bson.M{"$unset": bson.M{"orderhistory": ""}},
+		)
+		if err != nil && err != mgo.ErrNotFound {
+			return migrated, err
+		}
+	}
+	return migrated, nil
+}
+
+// parseOrderHistory parses an order history string, a "; " separated list
+// of "hotelId: 1, inDate: 2015-04-09, outDate: 2015-04-10, score: 4"
+// entries. Entries without a hotel id are skipped; a missing or invalid
+// score is 0.
+func parseOrderHistory(orderhistory string) []*pb.Order {
+	var orders []*pb.Order
+	for _, entry := range strings.Split(orderhistory, ";") {
+		fields := make(map[string]string)
+		for _, field := range strings.Split(entry, ",") {
+			kv := strings.SplitN(field, ":", 2)
+			if len(kv) == 2 {
+				fields[strings.TrimSpace(kv[0])] = strings.TrimSpace(kv[1])
+			}
+		}
+		if fields["hotelId"] == "" {
+			continue
+		}
+
+		score, err := strconv.ParseFloat(fields["score"], 32)
+		if err != nil {
+			score = 0
+		}
+		orders = append(orders, &pb.Order{
+			HotelId: fields["hotelId"],
+			InDate:  fields["inDate"],
+			OutDate: fields["outDate"],
+			Score:   float32(score),
+		})
+	}
+	return orders
+}
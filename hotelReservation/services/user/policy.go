@@ -0,0 +1,113 @@
+package user
+
+import (
+	"github.com/harlow/go-micro-services/rbac"
+	pb "github.com/harlow/go-micro-services/services/user/proto"
+)
+
+// self is the identity the user service calls other services with.
+var self = &rbac.Identity{Subject: name, Role: rbac.Service}
+
+// policy is who may call the user service. Logging in is public; the rest
+// is only for the user it is about. Points are only redeemed and earned
+// through the reservation service.
+var policy = rbac.Policy{
+	"/user.User/CheckUser":    {Public: true},
+	"/user.User/Register":     {Public: true},
+	"/user.User/Login":        {Public: true},
+	"/user.User/VerifyToken":  {Public: true},
+	"/user.User/RefreshToken": {Public: true},
+	"/user.User/Logout":       {Public: true},
+	// the share token is the credential
+	"/user.User/GetSharedWishlist": {Public: true},
+	"/user.User/UpdateUser": {
+		Roles: []rbac.Role{rbac.Guest},
+		Owner: func(req interface{}) string {
+			return req.(*pb.UpdateUserRequest).Username
+		},
+	},
+	"/user.User/Delete": {
+		Roles: []rbac.Role{rbac.Guest},
+		Owner: func(req interface{}) string {
+			return req.(*pb.Request).Username
+		},
+	},
+	"/user.User/ExportUser": {
+		Roles: []rbac.Role{rbac.Guest},
+		Owner: func(req interface{}) string {
+			return req.(*pb.UserDataRequest).Username
+		},
+	},
+	"/user.User/EraseUser": {
+		Roles: []rbac.Role{rbac.Guest},
+		Owner: func(req interface{}) string {
+			return req.(*pb.UserDataRequest).Username
+		},
+	},
+	"/user.User/OrderHistoryUpdate": {
+		Roles: []rbac.Role{rbac.Guest},
+		Owner: func(req interface{}) string {
+			return req.(*pb.OrderHistoryRequest).Username
+		},
+	},
+	"/user.User/GetOrderHistory": {
+		Roles: []rbac.Role{rbac.Guest},
+		Owner: func(req interface{}) string {
+			return req.(*pb.OrderHistoryQuery).Username
+		},
+	},
+	"/user.User/GetLoyalty": {
+		Roles: []rbac.Role{rbac.Guest},
+		Owner: func(req interface{}) string {
+			return req.(*pb.LoyaltyRequest).Username
+		},
+	},
+	"/user.User/GetLoyaltyHistory": {
+		Roles: []rbac.Role{rbac.Guest},
+		Owner: func(req interface{}) string {
+			return req.(*pb.LoyaltyHistoryQuery).Username
+		},
+	},
+	"/user.User/CreateWishlist": {
+		Roles: []rbac.Role{rbac.Guest},
+		Owner: func(req interface{}) string {
+			return req.(*pb.CreateWishlistRequest).Username
+		},
+	},
+	"/user.User/GetWishlists": {
+		Roles: []rbac.Role{rbac.Guest},
+		Owner: func(req interface{}) string {
+			return req.(*pb.WishlistsRequest).Username
+		},
+	},
+	"/user.User/GetWishlist": {
+		Roles: []rbac.Role{rbac.Guest},
+		Owner: func(req interface{}) string {
+			return req.(*pb.WishlistRequest).Username
+		},
+	},
+	"/user.User/UpdateWishlist": {
+		Roles: []rbac.Role{rbac.Guest},
+		Owner: func(req interface{}) string {
+			return req.(*pb.UpdateWishlistRequest).Username
+		},
+	},
+	"/user.User/DeleteWishlist": {
+		Roles: []rbac.Role{rbac.Guest},
+		Owner: func(req interface{}) string {
+			return req.(*pb.WishlistRequest).Username
+		},
+	},
+	"/user.User/ShareWishlist": {
+		Roles: []rbac.Role{rbac.Guest},
+		Owner: func(req interface{}) string {
+			return req.(*pb.ShareWishlistRequest).Username
+		},
+	},
+	"/user.User/GetReviews": {
+		Roles: []rbac.Role{rbac.Guest},
+		Owner: func(req interface{}) string {
+			return req.(*pb.ReviewsRequest).Username
+		},
+	},
+}
@@ -1,21 +1,50 @@
 // Code generated by protoc-gen-go. DO NOT EDIT.
-// source: user.proto
+// source: services/user/proto/user.proto
 
 /*
 Package user is a generated protocol buffer package.
 
 It is generated from these files:
-	user.proto
+	services/user/proto/user.proto
 
 It has these top-level messages:
 	Request
 	Result
 	RegisterRequest
 	RegisterResult
-	ModifyRequest
-	ModifyResult
+	UpdateUserRequest
+	UpdateUserResult
 	OrderHistoryRequest
 	OrderHistoryResult
+	OrderHistoryQuery
+	OrderHistoryPage
+	Order
+	ReviewsRequest
+	ReviewsResult
+	Review
+	LoginResult
+	TokenRequest
+	TokenResult
+	UserDataRequest
+	UserArchive
+	EraseReport
+	LoyaltyRequest
+	LoyaltyAccount
+	LoyaltyHistoryQuery
+	LoyaltyHistoryPage
+	LedgerEntry
+	RedeemRequest
+	RedeemResult
+	ReservationChange
+	ReservationChangeResult
+	CreateWishlistRequest
+	WishlistsRequest
+	WishlistsResult
+	WishlistRequest
+	UpdateWishlistRequest
+	ShareWishlistRequest
+	SharedWishlistRequest
+	Wishlist
 */
 package user
 
@@ -39,9 +68,33 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
 
+type ReservationChange_Event int32
+
+const (
+	ReservationChange_BOOKED    ReservationChange_Event = 0
+	ReservationChange_CANCELLED ReservationChange_Event = 1
+)
+
+var ReservationChange_Event_name = map[int32]string{
+	0: "BOOKED",
+	1: "CANCELLED",
+}
+var ReservationChange_Event_value = map[string]int32{
+	"BOOKED":    0,
+	"CANCELLED": 1,
+}
+
+func (x ReservationChange_Event) String() string {
+	return proto.EnumName(ReservationChange_Event_name, int32(x))
+}
+func (ReservationChange_Event) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{27, 0} }
+
+// clientIp is the address of the client logging in, for throttling
+// failed logins. It is empty for requests not made on behalf of a client.
 type Request struct {
 	Username string `protobuf:"bytes,1,opt,name=username" json:"username,omitempty"`
 	Password string `protobuf:"bytes,2,opt,name=password" json:"password,omitempty"`
+	ClientIp string `protobuf:"bytes,3,opt,name=clientIp" json:"clientIp,omitempty"`
 }
 
 func (m *Request) Reset()                    { *m = Request{} }
@@ -63,6 +116,13 @@ func (m *Request) GetPassword() string {
 	return ""
 }
 
+func (m *Request) GetClientIp() string {
+	if m != nil {
+		return m.ClientIp
+	}
+	return ""
+}
+
 type Result struct {
 	Correct bool `protobuf:"varint,1,opt,name=correct" json:"correct,omitempty"`
 }
@@ -80,13 +140,12 @@ func (m *Result) GetCorrect() bool {
 }
 
 type RegisterRequest struct {
-	Username     string `protobuf:"bytes,1,opt,name=username" json:"username,omitempty"`
-	Password     string `protobuf:"bytes,2,opt,name=password" json:"password,omitempty"`
-	Age          int32  `protobuf:"varint,3,opt,name=age" json:"age,omitempty"`
-	Sex          string `protobuf:"bytes,4,opt,name=sex" json:"sex,omitempty"`
-	Mail         string `protobuf:"bytes,5,opt,name=mail" json:"mail,omitempty"`
-	Phone        string `protobuf:"bytes,6,opt,name=phone" json:"phone,omitempty"`
-	Orderhistory string `protobuf:"bytes,7,opt,name=orderhistory" json:"orderhistory,omitempty"`
+	Username string `protobuf:"bytes,1,opt,name=username" json:"username,omitempty"`
+	Password string `protobuf:"bytes,2,opt,name=password" json:"password,omitempty"`
+	Age      int32  `protobuf:"varint,3,opt,name=age" json:"age,omitempty"`
+	Sex      string `protobuf:"bytes,4,opt,name=sex" json:"sex,omitempty"`
+	Mail     string `protobuf:"bytes,5,opt,name=mail" json:"mail,omitempty"`
+	Phone    string `protobuf:"bytes,6,opt,name=phone" json:"phone,omitempty"`
 }
 
 func (m *RegisterRequest) Reset()                    { *m = RegisterRequest{} }
@@ -136,13 +195,6 @@ func (m *RegisterRequest) GetPhone() string {
 	return ""
 }
 
-func (m *RegisterRequest) GetOrderhistory() string {
-	if m != nil {
-		return m.Orderhistory
-	}
-	return ""
-}
-
 type RegisterResult struct {
 	Correct bool `protobuf:"varint,1,opt,name=correct" json:"correct,omitempty"`
 }
@@ -159,89 +211,112 @@ func (m *RegisterResult) GetCorrect() bool {
 	return false
 }
 
-type ModifyRequest struct {
-	Username     string `protobuf:"bytes,1,opt,name=username" json:"username,omitempty"`
-	Password     string `protobuf:"bytes,2,opt,name=password" json:"password,omitempty"`
-	Age          int32  `protobuf:"varint,3,opt,name=age" json:"age,omitempty"`
-	Sex          string `protobuf:"bytes,4,opt,name=sex" json:"sex,omitempty"`
-	Mail         string `protobuf:"bytes,5,opt,name=mail" json:"mail,omitempty"`
-	Phone        string `protobuf:"bytes,6,opt,name=phone" json:"phone,omitempty"`
-	Orderhistory string `protobuf:"bytes,7,opt,name=orderhistory" json:"orderhistory,omitempty"`
+// updateMask names the fields to set: password, age, sex, mail and phone.
+// Setting password requires the current one in oldPassword.
+type UpdateUserRequest struct {
+	Username    string   `protobuf:"bytes,1,opt,name=username" json:"username,omitempty"`
+	UpdateMask  []string `protobuf:"bytes,2,rep,name=updateMask" json:"updateMask,omitempty"`
+	OldPassword string   `protobuf:"bytes,3,opt,name=oldPassword" json:"oldPassword,omitempty"`
+	Password    string   `protobuf:"bytes,4,opt,name=password" json:"password,omitempty"`
+	Age         int32    `protobuf:"varint,5,opt,name=age" json:"age,omitempty"`
+	Sex         string   `protobuf:"bytes,6,opt,name=sex" json:"sex,omitempty"`
+	Mail        string   `protobuf:"bytes,7,opt,name=mail" json:"mail,omitempty"`
+	Phone       string   `protobuf:"bytes,8,opt,name=phone" json:"phone,omitempty"`
 }
 
-func (m *ModifyRequest) Reset()                    { *m = ModifyRequest{} }
-func (m *ModifyRequest) String() string            { return proto.CompactTextString(m) }
-func (*ModifyRequest) ProtoMessage()               {}
-func (*ModifyRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
+func (m *UpdateUserRequest) Reset()                    { *m = UpdateUserRequest{} }
+func (m *UpdateUserRequest) String() string            { return proto.CompactTextString(m) }
+func (*UpdateUserRequest) ProtoMessage()               {}
+func (*UpdateUserRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
 
-func (m *ModifyRequest) GetUsername() string {
+func (m *UpdateUserRequest) GetUsername() string {
 	if m != nil {
 		return m.Username
 	}
 	return ""
 }
 
-func (m *ModifyRequest) GetPassword() string {
+func (m *UpdateUserRequest) GetUpdateMask() []string {
+	if m != nil {
+		return m.UpdateMask
+	}
+	return nil
+}
+
+func (m *UpdateUserRequest) GetOldPassword() string {
+	if m != nil {
+		return m.OldPassword
+	}
+	return ""
+}
+
+func (m *UpdateUserRequest) GetPassword() string {
 	if m != nil {
 		return m.Password
 	}
 	return ""
 }
 
-func (m *ModifyRequest) GetAge() int32 {
+func (m *UpdateUserRequest) GetAge() int32 {
 	if m != nil {
 		return m.Age
 	}
 	return 0
 }
 
-func (m *ModifyRequest) GetSex() string {
+func (m *UpdateUserRequest) GetSex() string {
 	if m != nil {
 		return m.Sex
 	}
 	return ""
 }
 
-func (m *ModifyRequest) GetMail() string {
+func (m *UpdateUserRequest) GetMail() string {
 	if m != nil {
 		return m.Mail
 	}
 	return ""
 }
 
-func (m *ModifyRequest) GetPhone() string {
+func (m *UpdateUserRequest) GetPhone() string {
 	if m != nil {
 		return m.Phone
 	}
 	return ""
 }
 
-func (m *ModifyRequest) GetOrderhistory() string {
-	if m != nil {
-		return m.Orderhistory
-	}
-	return ""
-}
-
-type ModifyResult struct {
-	Correct bool `protobuf:"varint,1,opt,name=correct" json:"correct,omitempty"`
+// correct is false if there is no such user or oldPassword is wrong.
+// updated lists the fields whose value changed.
+type UpdateUserResult struct {
+	Correct bool     `protobuf:"varint,1,opt,name=correct" json:"correct,omitempty"`
+	Updated []string `protobuf:"bytes,2,rep,name=updated" json:"updated,omitempty"`
 }
 
-func (m *ModifyResult) Reset()                    { *m = ModifyResult{} }
-func (m *ModifyResult) String() string            { return proto.CompactTextString(m) }
-func (*ModifyResult) ProtoMessage()               {}
-func (*ModifyResult) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+func (m *UpdateUserResult) Reset()                    { *m = UpdateUserResult{} }
+func (m *UpdateUserResult) String() string            { return proto.CompactTextString(m) }
+func (*UpdateUserResult) ProtoMessage()               {}
+func (*UpdateUserResult) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
 
-func (m *ModifyResult) GetCorrect() bool {
+func (m *UpdateUserResult) GetCorrect() bool {
 	if m != nil {
 		return m.Correct
 	}
 	return false
 }
 
+func (m *UpdateUserResult) GetUpdated() []string {
+	if m != nil {
+		return m.Updated
+	}
+	return nil
+}
+
+// An order with a reservationId updates the order of that reservation.
+// One without, such as the score of a stay, updates the user's order of
+// the same hotel and dates, or adds one.
 type OrderHistoryRequest struct {
-	Username     string `protobuf:"bytes,1,opt,name=username" json:"username,omitempty"`
-	Orderhistory string `protobuf:"bytes,2,opt,name=orderhistory" json:"orderhistory,omitempty"`
+	Username string `protobuf:"bytes,1,opt,name=username" json:"username,omitempty"`
+	Order    *Order `protobuf:"bytes,3,opt,name=order" json:"order,omitempty"`
 }
 
 func (m *OrderHistoryRequest) Reset()                    { *m = OrderHistoryRequest{} }
@@ -256,11 +331,11 @@ func (m *OrderHistoryRequest) GetUsername() string {
 	return ""
 }
 
-func (m *OrderHistoryRequest) GetOrderhistory() string {
+func (m *OrderHistoryRequest) GetOrder() *Order {
 	if m != nil {
-		return m.Orderhistory
+		return m.Order
 	}
-	return ""
+	return nil
 }
 
 type OrderHistoryResult struct {
@@ -279,190 +354,1855 @@ func (m *OrderHistoryResult) GetCorrect() bool {
 	return false
 }
 
-func init() {
-	proto.RegisterType((*Request)(nil), "user.Request")
-	proto.RegisterType((*Result)(nil), "user.Result")
-	proto.RegisterType((*RegisterRequest)(nil), "user.RegisterRequest")
-	proto.RegisterType((*RegisterResult)(nil), "user.RegisterResult")
-	proto.RegisterType((*ModifyRequest)(nil), "user.ModifyRequest")
-	proto.RegisterType((*ModifyResult)(nil), "user.ModifyResult")
-	proto.RegisterType((*OrderHistoryRequest)(nil), "user.OrderHistoryRequest")
-	proto.RegisterType((*OrderHistoryResult)(nil), "user.OrderHistoryResult")
+// pageSize of 0 selects the server default. pageToken is the
+// nextPageToken of the previous page, empty for the first page.
+type OrderHistoryQuery struct {
+	Username  string `protobuf:"bytes,1,opt,name=username" json:"username,omitempty"`
+	PageSize  int32  `protobuf:"varint,2,opt,name=pageSize" json:"pageSize,omitempty"`
+	PageToken string `protobuf:"bytes,3,opt,name=pageToken" json:"pageToken,omitempty"`
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
-
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
+func (m *OrderHistoryQuery) Reset()                    { *m = OrderHistoryQuery{} }
+func (m *OrderHistoryQuery) String() string            { return proto.CompactTextString(m) }
+func (*OrderHistoryQuery) ProtoMessage()               {}
+func (*OrderHistoryQuery) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
 
-// Client API for User service
+func (m *OrderHistoryQuery) GetUsername() string {
+	if m != nil {
+		return m.Username
+	}
+	return ""
+}
 
-type UserClient interface {
-	// CheckUser returns whether the username and password are correct
-	CheckUser(ctx context.Context, in *Request, opts ...grpc.CallOption) (*Result, error)
-	Register(ctx context.Context, in *RegisterRequest, opts ...grpc.CallOption) (*RegisterResult, error)
-	Modify(ctx context.Context, in *ModifyRequest, opts ...grpc.CallOption) (*ModifyResult, error)
-	Delete(ctx context.Context, in *Request, opts ...grpc.CallOption) (*Result, error)
-	OrderHistoryUpdate(ctx context.Context, in *OrderHistoryRequest, opts ...grpc.CallOption) (*OrderHistoryResult, error)
+func (m *OrderHistoryQuery) GetPageSize() int32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
 }
 
-type userClient struct {
-	cc *grpc.ClientConn
+func (m *OrderHistoryQuery) GetPageToken() string {
+	if m != nil {
+		return m.PageToken
+	}
+	return ""
 }
 
-func NewUserClient(cc *grpc.ClientConn) UserClient {
-	return &userClient{cc}
+// nextPageToken is empty on the last page.
+type OrderHistoryPage struct {
+	Orders        []*Order `protobuf:"bytes,1,rep,name=orders" json:"orders,omitempty"`
+	NextPageToken string   `protobuf:"bytes,2,opt,name=nextPageToken" json:"nextPageToken,omitempty"`
 }
 
-func (c *userClient) CheckUser(ctx context.Context, in *Request, opts ...grpc.CallOption) (*Result, error) {
-	out := new(Result)
-	err := grpc.Invoke(ctx, "/user.User/CheckUser", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *OrderHistoryPage) Reset()                    { *m = OrderHistoryPage{} }
+func (m *OrderHistoryPage) String() string            { return proto.CompactTextString(m) }
+func (*OrderHistoryPage) ProtoMessage()               {}
+func (*OrderHistoryPage) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
+
+func (m *OrderHistoryPage) GetOrders() []*Order {
+	if m != nil {
+		return m.Orders
 	}
-	return out, nil
+	return nil
 }
 
-func (c *userClient) Register(ctx context.Context, in *RegisterRequest, opts ...grpc.CallOption) (*RegisterResult, error) {
-	out := new(RegisterResult)
-	err := grpc.Invoke(ctx, "/user.User/Register", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *OrderHistoryPage) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
 	}
-	return out, nil
+	return ""
 }
 
-func (c *userClient) Modify(ctx context.Context, in *ModifyRequest, opts ...grpc.CallOption) (*ModifyResult, error) {
-	out := new(ModifyResult)
-	err := grpc.Invoke(ctx, "/user.User/Modify", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+// price is per room and night. discount is what the loyalty points
+// redeemed on the reservation took off the whole stay. score is 0 until
+// the stay is scored. created and updated are in unix seconds.
+type Order struct {
+	ReservationId string  `protobuf:"bytes,1,opt,name=reservationId" json:"reservationId,omitempty"`
+	HotelId       string  `protobuf:"bytes,2,opt,name=hotelId" json:"hotelId,omitempty"`
+	InDate        string  `protobuf:"bytes,3,opt,name=inDate" json:"inDate,omitempty"`
+	OutDate       string  `protobuf:"bytes,4,opt,name=outDate" json:"outDate,omitempty"`
+	RoomNumber    int32   `protobuf:"varint,5,opt,name=roomNumber" json:"roomNumber,omitempty"`
+	Price         float32 `protobuf:"fixed32,6,opt,name=price" json:"price,omitempty"`
+	Score         float32 `protobuf:"fixed32,7,opt,name=score" json:"score,omitempty"`
+	Created       int64   `protobuf:"varint,8,opt,name=created" json:"created,omitempty"`
+	Updated       int64   `protobuf:"varint,9,opt,name=updated" json:"updated,omitempty"`
+	Discount      float32 `protobuf:"fixed32,10,opt,name=discount" json:"discount,omitempty"`
 }
 
-func (c *userClient) Delete(ctx context.Context, in *Request, opts ...grpc.CallOption) (*Result, error) {
-	out := new(Result)
-	err := grpc.Invoke(ctx, "/user.User/Delete", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *Order) Reset()                    { *m = Order{} }
+func (m *Order) String() string            { return proto.CompactTextString(m) }
+func (*Order) ProtoMessage()               {}
+func (*Order) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
+
+func (m *Order) GetReservationId() string {
+	if m != nil {
+		return m.ReservationId
 	}
-	return out, nil
+	return ""
 }
 
-func (c *userClient) OrderHistoryUpdate(ctx context.Context, in *OrderHistoryRequest, opts ...grpc.CallOption) (*OrderHistoryResult, error) {
-	out := new(OrderHistoryResult)
-	err := grpc.Invoke(ctx, "/user.User/OrderHistoryUpdate", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *Order) GetHotelId() string {
+	if m != nil {
+		return m.HotelId
 	}
-	return out, nil
+	return ""
 }
 
-// Server API for User service
+func (m *Order) GetInDate() string {
+	if m != nil {
+		return m.InDate
+	}
+	return ""
+}
 
-type UserServer interface {
-	// CheckUser returns whether the username and password are correct
-	CheckUser(context.Context, *Request) (*Result, error)
-	Register(context.Context, *RegisterRequest) (*RegisterResult, error)
-	Modify(context.Context, *ModifyRequest) (*ModifyResult, error)
-	Delete(context.Context, *Request) (*Result, error)
-	OrderHistoryUpdate(context.Context, *OrderHistoryRequest) (*OrderHistoryResult, error)
+func (m *Order) GetOutDate() string {
+	if m != nil {
+		return m.OutDate
+	}
+	return ""
 }
 
-func RegisterUserServer(s *grpc.Server, srv UserServer) {
-	s.RegisterService(&_User_serviceDesc, srv)
+func (m *Order) GetRoomNumber() int32 {
+	if m != nil {
+		return m.RoomNumber
+	}
+	return 0
 }
 
-func _User_CheckUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Request)
-	if err := dec(in); err != nil {
-		return nil, err
+func (m *Order) GetPrice() float32 {
+	if m != nil {
+		return m.Price
 	}
-	if interceptor == nil {
-		return srv.(UserServer).CheckUser(ctx, in)
+	return 0
+}
+
+func (m *Order) GetScore() float32 {
+	if m != nil {
+		return m.Score
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/user.User/CheckUser",
+	return 0
+}
+
+func (m *Order) GetCreated() int64 {
+	if m != nil {
+		return m.Created
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(UserServer).CheckUser(ctx, req.(*Request))
+	return 0
+}
+
+func (m *Order) GetUpdated() int64 {
+	if m != nil {
+		return m.Updated
 	}
-	return interceptor(ctx, in, info, handler)
+	return 0
 }
 
-func _User_Register_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(RegisterRequest)
-	if err := dec(in); err != nil {
-		return nil, err
+func (m *Order) GetDiscount() float32 {
+	if m != nil {
+		return m.Discount
 	}
-	if interceptor == nil {
-		return srv.(UserServer).Register(ctx, in)
+	return 0
+}
+
+type ReviewsRequest struct {
+	Username string `protobuf:"bytes,1,opt,name=username" json:"username,omitempty"`
+}
+
+func (m *ReviewsRequest) Reset()                    { *m = ReviewsRequest{} }
+func (m *ReviewsRequest) String() string            { return proto.CompactTextString(m) }
+func (*ReviewsRequest) ProtoMessage()               {}
+func (*ReviewsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
+
+func (m *ReviewsRequest) GetUsername() string {
+	if m != nil {
+		return m.Username
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/user.User/Register",
+	return ""
+}
+
+type ReviewsResult struct {
+	Reviews []*Review `protobuf:"bytes,1,rep,name=reviews" json:"reviews,omitempty"`
+}
+
+func (m *ReviewsResult) Reset()                    { *m = ReviewsResult{} }
+func (m *ReviewsResult) String() string            { return proto.CompactTextString(m) }
+func (*ReviewsResult) ProtoMessage()               {}
+func (*ReviewsResult) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
+
+func (m *ReviewsResult) GetReviews() []*Review {
+	if m != nil {
+		return m.Reviews
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(UserServer).Register(ctx, req.(*RegisterRequest))
+	return nil
+}
+
+type Review struct {
+	HotelId string  `protobuf:"bytes,1,opt,name=hotelId" json:"hotelId,omitempty"`
+	InDate  string  `protobuf:"bytes,2,opt,name=inDate" json:"inDate,omitempty"`
+	OutDate string  `protobuf:"bytes,3,opt,name=outDate" json:"outDate,omitempty"`
+	Score   float32 `protobuf:"fixed32,4,opt,name=score" json:"score,omitempty"`
+}
+
+func (m *Review) Reset()                    { *m = Review{} }
+func (m *Review) String() string            { return proto.CompactTextString(m) }
+func (*Review) ProtoMessage()               {}
+func (*Review) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
+
+func (m *Review) GetHotelId() string {
+	if m != nil {
+		return m.HotelId
 	}
-	return interceptor(ctx, in, info, handler)
+	return ""
 }
 
-func _User_Modify_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ModifyRequest)
-	if err := dec(in); err != nil {
-		return nil, err
+func (m *Review) GetInDate() string {
+	if m != nil {
+		return m.InDate
 	}
-	if interceptor == nil {
-		return srv.(UserServer).Modify(ctx, in)
+	return ""
+}
+
+func (m *Review) GetOutDate() string {
+	if m != nil {
+		return m.OutDate
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/user.User/Modify",
+	return ""
+}
+
+func (m *Review) GetScore() float32 {
+	if m != nil {
+		return m.Score
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(UserServer).Modify(ctx, req.(*ModifyRequest))
+	return 0
+}
+
+// token is empty and expiresAt 0 when correct is false. expiresAt is in
+// unix seconds.
+type LoginResult struct {
+	Correct   bool   `protobuf:"varint,1,opt,name=correct" json:"correct,omitempty"`
+	Token     string `protobuf:"bytes,2,opt,name=token" json:"token,omitempty"`
+	ExpiresAt int64  `protobuf:"varint,3,opt,name=expiresAt" json:"expiresAt,omitempty"`
+}
+
+func (m *LoginResult) Reset()                    { *m = LoginResult{} }
+func (m *LoginResult) String() string            { return proto.CompactTextString(m) }
+func (*LoginResult) ProtoMessage()               {}
+func (*LoginResult) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
+
+func (m *LoginResult) GetCorrect() bool {
+	if m != nil {
+		return m.Correct
 	}
-	return interceptor(ctx, in, info, handler)
+	return false
 }
 
-func _User_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Request)
-	if err := dec(in); err != nil {
-		return nil, err
+func (m *LoginResult) GetToken() string {
+	if m != nil {
+		return m.Token
 	}
-	if interceptor == nil {
-		return srv.(UserServer).Delete(ctx, in)
+	return ""
+}
+
+func (m *LoginResult) GetExpiresAt() int64 {
+	if m != nil {
+		return m.ExpiresAt
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/user.User/Delete",
+	return 0
+}
+
+type TokenRequest struct {
+	Token string `protobuf:"bytes,1,opt,name=token" json:"token,omitempty"`
+}
+
+func (m *TokenRequest) Reset()                    { *m = TokenRequest{} }
+func (m *TokenRequest) String() string            { return proto.CompactTextString(m) }
+func (*TokenRequest) ProtoMessage()               {}
+func (*TokenRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
+
+func (m *TokenRequest) GetToken() string {
+	if m != nil {
+		return m.Token
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(UserServer).Delete(ctx, req.(*Request))
+	return ""
+}
+
+// username is only set for valid tokens.
+type TokenResult struct {
+	Valid     bool   `protobuf:"varint,1,opt,name=valid" json:"valid,omitempty"`
+	Username  string `protobuf:"bytes,2,opt,name=username" json:"username,omitempty"`
+	ExpiresAt int64  `protobuf:"varint,3,opt,name=expiresAt" json:"expiresAt,omitempty"`
+}
+
+func (m *TokenResult) Reset()                    { *m = TokenResult{} }
+func (m *TokenResult) String() string            { return proto.CompactTextString(m) }
+func (*TokenResult) ProtoMessage()               {}
+func (*TokenResult) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
+
+func (m *TokenResult) GetValid() bool {
+	if m != nil {
+		return m.Valid
 	}
-	return interceptor(ctx, in, info, handler)
+	return false
 }
 
-func _User_OrderHistoryUpdate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(OrderHistoryRequest)
-	if err := dec(in); err != nil {
-		return nil, err
+func (m *TokenResult) GetUsername() string {
+	if m != nil {
+		return m.Username
+	}
+	return ""
+}
+
+func (m *TokenResult) GetExpiresAt() int64 {
+	if m != nil {
+		return m.ExpiresAt
+	}
+	return 0
+}
+
+type UserDataRequest struct {
+	Username string `protobuf:"bytes,1,opt,name=username" json:"username,omitempty"`
+}
+
+func (m *UserDataRequest) Reset()                    { *m = UserDataRequest{} }
+func (m *UserDataRequest) String() string            { return proto.CompactTextString(m) }
+func (*UserDataRequest) ProtoMessage()               {}
+func (*UserDataRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
+
+func (m *UserDataRequest) GetUsername() string {
+	if m != nil {
+		return m.Username
+	}
+	return ""
+}
+
+// archive is a JSON document of the user's profile, orders, reviews,
+// profile changes, loyalty points, wishlists and reservations.
+type UserArchive struct {
+	Archive []byte `protobuf:"bytes,1,opt,name=archive,proto3" json:"archive,omitempty"`
+}
+
+func (m *UserArchive) Reset()                    { *m = UserArchive{} }
+func (m *UserArchive) String() string            { return proto.CompactTextString(m) }
+func (*UserArchive) ProtoMessage()               {}
+func (*UserArchive) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
+
+func (m *UserArchive) GetArchive() []byte {
+	if m != nil {
+		return m.Archive
+	}
+	return nil
+}
+
+// found is false if there was no user of the name; the other data of the
+// name is erased anyway. Reservations are kept for the rooms they take,
+// under a pseudonym.
+type EraseReport struct {
+	Found                  bool  `protobuf:"varint,1,opt,name=found" json:"found,omitempty"`
+	Orders                 int32 `protobuf:"varint,2,opt,name=orders" json:"orders,omitempty"`
+	Reviews                int32 `protobuf:"varint,3,opt,name=reviews" json:"reviews,omitempty"`
+	AuditRecords           int32 `protobuf:"varint,4,opt,name=auditRecords" json:"auditRecords,omitempty"`
+	ReservationsAnonymized int32 `protobuf:"varint,5,opt,name=reservationsAnonymized" json:"reservationsAnonymized,omitempty"`
+	SessionsRevoked        bool  `protobuf:"varint,6,opt,name=sessionsRevoked" json:"sessionsRevoked,omitempty"`
+	LoyaltyEntries         int32 `protobuf:"varint,7,opt,name=loyaltyEntries" json:"loyaltyEntries,omitempty"`
+	Wishlists              int32 `protobuf:"varint,8,opt,name=wishlists" json:"wishlists,omitempty"`
+	Alerts                 int32 `protobuf:"varint,9,opt,name=alerts" json:"alerts,omitempty"`
+}
+
+func (m *EraseReport) Reset()                    { *m = EraseReport{} }
+func (m *EraseReport) String() string            { return proto.CompactTextString(m) }
+func (*EraseReport) ProtoMessage()               {}
+func (*EraseReport) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{19} }
+
+func (m *EraseReport) GetFound() bool {
+	if m != nil {
+		return m.Found
+	}
+	return false
+}
+
+func (m *EraseReport) GetOrders() int32 {
+	if m != nil {
+		return m.Orders
+	}
+	return 0
+}
+
+func (m *EraseReport) GetReviews() int32 {
+	if m != nil {
+		return m.Reviews
+	}
+	return 0
+}
+
+func (m *EraseReport) GetAuditRecords() int32 {
+	if m != nil {
+		return m.AuditRecords
+	}
+	return 0
+}
+
+func (m *EraseReport) GetReservationsAnonymized() int32 {
+	if m != nil {
+		return m.ReservationsAnonymized
+	}
+	return 0
+}
+
+func (m *EraseReport) GetSessionsRevoked() bool {
+	if m != nil {
+		return m.SessionsRevoked
+	}
+	return false
+}
+
+func (m *EraseReport) GetLoyaltyEntries() int32 {
+	if m != nil {
+		return m.LoyaltyEntries
+	}
+	return 0
+}
+
+func (m *EraseReport) GetWishlists() int32 {
+	if m != nil {
+		return m.Wishlists
+	}
+	return 0
+}
+
+func (m *EraseReport) GetAlerts() int32 {
+	if m != nil {
+		return m.Alerts
+	}
+	return 0
+}
+
+type LoyaltyRequest struct {
+	Username string `protobuf:"bytes,1,opt,name=username" json:"username,omitempty"`
+}
+
+func (m *LoyaltyRequest) Reset()                    { *m = LoyaltyRequest{} }
+func (m *LoyaltyRequest) String() string            { return proto.CompactTextString(m) }
+func (*LoyaltyRequest) ProtoMessage()               {}
+func (*LoyaltyRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{20} }
+
+func (m *LoyaltyRequest) GetUsername() string {
+	if m != nil {
+		return m.Username
+	}
+	return ""
+}
+
+// balance are the points that may be redeemed; pending are the points of
+// stays not completed yet. The tier follows from the room nights stayed
+// over the last year; nextTier is empty at the top tier.
+type LoyaltyAccount struct {
+	Username         string `protobuf:"bytes,1,opt,name=username" json:"username,omitempty"`
+	Balance          int64  `protobuf:"varint,2,opt,name=balance" json:"balance,omitempty"`
+	Pending          int64  `protobuf:"varint,3,opt,name=pending" json:"pending,omitempty"`
+	Tier             string `protobuf:"bytes,4,opt,name=tier" json:"tier,omitempty"`
+	TierNights       int32  `protobuf:"varint,5,opt,name=tierNights" json:"tierNights,omitempty"`
+	NextTier         string `protobuf:"bytes,6,opt,name=nextTier" json:"nextTier,omitempty"`
+	NightsToNextTier int32  `protobuf:"varint,7,opt,name=nightsToNextTier" json:"nightsToNextTier,omitempty"`
+}
+
+func (m *LoyaltyAccount) Reset()                    { *m = LoyaltyAccount{} }
+func (m *LoyaltyAccount) String() string            { return proto.CompactTextString(m) }
+func (*LoyaltyAccount) ProtoMessage()               {}
+func (*LoyaltyAccount) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{21} }
+
+func (m *LoyaltyAccount) GetUsername() string {
+	if m != nil {
+		return m.Username
+	}
+	return ""
+}
+
+func (m *LoyaltyAccount) GetBalance() int64 {
+	if m != nil {
+		return m.Balance
+	}
+	return 0
+}
+
+func (m *LoyaltyAccount) GetPending() int64 {
+	if m != nil {
+		return m.Pending
+	}
+	return 0
+}
+
+func (m *LoyaltyAccount) GetTier() string {
+	if m != nil {
+		return m.Tier
+	}
+	return ""
+}
+
+func (m *LoyaltyAccount) GetTierNights() int32 {
+	if m != nil {
+		return m.TierNights
+	}
+	return 0
+}
+
+func (m *LoyaltyAccount) GetNextTier() string {
+	if m != nil {
+		return m.NextTier
+	}
+	return ""
+}
+
+func (m *LoyaltyAccount) GetNightsToNextTier() int32 {
+	if m != nil {
+		return m.NightsToNextTier
+	}
+	return 0
+}
+
+type LoyaltyHistoryQuery struct {
+	Username  string `protobuf:"bytes,1,opt,name=username" json:"username,omitempty"`
+	PageSize  int32  `protobuf:"varint,2,opt,name=pageSize" json:"pageSize,omitempty"`
+	PageToken string `protobuf:"bytes,3,opt,name=pageToken" json:"pageToken,omitempty"`
+}
+
+func (m *LoyaltyHistoryQuery) Reset()                    { *m = LoyaltyHistoryQuery{} }
+func (m *LoyaltyHistoryQuery) String() string            { return proto.CompactTextString(m) }
+func (*LoyaltyHistoryQuery) ProtoMessage()               {}
+func (*LoyaltyHistoryQuery) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{22} }
+
+func (m *LoyaltyHistoryQuery) GetUsername() string {
+	if m != nil {
+		return m.Username
+	}
+	return ""
+}
+
+func (m *LoyaltyHistoryQuery) GetPageSize() int32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+func (m *LoyaltyHistoryQuery) GetPageToken() string {
+	if m != nil {
+		return m.PageToken
+	}
+	return ""
+}
+
+// nextPageToken is empty on the last page.
+type LoyaltyHistoryPage struct {
+	Entries       []*LedgerEntry `protobuf:"bytes,1,rep,name=entries" json:"entries,omitempty"`
+	NextPageToken string         `protobuf:"bytes,2,opt,name=nextPageToken" json:"nextPageToken,omitempty"`
+}
+
+func (m *LoyaltyHistoryPage) Reset()                    { *m = LoyaltyHistoryPage{} }
+func (m *LoyaltyHistoryPage) String() string            { return proto.CompactTextString(m) }
+func (*LoyaltyHistoryPage) ProtoMessage()               {}
+func (*LoyaltyHistoryPage) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{23} }
+
+func (m *LoyaltyHistoryPage) GetEntries() []*LedgerEntry {
+	if m != nil {
+		return m.Entries
+	}
+	return nil
+}
+
+func (m *LoyaltyHistoryPage) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
+	}
+	return ""
+}
+
+// kind is earn, redeem, reverse (of earned points) or refund (of redeemed
+// points). points are negative for redeem and reverse, and nights are the
+// room nights earned or reversed. availableAt is when
+// the points count towards the balance; created and availableAt are in
+// unix seconds.
+type LedgerEntry struct {
+	Kind          string `protobuf:"bytes,1,opt,name=kind" json:"kind,omitempty"`
+	Points        int64  `protobuf:"varint,2,opt,name=points" json:"points,omitempty"`
+	ReservationId string `protobuf:"bytes,3,opt,name=reservationId" json:"reservationId,omitempty"`
+	HotelId       string `protobuf:"bytes,4,opt,name=hotelId" json:"hotelId,omitempty"`
+	Nights        int32  `protobuf:"varint,5,opt,name=nights" json:"nights,omitempty"`
+	Created       int64  `protobuf:"varint,6,opt,name=created" json:"created,omitempty"`
+	AvailableAt   int64  `protobuf:"varint,7,opt,name=availableAt" json:"availableAt,omitempty"`
+}
+
+func (m *LedgerEntry) Reset()                    { *m = LedgerEntry{} }
+func (m *LedgerEntry) String() string            { return proto.CompactTextString(m) }
+func (*LedgerEntry) ProtoMessage()               {}
+func (*LedgerEntry) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{24} }
+
+func (m *LedgerEntry) GetKind() string {
+	if m != nil {
+		return m.Kind
+	}
+	return ""
+}
+
+func (m *LedgerEntry) GetPoints() int64 {
+	if m != nil {
+		return m.Points
+	}
+	return 0
+}
+
+func (m *LedgerEntry) GetReservationId() string {
+	if m != nil {
+		return m.ReservationId
+	}
+	return ""
+}
+
+func (m *LedgerEntry) GetHotelId() string {
+	if m != nil {
+		return m.HotelId
+	}
+	return ""
+}
+
+func (m *LedgerEntry) GetNights() int32 {
+	if m != nil {
+		return m.Nights
+	}
+	return 0
+}
+
+func (m *LedgerEntry) GetCreated() int64 {
+	if m != nil {
+		return m.Created
+	}
+	return 0
+}
+
+func (m *LedgerEntry) GetAvailableAt() int64 {
+	if m != nil {
+		return m.AvailableAt
+	}
+	return 0
+}
+
+// maxDiscount is what the reservation costs, in the currency of the hotel
+// prices; points worth more than that are not redeemed.
+type RedeemRequest struct {
+	Username      string  `protobuf:"bytes,1,opt,name=username" json:"username,omitempty"`
+	Points        int64   `protobuf:"varint,2,opt,name=points" json:"points,omitempty"`
+	ReservationId string  `protobuf:"bytes,3,opt,name=reservationId" json:"reservationId,omitempty"`
+	MaxDiscount   float32 `protobuf:"fixed32,4,opt,name=maxDiscount" json:"maxDiscount,omitempty"`
+}
+
+func (m *RedeemRequest) Reset()                    { *m = RedeemRequest{} }
+func (m *RedeemRequest) String() string            { return proto.CompactTextString(m) }
+func (*RedeemRequest) ProtoMessage()               {}
+func (*RedeemRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{25} }
+
+func (m *RedeemRequest) GetUsername() string {
+	if m != nil {
+		return m.Username
+	}
+	return ""
+}
+
+func (m *RedeemRequest) GetPoints() int64 {
+	if m != nil {
+		return m.Points
+	}
+	return 0
+}
+
+func (m *RedeemRequest) GetReservationId() string {
+	if m != nil {
+		return m.ReservationId
+	}
+	return ""
+}
+
+func (m *RedeemRequest) GetMaxDiscount() float32 {
+	if m != nil {
+		return m.MaxDiscount
+	}
+	return 0
+}
+
+// discount is in the currency of the hotel prices.
+type RedeemResult struct {
+	Points   int64   `protobuf:"varint,1,opt,name=points" json:"points,omitempty"`
+	Discount float32 `protobuf:"fixed32,2,opt,name=discount" json:"discount,omitempty"`
+	Balance  int64   `protobuf:"varint,3,opt,name=balance" json:"balance,omitempty"`
+}
+
+func (m *RedeemResult) Reset()                    { *m = RedeemResult{} }
+func (m *RedeemResult) String() string            { return proto.CompactTextString(m) }
+func (*RedeemResult) ProtoMessage()               {}
+func (*RedeemResult) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{26} }
+
+func (m *RedeemResult) GetPoints() int64 {
+	if m != nil {
+		return m.Points
+	}
+	return 0
+}
+
+func (m *RedeemResult) GetDiscount() float32 {
+	if m != nil {
+		return m.Discount
+	}
+	return 0
+}
+
+func (m *RedeemResult) GetBalance() int64 {
+	if m != nil {
+		return m.Balance
+	}
+	return 0
+}
+
+// nights is the number of nights of the reservation still booked: all of
+// them when it is booked, fewer once some are cancelled.
+type ReservationChange struct {
+	Event         ReservationChange_Event `protobuf:"varint,1,opt,name=event,enum=user.ReservationChange_Event" json:"event,omitempty"`
+	Username      string                  `protobuf:"bytes,2,opt,name=username" json:"username,omitempty"`
+	ReservationId string                  `protobuf:"bytes,3,opt,name=reservationId" json:"reservationId,omitempty"`
+	HotelId       string                  `protobuf:"bytes,4,opt,name=hotelId" json:"hotelId,omitempty"`
+	InDate        string                  `protobuf:"bytes,5,opt,name=inDate" json:"inDate,omitempty"`
+	OutDate       string                  `protobuf:"bytes,6,opt,name=outDate" json:"outDate,omitempty"`
+	RoomNumber    int32                   `protobuf:"varint,7,opt,name=roomNumber" json:"roomNumber,omitempty"`
+	Nights        int32                   `protobuf:"varint,8,opt,name=nights" json:"nights,omitempty"`
+}
+
+func (m *ReservationChange) Reset()                    { *m = ReservationChange{} }
+func (m *ReservationChange) String() string            { return proto.CompactTextString(m) }
+func (*ReservationChange) ProtoMessage()               {}
+func (*ReservationChange) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{27} }
+
+func (m *ReservationChange) GetEvent() ReservationChange_Event {
+	if m != nil {
+		return m.Event
+	}
+	return ReservationChange_BOOKED
+}
+
+func (m *ReservationChange) GetUsername() string {
+	if m != nil {
+		return m.Username
+	}
+	return ""
+}
+
+func (m *ReservationChange) GetReservationId() string {
+	if m != nil {
+		return m.ReservationId
+	}
+	return ""
+}
+
+func (m *ReservationChange) GetHotelId() string {
+	if m != nil {
+		return m.HotelId
+	}
+	return ""
+}
+
+func (m *ReservationChange) GetInDate() string {
+	if m != nil {
+		return m.InDate
+	}
+	return ""
+}
+
+func (m *ReservationChange) GetOutDate() string {
+	if m != nil {
+		return m.OutDate
+	}
+	return ""
+}
+
+func (m *ReservationChange) GetRoomNumber() int32 {
+	if m != nil {
+		return m.RoomNumber
+	}
+	return 0
+}
+
+func (m *ReservationChange) GetNights() int32 {
+	if m != nil {
+		return m.Nights
+	}
+	return 0
+}
+
+// points is the change of the user's points.
+type ReservationChangeResult struct {
+	Points int64 `protobuf:"varint,1,opt,name=points" json:"points,omitempty"`
+}
+
+func (m *ReservationChangeResult) Reset()                    { *m = ReservationChangeResult{} }
+func (m *ReservationChangeResult) String() string            { return proto.CompactTextString(m) }
+func (*ReservationChangeResult) ProtoMessage()               {}
+func (*ReservationChangeResult) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{28} }
+
+func (m *ReservationChangeResult) GetPoints() int64 {
+	if m != nil {
+		return m.Points
+	}
+	return 0
+}
+
+type CreateWishlistRequest struct {
+	Username string `protobuf:"bytes,1,opt,name=username" json:"username,omitempty"`
+	Name     string `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
+}
+
+func (m *CreateWishlistRequest) Reset()                    { *m = CreateWishlistRequest{} }
+func (m *CreateWishlistRequest) String() string            { return proto.CompactTextString(m) }
+func (*CreateWishlistRequest) ProtoMessage()               {}
+func (*CreateWishlistRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{29} }
+
+func (m *CreateWishlistRequest) GetUsername() string {
+	if m != nil {
+		return m.Username
+	}
+	return ""
+}
+
+func (m *CreateWishlistRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type WishlistsRequest struct {
+	Username string `protobuf:"bytes,1,opt,name=username" json:"username,omitempty"`
+}
+
+func (m *WishlistsRequest) Reset()                    { *m = WishlistsRequest{} }
+func (m *WishlistsRequest) String() string            { return proto.CompactTextString(m) }
+func (*WishlistsRequest) ProtoMessage()               {}
+func (*WishlistsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{30} }
+
+func (m *WishlistsRequest) GetUsername() string {
+	if m != nil {
+		return m.Username
+	}
+	return ""
+}
+
+type WishlistsResult struct {
+	Wishlists []*Wishlist `protobuf:"bytes,1,rep,name=wishlists" json:"wishlists,omitempty"`
+}
+
+func (m *WishlistsResult) Reset()                    { *m = WishlistsResult{} }
+func (m *WishlistsResult) String() string            { return proto.CompactTextString(m) }
+func (*WishlistsResult) ProtoMessage()               {}
+func (*WishlistsResult) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{31} }
+
+func (m *WishlistsResult) GetWishlists() []*Wishlist {
+	if m != nil {
+		return m.Wishlists
+	}
+	return nil
+}
+
+type WishlistRequest struct {
+	Username   string `protobuf:"bytes,1,opt,name=username" json:"username,omitempty"`
+	WishlistId string `protobuf:"bytes,2,opt,name=wishlistId" json:"wishlistId,omitempty"`
+}
+
+func (m *WishlistRequest) Reset()                    { *m = WishlistRequest{} }
+func (m *WishlistRequest) String() string            { return proto.CompactTextString(m) }
+func (*WishlistRequest) ProtoMessage()               {}
+func (*WishlistRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{32} }
+
+func (m *WishlistRequest) GetUsername() string {
+	if m != nil {
+		return m.Username
+	}
+	return ""
+}
+
+func (m *WishlistRequest) GetWishlistId() string {
+	if m != nil {
+		return m.WishlistId
+	}
+	return ""
+}
+
+type UpdateWishlistRequest struct {
+	Username       string   `protobuf:"bytes,1,opt,name=username" json:"username,omitempty"`
+	WishlistId     string   `protobuf:"bytes,2,opt,name=wishlistId" json:"wishlistId,omitempty"`
+	AddHotelIds    []string `protobuf:"bytes,3,rep,name=addHotelIds" json:"addHotelIds,omitempty"`
+	RemoveHotelIds []string `protobuf:"bytes,4,rep,name=removeHotelIds" json:"removeHotelIds,omitempty"`
+}
+
+func (m *UpdateWishlistRequest) Reset()                    { *m = UpdateWishlistRequest{} }
+func (m *UpdateWishlistRequest) String() string            { return proto.CompactTextString(m) }
+func (*UpdateWishlistRequest) ProtoMessage()               {}
+func (*UpdateWishlistRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{33} }
+
+func (m *UpdateWishlistRequest) GetUsername() string {
+	if m != nil {
+		return m.Username
+	}
+	return ""
+}
+
+func (m *UpdateWishlistRequest) GetWishlistId() string {
+	if m != nil {
+		return m.WishlistId
+	}
+	return ""
+}
+
+func (m *UpdateWishlistRequest) GetAddHotelIds() []string {
+	if m != nil {
+		return m.AddHotelIds
+	}
+	return nil
+}
+
+func (m *UpdateWishlistRequest) GetRemoveHotelIds() []string {
+	if m != nil {
+		return m.RemoveHotelIds
+	}
+	return nil
+}
+
+type ShareWishlistRequest struct {
+	Username   string `protobuf:"bytes,1,opt,name=username" json:"username,omitempty"`
+	WishlistId string `protobuf:"bytes,2,opt,name=wishlistId" json:"wishlistId,omitempty"`
+	Revoke     bool   `protobuf:"varint,3,opt,name=revoke" json:"revoke,omitempty"`
+}
+
+func (m *ShareWishlistRequest) Reset()                    { *m = ShareWishlistRequest{} }
+func (m *ShareWishlistRequest) String() string            { return proto.CompactTextString(m) }
+func (*ShareWishlistRequest) ProtoMessage()               {}
+func (*ShareWishlistRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{34} }
+
+func (m *ShareWishlistRequest) GetUsername() string {
+	if m != nil {
+		return m.Username
+	}
+	return ""
+}
+
+func (m *ShareWishlistRequest) GetWishlistId() string {
+	if m != nil {
+		return m.WishlistId
+	}
+	return ""
+}
+
+func (m *ShareWishlistRequest) GetRevoke() bool {
+	if m != nil {
+		return m.Revoke
+	}
+	return false
+}
+
+type SharedWishlistRequest struct {
+	ShareToken string `protobuf:"bytes,1,opt,name=shareToken" json:"shareToken,omitempty"`
+}
+
+func (m *SharedWishlistRequest) Reset()                    { *m = SharedWishlistRequest{} }
+func (m *SharedWishlistRequest) String() string            { return proto.CompactTextString(m) }
+func (*SharedWishlistRequest) ProtoMessage()               {}
+func (*SharedWishlistRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{35} }
+
+func (m *SharedWishlistRequest) GetShareToken() string {
+	if m != nil {
+		return m.ShareToken
+	}
+	return ""
+}
+
+// hotelIds are in the order they were added. shareToken is empty if the
+// wishlist is not shared, and in shared wishlists. created and updated are
+// in unix seconds.
+type Wishlist struct {
+	Id         string   `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	Name       string   `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
+	HotelIds   []string `protobuf:"bytes,3,rep,name=hotelIds" json:"hotelIds,omitempty"`
+	ShareToken string   `protobuf:"bytes,4,opt,name=shareToken" json:"shareToken,omitempty"`
+	Created    int64    `protobuf:"varint,5,opt,name=created" json:"created,omitempty"`
+	Updated    int64    `protobuf:"varint,6,opt,name=updated" json:"updated,omitempty"`
+}
+
+func (m *Wishlist) Reset()                    { *m = Wishlist{} }
+func (m *Wishlist) String() string            { return proto.CompactTextString(m) }
+func (*Wishlist) ProtoMessage()               {}
+func (*Wishlist) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{36} }
+
+func (m *Wishlist) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Wishlist) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Wishlist) GetHotelIds() []string {
+	if m != nil {
+		return m.HotelIds
+	}
+	return nil
+}
+
+func (m *Wishlist) GetShareToken() string {
+	if m != nil {
+		return m.ShareToken
+	}
+	return ""
+}
+
+func (m *Wishlist) GetCreated() int64 {
+	if m != nil {
+		return m.Created
+	}
+	return 0
+}
+
+func (m *Wishlist) GetUpdated() int64 {
+	if m != nil {
+		return m.Updated
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterType((*Request)(nil), "user.Request")
+	proto.RegisterType((*Result)(nil), "user.Result")
+	proto.RegisterType((*RegisterRequest)(nil), "user.RegisterRequest")
+	proto.RegisterType((*RegisterResult)(nil), "user.RegisterResult")
+	proto.RegisterType((*UpdateUserRequest)(nil), "user.UpdateUserRequest")
+	proto.RegisterType((*UpdateUserResult)(nil), "user.UpdateUserResult")
+	proto.RegisterType((*OrderHistoryRequest)(nil), "user.OrderHistoryRequest")
+	proto.RegisterType((*OrderHistoryResult)(nil), "user.OrderHistoryResult")
+	proto.RegisterType((*OrderHistoryQuery)(nil), "user.OrderHistoryQuery")
+	proto.RegisterType((*OrderHistoryPage)(nil), "user.OrderHistoryPage")
+	proto.RegisterType((*Order)(nil), "user.Order")
+	proto.RegisterType((*ReviewsRequest)(nil), "user.ReviewsRequest")
+	proto.RegisterType((*ReviewsResult)(nil), "user.ReviewsResult")
+	proto.RegisterType((*Review)(nil), "user.Review")
+	proto.RegisterType((*LoginResult)(nil), "user.LoginResult")
+	proto.RegisterType((*TokenRequest)(nil), "user.TokenRequest")
+	proto.RegisterType((*TokenResult)(nil), "user.TokenResult")
+	proto.RegisterType((*UserDataRequest)(nil), "user.UserDataRequest")
+	proto.RegisterType((*UserArchive)(nil), "user.UserArchive")
+	proto.RegisterType((*EraseReport)(nil), "user.EraseReport")
+	proto.RegisterType((*LoyaltyRequest)(nil), "user.LoyaltyRequest")
+	proto.RegisterType((*LoyaltyAccount)(nil), "user.LoyaltyAccount")
+	proto.RegisterType((*LoyaltyHistoryQuery)(nil), "user.LoyaltyHistoryQuery")
+	proto.RegisterType((*LoyaltyHistoryPage)(nil), "user.LoyaltyHistoryPage")
+	proto.RegisterType((*LedgerEntry)(nil), "user.LedgerEntry")
+	proto.RegisterType((*RedeemRequest)(nil), "user.RedeemRequest")
+	proto.RegisterType((*RedeemResult)(nil), "user.RedeemResult")
+	proto.RegisterType((*ReservationChange)(nil), "user.ReservationChange")
+	proto.RegisterType((*ReservationChangeResult)(nil), "user.ReservationChangeResult")
+	proto.RegisterType((*CreateWishlistRequest)(nil), "user.CreateWishlistRequest")
+	proto.RegisterType((*WishlistsRequest)(nil), "user.WishlistsRequest")
+	proto.RegisterType((*WishlistsResult)(nil), "user.WishlistsResult")
+	proto.RegisterType((*WishlistRequest)(nil), "user.WishlistRequest")
+	proto.RegisterType((*UpdateWishlistRequest)(nil), "user.UpdateWishlistRequest")
+	proto.RegisterType((*ShareWishlistRequest)(nil), "user.ShareWishlistRequest")
+	proto.RegisterType((*SharedWishlistRequest)(nil), "user.SharedWishlistRequest")
+	proto.RegisterType((*Wishlist)(nil), "user.Wishlist")
+	proto.RegisterEnum("user.ReservationChange_Event", ReservationChange_Event_name, ReservationChange_Event_value)
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// Client API for User service
+
+type UserClient interface {
+	// CheckUser returns whether the username and password are correct. After
+	// too many failures it returns ResourceExhausted until the account or
+	// client may try again, or FailedPrecondition while it is locked.
+	CheckUser(ctx context.Context, in *Request, opts ...grpc.CallOption) (*Result, error)
+	Register(ctx context.Context, in *RegisterRequest, opts ...grpc.CallOption) (*RegisterResult, error)
+	// UpdateUser sets the fields named by the update mask, leaving the rest
+	UpdateUser(ctx context.Context, in *UpdateUserRequest, opts ...grpc.CallOption) (*UpdateUserResult, error)
+	// Delete erases a user like EraseUser
+	Delete(ctx context.Context, in *Request, opts ...grpc.CallOption) (*Result, error)
+	// ExportUser returns everything kept about a user as one JSON document
+	ExportUser(ctx context.Context, in *UserDataRequest, opts ...grpc.CallOption) (*UserArchive, error)
+	// EraseUser deletes everything kept about a user, or anonymizes what
+	// other data depends on, and reports what it removed
+	EraseUser(ctx context.Context, in *UserDataRequest, opts ...grpc.CallOption) (*EraseReport, error)
+	// OrderHistoryUpdate records an order, or the score of a stay
+	OrderHistoryUpdate(ctx context.Context, in *OrderHistoryRequest, opts ...grpc.CallOption) (*OrderHistoryResult, error)
+	// GetOrderHistory returns a user's orders, newest first, a page at a time
+	GetOrderHistory(ctx context.Context, in *OrderHistoryQuery, opts ...grpc.CallOption) (*OrderHistoryPage, error)
+	// GetReviews returns the hotel scores a user gave
+	GetReviews(ctx context.Context, in *ReviewsRequest, opts ...grpc.CallOption) (*ReviewsResult, error)
+	// Login checks the username and password like CheckUser and issues a
+	// session token
+	Login(ctx context.Context, in *Request, opts ...grpc.CallOption) (*LoginResult, error)
+	// VerifyToken returns the user a session token was issued to
+	VerifyToken(ctx context.Context, in *TokenRequest, opts ...grpc.CallOption) (*TokenResult, error)
+	// RefreshToken replaces a session token with one expiring later
+	RefreshToken(ctx context.Context, in *TokenRequest, opts ...grpc.CallOption) (*LoginResult, error)
+	// Logout revokes a session token
+	Logout(ctx context.Context, in *TokenRequest, opts ...grpc.CallOption) (*Result, error)
+	// GetLoyalty returns a user's points balance and tier
+	GetLoyalty(ctx context.Context, in *LoyaltyRequest, opts ...grpc.CallOption) (*LoyaltyAccount, error)
+	// GetLoyaltyHistory returns a user's points ledger, newest first, a page
+	// at a time
+	GetLoyaltyHistory(ctx context.Context, in *LoyaltyHistoryQuery, opts ...grpc.CallOption) (*LoyaltyHistoryPage, error)
+	// RedeemPoints spends points as a discount on a reservation, at most as
+	// many as maxDiscount is worth. It returns FailedPrecondition if the
+	// balance is too low.
+	RedeemPoints(ctx context.Context, in *RedeemRequest, opts ...grpc.CallOption) (*RedeemResult, error)
+	// ReservationChanged earns the points of a booked reservation, or
+	// reverses them and refunds the redeemed points of a cancelled one
+	ReservationChanged(ctx context.Context, in *ReservationChange, opts ...grpc.CallOption) (*ReservationChangeResult, error)
+	// CreateWishlist creates a named list of saved hotels
+	CreateWishlist(ctx context.Context, in *CreateWishlistRequest, opts ...grpc.CallOption) (*Wishlist, error)
+	// GetWishlists returns a user's wishlists
+	GetWishlists(ctx context.Context, in *WishlistsRequest, opts ...grpc.CallOption) (*WishlistsResult, error)
+	// GetWishlist returns one of a user's wishlists
+	GetWishlist(ctx context.Context, in *WishlistRequest, opts ...grpc.CallOption) (*Wishlist, error)
+	// UpdateWishlist adds hotels to and removes hotels from a wishlist
+	UpdateWishlist(ctx context.Context, in *UpdateWishlistRequest, opts ...grpc.CallOption) (*Wishlist, error)
+	// DeleteWishlist deletes a wishlist
+	DeleteWishlist(ctx context.Context, in *WishlistRequest, opts ...grpc.CallOption) (*Result, error)
+	// ShareWishlist sets a new share token on a wishlist, or revokes it
+	ShareWishlist(ctx context.Context, in *ShareWishlistRequest, opts ...grpc.CallOption) (*Wishlist, error)
+	// GetSharedWishlist returns the wishlist a share token was set on
+	GetSharedWishlist(ctx context.Context, in *SharedWishlistRequest, opts ...grpc.CallOption) (*Wishlist, error)
+}
+
+type userClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewUserClient(cc *grpc.ClientConn) UserClient {
+	return &userClient{cc}
+}
+
+func (c *userClient) CheckUser(ctx context.Context, in *Request, opts ...grpc.CallOption) (*Result, error) {
+	out := new(Result)
+	err := grpc.Invoke(ctx, "/user.User/CheckUser", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userClient) Register(ctx context.Context, in *RegisterRequest, opts ...grpc.CallOption) (*RegisterResult, error) {
+	out := new(RegisterResult)
+	err := grpc.Invoke(ctx, "/user.User/Register", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userClient) UpdateUser(ctx context.Context, in *UpdateUserRequest, opts ...grpc.CallOption) (*UpdateUserResult, error) {
+	out := new(UpdateUserResult)
+	err := grpc.Invoke(ctx, "/user.User/UpdateUser", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userClient) Delete(ctx context.Context, in *Request, opts ...grpc.CallOption) (*Result, error) {
+	out := new(Result)
+	err := grpc.Invoke(ctx, "/user.User/Delete", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userClient) ExportUser(ctx context.Context, in *UserDataRequest, opts ...grpc.CallOption) (*UserArchive, error) {
+	out := new(UserArchive)
+	err := grpc.Invoke(ctx, "/user.User/ExportUser", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userClient) EraseUser(ctx context.Context, in *UserDataRequest, opts ...grpc.CallOption) (*EraseReport, error) {
+	out := new(EraseReport)
+	err := grpc.Invoke(ctx, "/user.User/EraseUser", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userClient) OrderHistoryUpdate(ctx context.Context, in *OrderHistoryRequest, opts ...grpc.CallOption) (*OrderHistoryResult, error) {
+	out := new(OrderHistoryResult)
+	err := grpc.Invoke(ctx, "/user.User/OrderHistoryUpdate", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userClient) GetOrderHistory(ctx context.Context, in *OrderHistoryQuery, opts ...grpc.CallOption) (*OrderHistoryPage, error) {
+	out := new(OrderHistoryPage)
+	err := grpc.Invoke(ctx, "/user.User/GetOrderHistory", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userClient) GetReviews(ctx context.Context, in *ReviewsRequest, opts ...grpc.CallOption) (*ReviewsResult, error) {
+	out := new(ReviewsResult)
+	err := grpc.Invoke(ctx, "/user.User/GetReviews", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userClient) Login(ctx context.Context, in *Request, opts ...grpc.CallOption) (*LoginResult, error) {
+	out := new(LoginResult)
+	err := grpc.Invoke(ctx, "/user.User/Login", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userClient) VerifyToken(ctx context.Context, in *TokenRequest, opts ...grpc.CallOption) (*TokenResult, error) {
+	out := new(TokenResult)
+	err := grpc.Invoke(ctx, "/user.User/VerifyToken", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userClient) RefreshToken(ctx context.Context, in *TokenRequest, opts ...grpc.CallOption) (*LoginResult, error) {
+	out := new(LoginResult)
+	err := grpc.Invoke(ctx, "/user.User/RefreshToken", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userClient) Logout(ctx context.Context, in *TokenRequest, opts ...grpc.CallOption) (*Result, error) {
+	out := new(Result)
+	err := grpc.Invoke(ctx, "/user.User/Logout", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userClient) GetLoyalty(ctx context.Context, in *LoyaltyRequest, opts ...grpc.CallOption) (*LoyaltyAccount, error) {
+	out := new(LoyaltyAccount)
+	err := grpc.Invoke(ctx, "/user.User/GetLoyalty", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userClient) GetLoyaltyHistory(ctx context.Context, in *LoyaltyHistoryQuery, opts ...grpc.CallOption) (*LoyaltyHistoryPage, error) {
+	out := new(LoyaltyHistoryPage)
+	err := grpc.Invoke(ctx, "/user.User/GetLoyaltyHistory", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userClient) RedeemPoints(ctx context.Context, in *RedeemRequest, opts ...grpc.CallOption) (*RedeemResult, error) {
+	out := new(RedeemResult)
+	err := grpc.Invoke(ctx, "/user.User/RedeemPoints", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userClient) ReservationChanged(ctx context.Context, in *ReservationChange, opts ...grpc.CallOption) (*ReservationChangeResult, error) {
+	out := new(ReservationChangeResult)
+	err := grpc.Invoke(ctx, "/user.User/ReservationChanged", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userClient) CreateWishlist(ctx context.Context, in *CreateWishlistRequest, opts ...grpc.CallOption) (*Wishlist, error) {
+	out := new(Wishlist)
+	err := grpc.Invoke(ctx, "/user.User/CreateWishlist", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userClient) GetWishlists(ctx context.Context, in *WishlistsRequest, opts ...grpc.CallOption) (*WishlistsResult, error) {
+	out := new(WishlistsResult)
+	err := grpc.Invoke(ctx, "/user.User/GetWishlists", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userClient) GetWishlist(ctx context.Context, in *WishlistRequest, opts ...grpc.CallOption) (*Wishlist, error) {
+	out := new(Wishlist)
+	err := grpc.Invoke(ctx, "/user.User/GetWishlist", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userClient) UpdateWishlist(ctx context.Context, in *UpdateWishlistRequest, opts ...grpc.CallOption) (*Wishlist, error) {
+	out := new(Wishlist)
+	err := grpc.Invoke(ctx, "/user.User/UpdateWishlist", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userClient) DeleteWishlist(ctx context.Context, in *WishlistRequest, opts ...grpc.CallOption) (*Result, error) {
+	out := new(Result)
+	err := grpc.Invoke(ctx, "/user.User/DeleteWishlist", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userClient) ShareWishlist(ctx context.Context, in *ShareWishlistRequest, opts ...grpc.CallOption) (*Wishlist, error) {
+	out := new(Wishlist)
+	err := grpc.Invoke(ctx, "/user.User/ShareWishlist", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userClient) GetSharedWishlist(ctx context.Context, in *SharedWishlistRequest, opts ...grpc.CallOption) (*Wishlist, error) {
+	out := new(Wishlist)
+	err := grpc.Invoke(ctx, "/user.User/GetSharedWishlist", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for User service
+
+type UserServer interface {
+	// CheckUser returns whether the username and password are correct. After
+	// too many failures it returns ResourceExhausted until the account or
+	// client may try again, or FailedPrecondition while it is locked.
+	CheckUser(context.Context, *Request) (*Result, error)
+	Register(context.Context, *RegisterRequest) (*RegisterResult, error)
+	// UpdateUser sets the fields named by the update mask, leaving the rest
+	UpdateUser(context.Context, *UpdateUserRequest) (*UpdateUserResult, error)
+	// Delete erases a user like EraseUser
+	Delete(context.Context, *Request) (*Result, error)
+	// ExportUser returns everything kept about a user as one JSON document
+	ExportUser(context.Context, *UserDataRequest) (*UserArchive, error)
+	// EraseUser deletes everything kept about a user, or anonymizes what
+	// other data depends on, and reports what it removed
+	EraseUser(context.Context, *UserDataRequest) (*EraseReport, error)
+	// OrderHistoryUpdate records an order, or the score of a stay
+	OrderHistoryUpdate(context.Context, *OrderHistoryRequest) (*OrderHistoryResult, error)
+	// GetOrderHistory returns a user's orders, newest first, a page at a time
+	GetOrderHistory(context.Context, *OrderHistoryQuery) (*OrderHistoryPage, error)
+	// GetReviews returns the hotel scores a user gave
+	GetReviews(context.Context, *ReviewsRequest) (*ReviewsResult, error)
+	// Login checks the username and password like CheckUser and issues a
+	// session token
+	Login(context.Context, *Request) (*LoginResult, error)
+	// VerifyToken returns the user a session token was issued to
+	VerifyToken(context.Context, *TokenRequest) (*TokenResult, error)
+	// RefreshToken replaces a session token with one expiring later
+	RefreshToken(context.Context, *TokenRequest) (*LoginResult, error)
+	// Logout revokes a session token
+	Logout(context.Context, *TokenRequest) (*Result, error)
+	// GetLoyalty returns a user's points balance and tier
+	GetLoyalty(context.Context, *LoyaltyRequest) (*LoyaltyAccount, error)
+	// GetLoyaltyHistory returns a user's points ledger, newest first, a page
+	// at a time
+	GetLoyaltyHistory(context.Context, *LoyaltyHistoryQuery) (*LoyaltyHistoryPage, error)
+	// RedeemPoints spends points as a discount on a reservation, at most as
+	// many as maxDiscount is worth. It returns FailedPrecondition if the
+	// balance is too low.
+	RedeemPoints(context.Context, *RedeemRequest) (*RedeemResult, error)
+	// ReservationChanged earns the points of a booked reservation, or
+	// reverses them and refunds the redeemed points of a cancelled one
+	ReservationChanged(context.Context, *ReservationChange) (*ReservationChangeResult, error)
+	// CreateWishlist creates a named list of saved hotels
+	CreateWishlist(context.Context, *CreateWishlistRequest) (*Wishlist, error)
+	// GetWishlists returns a user's wishlists
+	GetWishlists(context.Context, *WishlistsRequest) (*WishlistsResult, error)
+	// GetWishlist returns one of a user's wishlists
+	GetWishlist(context.Context, *WishlistRequest) (*Wishlist, error)
+	// UpdateWishlist adds hotels to and removes hotels from a wishlist
+	UpdateWishlist(context.Context, *UpdateWishlistRequest) (*Wishlist, error)
+	// DeleteWishlist deletes a wishlist
+	DeleteWishlist(context.Context, *WishlistRequest) (*Result, error)
+	// ShareWishlist sets a new share token on a wishlist, or revokes it
+	ShareWishlist(context.Context, *ShareWishlistRequest) (*Wishlist, error)
+	// GetSharedWishlist returns the wishlist a share token was set on
+	GetSharedWishlist(context.Context, *SharedWishlistRequest) (*Wishlist, error)
+}
+
+func RegisterUserServer(s *grpc.Server, srv UserServer) {
+	s.RegisterService(&_User_serviceDesc, srv)
+}
+
+func _User_CheckUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Request)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServer).CheckUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.User/CheckUser",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServer).CheckUser(ctx, req.(*Request))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _User_Register_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegisterRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServer).Register(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.User/Register",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServer).Register(ctx, req.(*RegisterRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _User_UpdateUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServer).UpdateUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.User/UpdateUser",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServer).UpdateUser(ctx, req.(*UpdateUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _User_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Request)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServer).Delete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.User/Delete",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServer).Delete(ctx, req.(*Request))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _User_ExportUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserDataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServer).ExportUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.User/ExportUser",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServer).ExportUser(ctx, req.(*UserDataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _User_EraseUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserDataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServer).EraseUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.User/EraseUser",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServer).EraseUser(ctx, req.(*UserDataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _User_OrderHistoryUpdate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OrderHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServer).OrderHistoryUpdate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.User/OrderHistoryUpdate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServer).OrderHistoryUpdate(ctx, req.(*OrderHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _User_GetOrderHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OrderHistoryQuery)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(UserServer).OrderHistoryUpdate(ctx, in)
+		return srv.(UserServer).GetOrderHistory(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/user.User/OrderHistoryUpdate",
+		FullMethod: "/user.User/GetOrderHistory",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(UserServer).OrderHistoryUpdate(ctx, req.(*OrderHistoryRequest))
+		return srv.(UserServer).GetOrderHistory(ctx, req.(*OrderHistoryQuery))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _User_GetReviews_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReviewsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServer).GetReviews(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.User/GetReviews",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServer).GetReviews(ctx, req.(*ReviewsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _User_Login_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Request)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServer).Login(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.User/Login",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServer).Login(ctx, req.(*Request))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _User_VerifyToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServer).VerifyToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.User/VerifyToken",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServer).VerifyToken(ctx, req.(*TokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _User_RefreshToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServer).RefreshToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.User/RefreshToken",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServer).RefreshToken(ctx, req.(*TokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _User_Logout_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServer).Logout(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.User/Logout",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServer).Logout(ctx, req.(*TokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _User_GetLoyalty_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LoyaltyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServer).GetLoyalty(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.User/GetLoyalty",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServer).GetLoyalty(ctx, req.(*LoyaltyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _User_GetLoyaltyHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LoyaltyHistoryQuery)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServer).GetLoyaltyHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.User/GetLoyaltyHistory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServer).GetLoyaltyHistory(ctx, req.(*LoyaltyHistoryQuery))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _User_RedeemPoints_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RedeemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServer).RedeemPoints(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.User/RedeemPoints",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServer).RedeemPoints(ctx, req.(*RedeemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _User_ReservationChanged_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReservationChange)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServer).ReservationChanged(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.User/ReservationChanged",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServer).ReservationChanged(ctx, req.(*ReservationChange))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _User_CreateWishlist_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateWishlistRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServer).CreateWishlist(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.User/CreateWishlist",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServer).CreateWishlist(ctx, req.(*CreateWishlistRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _User_GetWishlists_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WishlistsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServer).GetWishlists(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.User/GetWishlists",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServer).GetWishlists(ctx, req.(*WishlistsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _User_GetWishlist_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WishlistRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServer).GetWishlist(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.User/GetWishlist",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServer).GetWishlist(ctx, req.(*WishlistRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _User_UpdateWishlist_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateWishlistRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServer).UpdateWishlist(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.User/UpdateWishlist",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServer).UpdateWishlist(ctx, req.(*UpdateWishlistRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _User_DeleteWishlist_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WishlistRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServer).DeleteWishlist(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.User/DeleteWishlist",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServer).DeleteWishlist(ctx, req.(*WishlistRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _User_ShareWishlist_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ShareWishlistRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServer).ShareWishlist(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.User/ShareWishlist",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServer).ShareWishlist(ctx, req.(*ShareWishlistRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _User_GetSharedWishlist_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SharedWishlistRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServer).GetSharedWishlist(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.User/GetSharedWishlist",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServer).GetSharedWishlist(ctx, req.(*SharedWishlistRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -480,46 +2220,207 @@ var _User_serviceDesc = grpc.ServiceDesc{
 			Handler:    _User_Register_Handler,
 		},
 		{
-			MethodName: "Modify",
-			Handler:    _User_Modify_Handler,
+			MethodName: "UpdateUser",
+			Handler:    _User_UpdateUser_Handler,
 		},
 		{
 			MethodName: "Delete",
 			Handler:    _User_Delete_Handler,
 		},
+		{
+			MethodName: "ExportUser",
+			Handler:    _User_ExportUser_Handler,
+		},
+		{
+			MethodName: "EraseUser",
+			Handler:    _User_EraseUser_Handler,
+		},
 		{
 			MethodName: "OrderHistoryUpdate",
 			Handler:    _User_OrderHistoryUpdate_Handler,
 		},
+		{
+			MethodName: "GetOrderHistory",
+			Handler:    _User_GetOrderHistory_Handler,
+		},
+		{
+			MethodName: "GetReviews",
+			Handler:    _User_GetReviews_Handler,
+		},
+		{
+			MethodName: "Login",
+			Handler:    _User_Login_Handler,
+		},
+		{
+			MethodName: "VerifyToken",
+			Handler:    _User_VerifyToken_Handler,
+		},
+		{
+			MethodName: "RefreshToken",
+			Handler:    _User_RefreshToken_Handler,
+		},
+		{
+			MethodName: "Logout",
+			Handler:    _User_Logout_Handler,
+		},
+		{
+			MethodName: "GetLoyalty",
+			Handler:    _User_GetLoyalty_Handler,
+		},
+		{
+			MethodName: "GetLoyaltyHistory",
+			Handler:    _User_GetLoyaltyHistory_Handler,
+		},
+		{
+			MethodName: "RedeemPoints",
+			Handler:    _User_RedeemPoints_Handler,
+		},
+		{
+			MethodName: "ReservationChanged",
+			Handler:    _User_ReservationChanged_Handler,
+		},
+		{
+			MethodName: "CreateWishlist",
+			Handler:    _User_CreateWishlist_Handler,
+		},
+		{
+			MethodName: "GetWishlists",
+			Handler:    _User_GetWishlists_Handler,
+		},
+		{
+			MethodName: "GetWishlist",
+			Handler:    _User_GetWishlist_Handler,
+		},
+		{
+			MethodName: "UpdateWishlist",
+			Handler:    _User_UpdateWishlist_Handler,
+		},
+		{
+			MethodName: "DeleteWishlist",
+			Handler:    _User_DeleteWishlist_Handler,
+		},
+		{
+			MethodName: "ShareWishlist",
+			Handler:    _User_ShareWishlist_Handler,
+		},
+		{
+			MethodName: "GetSharedWishlist",
+			Handler:    _User_GetSharedWishlist_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
-	Metadata: "user.proto",
+	Metadata: "services/user/proto/user.proto",
 }
 
-func init() { proto.RegisterFile("user.proto", fileDescriptor0) }
+func init() { proto.RegisterFile("services/user/proto/user.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 348 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xd4, 0x53, 0xc1, 0x4a, 0xc3, 0x40,
-	0x10, 0x25, 0x6d, 0x9a, 0xb6, 0x43, 0xab, 0x32, 0xad, 0xb0, 0xe6, 0x54, 0x02, 0x42, 0xf0, 0x50,
-	0x50, 0x0f, 0x9e, 0x45, 0x0f, 0x7a, 0x10, 0x21, 0xd0, 0x0f, 0x88, 0xcd, 0xd8, 0x06, 0xdb, 0x6e,
-	0xdc, 0xdd, 0xa2, 0xfd, 0x09, 0xff, 0x47, 0xfc, 0x39, 0xd9, 0x89, 0x51, 0xb6, 0x94, 0x56, 0xf0,
-	0xe4, 0x6d, 0xde, 0x7b, 0x3b, 0x93, 0xf7, 0x76, 0x27, 0x00, 0x4b, 0x4d, 0x6a, 0x58, 0x28, 0x69,
-	0x24, 0xfa, 0xb6, 0x8e, 0x2e, 0xa1, 0x99, 0xd0, 0xf3, 0x92, 0xb4, 0xc1, 0x10, 0x5a, 0x96, 0x5a,
-	0xa4, 0x73, 0x12, 0xde, 0xc0, 0x8b, 0xdb, 0xc9, 0x37, 0xb6, 0x5a, 0x91, 0x6a, 0xfd, 0x22, 0x55,
-	0x26, 0x6a, 0xa5, 0x56, 0xe1, 0x28, 0x82, 0x20, 0x21, 0xbd, 0x9c, 0x19, 0x14, 0xd0, 0x1c, 0x4b,
-	0xa5, 0x68, 0x6c, 0x78, 0x40, 0x2b, 0xa9, 0x60, 0xf4, 0xe1, 0xc1, 0x7e, 0x42, 0x93, 0x5c, 0x1b,
-	0x52, 0x7f, 0xfc, 0x1e, 0x1e, 0x40, 0x3d, 0x9d, 0x90, 0xa8, 0x0f, 0xbc, 0xb8, 0x91, 0xd8, 0xd2,
-	0x32, 0x9a, 0x5e, 0x85, 0xcf, 0x07, 0x6d, 0x89, 0x08, 0xfe, 0x3c, 0xcd, 0x67, 0xa2, 0xc1, 0x14,
-	0xd7, 0xd8, 0x87, 0x46, 0x31, 0x95, 0x0b, 0x12, 0x01, 0x93, 0x25, 0xc0, 0x08, 0x3a, 0x52, 0x65,
-	0xa4, 0xa6, 0xb9, 0x36, 0x52, 0xad, 0x44, 0x93, 0x45, 0x87, 0x8b, 0x4e, 0x60, 0xef, 0xc7, 0xfc,
-	0x8e, 0xa4, 0xef, 0x1e, 0x74, 0xef, 0x64, 0x96, 0x3f, 0xae, 0xfe, 0x5f, 0xce, 0x18, 0x3a, 0x95,
-	0xf5, 0x1d, 0x29, 0x47, 0xd0, 0xbb, 0xb7, 0x9d, 0x37, 0x65, 0xe7, 0x6f, 0xa2, 0xae, 0x1b, 0xa8,
-	0x6d, 0x30, 0x30, 0x04, 0x74, 0xc7, 0x6e, 0xb7, 0x71, 0xf6, 0x56, 0x03, 0x7f, 0xa4, 0x49, 0x61,
-	0x0c, 0xed, 0xab, 0x29, 0x8d, 0x9f, 0x18, 0x74, 0x87, 0xbc, 0xe6, 0x5f, 0xa6, 0xc2, 0x4e, 0x05,
-	0x79, 0xd8, 0x05, 0xb4, 0xaa, 0xb7, 0xc4, 0xc3, 0x4a, 0x71, 0x16, 0x33, 0xec, 0xaf, 0xd3, 0xdc,
-	0x78, 0x0a, 0x41, 0x79, 0x39, 0xd8, 0x2b, 0x75, 0xe7, 0x95, 0x43, 0x74, 0x49, 0x6e, 0x39, 0x86,
-	0xe0, 0x9a, 0x66, 0x64, 0x68, 0xbb, 0xa5, 0x5b, 0x37, 0xf5, 0xa8, 0xc8, 0x52, 0x43, 0x78, 0x54,
-	0x9e, 0xd9, 0x70, 0xcd, 0xa1, 0xd8, 0x24, 0xd9, 0x51, 0x0f, 0x01, 0xff, 0xdb, 0xe7, 0x9f, 0x01,
-	0x00, 0x00, 0xff, 0xff, 0x68, 0xaf, 0xec, 0x0b, 0xe9, 0x03, 0x00, 0x00,
+	// 1702 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x18, 0xdb, 0x52, 0x1b, 0x47,
+	0x76, 0x75, 0x19, 0x21, 0x8e, 0x40, 0x88, 0x36, 0x60, 0x59, 0xbb, 0xeb, 0x62, 0x7b, 0xbd, 0x5e,
+	0xd6, 0xeb, 0xc5, 0xb5, 0xf6, 0xda, 0x6c, 0x55, 0xca, 0x95, 0x60, 0x20, 0x18, 0x07, 0x63, 0x32,
+	0xc6, 0x71, 0x55, 0x12, 0x3f, 0x0c, 0x9a, 0xb6, 0x34, 0x46, 0x9a, 0x51, 0xba, 0x5b, 0x32, 0xf8,
+	0x17, 0xf2, 0x9c, 0xc7, 0xe4, 0x23, 0xf2, 0x96, 0x8f, 0xc8, 0x17, 0x24, 0xdf, 0x92, 0x4a, 0xf5,
+	0xe9, 0xe9, 0x51, 0xcf, 0x8c, 0x00, 0x55, 0xd9, 0x95, 0x27, 0xcd, 0xb9, 0x75, 0x9f, 0x3e, 0xf7,
+	0x23, 0xb8, 0x2e, 0x18, 0x1f, 0x05, 0x6d, 0x26, 0xee, 0x0c, 0x05, 0xe3, 0x77, 0x06, 0x3c, 0x92,
+	0x11, 0x7e, 0xae, 0xe3, 0x27, 0x29, 0xab, 0x6f, 0xfa, 0x0a, 0x66, 0x5c, 0xf6, 0xcd, 0x90, 0x09,
+	0x49, 0x5a, 0x50, 0x55, 0xa8, 0xd0, 0xeb, 0xb3, 0x66, 0x61, 0xb5, 0xb0, 0x36, 0xeb, 0x26, 0xb0,
+	0xa2, 0x0d, 0x3c, 0x21, 0xde, 0x46, 0xdc, 0x6f, 0x16, 0x35, 0xcd, 0xc0, 0x8a, 0xd6, 0xee, 0x05,
+	0x2c, 0x94, 0x7b, 0x83, 0x66, 0x49, 0xd3, 0x0c, 0x4c, 0x29, 0x54, 0x5c, 0x26, 0x86, 0x3d, 0x49,
+	0x9a, 0x30, 0xd3, 0x8e, 0x38, 0x67, 0x6d, 0x89, 0x87, 0x57, 0x5d, 0x03, 0xd2, 0xef, 0x0b, 0xb0,
+	0xe0, 0xb2, 0x4e, 0x20, 0x24, 0xe3, 0xef, 0xab, 0x4b, 0x03, 0x4a, 0x5e, 0x87, 0xa1, 0x1a, 0x8e,
+	0xab, 0x3e, 0x15, 0x46, 0xb0, 0xd3, 0x66, 0x19, 0x19, 0xd5, 0x27, 0x21, 0x50, 0xee, 0x7b, 0x41,
+	0xaf, 0xe9, 0x20, 0x0a, 0xbf, 0xc9, 0x12, 0x38, 0x83, 0x6e, 0x14, 0xb2, 0x66, 0x05, 0x91, 0x1a,
+	0x78, 0x52, 0xae, 0xce, 0x34, 0xaa, 0xf4, 0x16, 0xd4, 0xc7, 0xea, 0x5d, 0xf2, 0x96, 0x5f, 0x0a,
+	0xb0, 0xf8, 0x62, 0xe0, 0x7b, 0x92, 0xbd, 0x10, 0xd3, 0xbd, 0xe6, 0x3a, 0xc0, 0x10, 0x05, 0x9e,
+	0x7a, 0xe2, 0xa4, 0x59, 0x5c, 0x2d, 0xad, 0xcd, 0xba, 0x16, 0x86, 0xac, 0x42, 0x2d, 0xea, 0xf9,
+	0x87, 0xe6, 0xc1, 0xda, 0xc0, 0x36, 0x2a, 0x65, 0x8f, 0xf2, 0x64, 0x7b, 0x38, 0x39, 0x7b, 0x54,
+	0xf2, 0xf6, 0x98, 0x99, 0x64, 0x8f, 0xaa, 0x65, 0x0f, 0xfa, 0x29, 0x34, 0xec, 0xc7, 0x5d, 0x6c,
+	0x0b, 0x45, 0xd1, 0xef, 0xf0, 0xe3, 0x67, 0x19, 0x90, 0x7e, 0x09, 0x57, 0x9e, 0x71, 0x9f, 0xf1,
+	0xc7, 0x81, 0x90, 0x11, 0x3f, 0x9b, 0xc6, 0x4c, 0x7f, 0x03, 0x27, 0x52, 0x22, 0x68, 0x80, 0xda,
+	0xdd, 0xda, 0x3a, 0x46, 0x32, 0x9e, 0xe2, 0x6a, 0xca, 0x93, 0x72, 0xb5, 0xd8, 0x28, 0xd1, 0x75,
+	0x20, 0xe9, 0xb3, 0x2f, 0xf1, 0x58, 0x00, 0x8b, 0x36, 0xff, 0xe7, 0x43, 0xc6, 0xcf, 0x2e, 0x0f,
+	0xbf, 0x0e, 0x7b, 0x1e, 0xbc, 0x63, 0x18, 0x7e, 0x8e, 0x9b, 0xc0, 0xe4, 0x2f, 0x30, 0xab, 0xbe,
+	0x8f, 0xa2, 0x13, 0x16, 0xc6, 0xae, 0x1a, 0x23, 0xe8, 0x2b, 0x68, 0xd8, 0x57, 0x1d, 0x2a, 0x77,
+	0xfc, 0x1d, 0x2a, 0xa8, 0xbd, 0x68, 0x16, 0x56, 0x4b, 0xd9, 0x87, 0xc5, 0x24, 0x72, 0x03, 0xe6,
+	0x43, 0x76, 0x2a, 0x0f, 0x93, 0xa3, 0x75, 0xd8, 0xa7, 0x91, 0xf4, 0xbb, 0x22, 0x38, 0x28, 0xa7,
+	0xf8, 0x39, 0x53, 0xe9, 0xef, 0xc9, 0x20, 0x0a, 0xf7, 0xfc, 0xf8, 0x0d, 0x69, 0xa4, 0xb2, 0x49,
+	0x37, 0x92, 0xac, 0xb7, 0x67, 0xd2, 0xc8, 0x80, 0x64, 0x05, 0x2a, 0x41, 0xb8, 0xed, 0x49, 0x16,
+	0xbf, 0x21, 0x86, 0x94, 0x44, 0x34, 0x94, 0x48, 0xd0, 0x81, 0x66, 0x40, 0x15, 0xc5, 0x3c, 0x8a,
+	0xfa, 0x07, 0xc3, 0xfe, 0x31, 0xe3, 0x71, 0xb8, 0x59, 0x18, 0x8c, 0x27, 0x1e, 0xb4, 0x75, 0x7e,
+	0x15, 0x5d, 0x0d, 0x28, 0xac, 0x68, 0x47, 0x9c, 0x61, 0xe8, 0x15, 0x5d, 0x0d, 0xa0, 0xaf, 0x38,
+	0xc3, 0xb8, 0x51, 0xd1, 0x57, 0x72, 0x0d, 0x68, 0x47, 0xd4, 0xac, 0xa6, 0xc4, 0xa0, 0x72, 0x8a,
+	0x1f, 0x88, 0x76, 0x34, 0x0c, 0x65, 0x13, 0xf0, 0xb0, 0x04, 0xa6, 0xb7, 0x55, 0xfe, 0x8e, 0x02,
+	0xf6, 0x56, 0x4c, 0x11, 0x68, 0x74, 0x03, 0xe6, 0x13, 0x6e, 0x0c, 0x9d, 0x9b, 0x30, 0xc3, 0x35,
+	0x22, 0x76, 0xd1, 0x9c, 0x76, 0x91, 0xe6, 0x72, 0x0d, 0x91, 0xbe, 0x51, 0xa5, 0x4e, 0x7d, 0xda,
+	0x86, 0x2d, 0x9c, 0x67, 0xd8, 0xe2, 0x79, 0x86, 0x2d, 0xa5, 0x0d, 0x9b, 0x98, 0xa8, 0x6c, 0x99,
+	0x88, 0x7e, 0x05, 0xb5, 0xfd, 0xa8, 0x13, 0x84, 0x97, 0xe6, 0xe0, 0x12, 0x38, 0xd2, 0x8a, 0x18,
+	0x0d, 0xa8, 0x30, 0x65, 0xa7, 0x83, 0x80, 0x33, 0xb1, 0x29, 0xf1, 0xc2, 0x92, 0x3b, 0x46, 0xd0,
+	0x1b, 0x30, 0x87, 0x01, 0x65, 0xac, 0x95, 0x9c, 0x51, 0xb0, 0xce, 0xa0, 0xaf, 0xa0, 0x16, 0x73,
+	0xa1, 0x0a, 0x4b, 0xe0, 0x8c, 0xbc, 0x5e, 0xe0, 0xc7, 0x0a, 0x68, 0x20, 0x65, 0xe8, 0x62, 0x26,
+	0x8f, 0x2e, 0x56, 0xe2, 0x3f, 0xb0, 0xa0, 0x8a, 0xcc, 0xb6, 0x27, 0xbd, 0x69, 0xbc, 0xf6, 0x4f,
+	0xa8, 0x29, 0xf6, 0x4d, 0xde, 0xee, 0x06, 0x23, 0xb4, 0xa7, 0xa7, 0x3f, 0x91, 0x73, 0xce, 0x35,
+	0x20, 0xfd, 0xa9, 0x08, 0xb5, 0x1d, 0xee, 0x09, 0xe6, 0xb2, 0x41, 0xc4, 0x51, 0xef, 0xd7, 0xd1,
+	0x30, 0x4c, 0xf4, 0x46, 0x40, 0xf9, 0x29, 0xce, 0x4a, 0x9d, 0xe1, 0x31, 0xa4, 0xce, 0x35, 0xb1,
+	0xa0, 0x5b, 0x8c, 0x01, 0x09, 0x85, 0x39, 0x6f, 0xe8, 0x07, 0xd2, 0x65, 0xed, 0x88, 0xfb, 0x02,
+	0xdd, 0xe5, 0xb8, 0x29, 0x1c, 0x79, 0x00, 0x2b, 0x56, 0x06, 0x8a, 0xcd, 0x30, 0x0a, 0xcf, 0xfa,
+	0xc1, 0x3b, 0xe6, 0xc7, 0x09, 0x73, 0x0e, 0x95, 0xac, 0xc1, 0x82, 0x60, 0x42, 0x28, 0xac, 0xcb,
+	0x46, 0xd1, 0x09, 0xf3, 0x31, 0x8d, 0xaa, 0x6e, 0x16, 0x4d, 0x6e, 0x42, 0xbd, 0x17, 0x9d, 0x79,
+	0x3d, 0x79, 0xb6, 0x13, 0x4a, 0x1e, 0x30, 0x81, 0x99, 0xe5, 0xb8, 0x19, 0xac, 0xb2, 0xfd, 0xdb,
+	0x40, 0x74, 0x7b, 0x81, 0x90, 0x02, 0x93, 0xcc, 0x71, 0xc7, 0x08, 0xf5, 0x7a, 0xaf, 0xc7, 0xb8,
+	0x14, 0x98, 0x65, 0x8e, 0x1b, 0x43, 0x2a, 0x91, 0xf6, 0xf5, 0x39, 0xd3, 0xb8, 0xe4, 0xd7, 0x42,
+	0xc2, 0xbe, 0xd9, 0xc6, 0x4c, 0xbc, 0x88, 0x5d, 0x99, 0xf6, 0xd8, 0xeb, 0x79, 0x61, 0x5b, 0x47,
+	0x4a, 0xc9, 0x35, 0xa0, 0xa2, 0x0c, 0x58, 0xe8, 0x07, 0x61, 0x27, 0x0e, 0x13, 0x03, 0xaa, 0xce,
+	0x25, 0x03, 0xc6, 0xe3, 0x62, 0x84, 0xdf, 0xaa, 0x12, 0xa9, 0xdf, 0x83, 0xa0, 0xd3, 0x95, 0xc2,
+	0x54, 0xa2, 0x31, 0x46, 0xe9, 0xa0, 0xca, 0xe6, 0x91, 0x92, 0xd3, 0x4d, 0x30, 0x81, 0xc9, 0x2d,
+	0x68, 0x84, 0xc8, 0x75, 0x14, 0x1d, 0x18, 0x1e, 0x6d, 0xc0, 0x1c, 0x9e, 0x9e, 0xc0, 0x95, 0xf8,
+	0x75, 0x7f, 0x40, 0xe7, 0xe8, 0x00, 0x49, 0x5f, 0x86, 0xbd, 0xe3, 0xdf, 0x30, 0xc3, 0x62, 0x37,
+	0xeb, 0xca, 0xb4, 0xa8, 0x2b, 0xd3, 0x3e, 0xf3, 0x3b, 0x8c, 0x2b, 0x5f, 0x9f, 0xb9, 0x86, 0x63,
+	0xca, 0x1e, 0xf2, 0x73, 0x01, 0x6a, 0x96, 0xb8, 0xb2, 0xf0, 0x49, 0x10, 0x9a, 0x3a, 0x86, 0xdf,
+	0x2a, 0x3c, 0x06, 0x51, 0x10, 0x4a, 0x11, 0x3b, 0x2a, 0x86, 0xf2, 0x5d, 0xa7, 0x74, 0x49, 0xd7,
+	0x29, 0xe7, 0x8a, 0x63, 0x68, 0x7b, 0x2d, 0x86, 0xec, 0x7e, 0x50, 0x49, 0xf7, 0x83, 0x55, 0xa8,
+	0x79, 0x23, 0x2f, 0xe8, 0x79, 0xc7, 0x3d, 0xb6, 0x29, 0xd1, 0x55, 0x25, 0xd7, 0x46, 0xd1, 0x6f,
+	0x0b, 0xaa, 0x9c, 0xfb, 0x8c, 0xf5, 0xa7, 0x19, 0x32, 0xde, 0xef, 0x65, 0xab, 0x50, 0xeb, 0x7b,
+	0xa7, 0xdb, 0xa6, 0x0d, 0xe9, 0x82, 0x6d, 0xa3, 0xe8, 0xd7, 0x30, 0x67, 0x94, 0xc1, 0xa2, 0x39,
+	0xbe, 0xaf, 0x90, 0xba, 0xcf, 0xee, 0x66, 0xc5, 0x74, 0x37, 0xb3, 0xf3, 0xa4, 0x94, 0xca, 0x13,
+	0xfa, 0x63, 0x11, 0x16, 0xdd, 0xb1, 0x46, 0x5b, 0x5d, 0x2f, 0xec, 0x30, 0x72, 0x0f, 0x1c, 0x36,
+	0x62, 0xa1, 0xee, 0x0c, 0xf5, 0xbb, 0x7f, 0x35, 0xcd, 0x2b, 0xc3, 0xb7, 0xbe, 0xa3, 0x98, 0x5c,
+	0xcd, 0x7b, 0x61, 0xdd, 0xfe, 0x00, 0x6e, 0x8e, 0x7b, 0xa0, 0x73, 0x5e, 0x0f, 0xac, 0x5c, 0x34,
+	0x5c, 0xcc, 0xe4, 0x86, 0x8b, 0x71, 0xe0, 0x54, 0xed, 0xc0, 0xa1, 0x14, 0x1c, 0x7c, 0x15, 0x01,
+	0xa8, 0x3c, 0x7a, 0xf6, 0xec, 0xb3, 0x9d, 0xed, 0xc6, 0x9f, 0xc8, 0x3c, 0xcc, 0x6e, 0x6d, 0x1e,
+	0x6c, 0xed, 0xec, 0xef, 0xef, 0x6c, 0x37, 0x0a, 0xf4, 0xbf, 0x70, 0x35, 0x67, 0x8b, 0x8b, 0xbd,
+	0x43, 0x77, 0x61, 0x79, 0x0b, 0x03, 0xf0, 0x65, 0x5c, 0x31, 0xa7, 0x09, 0x2d, 0x02, 0x65, 0xcb,
+	0x9a, 0xf8, 0x4d, 0xd7, 0xa1, 0x61, 0x8e, 0x98, 0x6a, 0x34, 0xf9, 0x18, 0x16, 0x2c, 0x7e, 0xd4,
+	0xf1, 0xb6, 0x5d, 0xc8, 0x75, 0x11, 0xa8, 0x6b, 0x0f, 0x27, 0xca, 0x8d, 0x19, 0xe8, 0xd3, 0xf1,
+	0x01, 0x53, 0xae, 0x26, 0x46, 0x36, 0x99, 0x11, 0x2d, 0x8c, 0x5a, 0xdc, 0x96, 0xf5, 0x3e, 0xf0,
+	0x01, 0x4f, 0xc5, 0xa4, 0xf6, 0xfd, 0xc7, 0x3a, 0x5a, 0x54, 0x9f, 0x55, 0xab, 0x83, 0x8d, 0x52,
+	0x5d, 0x8e, 0xb3, 0x7e, 0x34, 0x62, 0x09, 0x53, 0x19, 0x99, 0x32, 0x58, 0xfa, 0x06, 0x96, 0x9e,
+	0x77, 0x3d, 0xfe, 0x41, 0xb5, 0x5b, 0x81, 0x0a, 0xc7, 0x66, 0x8b, 0x61, 0x5f, 0x75, 0x63, 0x88,
+	0x6e, 0xc0, 0x32, 0xde, 0xe5, 0x67, 0x2f, 0xbb, 0x0e, 0x20, 0x14, 0xe1, 0xc8, 0x1a, 0xa1, 0x2c,
+	0x0c, 0xfd, 0xa1, 0x00, 0x55, 0x23, 0x43, 0xea, 0x50, 0x0c, 0x4c, 0xb1, 0x2d, 0x06, 0xfe, 0xa4,
+	0xa8, 0x51, 0xda, 0x77, 0xd3, 0xc6, 0x49, 0xe0, 0xcc, 0x65, 0xe5, 0xec, 0x65, 0x76, 0x29, 0x75,
+	0xce, 0x1d, 0xad, 0x2b, 0xa9, 0xd1, 0xfa, 0xee, 0x6f, 0x00, 0x65, 0x35, 0x5b, 0x91, 0x35, 0x98,
+	0xdd, 0xea, 0xb2, 0xf6, 0x09, 0x02, 0xf3, 0xa6, 0x8e, 0xe0, 0x2b, 0x5b, 0xc9, 0x4c, 0x8c, 0x51,
+	0xb9, 0x01, 0x55, 0xb3, 0x31, 0x93, 0x65, 0x43, 0x49, 0x2d, 0xf8, 0xad, 0xa5, 0x2c, 0x1a, 0x05,
+	0x1f, 0x02, 0x8c, 0x17, 0x4c, 0x72, 0x55, 0xf3, 0xe4, 0xf6, 0xe9, 0xd6, 0x4a, 0x9e, 0x80, 0xe2,
+	0xff, 0x80, 0xca, 0x36, 0xeb, 0x31, 0xc9, 0x2e, 0x56, 0xef, 0x01, 0xc0, 0xce, 0xa9, 0x9a, 0xfe,
+	0x5e, 0x88, 0xb1, 0x82, 0x99, 0x69, 0xb3, 0xb5, 0x38, 0x46, 0x9b, 0xa9, 0xf2, 0x3e, 0xcc, 0xe2,
+	0xe8, 0x38, 0x85, 0x98, 0x3d, 0x62, 0xee, 0xa5, 0x37, 0x52, 0xad, 0x35, 0xb9, 0x66, 0x2d, 0x7a,
+	0xe9, 0x3d, 0xb8, 0xd5, 0x9c, 0x44, 0x42, 0xcd, 0x1f, 0xc1, 0xc2, 0x2e, 0x93, 0x36, 0xc1, 0x18,
+	0x29, 0xb7, 0xc3, 0xb6, 0x56, 0xf2, 0x04, 0x9c, 0x1a, 0x36, 0x00, 0x76, 0x99, 0x8c, 0x77, 0x1c,
+	0xb2, 0x64, 0x2f, 0x33, 0xa6, 0x0a, 0xb5, 0xae, 0x64, 0xb0, 0x78, 0xf9, 0xbf, 0xc0, 0xc1, 0xa5,
+	0x23, 0x6b, 0x5c, 0x33, 0x75, 0x58, 0x0b, 0xc9, 0xff, 0xa0, 0xf6, 0x05, 0xe3, 0xc1, 0xeb, 0x33,
+	0x1d, 0x76, 0x44, 0x73, 0xd8, 0x5b, 0x45, 0x6b, 0x31, 0x85, 0x43, 0xa9, 0xfb, 0xaa, 0x3d, 0xbe,
+	0xe6, 0x4c, 0x74, 0x2f, 0x15, 0xb3, 0x2f, 0xbb, 0x05, 0x95, 0xfd, 0xa8, 0x13, 0x0d, 0xe5, 0x44,
+	0x81, 0xb4, 0xeb, 0xff, 0x8f, 0x8f, 0x8f, 0x67, 0x29, 0xf3, 0xf8, 0xf4, 0x50, 0xdb, 0x4a, 0x63,
+	0xcd, 0xec, 0xfa, 0x18, 0x16, 0xc7, 0x92, 0xc6, 0xf8, 0xd7, 0x52, 0xac, 0x29, 0xf3, 0x37, 0x27,
+	0x91, 0x62, 0x07, 0xc4, 0x53, 0xc0, 0xa1, 0xee, 0xf6, 0x89, 0xb1, 0xad, 0x31, 0xa5, 0x45, 0xd2,
+	0x48, 0x54, 0x7e, 0x1f, 0x48, 0xae, 0x57, 0xf9, 0x26, 0x00, 0x72, 0x94, 0xd6, 0x79, 0xad, 0x3e,
+	0xc9, 0xb5, 0x7a, 0xba, 0x8d, 0x91, 0x3f, 0x6b, 0x81, 0x89, 0xcd, 0xad, 0x95, 0x69, 0x2b, 0xe4,
+	0x21, 0xcc, 0xed, 0x32, 0xf9, 0x72, 0xbc, 0x34, 0xa4, 0xe9, 0x49, 0x28, 0x2d, 0xe7, 0xf0, 0x26,
+	0x42, 0x2c, 0x71, 0x92, 0xe1, 0x3a, 0xff, 0xd2, 0x7a, 0xba, 0xe1, 0x18, 0x9d, 0x27, 0xb6, 0xa1,
+	0x9c, 0xf8, 0x7d, 0xa8, 0xeb, 0xfa, 0x70, 0xd9, 0xbd, 0xe9, 0xa0, 0xf9, 0x08, 0xe6, 0x53, 0x7d,
+	0x84, 0xb4, 0x34, 0x79, 0x52, 0x73, 0xc9, 0xdd, 0xf9, 0x09, 0xc6, 0x4d, 0xba, 0x37, 0x18, 0xad,
+	0x27, 0x76, 0x8c, 0xec, 0x09, 0xc7, 0x15, 0xfc, 0xbf, 0xf6, 0xde, 0xef, 0x03, 0x00, 0x28, 0x02,
+	0x28, 0xf0, 0xd1, 0x15, 0x00, 0x00,
 }
@@ -1,11 +1,17 @@
 package user
 
 import (
-	"crypto/sha256"
 	// "encoding/json"
 	"fmt"
 	"github.com/grpc-ecosystem/grpc-opentracing/go/otgrpc"
+	"github.com/harlow/go-micro-services/authtoken"
+	"github.com/harlow/go-micro-services/dialer"
+	"github.com/harlow/go-micro-services/lockout"
+	"github.com/harlow/go-micro-services/passhash"
+	"github.com/harlow/go-micro-services/rbac"
 	"github.com/harlow/go-micro-services/registry"
+	alert "github.com/harlow/go-micro-services/services/alert/proto"
+	reservation "github.com/harlow/go-micro-services/services/reservation/proto"
 	pb "github.com/harlow/go-micro-services/services/user/proto"
 	"github.com/opentracing/opentracing-go"
 	"golang.org/x/net/context"
@@ -26,11 +32,22 @@ const name = "srv-user"
 type Server struct {
 	users map[string]string
 
+	reservationClient reservation.ReservationClient
+	alertClient       alert.AlertClient
+
 	Tracer       opentracing.Tracer
 	Registry     *registry.Client
+	// Identity signs and verifies the identities calls are made by
+	Identity *rbac.Signer
 	Port         int
 	IpAddr       string
 	MongoSession *mgo.Session
+	// Tokens signs the session tokens of logged in users
+	Tokens *authtoken.Signer
+	// Lockout and AddrLockout throttle failed logins per account and per
+	// client address, nil disables them
+	Lockout     *lockout.Limiter
+	AddrLockout *lockout.Limiter
 }
 
 // Run starts the server
@@ -39,6 +56,14 @@ func (s *Server) Run() error {
 		return fmt.Errorf("server port must be set")
 	}
 
+	if s.Identity == nil {
+		return fmt.Errorf("identity keys must be set")
+	}
+
+	if s.Tokens == nil {
+		return fmt.Errorf("session token keys must be set")
+	}
+
 	if s.users == nil {
 		s.users = loadUsers(s.MongoSession)
 	}
@@ -50,13 +75,21 @@ func (s *Server) Run() error {
 		grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
 			PermitWithoutStream: true,
 		}),
-		grpc.UnaryInterceptor(
+		grpc.UnaryInterceptor(rbac.ChainUnaryServer(
 			otgrpc.OpenTracingServerInterceptor(s.Tracer),
-		),
+			rbac.UnaryServerInterceptor(s.Identity, policy),
+		)),
 	)
 
 	pb.RegisterUserServer(srv, s)
 
+	if err := s.initReservationClient("srv-reservation"); err != nil {
+		return err
+	}
+	if err := s.initAlertClient("srv-alert"); err != nil {
+		return err
+	}
+
 	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", s.Port))
 	if err != nil {
 		log.Fatalf("failed to listen: %v", err)
@@ -88,33 +121,49 @@ func (s *Server) Shutdown() {
 	s.Registry.Deregister(name)
 }
 
+func (s *Server) initReservationClient(name string) error {
+	conn, err := dialer.Dial(
+		name,
+		dialer.WithTracer(s.Tracer),
+		dialer.WithBalancer(s.Registry.Client),
+		dialer.WithIdentity(s.Identity, self),
+	)
+	if err != nil {
+		return fmt.Errorf("dialer error: %v", err)
+	}
+	s.reservationClient = reservation.NewReservationClient(conn)
+	return nil
+}
+
+func (s *Server) initAlertClient(name string) error {
+	conn, err := dialer.Dial(
+		name,
+		dialer.WithTracer(s.Tracer),
+		dialer.WithBalancer(s.Registry.Client),
+		dialer.WithIdentity(s.Identity, self),
+	)
+	if err != nil {
+		return fmt.Errorf("dialer error: %v", err)
+	}
+	s.alertClient = alert.NewAlertClient(conn)
+	return nil
+}
+
 // CheckUser returns whether the username and password are correct.
 func (s *Server) CheckUser(ctx context.Context, req *pb.Request) (*pb.Result, error) {
 	res := new(pb.Result)
 
 	// fmt.Printf("CheckUser")
 
-	sum := sha256.Sum256([]byte(req.Password))
-	pass := fmt.Sprintf("%x", sum)
-
-	session, err := mgo.Dial("mongodb-user")
-	if err != nil {
-		panic(err)
-	}
+	session := s.MongoSession.Copy()
 	defer session.Close()
 
 	c := session.DB("user-db").C("user")
-	var users []User
-	err2 := c.Find(&bson.M{"username": req.Username}).All(&users)
-	res.Correct = false
-	if err2 != nil {
-		log.Fatal("admin not found")
-		log.Fatal(err2)
-	} else {
-		for _, user := range users {
-			res.Correct = pass == user.Password
-		}
+	correct, err := s.login(c, req.Username, req.Password, req.ClientIp)
+	if err != nil {
+		return nil, err
 	}
+	res.Correct = correct
 
 	// res.Correct = false
 	// if true_pass, found := s.users[req.Username]; found {
@@ -135,18 +184,16 @@ func (s *Server) Register(ctx context.Context, req *pb.RegisterRequest) (*pb.Reg
 	res.Correct = false
 
 	user_name := req.Username
-	sum := sha256.Sum256([]byte(req.Password))
-	pass := fmt.Sprintf("%x", sum)
+	pass, err := passhash.Hash(req.Password)
+	if err != nil {
+		return nil, err
+	}
 	age := req.Age
 	sex := req.Sex
 	mail := req.Mail
 	phone := req.Phone
-	orderhistory := ""
 
-	session, err := mgo.Dial("mongodb-user")
-	if err != nil {
-		panic(err)
-	}
+	session := s.MongoSession.Copy()
 	defer session.Close()
 
 	c := session.DB("user-db").C("user")
@@ -156,7 +203,7 @@ func (s *Server) Register(ctx context.Context, req *pb.RegisterRequest) (*pb.Reg
 		log.Fatal(err)
 	}
 	if count == 0 {
-		err = c.Insert(&User{user_name, pass, age, sex, mail, phone, orderhistory})
+		err = c.Insert(&User{user_name, pass, age, sex, mail, phone})
 		if err != nil {
 			log.Fatal(err)
 		} else {
@@ -169,129 +216,58 @@ func (s *Server) Register(ctx context.Context, req *pb.RegisterRequest) (*pb.Reg
 	return res, nil
 }
 
-// CheckUser returns whether the username and password are correct.
-func (s *Server) Modify(ctx context.Context, req *pb.ModifyRequest) (*pb.ModifyResult, error) {
-
-	res := new(pb.ModifyResult)
+// Delete erases a user, see EraseUser.
+func (s *Server) Delete(ctx context.Context, req *pb.Request) (*pb.Result, error) {
+	res := new(pb.Result)
 	res.Correct = false
 
-	user_name := req.Username
-	sum := sha256.Sum256([]byte(req.Password))
-	pass := fmt.Sprintf("%x", sum)
-	age := req.Age
-	sex := req.Sex
-	mail := req.Mail
-	phone := req.Phone
-	orderhistory := ""
-
-	session, err := mgo.Dial("mongodb-user")
-	if err != nil {
-		panic(err)
-	}
+	session := s.MongoSession.Copy()
 	defer session.Close()
 
-	c := session.DB("user-db").C("user")
-
-	count, err := c.Find(&bson.M{"username": user_name}).Count()
+	report, err := s.erase(ctx, session, req.Username)
 	if err != nil {
-		log.Fatal(err)
-	}
-	if count == 1 {
-		err := c.Remove(&bson.M{"username": user_name})
-		if err != nil {
-			log.Fatal(err)
-		} else {
-			err_2 := c.Insert(&User{user_name, pass, age, sex, mail, phone, orderhistory})
-			if err_2 != nil {
-				log.Fatal(err_2)
-			} else {
-				res.Correct = true
-			}
-		}
-
+		return nil, err
 	}
-
-	fmt.Printf("Done modify users\n")
+	res.Correct = report.Found
 
 	return res, nil
 }
 
-// CheckUser returns whether the username and password are correct.
-func (s *Server) Delete(ctx context.Context, req *pb.Request) (*pb.Result, error) {
-	res := new(pb.Result)
-	res.Correct = false
-
-	// fmt.Printf("CheckUser")
-
-	// sum := sha256.Sum256([]byte(req.Password))
-	// pass := fmt.Sprintf("%x", sum)
-
-	session, err := mgo.Dial("mongodb-user")
-	if err != nil {
-		panic(err)
-	}
-	defer session.Close()
-
-	c := session.DB("user-db").C("user")
-	count, err := c.Find(&bson.M{"username": req.Username}).Count()
-
-	res.Correct = false
-	if err != nil {
-		log.Fatal(err)
+// checkUser returns whether the username and password are correct,
+// upgrading the stored hash if it is outdated.
+func checkUser(c *mgo.Collection, username, password string) (bool, error) {
+	var users []User
+	if err := c.Find(&bson.M{"username": username}).All(&users); err != nil {
+		return false, err
 	}
-	if count != 0 {
 
-		err := c.Remove(&bson.M{"username": req.Username})
-		if err != nil {
-			log.Fatal(err)
-		} else {
-			res.Correct = true
+	correct := false
+	for _, user := range users {
+		var rehash bool
+		correct, rehash = passhash.Verify(user.Password, password)
+		if rehash {
+			upgradePassword(c, user, password)
 		}
 	}
-
-	return res, nil
+	return correct, nil
 }
 
-// CheckUser returns whether the username and password are correct.
-func (s *Server) OrderHistoryUpdate(ctx context.Context, req *pb.OrderHistoryRequest) (*pb.OrderHistoryResult, error) {
-
-	res := new(pb.OrderHistoryResult)
-	res.Correct = false
-
-	user_name := req.Username
-	orderhistory := req.Orderhistory
-
-	session, err := mgo.Dial("mongodb-user")
+// upgradePassword replaces the stored hash of a user who just logged in
+// with password by a current one. A failed upgrade is retried on the next
+// login.
+func upgradePassword(c *mgo.Collection, user User, password string) {
+	pass, err := passhash.Hash(password)
 	if err != nil {
-		panic(err)
+		log.Println("Failed hash user password: ", err)
+		return
 	}
-	defer session.Close()
-
-	c := session.DB("user-db").C("user")
-
-	var user_prof User
-	err = c.Find(bson.M{"username": user_name}).One(&user_prof)
-
-	if err != nil {
-		log.Println("Failed get user data: ", err)
-	} else {
-		user_prof.Orderhistory = user_prof.Orderhistory + "; " + orderhistory
-		err2 := c.Update(
-			bson.M{"username": user_name},
-			bson.M{"$set": bson.M{
-				"orderhistory": user_prof.Orderhistory,
-			}},
-		)
-		if err2 != nil {
-			log.Println("Failed update user data: ", err2)
-		} else {
-			res.Correct = true
-		}
+	err = c.Update(
+		bson.M{"username": user.Username, "password": user.Password},
+		bson.M{"$set": bson.M{"password": pass}},
+	)
+	if err != nil && err != mgo.ErrNotFound {
+		log.Println("Failed upgrade user password: ", err)
 	}
-
-	fmt.Printf("Done update users orderhistory\n")
-
-	return res, nil
 }
 
 // loadUsers loads hotel users from mongodb.
@@ -353,7 +329,7 @@ func loadUsers(session *mgo.Session) map[string]string {
 // 		log.Fatal(err)
 // 	}
 // 	if count == 0{
-// 		err = c.Insert(&User{user_name, pass, age, sex, mail, phone, orderhistory})
+// 		err = c.Insert(&User{user_name, pass, age, sex, mail, phone})
 // 		if err != nil {
 // 			log.Fatal(err)
 // 		} else {
@@ -373,5 +349,4 @@ type User struct {
 	Sex          string `bson:"sex"`
 	Mail         string `bson:"mail"`
 	Phone        string `bson:"phone"`
-	Orderhistory string `bson:"orderhistory"`
 }
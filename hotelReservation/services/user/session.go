@@ -0,0 +1,155 @@
+package user
+
+import (
+	"log"
+	"time"
+
+	"github.com/harlow/go-micro-services/authtoken"
+	pb "github.com/harlow/go-micro-services/services/user/proto"
+	"golang.org/x/net/context"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// revokedToken is a logged out token, kept in user-db.revoked until it
+// would have expired anyway.
+type revokedToken struct {
+	ID      string    `bson:"jti"`
+	Expires time.Time `bson:"expires"`
+}
+
+// Login checks the username and password and issues a session token.
+func (s *Server) Login(ctx context.Context, req *pb.Request) (*pb.LoginResult, error) {
+	res := new(pb.LoginResult)
+
+	session := s.MongoSession.Copy()
+	defer session.Close()
+
+	c := session.DB("user-db").C("user")
+	correct, err := s.login(c, req.Username, req.Password, req.ClientIp)
+	if err != nil {
+		return nil, err
+	}
+	if !correct {
+		return res, nil
+	}
+
+	token, claims, err := s.Tokens.Issue(req.Username, time.Time{})
+	if err != nil {
+		return nil, err
+	}
+	res.Correct = true
+	res.Token = token
+	res.ExpiresAt = claims.ExpiresAt
+	return res, nil
+}
+
+// VerifyToken returns the user a session token was issued to, if the token
+// is valid and was not revoked.
+func (s *Server) VerifyToken(ctx context.Context, req *pb.TokenRequest) (*pb.TokenResult, error) {
+	res := new(pb.TokenResult)
+
+	session := s.MongoSession.Copy()
+	defer session.Close()
+
+	claims, err := s.verifyToken(session, req.Token)
+	if err != nil {
+		return nil, err
+	}
+	if claims == nil {
+		return res, nil
+	}
+
+	res.Valid = true
+	res.Username = claims.Subject
+	res.ExpiresAt = claims.ExpiresAt
+	return res, nil
+}
+
+// RefreshToken revokes a valid session token and issues a new one for the
+// same user. A session is not refreshed past the signer's MaxAge since the
+// user last logged in.
+func (s *Server) RefreshToken(ctx context.Context, req *pb.TokenRequest) (*pb.LoginResult, error) {
+	res := new(pb.LoginResult)
+
+	session := s.MongoSession.Copy()
+	defer session.Close()
+
+	claims, err := s.verifyToken(session, req.Token)
+	if err != nil {
+		return nil, err
+	}
+	if claims == nil {
+		return res, nil
+	}
+
+	token, next, err := s.Tokens.Issue(claims.Subject, time.Unix(claims.AuthTime, 0))
+	if err == authtoken.ErrExpired {
+		return res, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := revoke(session, claims); err != nil {
+		return nil, err
+	}
+
+	res.Correct = true
+	res.Token = token
+	res.ExpiresAt = next.ExpiresAt
+	return res, nil
+}
+
+// Logout revokes a session token. Logging out with an invalid token is
+// not an error, but correct is false.
+func (s *Server) Logout(ctx context.Context, req *pb.TokenRequest) (*pb.Result, error) {
+	res := new(pb.Result)
+
+	session := s.MongoSession.Copy()
+	defer session.Close()
+
+	claims, err := s.verifyToken(session, req.Token)
+	if err != nil {
+		return nil, err
+	}
+	if claims == nil {
+		return res, nil
+	}
+
+	if err := revoke(session, claims); err != nil {
+		return nil, err
+	}
+	res.Correct = true
+	return res, nil
+}
+
+// verifyToken returns the claims of a valid token that was not revoked,
+// or nil for any other token.
+func (s *Server) verifyToken(session *mgo.Session, token string) (*authtoken.Claims, error) {
+	claims, err := s.Tokens.Verify(token)
+	if err != nil {
+		log.Println("Rejected session token: ", err)
+		return nil, nil
+	}
+
+	// the token itself, or every session of its user, may be revoked
+	count, err := session.DB("user-db").C("revoked").Find(bson.M{"$or": []bson.M{
+		{"jti": claims.ID},
+		{"sub": claims.Subject, "before": bson.M{"$gte": time.Unix(claims.IssuedAt, 0)}},
+	}}).Count()
+	if err != nil {
+		return nil, err
+	}
+	if count != 0 {
+		return nil, nil
+	}
+	return claims, nil
+}
+
+func revoke(session *mgo.Session, claims *authtoken.Claims) error {
+	_, err := session.DB("user-db").C("revoked").Upsert(
+		bson.M{"jti": claims.ID},
+		&revokedToken{ID: claims.ID, Expires: time.Unix(claims.ExpiresAt, 0)},
+	)
+	return err
+}
@@ -0,0 +1,187 @@
+package user
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"time"
+
+	"github.com/harlow/go-micro-services/passhash"
+	pb "github.com/harlow/go-micro-services/services/user/proto"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+const maxAge = 150
+
+var (
+	mailPattern = regexp.MustCompile(`^[^@\s]+@[^@\s]+\.[^@\s.]+$`)
+	// digits with the usual separators and an optional international prefix
+	phonePattern = regexp.MustCompile(`^\+?[0-9 ()./-]+$`)
+)
+
+// audit is a change to a user in user-db.audit. Fields names the changed
+// fields, never their values.
+type audit struct {
+	Username string    `bson:"username"`
+	Action   string    `bson:"action"`
+	Fields   []string  `bson:"fields"`
+	At       time.Time `bson:"at"`
+}
+
+// UpdateUser sets the fields of a user named by the update mask in place,
+// so the others, and the user's orders, are kept. The changed fields are
+// recorded in user-db.audit. Changing the password revokes the sessions
+// issued before.
+func (s *Server) UpdateUser(ctx context.Context, req *pb.UpdateUserRequest) (*pb.UpdateUserResult, error) {
+	res := new(pb.UpdateUserResult)
+
+	if req.Username == "" || len(req.UpdateMask) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "username and update mask must be set")
+	}
+	values := make(map[string]interface{}, len(req.UpdateMask))
+	for _, field := range req.UpdateMask {
+		if _, dup := values[field]; dup {
+			continue
+		}
+		v, err := updateValue(req, field)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		values[field] = v
+	}
+
+	session := s.MongoSession.Copy()
+	defer session.Close()
+
+	c := session.DB("user-db").C("user")
+
+	var user User
+	err := c.Find(&bson.M{"username": req.Username}).One(&user)
+	if err == mgo.ErrNotFound {
+		return res, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	selector := bson.M{"username": req.Username}
+	if _, ok := values["password"]; ok {
+		// the old password can be guessed here as well as at login
+		if err := s.checkLockout(req.Username, ""); err != nil {
+			return nil, err
+		}
+		if ok, _ := passhash.Verify(user.Password, req.OldPassword); !ok {
+			s.loginFailed(req.Username, "")
+			return res, nil
+		}
+		pass, err := passhash.Hash(req.Password)
+		if err != nil {
+			return nil, err
+		}
+		values["password"] = pass
+		// a concurrent change of the password wins over this one
+		selector["password"] = user.Password
+	}
+
+	current := map[string]interface{}{
+		"age":   user.Age,
+		"sex":   user.Sex,
+		"mail":  user.Mail,
+		"phone": user.Phone,
+	}
+	set := bson.M{}
+	var updated []string
+	for _, field := range []string{"password", "age", "sex", "mail", "phone"} {
+		v, ok := values[field]
+		if !ok || (field != "password" && v == current[field]) {
+			continue
+		}
+		set[field] = v
+		updated = append(updated, field)
+	}
+
+	if len(set) > 0 {
+		err = c.Update(selector, bson.M{"$set": set})
+		if err == mgo.ErrNotFound {
+			return res, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if _, ok := set["password"]; ok {
+			// a stolen session must not outlive the password it was got with
+			now := time.Now()
+			err = session.DB("user-db").C("revoked").Insert(&revokedSessions{
+				Subject: req.Username,
+				Before:  now,
+				Expires: now.Add(s.Tokens.TTL),
+			})
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		err = session.DB("user-db").C("audit").Insert(&audit{
+			Username: req.Username,
+			Action:   "update",
+			Fields:   updated,
+			At:       time.Now(),
+		})
+		if err != nil {
+			log.Println("Failed record user update: ", err)
+		}
+	}
+
+	res.Correct = true
+	res.Updated = updated
+	return res, nil
+}
+
+// updateValue returns the validated value of a field of an update.
+func updateValue(req *pb.UpdateUserRequest, field string) (interface{}, error) {
+	switch field {
+	case "password":
+		if req.OldPassword == "" {
+			return nil, fmt.Errorf("changing the password requires the old password")
+		}
+		if req.Password == "" {
+			return nil, fmt.Errorf("password must not be empty")
+		}
+		return req.Password, nil
+	case "age":
+		if req.Age < 0 || req.Age > maxAge {
+			return nil, fmt.Errorf("age must be between 0 and %d", maxAge)
+		}
+		return req.Age, nil
+	case "sex":
+		return req.Sex, nil
+	case "mail":
+		if len(req.Mail) > 254 || !mailPattern.MatchString(req.Mail) {
+			return nil, fmt.Errorf("invalid mail address %q", req.Mail)
+		}
+		return req.Mail, nil
+	case "phone":
+		if !phonePattern.MatchString(req.Phone) || !validPhoneDigits(req.Phone) {
+			return nil, fmt.Errorf("invalid phone number %q", req.Phone)
+		}
+		return req.Phone, nil
+	}
+	return nil, fmt.Errorf("unknown field %q in update mask", field)
+}
+
+// validPhoneDigits returns whether a phone number has as many digits as
+// the local and international numbers of E.164 can.
+func validPhoneDigits(phone string) bool {
+	digits := 0
+	for _, r := range phone {
+		if r >= '0' && r <= '9' {
+			digits++
+		}
+	}
+	return digits >= 7 && digits <= 15
+}
@@ -0,0 +1,282 @@
+package user
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"strings"
+	"time"
+
+	pb "github.com/harlow/go-micro-services/services/user/proto"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+const (
+	maxWishlists         = 50
+	maxWishlistHotels    = 200
+	maxWishlistNameBytes = 100
+)
+
+// wishlist is a named list of hotels a user saved, in user-db.wishlists.
+type wishlist struct {
+	ID       bson.ObjectId `bson:"_id"`
+	Username string        `bson:"username"`
+	Name     string        `bson:"name"`
+	HotelIds []string      `bson:"hotelIds"`
+	// ShareToken lets anyone read the wishlist, until it is revoked
+	ShareToken string    `bson:"shareToken,omitempty"`
+	Created    time.Time `bson:"created"`
+	Updated    time.Time `bson:"updated"`
+}
+
+func (l *wishlist) proto() *pb.Wishlist {
+	hotelIds := l.HotelIds
+	if hotelIds == nil {
+		hotelIds = []string{}
+	}
+	return &pb.Wishlist{
+		Id:         l.ID.Hex(),
+		Name:       l.Name,
+		HotelIds:   hotelIds,
+		ShareToken: l.ShareToken,
+		Created:    l.Created.Unix(),
+		Updated:    l.Updated.Unix(),
+	}
+}
+
+// wishlistSelector selects the wishlist of a user with a hex id.
+func wishlistSelector(username, wishlistId string) (bson.M, error) {
+	if username == "" || !bson.IsObjectIdHex(wishlistId) {
+		return nil, status.Error(codes.InvalidArgument, "username and a valid wishlist id must be set")
+	}
+	return bson.M{"_id": bson.ObjectIdHex(wishlistId), "username": username}, nil
+}
+
+// findWishlist returns the wishlist selected, or a NotFound error.
+func findWishlist(c *mgo.Collection, selector bson.M) (*pb.Wishlist, error) {
+	var l wishlist
+	err := c.Find(selector).One(&l)
+	if err == mgo.ErrNotFound {
+		return nil, status.Error(codes.NotFound, "no such wishlist")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return l.proto(), nil
+}
+
+// CreateWishlist creates an empty wishlist. A user's wishlists have
+// distinct names.
+func (s *Server) CreateWishlist(ctx context.Context, req *pb.CreateWishlistRequest) (*pb.Wishlist, error) {
+	name := strings.TrimSpace(req.Name)
+	if req.Username == "" || name == "" {
+		return nil, status.Error(codes.InvalidArgument, "username and name must be set")
+	}
+	if len(name) > maxWishlistNameBytes {
+		return nil, status.Errorf(codes.InvalidArgument, "name must be at most %d bytes", maxWishlistNameBytes)
+	}
+
+	session := s.MongoSession.Copy()
+	defer session.Close()
+
+	c := session.DB("user-db").C("wishlists")
+
+	count, err := c.Find(bson.M{"username": req.Username}).Count()
+	if err != nil {
+		return nil, err
+	}
+	if count >= maxWishlists {
+		return nil, status.Errorf(codes.FailedPrecondition, "a user may have at most %d wishlists", maxWishlists)
+	}
+
+	now := time.Now()
+	l := &wishlist{
+		ID:       bson.NewObjectId(),
+		Username: req.Username,
+		Name:     name,
+		HotelIds: []string{},
+		Created:  now,
+		Updated:  now,
+	}
+	err = c.Insert(l)
+	if mgo.IsDup(err) {
+		return nil, status.Errorf(codes.AlreadyExists, "there is a wishlist named %q", name)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return l.proto(), nil
+}
+
+// GetWishlists returns the wishlists of a user, oldest first.
+func (s *Server) GetWishlists(ctx context.Context, req *pb.WishlistsRequest) (*pb.WishlistsResult, error) {
+	res := new(pb.WishlistsResult)
+
+	session := s.MongoSession.Copy()
+	defer session.Close()
+
+	var lists []wishlist
+	err := session.DB("user-db").C("wishlists").Find(bson.M{"username": req.Username}).Sort("_id").All(&lists)
+	if err != nil {
+		return nil, err
+	}
+	for i := range lists {
+		res.Wishlists = append(res.Wishlists, lists[i].proto())
+	}
+	return res, nil
+}
+
+// GetWishlist returns a wishlist of a user.
+func (s *Server) GetWishlist(ctx context.Context, req *pb.WishlistRequest) (*pb.Wishlist, error) {
+	selector, err := wishlistSelector(req.Username, req.WishlistId)
+	if err != nil {
+		return nil, err
+	}
+
+	session := s.MongoSession.Copy()
+	defer session.Close()
+
+	return findWishlist(session.DB("user-db").C("wishlists"), selector)
+}
+
+// UpdateWishlist adds hotels to the end of a wishlist, unless they are on
+// it already, and removes hotels from it.
+func (s *Server) UpdateWishlist(ctx context.Context, req *pb.UpdateWishlistRequest) (*pb.Wishlist, error) {
+	selector, err := wishlistSelector(req.Username, req.WishlistId)
+	if err != nil {
+		return nil, err
+	}
+	for _, hotelId := range append(req.AddHotelIds, req.RemoveHotelIds...) {
+		if hotelId == "" {
+			return nil, status.Error(codes.InvalidArgument, "hotel ids must not be empty")
+		}
+	}
+
+	session := s.MongoSession.Copy()
+	defer session.Close()
+
+	c := session.DB("user-db").C("wishlists")
+
+	if len(req.RemoveHotelIds) > 0 {
+		err := c.Update(selector, bson.M{
+			"$pull": bson.M{"hotelIds": bson.M{"$in": req.RemoveHotelIds}},
+			"$set":  bson.M{"updated": time.Now()},
+		})
+		if err == mgo.ErrNotFound {
+			return nil, status.Error(codes.NotFound, "no such wishlist")
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(req.AddHotelIds) > 0 {
+		l, err := findWishlist(c, selector)
+		if err != nil {
+			return nil, err
+		}
+		size := len(l.HotelIds)
+		on := make(map[string]bool, size)
+		for _, hotelId := range l.HotelIds {
+			on[hotelId] = true
+		}
+		for _, hotelId := range req.AddHotelIds {
+			if !on[hotelId] {
+				on[hotelId] = true
+				size++
+			}
+		}
+		if size > maxWishlistHotels {
+			return nil, status.Errorf(codes.FailedPrecondition, "a wishlist may have at most %d hotels", maxWishlistHotels)
+		}
+
+		err = c.Update(selector, bson.M{
+			"$addToSet": bson.M{"hotelIds": bson.M{"$each": req.AddHotelIds}},
+			"$set":      bson.M{"updated": time.Now()},
+		})
+		if err == mgo.ErrNotFound {
+			return nil, status.Error(codes.NotFound, "no such wishlist")
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return findWishlist(c, selector)
+}
+
+// DeleteWishlist deletes a wishlist, and so its share token.
+func (s *Server) DeleteWishlist(ctx context.Context, req *pb.WishlistRequest) (*pb.Result, error) {
+	res := new(pb.Result)
+
+	selector, err := wishlistSelector(req.Username, req.WishlistId)
+	if err != nil {
+		return nil, err
+	}
+
+	session := s.MongoSession.Copy()
+	defer session.Close()
+
+	err = session.DB("user-db").C("wishlists").Remove(selector)
+	if err == mgo.ErrNotFound {
+		return res, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	res.Correct = true
+	return res, nil
+}
+
+// ShareWishlist sets a new share token on a wishlist, so links with the
+// previous one stop working, or revokes it.
+func (s *Server) ShareWishlist(ctx context.Context, req *pb.ShareWishlistRequest) (*pb.Wishlist, error) {
+	selector, err := wishlistSelector(req.Username, req.WishlistId)
+	if err != nil {
+		return nil, err
+	}
+
+	update := bson.M{"$unset": bson.M{"shareToken": ""}}
+	if !req.Revoke {
+		b := make([]byte, 16)
+		if _, err := rand.Read(b); err != nil {
+			return nil, err
+		}
+		update = bson.M{"$set": bson.M{"shareToken": base64.RawURLEncoding.EncodeToString(b)}}
+	}
+
+	session := s.MongoSession.Copy()
+	defer session.Close()
+
+	c := session.DB("user-db").C("wishlists")
+
+	err = c.Update(selector, update)
+	if err == mgo.ErrNotFound {
+		return nil, status.Error(codes.NotFound, "no such wishlist")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return findWishlist(c, selector)
+}
+
+// GetSharedWishlist returns the wishlist a share token is set on, without
+// the token.
+func (s *Server) GetSharedWishlist(ctx context.Context, req *pb.SharedWishlistRequest) (*pb.Wishlist, error) {
+	if req.ShareToken == "" {
+		return nil, status.Error(codes.InvalidArgument, "share token must be set")
+	}
+
+	session := s.MongoSession.Copy()
+	defer session.Close()
+
+	l, err := findWishlist(session.DB("user-db").C("wishlists"), bson.M{"shareToken": req.ShareToken})
+	if err != nil {
+		return nil, err
+	}
+	l.ShareToken = ""
+	return l, nil
+}
@@ -84,14 +84,13 @@ local function reserve()
 
   local hotel_id = tostring(math.random(1, 80))
   local user_id, password = get_user()
-  local cust_name = user_id
 
   local num_room = "1"
 
   local method = "POST"
   local path = "http://localhost:5000/reservation?inDate=" .. in_date_str .. 
     "&outDate=" .. out_date_str .. "&lat=" .. tostring(lat) .. "&lon=" .. tostring(lon) ..
-    "&hotelId=" .. hotel_id .. "&customerName=" .. cust_name .. "&username=" .. user_id ..
+    "&hotelId=" .. hotel_id .. "&username=" .. user_id ..
     "&password=" .. password .. "&number=" .. num_room
   local headers = {}
   -- headers["Content-Type"] = "application/x-www-form-urlencoded"